@@ -453,6 +453,8 @@ type MergeProfilesStacktracesRequest struct {
 	Request *SelectProfilesRequest `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
 	// Max nodes in the resulting tree.
 	MaxNodes *int64 `protobuf:"varint,3,opt,name=max_nodes,json=maxNodes,proto3,oneof" json:"max_nodes,omitempty"`
+	// Filter frames of the stack traces before the tree is truncated.
+	FrameFilter *v1.FrameFilter `protobuf:"bytes,4,opt,name=frame_filter,json=frameFilter,proto3,oneof" json:"frame_filter,omitempty"`
 	// On a batch of profiles, the client sends the profiles to keep for merging.
 	Profiles      []bool `protobuf:"varint,2,rep,packed,name=profiles,proto3" json:"profiles,omitempty"`
 	unknownFields protoimpl.UnknownFields
@@ -503,6 +505,13 @@ func (x *MergeProfilesStacktracesRequest) GetMaxNodes() int64 {
 	return 0
 }
 
+func (x *MergeProfilesStacktracesRequest) GetFrameFilter() *v1.FrameFilter {
+	if x != nil {
+		return x.FrameFilter
+	}
+	return nil
+}
+
 func (x *MergeProfilesStacktracesRequest) GetProfiles() []bool {
 	if x != nil {
 		return x.Profiles
@@ -1811,7 +1820,7 @@ var file_ingester_v1_ingester_proto_rawDesc = string([]byte{
 	0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x48, 0x01, 0x52, 0x0b, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67,
 	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x68, 0x69, 0x6e,
 	0x74, 0x73, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x22, 0xab, 0x01, 0x0a, 0x1f, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x50, 0x72, 0x6f, 0x66,
+	0x6f, 0x6e, 0x22, 0xfb, 0x01, 0x0a, 0x1f, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x50, 0x72, 0x6f, 0x66,
 	0x69, 0x6c, 0x65, 0x73, 0x53, 0x74, 0x61, 0x63, 0x6b, 0x74, 0x72, 0x61, 0x63, 0x65, 0x73, 0x52,
 	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3c, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
 	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x69, 0x6e, 0x67, 0x65, 0x73, 0x74,
@@ -1819,9 +1828,14 @@ var file_ingester_v1_ingester_proto_rawDesc = string([]byte{
 	0x69, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x07, 0x72, 0x65, 0x71,
 	0x75, 0x65, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f, 0x6e, 0x6f, 0x64, 0x65,
 	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x4e, 0x6f,
-	0x64, 0x65, 0x73, 0x88, 0x01, 0x01, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c,
-	0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x08, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c,
-	0x65, 0x73, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x73,
+	0x64, 0x65, 0x73, 0x88, 0x01, 0x01, 0x12, 0x3d, 0x0a, 0x0c, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x5f,
+	0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x74,
+	0x79, 0x70, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x46, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x48, 0x01, 0x52, 0x0b, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x46, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x88, 0x01, 0x01, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x08, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65,
+	0x73, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x42,
+	0x0f, 0x0a, 0x0d, 0x5f, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72,
 	0x22, 0xe4, 0x01, 0x0a, 0x1e, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c,
 	0x65, 0x73, 0x53, 0x74, 0x61, 0x63, 0x6b, 0x74, 0x72, 0x61, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73,
 	0x75, 0x6c, 0x74, 0x12, 0x3b, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x03, 0x20,
@@ -2150,18 +2164,19 @@ var file_ingester_v1_ingester_proto_goTypes = []any{
 	(*v1.ProfileType)(nil),                   // 30: types.v1.ProfileType
 	(*v1.Labels)(nil),                        // 31: types.v1.Labels
 	(v1.TimeSeriesAggregationType)(0),        // 32: types.v1.TimeSeriesAggregationType
-	(*v1.LabelPair)(nil),                     // 33: types.v1.LabelPair
-	(*v1.StackTraceSelector)(nil),            // 34: types.v1.StackTraceSelector
-	(*v1.Series)(nil),                        // 35: types.v1.Series
-	(*v1.BlockInfo)(nil),                     // 36: types.v1.BlockInfo
-	(*v11.PushRequest)(nil),                  // 37: push.v1.PushRequest
-	(*v1.LabelValuesRequest)(nil),            // 38: types.v1.LabelValuesRequest
-	(*v1.LabelNamesRequest)(nil),             // 39: types.v1.LabelNamesRequest
-	(*v1.GetProfileStatsRequest)(nil),        // 40: types.v1.GetProfileStatsRequest
-	(*v11.PushResponse)(nil),                 // 41: push.v1.PushResponse
-	(*v1.LabelValuesResponse)(nil),           // 42: types.v1.LabelValuesResponse
-	(*v1.LabelNamesResponse)(nil),            // 43: types.v1.LabelNamesResponse
-	(*v1.GetProfileStatsResponse)(nil),       // 44: types.v1.GetProfileStatsResponse
+	(*v1.FrameFilter)(nil),                   // 33: types.v1.FrameFilter
+	(*v1.LabelPair)(nil),                     // 34: types.v1.LabelPair
+	(*v1.StackTraceSelector)(nil),            // 35: types.v1.StackTraceSelector
+	(*v1.Series)(nil),                        // 36: types.v1.Series
+	(*v1.BlockInfo)(nil),                     // 37: types.v1.BlockInfo
+	(*v11.PushRequest)(nil),                  // 38: push.v1.PushRequest
+	(*v1.LabelValuesRequest)(nil),            // 39: types.v1.LabelValuesRequest
+	(*v1.LabelNamesRequest)(nil),             // 40: types.v1.LabelNamesRequest
+	(*v1.GetProfileStatsRequest)(nil),        // 41: types.v1.GetProfileStatsRequest
+	(*v11.PushResponse)(nil),                 // 42: push.v1.PushResponse
+	(*v1.LabelValuesResponse)(nil),           // 43: types.v1.LabelValuesResponse
+	(*v1.LabelNamesResponse)(nil),            // 44: types.v1.LabelNamesResponse
+	(*v1.GetProfileStatsResponse)(nil),       // 45: types.v1.GetProfileStatsResponse
 }
 var file_ingester_v1_ingester_proto_depIdxs = []int32{
 	30, // 0: ingester.v1.ProfileTypesResponse.profile_types:type_name -> types.v1.ProfileType
@@ -2170,61 +2185,62 @@ var file_ingester_v1_ingester_proto_depIdxs = []int32{
 	25, // 3: ingester.v1.SelectProfilesRequest.hints:type_name -> ingester.v1.Hints
 	32, // 4: ingester.v1.SelectProfilesRequest.aggregation:type_name -> types.v1.TimeSeriesAggregationType
 	7,  // 5: ingester.v1.MergeProfilesStacktracesRequest.request:type_name -> ingester.v1.SelectProfilesRequest
-	0,  // 6: ingester.v1.MergeProfilesStacktracesResult.format:type_name -> ingester.v1.StacktracesMergeFormat
-	18, // 7: ingester.v1.MergeProfilesStacktracesResult.stacktraces:type_name -> ingester.v1.StacktraceSample
-	15, // 8: ingester.v1.MergeProfilesStacktracesResponse.selectedProfiles:type_name -> ingester.v1.ProfileSets
-	9,  // 9: ingester.v1.MergeProfilesStacktracesResponse.result:type_name -> ingester.v1.MergeProfilesStacktracesResult
-	30, // 10: ingester.v1.SelectSpanProfileRequest.type:type_name -> types.v1.ProfileType
-	25, // 11: ingester.v1.SelectSpanProfileRequest.hints:type_name -> ingester.v1.Hints
-	11, // 12: ingester.v1.MergeSpanProfileRequest.request:type_name -> ingester.v1.SelectSpanProfileRequest
-	15, // 13: ingester.v1.MergeSpanProfileResponse.selectedProfiles:type_name -> ingester.v1.ProfileSets
-	14, // 14: ingester.v1.MergeSpanProfileResponse.result:type_name -> ingester.v1.MergeSpanProfileResult
-	31, // 15: ingester.v1.ProfileSets.labelsSets:type_name -> types.v1.Labels
-	16, // 16: ingester.v1.ProfileSets.profiles:type_name -> ingester.v1.SeriesProfile
-	30, // 17: ingester.v1.Profile.type:type_name -> types.v1.ProfileType
-	33, // 18: ingester.v1.Profile.labels:type_name -> types.v1.LabelPair
-	18, // 19: ingester.v1.Profile.stacktraces:type_name -> ingester.v1.StacktraceSample
-	7,  // 20: ingester.v1.MergeProfilesLabelsRequest.request:type_name -> ingester.v1.SelectProfilesRequest
-	34, // 21: ingester.v1.MergeProfilesLabelsRequest.stack_trace_selector:type_name -> types.v1.StackTraceSelector
-	15, // 22: ingester.v1.MergeProfilesLabelsResponse.selectedProfiles:type_name -> ingester.v1.ProfileSets
-	35, // 23: ingester.v1.MergeProfilesLabelsResponse.series:type_name -> types.v1.Series
-	7,  // 24: ingester.v1.MergeProfilesPprofRequest.request:type_name -> ingester.v1.SelectProfilesRequest
-	34, // 25: ingester.v1.MergeProfilesPprofRequest.stack_trace_selector:type_name -> types.v1.StackTraceSelector
-	15, // 26: ingester.v1.MergeProfilesPprofResponse.selectedProfiles:type_name -> ingester.v1.ProfileSets
-	36, // 27: ingester.v1.BlockMetadataResponse.blocks:type_name -> types.v1.BlockInfo
-	26, // 28: ingester.v1.Hints.block:type_name -> ingester.v1.BlockHints
-	29, // 29: ingester.v1.GetBlockStatsResponse.block_stats:type_name -> ingester.v1.BlockStats
-	37, // 30: ingester.v1.IngesterService.Push:input_type -> push.v1.PushRequest
-	38, // 31: ingester.v1.IngesterService.LabelValues:input_type -> types.v1.LabelValuesRequest
-	39, // 32: ingester.v1.IngesterService.LabelNames:input_type -> types.v1.LabelNamesRequest
-	1,  // 33: ingester.v1.IngesterService.ProfileTypes:input_type -> ingester.v1.ProfileTypesRequest
-	3,  // 34: ingester.v1.IngesterService.Series:input_type -> ingester.v1.SeriesRequest
-	5,  // 35: ingester.v1.IngesterService.Flush:input_type -> ingester.v1.FlushRequest
-	8,  // 36: ingester.v1.IngesterService.MergeProfilesStacktraces:input_type -> ingester.v1.MergeProfilesStacktracesRequest
-	19, // 37: ingester.v1.IngesterService.MergeProfilesLabels:input_type -> ingester.v1.MergeProfilesLabelsRequest
-	21, // 38: ingester.v1.IngesterService.MergeProfilesPprof:input_type -> ingester.v1.MergeProfilesPprofRequest
-	12, // 39: ingester.v1.IngesterService.MergeSpanProfile:input_type -> ingester.v1.MergeSpanProfileRequest
-	23, // 40: ingester.v1.IngesterService.BlockMetadata:input_type -> ingester.v1.BlockMetadataRequest
-	40, // 41: ingester.v1.IngesterService.GetProfileStats:input_type -> types.v1.GetProfileStatsRequest
-	27, // 42: ingester.v1.IngesterService.GetBlockStats:input_type -> ingester.v1.GetBlockStatsRequest
-	41, // 43: ingester.v1.IngesterService.Push:output_type -> push.v1.PushResponse
-	42, // 44: ingester.v1.IngesterService.LabelValues:output_type -> types.v1.LabelValuesResponse
-	43, // 45: ingester.v1.IngesterService.LabelNames:output_type -> types.v1.LabelNamesResponse
-	2,  // 46: ingester.v1.IngesterService.ProfileTypes:output_type -> ingester.v1.ProfileTypesResponse
-	4,  // 47: ingester.v1.IngesterService.Series:output_type -> ingester.v1.SeriesResponse
-	6,  // 48: ingester.v1.IngesterService.Flush:output_type -> ingester.v1.FlushResponse
-	10, // 49: ingester.v1.IngesterService.MergeProfilesStacktraces:output_type -> ingester.v1.MergeProfilesStacktracesResponse
-	20, // 50: ingester.v1.IngesterService.MergeProfilesLabels:output_type -> ingester.v1.MergeProfilesLabelsResponse
-	22, // 51: ingester.v1.IngesterService.MergeProfilesPprof:output_type -> ingester.v1.MergeProfilesPprofResponse
-	13, // 52: ingester.v1.IngesterService.MergeSpanProfile:output_type -> ingester.v1.MergeSpanProfileResponse
-	24, // 53: ingester.v1.IngesterService.BlockMetadata:output_type -> ingester.v1.BlockMetadataResponse
-	44, // 54: ingester.v1.IngesterService.GetProfileStats:output_type -> types.v1.GetProfileStatsResponse
-	28, // 55: ingester.v1.IngesterService.GetBlockStats:output_type -> ingester.v1.GetBlockStatsResponse
-	43, // [43:56] is the sub-list for method output_type
-	30, // [30:43] is the sub-list for method input_type
-	30, // [30:30] is the sub-list for extension type_name
-	30, // [30:30] is the sub-list for extension extendee
-	0,  // [0:30] is the sub-list for field type_name
+	33, // 6: ingester.v1.MergeProfilesStacktracesRequest.frame_filter:type_name -> types.v1.FrameFilter
+	0,  // 7: ingester.v1.MergeProfilesStacktracesResult.format:type_name -> ingester.v1.StacktracesMergeFormat
+	18, // 8: ingester.v1.MergeProfilesStacktracesResult.stacktraces:type_name -> ingester.v1.StacktraceSample
+	15, // 9: ingester.v1.MergeProfilesStacktracesResponse.selectedProfiles:type_name -> ingester.v1.ProfileSets
+	9,  // 10: ingester.v1.MergeProfilesStacktracesResponse.result:type_name -> ingester.v1.MergeProfilesStacktracesResult
+	30, // 11: ingester.v1.SelectSpanProfileRequest.type:type_name -> types.v1.ProfileType
+	25, // 12: ingester.v1.SelectSpanProfileRequest.hints:type_name -> ingester.v1.Hints
+	11, // 13: ingester.v1.MergeSpanProfileRequest.request:type_name -> ingester.v1.SelectSpanProfileRequest
+	15, // 14: ingester.v1.MergeSpanProfileResponse.selectedProfiles:type_name -> ingester.v1.ProfileSets
+	14, // 15: ingester.v1.MergeSpanProfileResponse.result:type_name -> ingester.v1.MergeSpanProfileResult
+	31, // 16: ingester.v1.ProfileSets.labelsSets:type_name -> types.v1.Labels
+	16, // 17: ingester.v1.ProfileSets.profiles:type_name -> ingester.v1.SeriesProfile
+	30, // 18: ingester.v1.Profile.type:type_name -> types.v1.ProfileType
+	34, // 19: ingester.v1.Profile.labels:type_name -> types.v1.LabelPair
+	18, // 20: ingester.v1.Profile.stacktraces:type_name -> ingester.v1.StacktraceSample
+	7,  // 21: ingester.v1.MergeProfilesLabelsRequest.request:type_name -> ingester.v1.SelectProfilesRequest
+	35, // 22: ingester.v1.MergeProfilesLabelsRequest.stack_trace_selector:type_name -> types.v1.StackTraceSelector
+	15, // 23: ingester.v1.MergeProfilesLabelsResponse.selectedProfiles:type_name -> ingester.v1.ProfileSets
+	36, // 24: ingester.v1.MergeProfilesLabelsResponse.series:type_name -> types.v1.Series
+	7,  // 25: ingester.v1.MergeProfilesPprofRequest.request:type_name -> ingester.v1.SelectProfilesRequest
+	35, // 26: ingester.v1.MergeProfilesPprofRequest.stack_trace_selector:type_name -> types.v1.StackTraceSelector
+	15, // 27: ingester.v1.MergeProfilesPprofResponse.selectedProfiles:type_name -> ingester.v1.ProfileSets
+	37, // 28: ingester.v1.BlockMetadataResponse.blocks:type_name -> types.v1.BlockInfo
+	26, // 29: ingester.v1.Hints.block:type_name -> ingester.v1.BlockHints
+	29, // 30: ingester.v1.GetBlockStatsResponse.block_stats:type_name -> ingester.v1.BlockStats
+	38, // 31: ingester.v1.IngesterService.Push:input_type -> push.v1.PushRequest
+	39, // 32: ingester.v1.IngesterService.LabelValues:input_type -> types.v1.LabelValuesRequest
+	40, // 33: ingester.v1.IngesterService.LabelNames:input_type -> types.v1.LabelNamesRequest
+	1,  // 34: ingester.v1.IngesterService.ProfileTypes:input_type -> ingester.v1.ProfileTypesRequest
+	3,  // 35: ingester.v1.IngesterService.Series:input_type -> ingester.v1.SeriesRequest
+	5,  // 36: ingester.v1.IngesterService.Flush:input_type -> ingester.v1.FlushRequest
+	8,  // 37: ingester.v1.IngesterService.MergeProfilesStacktraces:input_type -> ingester.v1.MergeProfilesStacktracesRequest
+	19, // 38: ingester.v1.IngesterService.MergeProfilesLabels:input_type -> ingester.v1.MergeProfilesLabelsRequest
+	21, // 39: ingester.v1.IngesterService.MergeProfilesPprof:input_type -> ingester.v1.MergeProfilesPprofRequest
+	12, // 40: ingester.v1.IngesterService.MergeSpanProfile:input_type -> ingester.v1.MergeSpanProfileRequest
+	23, // 41: ingester.v1.IngesterService.BlockMetadata:input_type -> ingester.v1.BlockMetadataRequest
+	41, // 42: ingester.v1.IngesterService.GetProfileStats:input_type -> types.v1.GetProfileStatsRequest
+	27, // 43: ingester.v1.IngesterService.GetBlockStats:input_type -> ingester.v1.GetBlockStatsRequest
+	42, // 44: ingester.v1.IngesterService.Push:output_type -> push.v1.PushResponse
+	43, // 45: ingester.v1.IngesterService.LabelValues:output_type -> types.v1.LabelValuesResponse
+	44, // 46: ingester.v1.IngesterService.LabelNames:output_type -> types.v1.LabelNamesResponse
+	2,  // 47: ingester.v1.IngesterService.ProfileTypes:output_type -> ingester.v1.ProfileTypesResponse
+	4,  // 48: ingester.v1.IngesterService.Series:output_type -> ingester.v1.SeriesResponse
+	6,  // 49: ingester.v1.IngesterService.Flush:output_type -> ingester.v1.FlushResponse
+	10, // 50: ingester.v1.IngesterService.MergeProfilesStacktraces:output_type -> ingester.v1.MergeProfilesStacktracesResponse
+	20, // 51: ingester.v1.IngesterService.MergeProfilesLabels:output_type -> ingester.v1.MergeProfilesLabelsResponse
+	22, // 52: ingester.v1.IngesterService.MergeProfilesPprof:output_type -> ingester.v1.MergeProfilesPprofResponse
+	13, // 53: ingester.v1.IngesterService.MergeSpanProfile:output_type -> ingester.v1.MergeSpanProfileResponse
+	24, // 54: ingester.v1.IngesterService.BlockMetadata:output_type -> ingester.v1.BlockMetadataResponse
+	45, // 55: ingester.v1.IngesterService.GetProfileStats:output_type -> types.v1.GetProfileStatsResponse
+	28, // 56: ingester.v1.IngesterService.GetBlockStats:output_type -> ingester.v1.GetBlockStatsResponse
+	44, // [44:57] is the sub-list for method output_type
+	31, // [31:44] is the sub-list for method input_type
+	31, // [31:31] is the sub-list for extension type_name
+	31, // [31:31] is the sub-list for extension extendee
+	0,  // [0:31] is the sub-list for field type_name
 }
 
 func init() { file_ingester_v1_ingester_proto_init() }
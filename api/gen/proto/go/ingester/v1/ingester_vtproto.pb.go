@@ -198,6 +198,13 @@ func (m *MergeProfilesStacktracesRequest) CloneVT() *MergeProfilesStacktracesReq
 		tmpVal := *rhs
 		r.MaxNodes = &tmpVal
 	}
+	if rhs := m.FrameFilter; rhs != nil {
+		if vtpb, ok := interface{}(rhs).(interface{ CloneVT() *v1.FrameFilter }); ok {
+			r.FrameFilter = vtpb.CloneVT()
+		} else {
+			r.FrameFilter = proto.Clone(rhs).(*v1.FrameFilter)
+		}
+	}
 	if rhs := m.Profiles; rhs != nil {
 		tmpContainer := make([]bool, len(rhs))
 		copy(tmpContainer, rhs)
@@ -979,6 +986,13 @@ func (this *MergeProfilesStacktracesRequest) EqualVT(that *MergeProfilesStacktra
 	if p, q := this.MaxNodes, that.MaxNodes; (p == nil && q != nil) || (p != nil && (q == nil || *p != *q)) {
 		return false
 	}
+	if equal, ok := interface{}(this.FrameFilter).(interface{ EqualVT(*v1.FrameFilter) bool }); ok {
+		if !equal.EqualVT(that.FrameFilter) {
+			return false
+		}
+	} else if !proto.Equal(this.FrameFilter, that.FrameFilter) {
+		return false
+	}
 	return string(this.unknownFields) == string(that.unknownFields)
 }
 
@@ -2761,6 +2775,28 @@ func (m *MergeProfilesStacktracesRequest) MarshalToSizedBufferVT(dAtA []byte) (i
 		i -= len(m.unknownFields)
 		copy(dAtA[i:], m.unknownFields)
 	}
+	if m.FrameFilter != nil {
+		if vtmsg, ok := interface{}(m.FrameFilter).(interface {
+			MarshalToSizedBufferVT([]byte) (int, error)
+		}); ok {
+			size, err := vtmsg.MarshalToSizedBufferVT(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = protohelpers.EncodeVarint(dAtA, i, uint64(size))
+		} else {
+			encoded, err := proto.Marshal(m.FrameFilter)
+			if err != nil {
+				return 0, err
+			}
+			i -= len(encoded)
+			copy(dAtA[i:], encoded)
+			i = protohelpers.EncodeVarint(dAtA, i, uint64(len(encoded)))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
 	if m.MaxNodes != nil {
 		i = protohelpers.EncodeVarint(dAtA, i, uint64(*m.MaxNodes))
 		i--
@@ -4243,6 +4279,16 @@ func (m *MergeProfilesStacktracesRequest) SizeVT() (n int) {
 	if m.MaxNodes != nil {
 		n += 1 + protohelpers.SizeOfVarint(uint64(*m.MaxNodes))
 	}
+	if m.FrameFilter != nil {
+		if size, ok := interface{}(m.FrameFilter).(interface {
+			SizeVT() int
+		}); ok {
+			l = size.SizeVT()
+		} else {
+			l = proto.Size(m.FrameFilter)
+		}
+		n += 1 + l + protohelpers.SizeOfVarint(uint64(l))
+	}
 	n += len(m.unknownFields)
 	return n
 }
@@ -5646,6 +5692,50 @@ func (m *MergeProfilesStacktracesRequest) UnmarshalVT(dAtA []byte) error {
 				}
 			}
 			m.MaxNodes = &v
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FrameFilter", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return protohelpers.ErrIntOverflow
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return protohelpers.ErrInvalidLength
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return protohelpers.ErrInvalidLength
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.FrameFilter == nil {
+				m.FrameFilter = &v1.FrameFilter{}
+			}
+			if unmarshal, ok := interface{}(m.FrameFilter).(interface {
+				UnmarshalVT([]byte) error
+			}); ok {
+				if err := unmarshal.UnmarshalVT(dAtA[iNdEx:postIndex]); err != nil {
+					return err
+				}
+			} else {
+				if err := proto.Unmarshal(dAtA[iNdEx:postIndex], m.FrameFilter); err != nil {
+					return err
+				}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := protohelpers.Skip(dAtA[iNdEx:])
@@ -58,6 +58,24 @@ const (
 	// QuerierServiceSelectSeriesProcedure is the fully-qualified name of the QuerierService's
 	// SelectSeries RPC.
 	QuerierServiceSelectSeriesProcedure = "/querier.v1.QuerierService/SelectSeries"
+	// QuerierServiceSelectTopTableProcedure is the fully-qualified name of the QuerierService's
+	// SelectTopTable RPC.
+	QuerierServiceSelectTopTableProcedure = "/querier.v1.QuerierService/SelectTopTable"
+	// QuerierServiceSelectCallGraphProcedure is the fully-qualified name of the QuerierService's
+	// SelectCallGraph RPC.
+	QuerierServiceSelectCallGraphProcedure = "/querier.v1.QuerierService/SelectCallGraph"
+	// QuerierServiceSelectFunctionDetailsProcedure is the fully-qualified name of the QuerierService's
+	// SelectFunctionDetails RPC.
+	QuerierServiceSelectFunctionDetailsProcedure = "/querier.v1.QuerierService/SelectFunctionDetails"
+	// QuerierServiceSelectHeatmapProcedure is the fully-qualified name of the QuerierService's
+	// SelectHeatmap RPC.
+	QuerierServiceSelectHeatmapProcedure = "/querier.v1.QuerierService/SelectHeatmap"
+	// QuerierServiceSelectExemplarsProcedure is the fully-qualified name of the QuerierService's
+	// SelectExemplars RPC.
+	QuerierServiceSelectExemplarsProcedure = "/querier.v1.QuerierService/SelectExemplars"
+	// QuerierServiceSelectProfileByIDProcedure is the fully-qualified name of the QuerierService's
+	// SelectProfileByID RPC.
+	QuerierServiceSelectProfileByIDProcedure = "/querier.v1.QuerierService/SelectProfileByID"
 	// QuerierServiceDiffProcedure is the fully-qualified name of the QuerierService's Diff RPC.
 	QuerierServiceDiffProcedure = "/querier.v1.QuerierService/Diff"
 	// QuerierServiceGetProfileStatsProcedure is the fully-qualified name of the QuerierService's
@@ -86,6 +104,25 @@ type QuerierServiceClient interface {
 	SelectMergeProfile(context.Context, *connect.Request[v1.SelectMergeProfileRequest]) (*connect.Response[v12.Profile], error)
 	// SelectSeries returns a time series for the total sum of the requested profiles.
 	SelectSeries(context.Context, *connect.Request[v1.SelectSeriesRequest]) (*connect.Response[v1.SelectSeriesResponse], error)
+	// SelectTopTable returns the list of functions of the matching profiles ordered by their self or total value.
+	SelectTopTable(context.Context, *connect.Request[v1.SelectTopTableRequest]) (*connect.Response[v1.SelectTopTableResponse], error)
+	// SelectCallGraph returns the call graph of the matching profiles.
+	SelectCallGraph(context.Context, *connect.Request[v1.SelectCallGraphRequest]) (*connect.Response[v1.SelectCallGraphResponse], error)
+	// SelectFunctionDetails returns the callers and callees of the function, and the cost of its source code lines.
+	SelectFunctionDetails(context.Context, *connect.Request[v1.SelectFunctionDetailsRequest]) (*connect.Response[v1.SelectFunctionDetailsResponse], error)
+	// SelectHeatmap returns the distribution of individual profile values over time.
+	// Heatmaps are only built by the query backend: the ingesters and
+	// store-gateways return an Unimplemented error.
+	SelectHeatmap(context.Context, *connect.Request[v1.SelectHeatmapRequest]) (*connect.Response[v1.SelectHeatmapResponse], error)
+	// SelectExemplars returns the individual profiles with the highest values.
+	// Profile IDs are only stored by the query backend: the profiles stored
+	// by the ingesters and store-gateways are not returned.
+	SelectExemplars(context.Context, *connect.Request[v1.SelectExemplarsRequest]) (*connect.Response[v1.SelectExemplarsResponse], error)
+	// SelectProfileByID returns the profile with the given ID in pprof format.
+	// Only the profiles stored by the query backend can be fetched: the
+	// ingesters and store-gateways return an Unimplemented error. A NotFound
+	// error is returned if no profile has the ID in the time range.
+	SelectProfileByID(context.Context, *connect.Request[v1.SelectProfileByIDRequest]) (*connect.Response[v12.Profile], error)
 	// Diff returns a diff of two profiles
 	Diff(context.Context, *connect.Request[v1.DiffRequest]) (*connect.Response[v1.DiffResponse], error)
 	// GetProfileStats returns profile stats for the current tenant.
@@ -152,6 +189,42 @@ func NewQuerierServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(querierServiceMethods.ByName("SelectSeries")),
 			connect.WithClientOptions(opts...),
 		),
+		selectTopTable: connect.NewClient[v1.SelectTopTableRequest, v1.SelectTopTableResponse](
+			httpClient,
+			baseURL+QuerierServiceSelectTopTableProcedure,
+			connect.WithSchema(querierServiceMethods.ByName("SelectTopTable")),
+			connect.WithClientOptions(opts...),
+		),
+		selectCallGraph: connect.NewClient[v1.SelectCallGraphRequest, v1.SelectCallGraphResponse](
+			httpClient,
+			baseURL+QuerierServiceSelectCallGraphProcedure,
+			connect.WithSchema(querierServiceMethods.ByName("SelectCallGraph")),
+			connect.WithClientOptions(opts...),
+		),
+		selectFunctionDetails: connect.NewClient[v1.SelectFunctionDetailsRequest, v1.SelectFunctionDetailsResponse](
+			httpClient,
+			baseURL+QuerierServiceSelectFunctionDetailsProcedure,
+			connect.WithSchema(querierServiceMethods.ByName("SelectFunctionDetails")),
+			connect.WithClientOptions(opts...),
+		),
+		selectHeatmap: connect.NewClient[v1.SelectHeatmapRequest, v1.SelectHeatmapResponse](
+			httpClient,
+			baseURL+QuerierServiceSelectHeatmapProcedure,
+			connect.WithSchema(querierServiceMethods.ByName("SelectHeatmap")),
+			connect.WithClientOptions(opts...),
+		),
+		selectExemplars: connect.NewClient[v1.SelectExemplarsRequest, v1.SelectExemplarsResponse](
+			httpClient,
+			baseURL+QuerierServiceSelectExemplarsProcedure,
+			connect.WithSchema(querierServiceMethods.ByName("SelectExemplars")),
+			connect.WithClientOptions(opts...),
+		),
+		selectProfileByID: connect.NewClient[v1.SelectProfileByIDRequest, v12.Profile](
+			httpClient,
+			baseURL+QuerierServiceSelectProfileByIDProcedure,
+			connect.WithSchema(querierServiceMethods.ByName("SelectProfileByID")),
+			connect.WithClientOptions(opts...),
+		),
 		diff: connect.NewClient[v1.DiffRequest, v1.DiffResponse](
 			httpClient,
 			baseURL+QuerierServiceDiffProcedure,
@@ -183,6 +256,12 @@ type querierServiceClient struct {
 	selectMergeSpanProfile *connect.Client[v1.SelectMergeSpanProfileRequest, v1.SelectMergeSpanProfileResponse]
 	selectMergeProfile     *connect.Client[v1.SelectMergeProfileRequest, v12.Profile]
 	selectSeries           *connect.Client[v1.SelectSeriesRequest, v1.SelectSeriesResponse]
+	selectTopTable         *connect.Client[v1.SelectTopTableRequest, v1.SelectTopTableResponse]
+	selectCallGraph        *connect.Client[v1.SelectCallGraphRequest, v1.SelectCallGraphResponse]
+	selectFunctionDetails  *connect.Client[v1.SelectFunctionDetailsRequest, v1.SelectFunctionDetailsResponse]
+	selectHeatmap          *connect.Client[v1.SelectHeatmapRequest, v1.SelectHeatmapResponse]
+	selectExemplars        *connect.Client[v1.SelectExemplarsRequest, v1.SelectExemplarsResponse]
+	selectProfileByID      *connect.Client[v1.SelectProfileByIDRequest, v12.Profile]
 	diff                   *connect.Client[v1.DiffRequest, v1.DiffResponse]
 	getProfileStats        *connect.Client[v11.GetProfileStatsRequest, v11.GetProfileStatsResponse]
 	analyzeQuery           *connect.Client[v1.AnalyzeQueryRequest, v1.AnalyzeQueryResponse]
@@ -228,6 +307,36 @@ func (c *querierServiceClient) SelectSeries(ctx context.Context, req *connect.Re
 	return c.selectSeries.CallUnary(ctx, req)
 }
 
+// SelectTopTable calls querier.v1.QuerierService.SelectTopTable.
+func (c *querierServiceClient) SelectTopTable(ctx context.Context, req *connect.Request[v1.SelectTopTableRequest]) (*connect.Response[v1.SelectTopTableResponse], error) {
+	return c.selectTopTable.CallUnary(ctx, req)
+}
+
+// SelectCallGraph calls querier.v1.QuerierService.SelectCallGraph.
+func (c *querierServiceClient) SelectCallGraph(ctx context.Context, req *connect.Request[v1.SelectCallGraphRequest]) (*connect.Response[v1.SelectCallGraphResponse], error) {
+	return c.selectCallGraph.CallUnary(ctx, req)
+}
+
+// SelectFunctionDetails calls querier.v1.QuerierService.SelectFunctionDetails.
+func (c *querierServiceClient) SelectFunctionDetails(ctx context.Context, req *connect.Request[v1.SelectFunctionDetailsRequest]) (*connect.Response[v1.SelectFunctionDetailsResponse], error) {
+	return c.selectFunctionDetails.CallUnary(ctx, req)
+}
+
+// SelectHeatmap calls querier.v1.QuerierService.SelectHeatmap.
+func (c *querierServiceClient) SelectHeatmap(ctx context.Context, req *connect.Request[v1.SelectHeatmapRequest]) (*connect.Response[v1.SelectHeatmapResponse], error) {
+	return c.selectHeatmap.CallUnary(ctx, req)
+}
+
+// SelectExemplars calls querier.v1.QuerierService.SelectExemplars.
+func (c *querierServiceClient) SelectExemplars(ctx context.Context, req *connect.Request[v1.SelectExemplarsRequest]) (*connect.Response[v1.SelectExemplarsResponse], error) {
+	return c.selectExemplars.CallUnary(ctx, req)
+}
+
+// SelectProfileByID calls querier.v1.QuerierService.SelectProfileByID.
+func (c *querierServiceClient) SelectProfileByID(ctx context.Context, req *connect.Request[v1.SelectProfileByIDRequest]) (*connect.Response[v12.Profile], error) {
+	return c.selectProfileByID.CallUnary(ctx, req)
+}
+
 // Diff calls querier.v1.QuerierService.Diff.
 func (c *querierServiceClient) Diff(ctx context.Context, req *connect.Request[v1.DiffRequest]) (*connect.Response[v1.DiffResponse], error) {
 	return c.diff.CallUnary(ctx, req)
@@ -261,6 +370,25 @@ type QuerierServiceHandler interface {
 	SelectMergeProfile(context.Context, *connect.Request[v1.SelectMergeProfileRequest]) (*connect.Response[v12.Profile], error)
 	// SelectSeries returns a time series for the total sum of the requested profiles.
 	SelectSeries(context.Context, *connect.Request[v1.SelectSeriesRequest]) (*connect.Response[v1.SelectSeriesResponse], error)
+	// SelectTopTable returns the list of functions of the matching profiles ordered by their self or total value.
+	SelectTopTable(context.Context, *connect.Request[v1.SelectTopTableRequest]) (*connect.Response[v1.SelectTopTableResponse], error)
+	// SelectCallGraph returns the call graph of the matching profiles.
+	SelectCallGraph(context.Context, *connect.Request[v1.SelectCallGraphRequest]) (*connect.Response[v1.SelectCallGraphResponse], error)
+	// SelectFunctionDetails returns the callers and callees of the function, and the cost of its source code lines.
+	SelectFunctionDetails(context.Context, *connect.Request[v1.SelectFunctionDetailsRequest]) (*connect.Response[v1.SelectFunctionDetailsResponse], error)
+	// SelectHeatmap returns the distribution of individual profile values over time.
+	// Heatmaps are only built by the query backend: the ingesters and
+	// store-gateways return an Unimplemented error.
+	SelectHeatmap(context.Context, *connect.Request[v1.SelectHeatmapRequest]) (*connect.Response[v1.SelectHeatmapResponse], error)
+	// SelectExemplars returns the individual profiles with the highest values.
+	// Profile IDs are only stored by the query backend: the profiles stored
+	// by the ingesters and store-gateways are not returned.
+	SelectExemplars(context.Context, *connect.Request[v1.SelectExemplarsRequest]) (*connect.Response[v1.SelectExemplarsResponse], error)
+	// SelectProfileByID returns the profile with the given ID in pprof format.
+	// Only the profiles stored by the query backend can be fetched: the
+	// ingesters and store-gateways return an Unimplemented error. A NotFound
+	// error is returned if no profile has the ID in the time range.
+	SelectProfileByID(context.Context, *connect.Request[v1.SelectProfileByIDRequest]) (*connect.Response[v12.Profile], error)
 	// Diff returns a diff of two profiles
 	Diff(context.Context, *connect.Request[v1.DiffRequest]) (*connect.Response[v1.DiffResponse], error)
 	// GetProfileStats returns profile stats for the current tenant.
@@ -323,6 +451,42 @@ func NewQuerierServiceHandler(svc QuerierServiceHandler, opts ...connect.Handler
 		connect.WithSchema(querierServiceMethods.ByName("SelectSeries")),
 		connect.WithHandlerOptions(opts...),
 	)
+	querierServiceSelectTopTableHandler := connect.NewUnaryHandler(
+		QuerierServiceSelectTopTableProcedure,
+		svc.SelectTopTable,
+		connect.WithSchema(querierServiceMethods.ByName("SelectTopTable")),
+		connect.WithHandlerOptions(opts...),
+	)
+	querierServiceSelectCallGraphHandler := connect.NewUnaryHandler(
+		QuerierServiceSelectCallGraphProcedure,
+		svc.SelectCallGraph,
+		connect.WithSchema(querierServiceMethods.ByName("SelectCallGraph")),
+		connect.WithHandlerOptions(opts...),
+	)
+	querierServiceSelectFunctionDetailsHandler := connect.NewUnaryHandler(
+		QuerierServiceSelectFunctionDetailsProcedure,
+		svc.SelectFunctionDetails,
+		connect.WithSchema(querierServiceMethods.ByName("SelectFunctionDetails")),
+		connect.WithHandlerOptions(opts...),
+	)
+	querierServiceSelectHeatmapHandler := connect.NewUnaryHandler(
+		QuerierServiceSelectHeatmapProcedure,
+		svc.SelectHeatmap,
+		connect.WithSchema(querierServiceMethods.ByName("SelectHeatmap")),
+		connect.WithHandlerOptions(opts...),
+	)
+	querierServiceSelectExemplarsHandler := connect.NewUnaryHandler(
+		QuerierServiceSelectExemplarsProcedure,
+		svc.SelectExemplars,
+		connect.WithSchema(querierServiceMethods.ByName("SelectExemplars")),
+		connect.WithHandlerOptions(opts...),
+	)
+	querierServiceSelectProfileByIDHandler := connect.NewUnaryHandler(
+		QuerierServiceSelectProfileByIDProcedure,
+		svc.SelectProfileByID,
+		connect.WithSchema(querierServiceMethods.ByName("SelectProfileByID")),
+		connect.WithHandlerOptions(opts...),
+	)
 	querierServiceDiffHandler := connect.NewUnaryHandler(
 		QuerierServiceDiffProcedure,
 		svc.Diff,
@@ -359,6 +523,18 @@ func NewQuerierServiceHandler(svc QuerierServiceHandler, opts ...connect.Handler
 			querierServiceSelectMergeProfileHandler.ServeHTTP(w, r)
 		case QuerierServiceSelectSeriesProcedure:
 			querierServiceSelectSeriesHandler.ServeHTTP(w, r)
+		case QuerierServiceSelectTopTableProcedure:
+			querierServiceSelectTopTableHandler.ServeHTTP(w, r)
+		case QuerierServiceSelectCallGraphProcedure:
+			querierServiceSelectCallGraphHandler.ServeHTTP(w, r)
+		case QuerierServiceSelectFunctionDetailsProcedure:
+			querierServiceSelectFunctionDetailsHandler.ServeHTTP(w, r)
+		case QuerierServiceSelectHeatmapProcedure:
+			querierServiceSelectHeatmapHandler.ServeHTTP(w, r)
+		case QuerierServiceSelectExemplarsProcedure:
+			querierServiceSelectExemplarsHandler.ServeHTTP(w, r)
+		case QuerierServiceSelectProfileByIDProcedure:
+			querierServiceSelectProfileByIDHandler.ServeHTTP(w, r)
 		case QuerierServiceDiffProcedure:
 			querierServiceDiffHandler.ServeHTTP(w, r)
 		case QuerierServiceGetProfileStatsProcedure:
@@ -406,6 +582,30 @@ func (UnimplementedQuerierServiceHandler) SelectSeries(context.Context, *connect
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("querier.v1.QuerierService.SelectSeries is not implemented"))
 }
 
+func (UnimplementedQuerierServiceHandler) SelectTopTable(context.Context, *connect.Request[v1.SelectTopTableRequest]) (*connect.Response[v1.SelectTopTableResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("querier.v1.QuerierService.SelectTopTable is not implemented"))
+}
+
+func (UnimplementedQuerierServiceHandler) SelectCallGraph(context.Context, *connect.Request[v1.SelectCallGraphRequest]) (*connect.Response[v1.SelectCallGraphResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("querier.v1.QuerierService.SelectCallGraph is not implemented"))
+}
+
+func (UnimplementedQuerierServiceHandler) SelectFunctionDetails(context.Context, *connect.Request[v1.SelectFunctionDetailsRequest]) (*connect.Response[v1.SelectFunctionDetailsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("querier.v1.QuerierService.SelectFunctionDetails is not implemented"))
+}
+
+func (UnimplementedQuerierServiceHandler) SelectHeatmap(context.Context, *connect.Request[v1.SelectHeatmapRequest]) (*connect.Response[v1.SelectHeatmapResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("querier.v1.QuerierService.SelectHeatmap is not implemented"))
+}
+
+func (UnimplementedQuerierServiceHandler) SelectExemplars(context.Context, *connect.Request[v1.SelectExemplarsRequest]) (*connect.Response[v1.SelectExemplarsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("querier.v1.QuerierService.SelectExemplars is not implemented"))
+}
+
+func (UnimplementedQuerierServiceHandler) SelectProfileByID(context.Context, *connect.Request[v1.SelectProfileByIDRequest]) (*connect.Response[v12.Profile], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("querier.v1.QuerierService.SelectProfileByID is not implemented"))
+}
+
 func (UnimplementedQuerierServiceHandler) Diff(context.Context, *connect.Request[v1.DiffRequest]) (*connect.Response[v1.DiffResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("querier.v1.QuerierService.Diff is not implemented"))
 }
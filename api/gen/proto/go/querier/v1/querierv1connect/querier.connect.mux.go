@@ -59,6 +59,36 @@ func RegisterQuerierServiceHandler(mux *mux.Router, svc QuerierServiceHandler, o
 		svc.SelectSeries,
 		opts...,
 	))
+	mux.Handle("/querier.v1.QuerierService/SelectTopTable", connect.NewUnaryHandler(
+		"/querier.v1.QuerierService/SelectTopTable",
+		svc.SelectTopTable,
+		opts...,
+	))
+	mux.Handle("/querier.v1.QuerierService/SelectCallGraph", connect.NewUnaryHandler(
+		"/querier.v1.QuerierService/SelectCallGraph",
+		svc.SelectCallGraph,
+		opts...,
+	))
+	mux.Handle("/querier.v1.QuerierService/SelectFunctionDetails", connect.NewUnaryHandler(
+		"/querier.v1.QuerierService/SelectFunctionDetails",
+		svc.SelectFunctionDetails,
+		opts...,
+	))
+	mux.Handle("/querier.v1.QuerierService/SelectHeatmap", connect.NewUnaryHandler(
+		"/querier.v1.QuerierService/SelectHeatmap",
+		svc.SelectHeatmap,
+		opts...,
+	))
+	mux.Handle("/querier.v1.QuerierService/SelectExemplars", connect.NewUnaryHandler(
+		"/querier.v1.QuerierService/SelectExemplars",
+		svc.SelectExemplars,
+		opts...,
+	))
+	mux.Handle("/querier.v1.QuerierService/SelectProfileByID", connect.NewUnaryHandler(
+		"/querier.v1.QuerierService/SelectProfileByID",
+		svc.SelectProfileByID,
+		opts...,
+	))
 	mux.Handle("/querier.v1.QuerierService/Diff", connect.NewUnaryHandler(
 		"/querier.v1.QuerierService/Diff",
 		svc.Diff,
@@ -67,6 +67,52 @@ func (TimeSeriesAggregationType) EnumDescriptor() ([]byte, []int) {
 	return file_types_v1_types_proto_rawDescGZIP(), []int{0}
 }
 
+type TopTableOrderBy int32
+
+const (
+	TopTableOrderBy_TOP_TABLE_ORDER_BY_SELF  TopTableOrderBy = 0
+	TopTableOrderBy_TOP_TABLE_ORDER_BY_TOTAL TopTableOrderBy = 1
+)
+
+// Enum value maps for TopTableOrderBy.
+var (
+	TopTableOrderBy_name = map[int32]string{
+		0: "TOP_TABLE_ORDER_BY_SELF",
+		1: "TOP_TABLE_ORDER_BY_TOTAL",
+	}
+	TopTableOrderBy_value = map[string]int32{
+		"TOP_TABLE_ORDER_BY_SELF":  0,
+		"TOP_TABLE_ORDER_BY_TOTAL": 1,
+	}
+)
+
+func (x TopTableOrderBy) Enum() *TopTableOrderBy {
+	p := new(TopTableOrderBy)
+	*p = x
+	return p
+}
+
+func (x TopTableOrderBy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TopTableOrderBy) Descriptor() protoreflect.EnumDescriptor {
+	return file_types_v1_types_proto_enumTypes[1].Descriptor()
+}
+
+func (TopTableOrderBy) Type() protoreflect.EnumType {
+	return &file_types_v1_types_proto_enumTypes[1]
+}
+
+func (x TopTableOrderBy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TopTableOrderBy.Descriptor instead.
+func (TopTableOrderBy) EnumDescriptor() ([]byte, []int) {
+	return file_types_v1_types_proto_rawDescGZIP(), []int{1}
+}
+
 type LabelPair struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -867,6 +913,82 @@ func (x *Location) GetName() string {
 	return ""
 }
 
+// FrameFilter filters stack trace frames by function name. The filters
+// are regular expressions matched against function names, and have the
+// same semantics as the pprof options of the same name.
+// Empty filters are ignored.
+type FrameFilter struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only stack traces that have a frame matching the expression are kept.
+	Focus string `protobuf:"bytes,1,opt,name=focus,proto3" json:"focus,omitempty"`
+	// Stack traces that have a frame matching the expression are dropped.
+	Ignore string `protobuf:"bytes,2,opt,name=ignore,proto3" json:"ignore,omitempty"`
+	// Frames matching the expression are removed from stack traces.
+	Hide string `protobuf:"bytes,3,opt,name=hide,proto3" json:"hide,omitempty"`
+	// Frames called by the outermost frame matching the expression are removed.
+	PruneFrom     string `protobuf:"bytes,4,opt,name=prune_from,json=pruneFrom,proto3" json:"prune_from,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FrameFilter) Reset() {
+	*x = FrameFilter{}
+	mi := &file_types_v1_types_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FrameFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FrameFilter) ProtoMessage() {}
+
+func (x *FrameFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_types_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FrameFilter.ProtoReflect.Descriptor instead.
+func (*FrameFilter) Descriptor() ([]byte, []int) {
+	return file_types_v1_types_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *FrameFilter) GetFocus() string {
+	if x != nil {
+		return x.Focus
+	}
+	return ""
+}
+
+func (x *FrameFilter) GetIgnore() string {
+	if x != nil {
+		return x.Ignore
+	}
+	return ""
+}
+
+func (x *FrameFilter) GetHide() string {
+	if x != nil {
+		return x.Hide
+	}
+	return ""
+}
+
+func (x *FrameFilter) GetPruneFrom() string {
+	if x != nil {
+		return x.PruneFrom
+	}
+	return ""
+}
+
 type GoPGO struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Specifies the number of leaf locations to keep.
@@ -880,7 +1002,7 @@ type GoPGO struct {
 
 func (x *GoPGO) Reset() {
 	*x = GoPGO{}
-	mi := &file_types_v1_types_proto_msgTypes[14]
+	mi := &file_types_v1_types_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -892,7 +1014,7 @@ func (x *GoPGO) String() string {
 func (*GoPGO) ProtoMessage() {}
 
 func (x *GoPGO) ProtoReflect() protoreflect.Message {
-	mi := &file_types_v1_types_proto_msgTypes[14]
+	mi := &file_types_v1_types_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -905,7 +1027,7 @@ func (x *GoPGO) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GoPGO.ProtoReflect.Descriptor instead.
 func (*GoPGO) Descriptor() ([]byte, []int) {
-	return file_types_v1_types_proto_rawDescGZIP(), []int{14}
+	return file_types_v1_types_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *GoPGO) GetKeepLocations() uint32 {
@@ -922,6 +1044,560 @@ func (x *GoPGO) GetAggregateCallees() bool {
 	return false
 }
 
+// TopTableEntry describes the aggregated cost of a single function.
+type TopTableEntry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Function name.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Value of the samples where the function is the leaf frame.
+	Self int64 `protobuf:"varint,2,opt,name=self,proto3" json:"self,omitempty"`
+	// Value of the samples where the function is present in the stack
+	// trace. Recursive calls are accounted once.
+	Total int64 `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	// Number of the samples where the function is present in the
+	// stack trace. Not set if the table is built from merged stack
+	// traces, which do not retain sample counts: this is the case for
+	// the v1 read path and for profiles symbolized at query time.
+	Samples       *int64 `protobuf:"varint,4,opt,name=samples,proto3,oneof" json:"samples,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopTableEntry) Reset() {
+	*x = TopTableEntry{}
+	mi := &file_types_v1_types_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopTableEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopTableEntry) ProtoMessage() {}
+
+func (x *TopTableEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_types_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopTableEntry.ProtoReflect.Descriptor instead.
+func (*TopTableEntry) Descriptor() ([]byte, []int) {
+	return file_types_v1_types_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *TopTableEntry) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TopTableEntry) GetSelf() int64 {
+	if x != nil {
+		return x.Self
+	}
+	return 0
+}
+
+func (x *TopTableEntry) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *TopTableEntry) GetSamples() int64 {
+	if x != nil && x.Samples != nil {
+		return *x.Samples
+	}
+	return 0
+}
+
+// CallGraph is a directed graph of function calls: nodes represent
+// functions, and edges connect callers with their callees.
+type CallGraph struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Nodes         []*CallGraphNode       `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	Edges         []*CallGraphEdge       `protobuf:"bytes,2,rep,name=edges,proto3" json:"edges,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CallGraph) Reset() {
+	*x = CallGraph{}
+	mi := &file_types_v1_types_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CallGraph) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CallGraph) ProtoMessage() {}
+
+func (x *CallGraph) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_types_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CallGraph.ProtoReflect.Descriptor instead.
+func (*CallGraph) Descriptor() ([]byte, []int) {
+	return file_types_v1_types_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CallGraph) GetNodes() []*CallGraphNode {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *CallGraph) GetEdges() []*CallGraphEdge {
+	if x != nil {
+		return x.Edges
+	}
+	return nil
+}
+
+type CallGraphNode struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Function name.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Value of the samples where the function is the leaf frame.
+	Self int64 `protobuf:"varint,2,opt,name=self,proto3" json:"self,omitempty"`
+	// Value of the samples where the function is present in the stack
+	// trace. Recursive calls are accounted once.
+	Total         int64 `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CallGraphNode) Reset() {
+	*x = CallGraphNode{}
+	mi := &file_types_v1_types_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CallGraphNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CallGraphNode) ProtoMessage() {}
+
+func (x *CallGraphNode) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_types_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CallGraphNode.ProtoReflect.Descriptor instead.
+func (*CallGraphNode) Descriptor() ([]byte, []int) {
+	return file_types_v1_types_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CallGraphNode) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CallGraphNode) GetSelf() int64 {
+	if x != nil {
+		return x.Self
+	}
+	return 0
+}
+
+func (x *CallGraphNode) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type CallGraphEdge struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Index of the caller node.
+	Caller int32 `protobuf:"varint,1,opt,name=caller,proto3" json:"caller,omitempty"`
+	// Index of the callee node.
+	Callee int32 `protobuf:"varint,2,opt,name=callee,proto3" json:"callee,omitempty"`
+	// Value of the samples where the call is present in the stack
+	// trace. Recursive calls are accounted once.
+	Value         int64 `protobuf:"varint,3,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CallGraphEdge) Reset() {
+	*x = CallGraphEdge{}
+	mi := &file_types_v1_types_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CallGraphEdge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CallGraphEdge) ProtoMessage() {}
+
+func (x *CallGraphEdge) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_types_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CallGraphEdge.ProtoReflect.Descriptor instead.
+func (*CallGraphEdge) Descriptor() ([]byte, []int) {
+	return file_types_v1_types_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CallGraphEdge) GetCaller() int32 {
+	if x != nil {
+		return x.Caller
+	}
+	return 0
+}
+
+func (x *CallGraphEdge) GetCallee() int32 {
+	if x != nil {
+		return x.Callee
+	}
+	return 0
+}
+
+func (x *CallGraphEdge) GetValue() int64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+// FunctionLine describes the cost of a single source code line of a function.
+type FunctionLine struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	FileName string                 `protobuf:"bytes,1,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	Line     int64                  `protobuf:"varint,2,opt,name=line,proto3" json:"line,omitempty"`
+	// Value of the samples where the line is in the leaf frame.
+	Self int64 `protobuf:"varint,3,opt,name=self,proto3" json:"self,omitempty"`
+	// Value of the samples where the line is present in the stack
+	// trace. Recursive calls are accounted once.
+	Total         int64 `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FunctionLine) Reset() {
+	*x = FunctionLine{}
+	mi := &file_types_v1_types_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FunctionLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FunctionLine) ProtoMessage() {}
+
+func (x *FunctionLine) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_types_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FunctionLine.ProtoReflect.Descriptor instead.
+func (*FunctionLine) Descriptor() ([]byte, []int) {
+	return file_types_v1_types_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *FunctionLine) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *FunctionLine) GetLine() int64 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *FunctionLine) GetSelf() int64 {
+	if x != nil {
+		return x.Self
+	}
+	return 0
+}
+
+func (x *FunctionLine) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+// ProfileExemplar describes an individual profile.
+type ProfileExemplar struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ID of the profile assigned at ingestion. A profile might be split
+	// into multiple series: such exemplars share the same ID.
+	ProfileId string `protobuf:"bytes,1,opt,name=profile_id,json=profileId,proto3" json:"profile_id,omitempty"`
+	// Milliseconds since epoch.
+	Timestamp int64 `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Total value of the profile samples.
+	Value int64 `protobuf:"varint,3,opt,name=value,proto3" json:"value,omitempty"`
+	// Labels of the profile series.
+	Labels        []*LabelPair `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProfileExemplar) Reset() {
+	*x = ProfileExemplar{}
+	mi := &file_types_v1_types_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProfileExemplar) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProfileExemplar) ProtoMessage() {}
+
+func (x *ProfileExemplar) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_types_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProfileExemplar.ProtoReflect.Descriptor instead.
+func (*ProfileExemplar) Descriptor() ([]byte, []int) {
+	return file_types_v1_types_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ProfileExemplar) GetProfileId() string {
+	if x != nil {
+		return x.ProfileId
+	}
+	return ""
+}
+
+func (x *ProfileExemplar) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *ProfileExemplar) GetValue() int64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *ProfileExemplar) GetLabels() []*LabelPair {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+// Heatmap is a histogram of individual profile values over time.
+// Time buckets are aligned to multiples of the step. Value buckets are
+// exponential: with the resolution r, bucket i > 0 covers the range
+// (2^((i-1)/r), 2^(i/r)], and bucket 0 covers values up to 1.
+// The bucket layout does not depend on the data, therefore heatmaps
+// with the same step and resolution can be merged.
+type Heatmap struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Width of the time buckets in milliseconds.
+	Step int64 `protobuf:"varint,1,opt,name=step,proto3" json:"step,omitempty"`
+	// Number of value buckets per power of two.
+	ValueResolution int32 `protobuf:"varint,2,opt,name=value_resolution,json=valueResolution,proto3" json:"value_resolution,omitempty"`
+	// Non-empty cells ordered by timestamp and value bucket.
+	Cells         []*HeatmapCell `protobuf:"bytes,3,rep,name=cells,proto3" json:"cells,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Heatmap) Reset() {
+	*x = Heatmap{}
+	mi := &file_types_v1_types_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Heatmap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Heatmap) ProtoMessage() {}
+
+func (x *Heatmap) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_types_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Heatmap.ProtoReflect.Descriptor instead.
+func (*Heatmap) Descriptor() ([]byte, []int) {
+	return file_types_v1_types_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Heatmap) GetStep() int64 {
+	if x != nil {
+		return x.Step
+	}
+	return 0
+}
+
+func (x *Heatmap) GetValueResolution() int32 {
+	if x != nil {
+		return x.ValueResolution
+	}
+	return 0
+}
+
+func (x *Heatmap) GetCells() []*HeatmapCell {
+	if x != nil {
+		return x.Cells
+	}
+	return nil
+}
+
+type HeatmapCell struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Start of the time bucket. Milliseconds since epoch.
+	Timestamp int64 `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Index of the value bucket.
+	ValueBucket int32 `protobuf:"varint,2,opt,name=value_bucket,json=valueBucket,proto3" json:"value_bucket,omitempty"`
+	// Bounds of the value bucket: (min_value, max_value].
+	MinValue float64 `protobuf:"fixed64,3,opt,name=min_value,json=minValue,proto3" json:"min_value,omitempty"`
+	MaxValue float64 `protobuf:"fixed64,4,opt,name=max_value,json=maxValue,proto3" json:"max_value,omitempty"`
+	// Number of profiles that fall into the cell.
+	Count         int64 `protobuf:"varint,5,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeatmapCell) Reset() {
+	*x = HeatmapCell{}
+	mi := &file_types_v1_types_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeatmapCell) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeatmapCell) ProtoMessage() {}
+
+func (x *HeatmapCell) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_types_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeatmapCell.ProtoReflect.Descriptor instead.
+func (*HeatmapCell) Descriptor() ([]byte, []int) {
+	return file_types_v1_types_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *HeatmapCell) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *HeatmapCell) GetValueBucket() int32 {
+	if x != nil {
+		return x.ValueBucket
+	}
+	return 0
+}
+
+func (x *HeatmapCell) GetMinValue() float64 {
+	if x != nil {
+		return x.MinValue
+	}
+	return 0
+}
+
+func (x *HeatmapCell) GetMaxValue() float64 {
+	if x != nil {
+		return x.MaxValue
+	}
+	return 0
+}
+
+func (x *HeatmapCell) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
 type GetProfileStatsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -930,7 +1606,7 @@ type GetProfileStatsRequest struct {
 
 func (x *GetProfileStatsRequest) Reset() {
 	*x = GetProfileStatsRequest{}
-	mi := &file_types_v1_types_proto_msgTypes[15]
+	mi := &file_types_v1_types_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -942,7 +1618,7 @@ func (x *GetProfileStatsRequest) String() string {
 func (*GetProfileStatsRequest) ProtoMessage() {}
 
 func (x *GetProfileStatsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_types_v1_types_proto_msgTypes[15]
+	mi := &file_types_v1_types_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -955,7 +1631,7 @@ func (x *GetProfileStatsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetProfileStatsRequest.ProtoReflect.Descriptor instead.
 func (*GetProfileStatsRequest) Descriptor() ([]byte, []int) {
-	return file_types_v1_types_proto_rawDescGZIP(), []int{15}
+	return file_types_v1_types_proto_rawDescGZIP(), []int{24}
 }
 
 type GetProfileStatsResponse struct {
@@ -972,7 +1648,7 @@ type GetProfileStatsResponse struct {
 
 func (x *GetProfileStatsResponse) Reset() {
 	*x = GetProfileStatsResponse{}
-	mi := &file_types_v1_types_proto_msgTypes[16]
+	mi := &file_types_v1_types_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -984,7 +1660,7 @@ func (x *GetProfileStatsResponse) String() string {
 func (*GetProfileStatsResponse) ProtoMessage() {}
 
 func (x *GetProfileStatsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_types_v1_types_proto_msgTypes[16]
+	mi := &file_types_v1_types_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -997,7 +1673,7 @@ func (x *GetProfileStatsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetProfileStatsResponse.ProtoReflect.Descriptor instead.
 func (*GetProfileStatsResponse) Descriptor() ([]byte, []int) {
-	return file_types_v1_types_proto_rawDescGZIP(), []int{16}
+	return file_types_v1_types_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *GetProfileStatsResponse) GetDataIngested() bool {
@@ -1106,31 +1782,101 @@ var file_types_v1_types_proto_rawDesc = string([]byte{
 	0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47,
 	0x6f, 0x50, 0x47, 0x4f, 0x52, 0x05, 0x67, 0x6f, 0x50, 0x67, 0x6f, 0x22, 0x1e, 0x0a, 0x08, 0x4c,
 	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x5b, 0x0a, 0x05, 0x47,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x6e, 0x0a, 0x0b, 0x46,
+	0x72, 0x61, 0x6d, 0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6f,
+	0x63, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x6f, 0x63, 0x75, 0x73,
+	0x12, 0x16, 0x0a, 0x06, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x69, 0x64, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x69, 0x64, 0x65, 0x12, 0x1d, 0x0a, 0x0a,
+	0x70, 0x72, 0x75, 0x6e, 0x65, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x70, 0x72, 0x75, 0x6e, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x22, 0x5b, 0x0a, 0x05, 0x47,
 	0x6f, 0x50, 0x47, 0x4f, 0x12, 0x25, 0x0a, 0x0e, 0x6b, 0x65, 0x65, 0x70, 0x5f, 0x6c, 0x6f, 0x63,
 	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x6b, 0x65,
 	0x65, 0x70, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x61,
 	0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x5f, 0x63, 0x61, 0x6c, 0x6c, 0x65, 0x65, 0x73,
 	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74,
-	0x65, 0x43, 0x61, 0x6c, 0x6c, 0x65, 0x65, 0x73, 0x22, 0x18, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x50,
-	0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x22, 0x9e, 0x01, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c,
-	0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23,
-	0x0a, 0x0d, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x69, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x65, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x49, 0x6e, 0x67, 0x65, 0x73,
-	0x74, 0x65, 0x64, 0x12, 0x2e, 0x0a, 0x13, 0x6f, 0x6c, 0x64, 0x65, 0x73, 0x74, 0x5f, 0x70, 0x72,
-	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x11, 0x6f, 0x6c, 0x64, 0x65, 0x73, 0x74, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x54,
-	0x69, 0x6d, 0x65, 0x12, 0x2e, 0x0a, 0x13, 0x6e, 0x65, 0x77, 0x65, 0x73, 0x74, 0x5f, 0x70, 0x72,
-	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x11, 0x6e, 0x65, 0x77, 0x65, 0x73, 0x74, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x54,
-	0x69, 0x6d, 0x65, 0x2a, 0x6b, 0x0a, 0x19, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x69, 0x65,
-	0x73, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65,
-	0x12, 0x24, 0x0a, 0x20, 0x54, 0x49, 0x4d, 0x45, 0x5f, 0x53, 0x45, 0x52, 0x49, 0x45, 0x53, 0x5f,
-	0x41, 0x47, 0x47, 0x52, 0x45, 0x47, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x54, 0x59, 0x50, 0x45,
-	0x5f, 0x53, 0x55, 0x4d, 0x10, 0x00, 0x12, 0x28, 0x0a, 0x24, 0x54, 0x49, 0x4d, 0x45, 0x5f, 0x53,
-	0x45, 0x52, 0x49, 0x45, 0x53, 0x5f, 0x41, 0x47, 0x47, 0x52, 0x45, 0x47, 0x41, 0x54, 0x49, 0x4f,
-	0x4e, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x41, 0x56, 0x45, 0x52, 0x41, 0x47, 0x45, 0x10, 0x01,
+	0x65, 0x43, 0x61, 0x6c, 0x6c, 0x65, 0x65, 0x73, 0x22, 0x78, 0x0a, 0x0d, 0x54, 0x6f, 0x70, 0x54,
+	0x61, 0x62, 0x6c, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x73, 0x65, 0x6c, 0x66, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x65, 0x6c,
+	0x66, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x1d, 0x0a, 0x07, 0x73, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x07, 0x73, 0x61, 0x6d, 0x70,
+	0x6c, 0x65, 0x73, 0x88, 0x01, 0x01, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x73, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x73, 0x22, 0x69, 0x0a, 0x09, 0x43, 0x61, 0x6c, 0x6c, 0x47, 0x72, 0x61, 0x70, 0x68, 0x12,
+	0x2d, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17,
+	0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6c, 0x6c, 0x47, 0x72,
+	0x61, 0x70, 0x68, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x2d,
+	0x0a, 0x05, 0x65, 0x64, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e,
+	0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6c, 0x6c, 0x47, 0x72, 0x61,
+	0x70, 0x68, 0x45, 0x64, 0x67, 0x65, 0x52, 0x05, 0x65, 0x64, 0x67, 0x65, 0x73, 0x22, 0x4d, 0x0a,
+	0x0d, 0x43, 0x61, 0x6c, 0x6c, 0x47, 0x72, 0x61, 0x70, 0x68, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x65, 0x6c, 0x66, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x04, 0x73, 0x65, 0x6c, 0x66, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x22, 0x55, 0x0a, 0x0d,
+	0x43, 0x61, 0x6c, 0x6c, 0x47, 0x72, 0x61, 0x70, 0x68, 0x45, 0x64, 0x67, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x63, 0x61, 0x6c, 0x6c, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x63,
+	0x61, 0x6c, 0x6c, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x61, 0x6c, 0x6c, 0x65, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x63, 0x61, 0x6c, 0x6c, 0x65, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x22, 0x69, 0x0a, 0x0c, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4c,
+	0x69, 0x6e, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04,
+	0x6c, 0x69, 0x6e, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x65, 0x6c, 0x66, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x04, 0x73, 0x65, 0x6c, 0x66, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x22, 0x91,
+	0x01, 0x0a, 0x0f, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x45, 0x78, 0x65, 0x6d, 0x70, 0x6c,
+	0x61, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x49,
+	0x64, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x2b, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x50, 0x61, 0x69, 0x72, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65,
+	0x6c, 0x73, 0x22, 0x75, 0x0a, 0x07, 0x48, 0x65, 0x61, 0x74, 0x6d, 0x61, 0x70, 0x12, 0x12, 0x0a,
+	0x04, 0x73, 0x74, 0x65, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x74, 0x65,
+	0x70, 0x12, 0x29, 0x0a, 0x10, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f, 0x72, 0x65, 0x73, 0x6f, 0x6c,
+	0x75, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2b, 0x0a, 0x05,
+	0x63, 0x65, 0x6c, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x74, 0x79,
+	0x70, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x74, 0x6d, 0x61, 0x70, 0x43, 0x65,
+	0x6c, 0x6c, 0x52, 0x05, 0x63, 0x65, 0x6c, 0x6c, 0x73, 0x22, 0x9e, 0x01, 0x0a, 0x0b, 0x48, 0x65,
+	0x61, 0x74, 0x6d, 0x61, 0x70, 0x43, 0x65, 0x6c, 0x6c, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x21, 0x0a, 0x0c, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x5f, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x69,
+	0x6e, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6d,
+	0x69, 0x6e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x18, 0x0a, 0x16, 0x47, 0x65,
+	0x74, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x22, 0x9e, 0x01, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x66,
+	0x69, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x23, 0x0a, 0x0d, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x69, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x65,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x49, 0x6e, 0x67,
+	0x65, 0x73, 0x74, 0x65, 0x64, 0x12, 0x2e, 0x0a, 0x13, 0x6f, 0x6c, 0x64, 0x65, 0x73, 0x74, 0x5f,
+	0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x11, 0x6f, 0x6c, 0x64, 0x65, 0x73, 0x74, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c,
+	0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x2e, 0x0a, 0x13, 0x6e, 0x65, 0x77, 0x65, 0x73, 0x74, 0x5f,
+	0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x11, 0x6e, 0x65, 0x77, 0x65, 0x73, 0x74, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c,
+	0x65, 0x54, 0x69, 0x6d, 0x65, 0x2a, 0x6b, 0x0a, 0x19, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x65, 0x72,
+	0x69, 0x65, 0x73, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x24, 0x0a, 0x20, 0x54, 0x49, 0x4d, 0x45, 0x5f, 0x53, 0x45, 0x52, 0x49, 0x45,
+	0x53, 0x5f, 0x41, 0x47, 0x47, 0x52, 0x45, 0x47, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x54, 0x59,
+	0x50, 0x45, 0x5f, 0x53, 0x55, 0x4d, 0x10, 0x00, 0x12, 0x28, 0x0a, 0x24, 0x54, 0x49, 0x4d, 0x45,
+	0x5f, 0x53, 0x45, 0x52, 0x49, 0x45, 0x53, 0x5f, 0x41, 0x47, 0x47, 0x52, 0x45, 0x47, 0x41, 0x54,
+	0x49, 0x4f, 0x4e, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x41, 0x56, 0x45, 0x52, 0x41, 0x47, 0x45,
+	0x10, 0x01, 0x2a, 0x4c, 0x0a, 0x0f, 0x54, 0x6f, 0x70, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x4f, 0x72,
+	0x64, 0x65, 0x72, 0x42, 0x79, 0x12, 0x1b, 0x0a, 0x17, 0x54, 0x4f, 0x50, 0x5f, 0x54, 0x41, 0x42,
+	0x4c, 0x45, 0x5f, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x42, 0x59, 0x5f, 0x53, 0x45, 0x4c, 0x46,
+	0x10, 0x00, 0x12, 0x1c, 0x0a, 0x18, 0x54, 0x4f, 0x50, 0x5f, 0x54, 0x41, 0x42, 0x4c, 0x45, 0x5f,
+	0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x42, 0x59, 0x5f, 0x54, 0x4f, 0x54, 0x41, 0x4c, 0x10, 0x01,
 	0x42, 0x9b, 0x01, 0x0a, 0x0c, 0x63, 0x6f, 0x6d, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x76,
 	0x31, 0x42, 0x0a, 0x54, 0x79, 0x70, 0x65, 0x73, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a,
 	0x3e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x72, 0x61, 0x66,
@@ -1156,42 +1902,56 @@ func file_types_v1_types_proto_rawDescGZIP() []byte {
 	return file_types_v1_types_proto_rawDescData
 }
 
-var file_types_v1_types_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_types_v1_types_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_types_v1_types_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_types_v1_types_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
 var file_types_v1_types_proto_goTypes = []any{
 	(TimeSeriesAggregationType)(0),  // 0: types.v1.TimeSeriesAggregationType
-	(*LabelPair)(nil),               // 1: types.v1.LabelPair
-	(*ProfileType)(nil),             // 2: types.v1.ProfileType
-	(*Labels)(nil),                  // 3: types.v1.Labels
-	(*Series)(nil),                  // 4: types.v1.Series
-	(*Point)(nil),                   // 5: types.v1.Point
-	(*ProfileAnnotation)(nil),       // 6: types.v1.ProfileAnnotation
-	(*LabelValuesRequest)(nil),      // 7: types.v1.LabelValuesRequest
-	(*LabelValuesResponse)(nil),     // 8: types.v1.LabelValuesResponse
-	(*LabelNamesRequest)(nil),       // 9: types.v1.LabelNamesRequest
-	(*LabelNamesResponse)(nil),      // 10: types.v1.LabelNamesResponse
-	(*BlockInfo)(nil),               // 11: types.v1.BlockInfo
-	(*BlockCompaction)(nil),         // 12: types.v1.BlockCompaction
-	(*StackTraceSelector)(nil),      // 13: types.v1.StackTraceSelector
-	(*Location)(nil),                // 14: types.v1.Location
-	(*GoPGO)(nil),                   // 15: types.v1.GoPGO
-	(*GetProfileStatsRequest)(nil),  // 16: types.v1.GetProfileStatsRequest
-	(*GetProfileStatsResponse)(nil), // 17: types.v1.GetProfileStatsResponse
+	(TopTableOrderBy)(0),            // 1: types.v1.TopTableOrderBy
+	(*LabelPair)(nil),               // 2: types.v1.LabelPair
+	(*ProfileType)(nil),             // 3: types.v1.ProfileType
+	(*Labels)(nil),                  // 4: types.v1.Labels
+	(*Series)(nil),                  // 5: types.v1.Series
+	(*Point)(nil),                   // 6: types.v1.Point
+	(*ProfileAnnotation)(nil),       // 7: types.v1.ProfileAnnotation
+	(*LabelValuesRequest)(nil),      // 8: types.v1.LabelValuesRequest
+	(*LabelValuesResponse)(nil),     // 9: types.v1.LabelValuesResponse
+	(*LabelNamesRequest)(nil),       // 10: types.v1.LabelNamesRequest
+	(*LabelNamesResponse)(nil),      // 11: types.v1.LabelNamesResponse
+	(*BlockInfo)(nil),               // 12: types.v1.BlockInfo
+	(*BlockCompaction)(nil),         // 13: types.v1.BlockCompaction
+	(*StackTraceSelector)(nil),      // 14: types.v1.StackTraceSelector
+	(*Location)(nil),                // 15: types.v1.Location
+	(*FrameFilter)(nil),             // 16: types.v1.FrameFilter
+	(*GoPGO)(nil),                   // 17: types.v1.GoPGO
+	(*TopTableEntry)(nil),           // 18: types.v1.TopTableEntry
+	(*CallGraph)(nil),               // 19: types.v1.CallGraph
+	(*CallGraphNode)(nil),           // 20: types.v1.CallGraphNode
+	(*CallGraphEdge)(nil),           // 21: types.v1.CallGraphEdge
+	(*FunctionLine)(nil),            // 22: types.v1.FunctionLine
+	(*ProfileExemplar)(nil),         // 23: types.v1.ProfileExemplar
+	(*Heatmap)(nil),                 // 24: types.v1.Heatmap
+	(*HeatmapCell)(nil),             // 25: types.v1.HeatmapCell
+	(*GetProfileStatsRequest)(nil),  // 26: types.v1.GetProfileStatsRequest
+	(*GetProfileStatsResponse)(nil), // 27: types.v1.GetProfileStatsResponse
 }
 var file_types_v1_types_proto_depIdxs = []int32{
-	1,  // 0: types.v1.Labels.labels:type_name -> types.v1.LabelPair
-	1,  // 1: types.v1.Series.labels:type_name -> types.v1.LabelPair
-	5,  // 2: types.v1.Series.points:type_name -> types.v1.Point
-	6,  // 3: types.v1.Point.annotations:type_name -> types.v1.ProfileAnnotation
-	12, // 4: types.v1.BlockInfo.compaction:type_name -> types.v1.BlockCompaction
-	1,  // 5: types.v1.BlockInfo.labels:type_name -> types.v1.LabelPair
-	14, // 6: types.v1.StackTraceSelector.call_site:type_name -> types.v1.Location
-	15, // 7: types.v1.StackTraceSelector.go_pgo:type_name -> types.v1.GoPGO
-	8,  // [8:8] is the sub-list for method output_type
-	8,  // [8:8] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+	2,  // 0: types.v1.Labels.labels:type_name -> types.v1.LabelPair
+	2,  // 1: types.v1.Series.labels:type_name -> types.v1.LabelPair
+	6,  // 2: types.v1.Series.points:type_name -> types.v1.Point
+	7,  // 3: types.v1.Point.annotations:type_name -> types.v1.ProfileAnnotation
+	13, // 4: types.v1.BlockInfo.compaction:type_name -> types.v1.BlockCompaction
+	2,  // 5: types.v1.BlockInfo.labels:type_name -> types.v1.LabelPair
+	15, // 6: types.v1.StackTraceSelector.call_site:type_name -> types.v1.Location
+	17, // 7: types.v1.StackTraceSelector.go_pgo:type_name -> types.v1.GoPGO
+	20, // 8: types.v1.CallGraph.nodes:type_name -> types.v1.CallGraphNode
+	21, // 9: types.v1.CallGraph.edges:type_name -> types.v1.CallGraphEdge
+	2,  // 10: types.v1.ProfileExemplar.labels:type_name -> types.v1.LabelPair
+	25, // 11: types.v1.Heatmap.cells:type_name -> types.v1.HeatmapCell
+	12, // [12:12] is the sub-list for method output_type
+	12, // [12:12] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
 }
 
 func init() { file_types_v1_types_proto_init() }
@@ -1199,13 +1959,14 @@ func file_types_v1_types_proto_init() {
 	if File_types_v1_types_proto != nil {
 		return
 	}
+	file_types_v1_types_proto_msgTypes[16].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_types_v1_types_proto_rawDesc), len(file_types_v1_types_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   17,
+			NumEnums:      2,
+			NumMessages:   26,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/go-kit/log/level"
+)
+
+type debuginfoMeta struct {
+	BuildID    string `json:"build_id"`
+	LidiaSize  int64  `json:"lidia_size"`
+	UploadedAt string `json:"uploaded_at"`
+}
+
+type debuginfoUploadParams struct {
+	*phlareClient
+	paths []string
+}
+
+func addDebuginfoUploadParams(cmd commander) *debuginfoUploadParams {
+	params := &debuginfoUploadParams{}
+	params.phlareClient = addPhlareClient(cmd)
+
+	cmd.Arg("path", "Path(s) to the ELF executables or debug files to upload, optionally compressed with gzip or zstd.").Required().ExistingFilesVar(&params.paths)
+	return params
+}
+
+type debuginfoListParams struct {
+	*phlareClient
+}
+
+func addDebuginfoListParams(cmd commander) *debuginfoListParams {
+	params := &debuginfoListParams{}
+	params.phlareClient = addPhlareClient(cmd)
+	return params
+}
+
+type debuginfoDeleteParams struct {
+	*phlareClient
+	buildIDs []string
+}
+
+func addDebuginfoDeleteParams(cmd commander) *debuginfoDeleteParams {
+	params := &debuginfoDeleteParams{}
+	params.phlareClient = addPhlareClient(cmd)
+
+	cmd.Arg("build-id", "Build ID(s) of the debug files to delete.").Required().StringsVar(&params.buildIDs)
+	return params
+}
+
+func debuginfoUpload(ctx context.Context, params *debuginfoUploadParams) error {
+	for _, path := range params.paths {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		var meta debuginfoMeta
+		err = params.debuginfoRequest(ctx, http.MethodPost, "upload", f, &meta)
+		_ = f.Close()
+		if err != nil {
+			return fmt.Errorf("failed to upload %s: %w", path, err)
+		}
+		level.Info(logger).Log("msg", "debug info uploaded", "path", path, "build_id", meta.BuildID, "lidia_size", meta.LidiaSize)
+	}
+	return nil
+}
+
+func debuginfoList(ctx context.Context, params *debuginfoListParams) error {
+	var list []debuginfoMeta
+	if err := params.debuginfoRequest(ctx, http.MethodGet, "list", nil, &list); err != nil {
+		return err
+	}
+	enc := json.NewEncoder(output(ctx))
+	for _, meta := range list {
+		if err := enc.Encode(meta); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func debuginfoDelete(ctx context.Context, params *debuginfoDeleteParams) error {
+	for _, buildID := range params.buildIDs {
+		if err := params.debuginfoRequest(ctx, http.MethodDelete, url.PathEscape(buildID), nil, nil); err != nil {
+			return fmt.Errorf("failed to delete %s: %w", buildID, err)
+		}
+		level.Info(logger).Log("msg", "debug info deleted", "build_id", buildID)
+	}
+	return nil
+}
+
+// debuginfoRequest sends a request to the debug info API, and decodes
+// the JSON response into the result, unless it is nil.
+func (c *phlareClient) debuginfoRequest(ctx context.Context, method, path string, body io.Reader, result any) error {
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(c.URL, "/")+"/debuginfo/v1/"+path, body)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/octet-stream")
+	}
+
+	res, err := c.httpClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("%s: %s", res.Status, strings.TrimSpace(string(msg)))
+	}
+	if result == nil {
+		return nil
+	}
+	return json.NewDecoder(res.Body).Decode(result)
+}
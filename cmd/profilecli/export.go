@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/go-kit/log/level"
+	"github.com/pkg/errors"
+)
+
+type exportParams struct {
+	*queryParams
+	ProfileType string
+	Output      string
+}
+
+func addExportParams(cmd commander) *exportParams {
+	params := new(exportParams)
+	params.queryParams = addQueryParams(cmd)
+	cmd.Flag("profile-type", "Profile type to export.").Default("process_cpu:cpu:nanoseconds:cpu:nanoseconds").StringVar(&params.ProfileType)
+	cmd.Flag("output", "Path of the Parquet file to write.").Default("profiles.parquet").StringVar(&params.Output)
+	return params
+}
+
+func export(ctx context.Context, params *exportParams) (err error) {
+	from, to, err := params.parseFromTo()
+	if err != nil {
+		return err
+	}
+	level.Info(logger).Log("msg", "exporting profiles", "url", params.URL, "from", from, "to", to, "query", params.Query, "type", params.ProfileType)
+
+	// The endpoint expects the profile type
+	// to be specified as the metric name.
+	query := params.ProfileType + strings.TrimSpace(params.Query)
+	u := fmt.Sprintf("%s/pyroscope/export?%s", strings.TrimSuffix(params.URL, "/"), url.Values{
+		"query": []string{query},
+		"from":  []string{strconv.FormatInt(from.Unix(), 10)},
+		"until": []string{strconv.FormatInt(to.Unix(), 10)},
+	}.Encode())
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return err
+	}
+	res, err := params.httpClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(res.Body)
+		return errors.Errorf("failed to export profiles: %s: %s", res.Status, strings.TrimSpace(string(body)))
+	}
+
+	f, err := os.Create(params.Output)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	n, err := io.Copy(f, res.Body)
+	if err != nil {
+		return errors.Wrap(err, "failed to write the export")
+	}
+	level.Info(logger).Log("msg", "profiles exported", "output", params.Output, "size", humanize.Bytes(uint64(n)))
+	return nil
+}
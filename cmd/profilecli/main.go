@@ -72,7 +72,7 @@ func main() {
 
 	queryCmd := app.Command("query", "Query profile store.")
 	queryProfileCmd := queryCmd.Command("profile", "Request merged profile.").Alias("merge")
-	queryProfileOutput := queryProfileCmd.Flag("output", "How to output the result, examples: console, raw, pprof=./my.pprof").Default("console").String()
+	queryProfileOutput := queryProfileCmd.Flag("output", "How to output the result, examples: console, raw, pprof=./my.pprof, otlp=./my.otlp.pb").Default("console").String()
 	queryProfileParams := addQueryProfileParams(queryProfileCmd)
 	queryGoPGOCmd := queryCmd.Command("go-pgo", "Request profile for Go PGO.")
 	queryGoPGOOutput := queryGoPGOCmd.Flag("output", "How to output the result, examples: console, raw, pprof=./my.pprof").Default("pprof=./default.pgo").String()
@@ -82,12 +82,23 @@ func main() {
 	queryLabelValuesCardinalityCmd := queryCmd.Command("label-values-cardinality", "Request label values cardinality.")
 	queryLabelValuesCardinalityParams := addQueryLabelValuesCardinalityParams(queryLabelValuesCardinalityCmd)
 
+	exportCmd := app.Command("export", "Export raw profiles to a Parquet file.")
+	exportParams := addExportParams(exportCmd)
+
 	queryTracerCmd := app.Command("query-tracer", "Analyze query traces.")
 	queryTracerParams := addQueryTracerParams(queryTracerCmd)
 
 	uploadCmd := app.Command("upload", "Upload profile(s).")
 	uploadParams := addUploadParams(uploadCmd)
 
+	debuginfoCmd := app.Command("debuginfo", "Manage the debug files used to symbolize native profiles.")
+	debuginfoUploadCmd := debuginfoCmd.Command("upload", "Upload ELF executables or debug files.")
+	debuginfoUploadParams := addDebuginfoUploadParams(debuginfoUploadCmd)
+	debuginfoListCmd := debuginfoCmd.Command("list", "List the uploaded debug files.")
+	debuginfoListParams := addDebuginfoListParams(debuginfoListCmd)
+	debuginfoDeleteCmd := debuginfoCmd.Command("delete", "Delete uploaded debug files.")
+	debuginfoDeleteParams := addDebuginfoDeleteParams(debuginfoDeleteCmd)
+
 	canaryExporterCmd := app.Command("canary-exporter", "Run the canary exporter.")
 	canaryExporterParams := addCanaryExporterParams(canaryExporterCmd)
 
@@ -152,6 +163,11 @@ func main() {
 			os.Exit(checkError(err))
 		}
 
+	case exportCmd.FullCommand():
+		if err := export(ctx, exportParams); err != nil {
+			os.Exit(checkError(err))
+		}
+
 	case queryTracerCmd.FullCommand():
 		if err := queryTracer(ctx, queryTracerParams); err != nil {
 			os.Exit(checkError(err))
@@ -161,6 +177,18 @@ func main() {
 		if err := upload(ctx, uploadParams); err != nil {
 			os.Exit(checkError(err))
 		}
+	case debuginfoUploadCmd.FullCommand():
+		if err := debuginfoUpload(ctx, debuginfoUploadParams); err != nil {
+			os.Exit(checkError(err))
+		}
+	case debuginfoListCmd.FullCommand():
+		if err := debuginfoList(ctx, debuginfoListParams); err != nil {
+			os.Exit(checkError(err))
+		}
+	case debuginfoDeleteCmd.FullCommand():
+		if err := debuginfoDelete(ctx, debuginfoDeleteParams); err != nil {
+			os.Exit(checkError(err))
+		}
 	case canaryExporterCmd.FullCommand():
 		if err := newCanaryExporter(canaryExporterParams).run(ctx); err != nil {
 			os.Exit(checkError(err))
@@ -15,15 +15,18 @@ import (
 	"github.com/klauspost/compress/gzip"
 	"github.com/mattn/go-isatty"
 	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
 
 	googlev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
 	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+	"github.com/grafana/pyroscope/pkg/ingester/otlp"
 )
 
 const (
 	outputConsole = "console"
 	outputRaw     = "raw"
 	outputPprof   = "pprof="
+	outputOTLP    = "otlp="
 )
 
 func outputSeries(result []*typesv1.Labels) error {
@@ -94,5 +97,33 @@ func outputMergeProfile(ctx context.Context, outputFlag string, profile *googlev
 		return nil
 	}
 
+	if strings.HasPrefix(outputFlag, outputOTLP) {
+		filePath := strings.TrimPrefix(outputFlag, outputOTLP)
+		if filePath == "" {
+			return errors.New("no file path specified after otlp=")
+		}
+		data, err := otlp.ConvertGoogleToOtel(profile)
+		if err != nil {
+			return errors.Wrap(err, "failed to convert profile to OTLP")
+		}
+		buf, err := proto.Marshal(data)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal protobuf")
+		}
+
+		// open new file, fail when the file already exists
+		f, err := os.OpenFile(filePath, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0644)
+		if err != nil {
+			return errors.Wrap(err, "failed to create OTLP file")
+		}
+		defer runutil.CloseWithErrCapture(&err, f, "failed to close OTLP file")
+
+		if _, err := f.Write(buf); err != nil {
+			return errors.Wrap(err, "failed to write OTLP")
+		}
+
+		return nil
+	}
+
 	return errors.Errorf("unknown output %s", outputFlag)
 }
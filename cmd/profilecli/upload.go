@@ -9,10 +9,14 @@ import (
 	"github.com/go-kit/log/level"
 	"github.com/google/uuid"
 
+	profilev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
 	pushv1 "github.com/grafana/pyroscope/api/gen/proto/go/push/v1"
 	"github.com/grafana/pyroscope/api/gen/proto/go/push/v1/pushv1connect"
 	connectapi "github.com/grafana/pyroscope/pkg/api/connect"
 	"github.com/grafana/pyroscope/pkg/model"
+	"github.com/grafana/pyroscope/pkg/og/convert/gecko"
+	"github.com/grafana/pyroscope/pkg/og/convert/v8"
+	"github.com/grafana/pyroscope/pkg/og/ingestion"
 	"github.com/grafana/pyroscope/pkg/pprof"
 )
 
@@ -69,6 +73,10 @@ func upload(ctx context.Context, params *uploadParams) (err error) {
 			return nil
 		}
 
+		if data, err = toPprof(data); err != nil {
+			return err
+		}
+
 		profile, err := pprof.RawFromBytes(data)
 		if err != nil {
 			return err
@@ -90,6 +98,9 @@ func upload(ctx context.Context, params *uploadParams) (err error) {
 					if s := profile.StringTable[sid]; s == "cpu" {
 						name = "process_cpu"
 						break
+					} else if s == "wall" {
+						name = "wall"
+						break
 					} else if s == "alloc_space" || s == "inuse_space" {
 						name = "memory"
 						break
@@ -129,3 +140,23 @@ func upload(ctx context.Context, params *uploadParams) (err error) {
 
 	return nil
 }
+
+// toPprof converts V8 CPU profiles and Gecko profiles to pprof,
+// other profiles are expected to be pprof already.
+func toPprof(data []byte) ([]byte, error) {
+	var convert func([]byte, ingestion.Metadata) (*profilev1.Profile, error)
+	switch {
+	case v8.IsCPUProfile(data):
+		convert = v8.ToPprof
+	case gecko.IsGeckoProfile(data):
+		convert = gecko.ToPprof
+	default:
+		return data, nil
+	}
+	now := time.Now()
+	p, err := convert(data, ingestion.Metadata{StartTime: now, EndTime: now})
+	if err != nil {
+		return nil, err
+	}
+	return pprof.Marshal(p, true)
+}
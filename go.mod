@@ -45,6 +45,7 @@ require (
 	github.com/hashicorp/raft v1.7.2-0.20241119084901-7e8e836fe2e8
 	github.com/hashicorp/raft-wal v0.4.1
 	github.com/iancoleman/strcase v0.3.0
+	github.com/ianlancetaylor/demangle v0.0.0-20240312041847-bd984b5ce465
 	github.com/json-iterator/go v1.1.12
 	github.com/k0kubun/pp/v3 v3.2.0
 	github.com/klauspost/compress v1.17.11
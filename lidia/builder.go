@@ -113,6 +113,7 @@ type rangeCollector struct {
 
 func (rc *rangeCollector) VisitRange(r *Range) {
 	lt := lineTableRef{}
+	callLine := uint32(0)
 	funcOffset := rc.sb.add(r.Function)
 	fileOffset := rc.sb.emptystr
 	callFileOffset := rc.sb.emptystr
@@ -123,6 +124,7 @@ func (rc *rangeCollector) VisitRange(r *Range) {
 
 	if rc.opt.lines {
 		lt = rc.lb.add(r.LineTable)
+		callLine = r.CallLine
 	}
 	e := rangeEntry{
 		length:     uint64(r.Length),
@@ -131,7 +133,7 @@ func (rc *rangeCollector) VisitRange(r *Range) {
 		fileOffset: fileOffset,
 		lineTable:  lt,
 		callFile:   callFileOffset,
-		callLine:   uint64(r.CallLine),
+		callLine:   uint64(callLine),
 	}
 	rc.rb.add(r.VA, e)
 }
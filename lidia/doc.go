@@ -71,6 +71,9 @@
 //   - WithFiles(): Includes source file information
 //   - WithLines(): Includes line number information
 //
+// Lidia files created from ELF files with DWARF debug information include the
+// functions inlined: a lookup returns a frame per inlined function, innermost first.
+//
 // When creating a lidia file with WithCRC(), the same option must be used when
 // opening the file, or an error will be returned.
 package lidia
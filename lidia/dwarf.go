@@ -0,0 +1,373 @@
+package lidia
+
+import (
+	"debug/dwarf"
+	"debug/elf"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// attrMIPSLinkageName is the pre-DWARF 4 linkage name attribute, still
+// emitted by some compilers instead of DW_AT_linkage_name.
+const attrMIPSLinkageName dwarf.Attr = 0x2007
+
+// maxOriginChain limits the number of DW_AT_abstract_origin and
+// DW_AT_specification references followed to name a function.
+const maxOriginChain = 8
+
+// dwarfUnit is a compilation unit of the DWARF debug information.
+type dwarfUnit struct {
+	offset dwarf.Offset
+	entry  *dwarf.Entry
+
+	loaded bool
+	files  []*dwarf.LineFile
+	rows   []lineRow
+}
+
+// lineRow is a row of the line number program of a compilation unit.
+// Rows ending a sequence have no line.
+type lineRow struct {
+	address uint64
+	line    uint32
+	end     bool
+}
+
+// origin is the name and the declaration file of a function.
+type origin struct {
+	name string
+	file string
+}
+
+// dwarfCollector collects the ranges of the functions and of their
+// inlined subroutines from the DWARF debug information of an ELF file.
+type dwarfCollector struct {
+	data  *dwarf.Data
+	opt   options
+	units []*dwarfUnit
+
+	origins map[dwarf.Offset]origin
+
+	ranges []Range
+}
+
+// readDWARFRanges returns the ranges of the functions described by the
+// DWARF debug information of the ELF file, and of the subroutines inlined
+// into them. It returns no ranges if the file has no debug information.
+func readDWARFRanges(elfFile *elf.File, opt options) ([]Range, error) {
+	if elfFile.Section(".debug_info") == nil && elfFile.Section(".zdebug_info") == nil {
+		return nil, nil
+	}
+	data, err := elfFile.DWARF()
+	if err != nil {
+		return nil, err
+	}
+	dc := &dwarfCollector{
+		data:    data,
+		opt:     opt,
+		origins: make(map[dwarf.Offset]origin),
+	}
+	if err = dc.readUnits(); err != nil {
+		return nil, err
+	}
+	for _, u := range dc.units {
+		if err = dc.visitUnit(u); err != nil {
+			return nil, err
+		}
+	}
+	return dc.ranges, nil
+}
+
+func (dc *dwarfCollector) readUnits() error {
+	r := dc.data.Reader()
+	for {
+		e, err := r.Next()
+		if err != nil {
+			return err
+		}
+		if e == nil {
+			return nil
+		}
+		if e.Tag == dwarf.TagCompileUnit || e.Tag == dwarf.TagPartialUnit {
+			dc.units = append(dc.units, &dwarfUnit{offset: e.Offset, entry: e})
+		}
+		r.SkipChildren()
+	}
+}
+
+// unit returns the compilation unit the entry at the offset belongs to.
+func (dc *dwarfCollector) unit(off dwarf.Offset) *dwarfUnit {
+	i := sort.Search(len(dc.units), func(i int) bool {
+		return dc.units[i].offset > off
+	})
+	if i == 0 {
+		return nil
+	}
+	return dc.units[i-1]
+}
+
+// load reads the file names and the line number program of the unit.
+func (dc *dwarfCollector) load(u *dwarfUnit) error {
+	if u.loaded {
+		return nil
+	}
+	u.loaded = true
+	lr, err := dc.data.LineReader(u.entry)
+	if err != nil || lr == nil {
+		return err
+	}
+	var le dwarf.LineEntry
+	for {
+		if err = lr.Next(&le); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return err
+		}
+		row := lineRow{address: le.Address, end: le.EndSequence}
+		if !le.EndSequence && le.Line > 0 {
+			row.line = uint32(le.Line)
+		}
+		u.rows = append(u.rows, row)
+	}
+	u.files = lr.Files()
+	// Sequences are not necessarily ordered. A sequence may start
+	// at the address another one ends at: the end comes first.
+	sort.SliceStable(u.rows, func(i, j int) bool {
+		if u.rows[i].address == u.rows[j].address {
+			return u.rows[i].end && !u.rows[j].end
+		}
+		return u.rows[i].address < u.rows[j].address
+	})
+	return nil
+}
+
+func (dc *dwarfCollector) file(u *dwarfUnit, e *dwarf.Entry, attr dwarf.Attr) string {
+	if u == nil || !dc.opt.files {
+		return ""
+	}
+	idx, ok := e.Val(attr).(int64)
+	if !ok || idx < 0 || idx >= int64(len(u.files)) || u.files[idx] == nil {
+		return ""
+	}
+	return u.files[idx].Name
+}
+
+// lineTable returns the line table of the range [lo, hi) of the unit.
+func (dc *dwarfCollector) lineTable(u *dwarfUnit, lo, hi uint64) LineTable {
+	if !dc.opt.lines {
+		return nil
+	}
+	// The row in effect at lo is the last one at or before it.
+	i := sort.Search(len(u.rows), func(i int) bool {
+		return u.rows[i].address > lo
+	})
+	if i > 0 && !u.rows[i-1].end {
+		i--
+	}
+	var lt LineTable
+	for ; i < len(u.rows) && u.rows[i].address < hi; i++ {
+		row := u.rows[i]
+		var offset uint32
+		if row.address > lo {
+			offset = uint32(row.address - lo)
+		}
+		if n := len(lt); n > 0 && lt[n-1].LineNumber == row.line {
+			continue
+		}
+		lt = append(lt, LineTableEntry{Offset: offset, LineNumber: row.line})
+	}
+	return lt
+}
+
+// scope is the context the children of an entry are visited in.
+type scope struct {
+	// inFunction tells whether the entry is within the
+	// code of a function, or is such a function itself.
+	inFunction bool
+	// depth is the inline depth of the subroutines inlined
+	// into the entry.
+	depth uint32
+}
+
+func (dc *dwarfCollector) visitUnit(u *dwarfUnit) error {
+	if err := dc.load(u); err != nil {
+		return err
+	}
+	r := dc.data.Reader()
+	r.Seek(u.offset)
+	if _, err := r.Next(); err != nil {
+		return err
+	}
+	if !u.entry.Children {
+		return nil
+	}
+	scopes := []scope{{}}
+	for len(scopes) > 0 {
+		e, err := r.Next()
+		if err != nil {
+			return err
+		}
+		if e == nil {
+			return nil
+		}
+		if e.Tag == 0 {
+			scopes = scopes[:len(scopes)-1]
+			continue
+		}
+		s := scopes[len(scopes)-1]
+		switch e.Tag {
+		case dwarf.TagSubprogram:
+			ok, err := dc.visitFunction(u, e, 0)
+			if err != nil {
+				return err
+			}
+			s = scope{inFunction: ok, depth: 1}
+		case dwarf.TagInlinedSubroutine:
+			if !s.inFunction {
+				break
+			}
+			if _, err = dc.visitFunction(u, e, s.depth); err != nil {
+				return err
+			}
+			s.depth++
+		}
+		if e.Children {
+			scopes = append(scopes, s)
+		}
+	}
+	return nil
+}
+
+// visitFunction adds the ranges of the function or inlined subroutine entry.
+// It reports whether the entry has code.
+func (dc *dwarfCollector) visitFunction(u *dwarfUnit, e *dwarf.Entry, depth uint32) (bool, error) {
+	pcs, err := dc.data.Ranges(e)
+	if err != nil {
+		return false, fmt.Errorf("failed to read ranges of entry at offset 0x%x: %w", e.Offset, err)
+	}
+	if len(pcs) == 0 {
+		return false, nil
+	}
+	o, err := dc.origin(u, e)
+	if err != nil {
+		return false, err
+	}
+	var (
+		callFile string
+		callLine uint32
+	)
+	if depth > 0 {
+		callFile = dc.file(u, e, dwarf.AttrCallFile)
+		if line, ok := e.Val(dwarf.AttrCallLine).(int64); ok && line > 0 {
+			callLine = uint32(line)
+		}
+	}
+	for _, pc := range pcs {
+		lo, hi := pc[0], pc[1]
+		if hi <= lo {
+			continue
+		}
+		dc.ranges = append(dc.ranges, Range{
+			VA:        lo,
+			Length:    uint32(hi - lo),
+			Function:  o.name,
+			File:      o.file,
+			CallFile:  callFile,
+			CallLine:  callLine,
+			Depth:     depth,
+			LineTable: dc.lineTable(u, lo, hi),
+		})
+	}
+	return true, nil
+}
+
+// origin returns the name and the declaration file of the function entry.
+// Concrete instances of functions, and the subroutines inlined, refer to
+// their declaration with DW_AT_abstract_origin or DW_AT_specification.
+// The linkage name is preferred over the name, as it is qualified.
+func (dc *dwarfCollector) origin(u *dwarfUnit, e *dwarf.Entry) (origin, error) {
+	if o, ok := dc.origins[e.Offset]; ok {
+		return o, nil
+	}
+	var (
+		o    origin
+		name string
+		r    *dwarf.Reader
+	)
+	for cur, cu, n := e, u, 0; cur != nil && n < maxOriginChain; n++ {
+		if o.name == "" {
+			o.name, _ = cur.Val(dwarf.AttrLinkageName).(string)
+		}
+		if o.name == "" {
+			o.name, _ = cur.Val(attrMIPSLinkageName).(string)
+		}
+		if name == "" {
+			name, _ = cur.Val(dwarf.AttrName).(string)
+		}
+		if o.file == "" && cu != nil {
+			if err := dc.load(cu); err != nil {
+				return origin{}, err
+			}
+			o.file = dc.file(cu, cur, dwarf.AttrDeclFile)
+		}
+		ref, ok := cur.Val(dwarf.AttrAbstractOrigin).(dwarf.Offset)
+		if !ok {
+			ref, ok = cur.Val(dwarf.AttrSpecification).(dwarf.Offset)
+		}
+		if !ok {
+			break
+		}
+		if cached, ok := dc.origins[ref]; ok {
+			if o.name == "" {
+				o.name = cached.name
+			}
+			if o.file == "" {
+				o.file = cached.file
+			}
+			break
+		}
+		if r == nil {
+			r = dc.data.Reader()
+		}
+		r.Seek(ref)
+		next, err := r.Next()
+		if err != nil {
+			return origin{}, fmt.Errorf("failed to read entry at offset 0x%x: %w", ref, err)
+		}
+		cur, cu = next, dc.unit(ref)
+	}
+	if o.name == "" {
+		o.name = name
+	}
+	dc.origins[e.Offset] = o
+	return o, nil
+}
+
+// addressRanges is a sorted list of address ranges.
+type addressRanges [][2]uint64
+
+func newAddressRanges(ranges []Range) addressRanges {
+	var ar addressRanges
+	for i := range ranges {
+		if ranges[i].Depth == 0 {
+			ar = append(ar, [2]uint64{ranges[i].VA, ranges[i].VA + uint64(ranges[i].Length)})
+		}
+	}
+	sort.Slice(ar, func(i, j int) bool {
+		return ar[i][0] < ar[j][0]
+	})
+	return ar
+}
+
+// contains reports whether the address is within one of the ranges.
+// The ranges of distinct functions do not overlap: only the closest
+// range starting at or before the address may contain it.
+func (ar addressRanges) contains(addr uint64) bool {
+	i := sort.Search(len(ar), func(i int) bool {
+		return ar[i][0] > addr
+	})
+	return i > 0 && addr < ar[i-1][1]
+}
@@ -307,7 +307,7 @@ func calculateSizes(rb *rangesBuilder, hdr *header) {
 	for _, e := range rb.entries {
 		if e.length > maxUint32 || e.depth > maxUint32 || uint64(e.funcOffset) > maxUint32 ||
 			uint64(e.fileOffset) > maxUint32 || e.lineTable.idx > maxUint32 ||
-			e.lineTable.count > maxUint32 {
+			e.lineTable.count > maxUint32 || uint64(e.callFile) > maxUint32 || e.callLine > maxUint32 {
 			hdr.rangeTableHeader.fieldSize = 8
 			break
 		}
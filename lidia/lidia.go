@@ -7,6 +7,7 @@ package lidia
 
 import (
 	"debug/elf"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -26,7 +27,8 @@ type Table struct {
 
 	vaTable []byte
 
-	fieldsBuffer []byte
+	fieldsBuffer    []byte
+	lineTableBuffer []byte
 }
 
 // SourceInfoFrame represents a single frame of symbolized profiling information.
@@ -162,12 +164,28 @@ func CreateLidiaFromELF(elfFile *elf.File, output io.WriteSeeker, opts ...Option
 		o(&rc.opt)
 	}
 
-	symbols, err := elfFile.Symbols()
+	// The functions described by the DWARF debug information are added with
+	// the subroutines inlined into them. The debug information is optional:
+	// if it cannot be read, only the symbol table is used.
+	dwarfRanges, err := readDWARFRanges(elfFile, rc.opt)
 	if err != nil {
+		dwarfRanges = nil
+	}
+	for i := range dwarfRanges {
+		rc.VisitRange(&dwarfRanges[i])
+	}
+
+	// The symbol table covers the functions without debug information.
+	symbols, err := elfFile.Symbols()
+	if err != nil && (!errors.Is(err, elf.ErrNoSymbols) || len(dwarfRanges) == 0) {
 		return fmt.Errorf("failed to read symbols from ELF file: %w", err)
 	}
 
+	covered := newAddressRanges(dwarfRanges)
 	for _, symbol := range symbols {
+		if covered.contains(symbol.Value) {
+			continue
+		}
 		rc.VisitRange(&Range{
 			VA:        symbol.Value,
 			Length:    uint32(symbol.Size),
@@ -204,6 +222,11 @@ func (st *Table) Lookup(dst []SourceInfoFrame, addr uint64) ([]SourceInfoFrame,
 	})
 	idx--
 
+	// The ranges covering the address are visited from the innermost
+	// inlined subroutine to the function it is inlined into. The line of
+	// the innermost frame comes from its line table, the line of the
+	// others from the call site of the frame inlined into them.
+	var callLine uint64
 	for idx >= 0 {
 		it, err := st.getEntry(idx)
 		if err != nil {
@@ -218,12 +241,15 @@ func (st *Table) Lookup(dst []SourceInfoFrame, addr uint64) ([]SourceInfoFrame,
 			res := SourceInfoFrame{
 				FunctionName: name,
 				FilePath:     file,
+				LineNumber:   callLine,
 			}
-
-			// Add line number information if available
-			//if it.lineTable.count > 0 {
-			// Line number could be extracted here if implemented
-			//}
+			if len(dst) == 0 {
+				res.LineNumber, err = st.lineNumber(it.lineTable, addr-it.va)
+				if err != nil {
+					return dst, fmt.Errorf("failed to read line table at index %d: %w", it.lineTable.idx, err)
+				}
+			}
+			callLine = it.callLine
 
 			dst = append(dst, res)
 		}
@@ -129,6 +129,100 @@ func TestCreateReadLookup(t *testing.T) {
 	}
 }
 
+// TestCreateReadLookupDWARF tests lookups in lidia files created from
+// binaries with DWARF debug information, built from testdata/inline.c.
+func TestCreateReadLookupDWARF(t *testing.T) {
+	testCases := []struct {
+		name   string
+		addr   uint64
+		frames []lidia.SourceInfoFrame
+	}{
+		{
+			name: "Function without inlined subroutines",
+			addr: 0x11b5,
+			frames: []lidia.SourceInfoFrame{
+				{FunctionName: "outer", FilePath: "/src/inline.c", LineNumber: 20},
+			},
+		},
+		{
+			name: "Inlined subroutine",
+			addr: 0x1190,
+			frames: []lidia.SourceInfoFrame{
+				{FunctionName: "middle", FilePath: "/src/inline.c", LineNumber: 14},
+				{FunctionName: "outer", FilePath: "/src/inline.c", LineNumber: 20},
+			},
+		},
+		{
+			name: "Nested inlined subroutines",
+			addr: 0x11a3,
+			frames: []lidia.SourceInfoFrame{
+				{FunctionName: "leaf", FilePath: "/src/inline.c", LineNumber: 9},
+				{FunctionName: "middle", FilePath: "/src/inline.c", LineNumber: 15},
+				{FunctionName: "outer", FilePath: "/src/inline.c", LineNumber: 20},
+			},
+		},
+		{
+			name: "Function without debug information",
+			addr: 0x10a0,
+			frames: []lidia.SourceInfoFrame{
+				{FunctionName: "_start"},
+			},
+		},
+		{
+			name: "Unknown address",
+			addr: 0x100,
+		},
+	}
+
+	for _, binary := range []string{"testdata/inline.dwarf4", "testdata/inline.dwarf5"} {
+		t.Run(filepath.Base(binary), func(t *testing.T) {
+			lidiaPath := filepath.Join(t.TempDir(), "test.lidia")
+			err := lidia.CreateLidia(binary, lidiaPath,
+				lidia.WithCRC(), lidia.WithFiles(), lidia.WithLines())
+			require.NoError(t, err)
+
+			bs, err := os.ReadFile(lidiaPath)
+			require.NoError(t, err)
+			table, err := lidia.OpenReader(&bufferCloser{bs, 0}, lidia.WithCRC())
+			require.NoError(t, err)
+			defer table.Close()
+
+			var results []lidia.SourceInfoFrame
+			for _, tc := range testCases {
+				results, err = table.Lookup(results, tc.addr)
+				require.NoError(t, err)
+				if len(tc.frames) == 0 {
+					require.Empty(t, results, tc.name)
+					continue
+				}
+				require.Equal(t, tc.frames, results, tc.name)
+			}
+		})
+	}
+}
+
+// TestCreateReadLookupDWARFWithoutFilesAndLines tests that file names
+// and line numbers are omitted unless requested.
+func TestCreateReadLookupDWARFWithoutFilesAndLines(t *testing.T) {
+	lidiaPath := filepath.Join(t.TempDir(), "test.lidia")
+	err := lidia.CreateLidia("testdata/inline.dwarf5", lidiaPath, lidia.WithCRC())
+	require.NoError(t, err)
+
+	bs, err := os.ReadFile(lidiaPath)
+	require.NoError(t, err)
+	table, err := lidia.OpenReader(&bufferCloser{bs, 0}, lidia.WithCRC())
+	require.NoError(t, err)
+	defer table.Close()
+
+	results, err := table.Lookup(nil, 0x11a3)
+	require.NoError(t, err)
+	require.Equal(t, []lidia.SourceInfoFrame{
+		{FunctionName: "leaf"},
+		{FunctionName: "middle"},
+		{FunctionName: "outer"},
+	}, results)
+}
+
 // bufferCloser implements the lidia.ReaderAtCloser interface for testing
 type bufferCloser struct {
 	bs  []byte
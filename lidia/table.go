@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"hash/crc32"
 	"io"
+	"sort"
 )
 
 type entry struct {
@@ -80,6 +81,46 @@ func (st *Table) getEntryVA(i int) uint64 {
 	return binary.LittleEndian.Uint64(it)
 }
 
+// lineNumber returns the line number at the offset
+// from the start of the range the line table refers to.
+func (st *Table) lineNumber(lt lineTableRef, offset uint64) (uint64, error) {
+	if lt.count == 0 {
+		return 0, nil
+	}
+	fieldSize := st.hdr.lineTablesHeader.fieldSize
+	if fieldSize != 2 && fieldSize != 4 {
+		return 0, fmt.Errorf("invalid line table field size: %d", fieldSize)
+	}
+	if lt.idx+lt.count > st.hdr.lineTablesHeader.count {
+		return 0, errors.New("line table out of bounds")
+	}
+	entrySize := int(fieldSize) * lineTableFieldsCount
+	size := entrySize * int(lt.count)
+	if cap(st.lineTableBuffer) < size {
+		st.lineTableBuffer = make([]byte, size)
+	}
+	buf := st.lineTableBuffer[:size]
+	if _, err := st.file.ReadAt(buf, int64(st.hdr.lineTablesHeader.offset+lt.idx*uint64(entrySize))); err != nil {
+		return 0, err
+	}
+	field := func(i, j int) uint64 {
+		b := buf[i*entrySize+j*int(fieldSize):]
+		if fieldSize == 2 {
+			return uint64(binary.LittleEndian.Uint16(b))
+		}
+		return uint64(binary.LittleEndian.Uint32(b))
+	}
+	// The entries are sorted by offset: the line in effect
+	// is the one of the last entry at or before the offset.
+	i := sort.Search(int(lt.count), func(i int) bool {
+		return field(i, 0) > offset
+	})
+	if i == 0 {
+		return 0, nil
+	}
+	return field(i-1, 1), nil
+}
+
 func (st *Table) str(offset stringOffset) string {
 	if offset == 0 {
 		return ""
@@ -0,0 +1,28 @@
+// inline.c is the source of the DWARF test fixtures:
+//
+//	gcc -O2 -g -gdwarf-4 -ffile-prefix-map=$PWD=/src -o inline.dwarf4 inline.c
+//	gcc -O2 -g -gdwarf-5 -ffile-prefix-map=$PWD=/src -o inline.dwarf5 inline.c
+#include <stdio.h>
+#include <stdlib.h>
+
+static inline __attribute__((always_inline)) long leaf(long x) {
+	return x * x + 3;
+}
+
+static inline __attribute__((always_inline)) long middle(long x) {
+	long r = 0;
+	for (long i = 0; i < x; i++)
+		r += leaf(i);
+	return r;
+}
+
+__attribute__((noinline)) long outer(long x) {
+	return middle(x) + 1;
+}
+
+int main(int argc, char **argv) {
+	if (argc < 2)
+		return 1;
+	printf("%ld\n", outer(strtol(argv[1], NULL, 10)));
+	return 0;
+}
@@ -46,6 +46,7 @@ import (
 	"github.com/grafana/pyroscope/pkg/scheduler/schedulerpb/schedulerpbconnect"
 	"github.com/grafana/pyroscope/pkg/settings"
 	"github.com/grafana/pyroscope/pkg/storegateway"
+	"github.com/grafana/pyroscope/pkg/validation"
 	"github.com/grafana/pyroscope/pkg/validation/exporter"
 )
 
@@ -190,9 +191,9 @@ func (a *API) RegisterOverridesExporter(oe *exporter.OverridesExporter) {
 }
 
 // RegisterDistributor registers the endpoints associated with the distributor.
-func (a *API) RegisterDistributor(d *distributor.Distributor, multitenancyEnabled bool) {
+func (a *API) RegisterDistributor(d *distributor.Distributor, limits *validation.Overrides, multitenancyEnabled bool) {
 	pyroscopeHandler := pyroscope.NewPyroscopeIngestHandler(d, a.logger)
-	otlpHandler := otlp.NewOTLPIngestHandler(d, a.logger, multitenancyEnabled)
+	otlpHandler := otlp.NewOTLPIngestHandler(d, limits, a.logger, multitenancyEnabled)
 
 	a.RegisterRoute("/ingest", pyroscopeHandler, a.registerOptionsWritePath()...)
 	a.RegisterRoute("/pyroscope/ingest", pyroscopeHandler, a.registerOptionsWritePath()...)
@@ -203,8 +204,7 @@ func (a *API) RegisterDistributor(d *distributor.Distributor, multitenancyEnable
 	})
 
 	a.RegisterRoute("/opentelemetry.proto.collector.profiles.v1development.ProfilesService/Export", otlpHandler, a.registerOptionsWritePath()...)
-	// TODO(@petethepig): implement http/protobuf and http/json support
-	// a.RegisterRoute("/v1/profiles", otlpHandler, true, true, "POST")
+	a.RegisterRoute("/v1/profiles", otlpHandler, a.registerOptionsWritePath()...)
 }
 
 // RegisterMemberlistKV registers the endpoints associated with the memberlist KV store.
@@ -236,6 +236,12 @@ func (a *API) RegisterPyroscopeHandlers(client querierv1connect.QuerierServiceCl
 	a.RegisterRoute("/pyroscope/render", http.HandlerFunc(handlers.Render), a.registerOptionsReadPath()...)
 	a.RegisterRoute("/pyroscope/render-diff", http.HandlerFunc(handlers.RenderDiff), a.registerOptionsReadPath()...)
 	a.RegisterRoute("/pyroscope/label-values", http.HandlerFunc(handlers.LabelValues), a.registerOptionsReadPath()...)
+	a.RegisterRoute("/pyroscope/merge-profile", http.HandlerFunc(handlers.MergeProfile), append(a.registerOptionsReadPath(), WithMethod("POST"))...)
+}
+
+// RegisterProfileExport registers the endpoint exporting raw profiles.
+func (a *API) RegisterProfileExport(exporter querier.ProfileExporter) {
+	a.RegisterRoute("/pyroscope/export", querier.NewExportHandler(exporter), a.registerOptionsReadPath()...)
 }
 
 // RegisterIngester registers the endpoints associated with the ingester.
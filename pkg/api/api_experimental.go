@@ -8,6 +8,7 @@ import (
 	segmentwriter "github.com/grafana/pyroscope/pkg/experiment/ingester"
 	metastoreadmin "github.com/grafana/pyroscope/pkg/experiment/metastore/admin"
 	querybackend "github.com/grafana/pyroscope/pkg/experiment/query_backend"
+	"github.com/grafana/pyroscope/pkg/experiment/symbolizer"
 )
 
 // TODO(kolesnikovae): Recovery interceptor.
@@ -36,3 +37,15 @@ func (a *API) RegisterMetastoreAdmin(adm *metastoreadmin.Admin) {
 		{Desc: "Client Test", Path: "/metastore-client-test"},
 	})
 }
+
+// RegisterDebuginfo registers the endpoints the tenants manage their debug files with.
+func (a *API) RegisterDebuginfo(h *symbolizer.DebuginfoHandler) {
+	a.RegisterRoute("/debuginfo/v1/upload", http.HandlerFunc(h.Upload), a.registerOptionsWritePath()...)
+	a.RegisterRoute("/debuginfo/v1/list", http.HandlerFunc(h.List), a.registerOptionsReadPath()...)
+	a.RegisterRoute("/debuginfo/v1/{build_id}", http.HandlerFunc(h.Delete), a.WithAuthMiddleware(), WithMethod("DELETE"))
+}
+
+// RegisterDebuginfod registers the debuginfod-compatible endpoint serving the debug files uploaded by the tenants.
+func (a *API) RegisterDebuginfod(h *symbolizer.DebuginfoHandler) {
+	a.RegisterRoute("/buildid/{build_id}/debuginfo", http.HandlerFunc(h.Debuginfod), a.registerOptionsReadPath()...)
+}
@@ -138,6 +138,7 @@ type Limits interface {
 	MaxProfileSymbolValueLength(tenantID string) int
 	MaxSessionsPerSeries(tenantID string) int
 	EnforceLabelsOrder(tenantID string) bool
+	SymbolizerDemangle(tenantID string) pprof.DemangleMode
 	IngestionRelabelingRules(tenantID string) []*relabel.Config
 	DistributorUsageGroups(tenantID string) *validation.UsageGroupConfig
 	validation.ProfileValidationLimits
@@ -391,11 +392,13 @@ func (d *Distributor) PushParsed(ctx context.Context, req *distributormodel.Push
 
 	// Normalisation is quite an expensive operation,
 	// therefore it should be done after the rate limit check.
+	demangle := d.limits.SymbolizerDemangle(tenantID)
 	for _, series := range req.Series {
 		for _, sample := range series.Samples {
 			if series.Language == "go" {
 				sample.Profile.Profile = pprof.FixGoProfile(sample.Profile.Profile)
 			}
+			pprof.DemangleFunctions(sample.Profile.Profile, demangle)
 			sample.Profile.Normalize()
 		}
 	}
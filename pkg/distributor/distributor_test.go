@@ -2558,6 +2558,53 @@ func TestPush_LabelRewrites(t *testing.T) {
 	}
 }
 
+func TestPush_Demangle(t *testing.T) {
+	ing := newFakeIngester(t, false)
+	overrides := validation.MockOverrides(func(defaults *validation.Limits, tenantLimits map[string]*validation.Limits) {
+		l := validation.MockDefaultLimits()
+		l.Symbolizer.Demangle = pprof2.DemangleFull
+		tenantLimits["user-1"] = l
+	})
+	d, err := New(Config{
+		DistributorRing: ringConfig,
+	}, testhelper.NewMockRing([]ring.InstanceDesc{
+		{Addr: "mock"},
+		{Addr: "mock"},
+		{Addr: "mock"},
+	}, 3), &poolFactory{f: func(addr string) (client.PoolClient, error) {
+		return ing, nil
+	}}, overrides, nil, log.NewLogfmtLogger(os.Stdout), nil)
+	require.NoError(t, err)
+
+	p := pproftesthelper.NewProfileBuilderWithLabels(1000, nil).CPUProfile()
+	p.ForStacktraceString("_ZN3foo3barEi", "main").AddSamples(1)
+	data, err := p.Profile.MarshalVT()
+	require.NoError(t, err)
+
+	ctx := tenant.InjectTenantID(context.Background(), "user-1")
+	_, err = d.Push(ctx, connect.NewRequest(&pushv1.PushRequest{
+		Series: []*pushv1.RawProfileSeries{{
+			Labels:  p.Labels,
+			Samples: []*pushv1.RawSample{{RawProfile: data}},
+		}},
+	}))
+	require.NoError(t, err)
+
+	ing.mtx.Lock()
+	defer ing.mtx.Unlock()
+	require.Len(t, ing.requests, 1)
+	actual, err := pprof2.RawFromBytes(ing.requests[0].Series[0].Samples[0].RawProfile)
+	require.NoError(t, err)
+	functions := make(map[string]string)
+	for _, fn := range actual.Function {
+		functions[actual.StringTable[fn.Name]] = actual.StringTable[fn.SystemName]
+	}
+	require.Equal(t, map[string]string{
+		"foo::bar(int)": "_ZN3foo3barEi",
+		"main":          "",
+	}, functions)
+}
+
 func TestDistributor_shouldSample(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -14,6 +14,12 @@ type RawProfileType string
 const RawProfileTypePPROF = RawProfileType("pprof")
 const RawProfileTypeJFR = RawProfileType("jfr")
 const RawProfileTypeOTEL = RawProfileType("otel")
+const RawProfileTypePerfScript = RawProfileType("perf_script")
+const RawProfileTypePerfData = RawProfileType("perf_data")
+const RawProfileTypeCPUProfile = RawProfileType("cpuprofile")
+const RawProfileTypeGecko = RawProfileType("gecko")
+const RawProfileTypeCallgrind = RawProfileType("callgrind")
+const RawProfileTypeMassif = RawProfileType("massif")
 
 type PushRequest struct {
 	TenantID       string
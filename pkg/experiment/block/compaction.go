@@ -10,6 +10,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/grafana/dskit/multierror"
 	"github.com/parquet-go/parquet-go"
@@ -24,6 +25,7 @@ import (
 	"github.com/grafana/pyroscope/pkg/objstore"
 	"github.com/grafana/pyroscope/pkg/phlaredb/symdb"
 	"github.com/grafana/pyroscope/pkg/phlaredb/tsdb/index"
+	"github.com/grafana/pyroscope/pkg/tenant"
 	"github.com/grafana/pyroscope/pkg/util"
 )
 
@@ -64,6 +66,9 @@ type compactionConfig struct {
 	destination    objstore.Bucket
 	tempdir        string
 	sampleObserver SampleObserver
+
+	symbolizer        Symbolizer
+	symbolizerTimeout time.Duration
 }
 
 type SampleObserver interface {
@@ -100,8 +105,18 @@ func Compact(
 		_ = objects.Close()
 	}()
 
+	// The timeout applies to all the blocks compacted.
+	symbolizerCtx := ctx
+	if c.symbolizer != nil && c.symbolizerTimeout > 0 {
+		var cancel context.CancelFunc
+		symbolizerCtx, cancel = context.WithTimeoutCause(ctx, c.symbolizerTimeout, errSymbolizationTimeout)
+		defer cancel()
+	}
+
 	compacted := make([]*metastorev1.BlockMeta, 0, len(plan))
 	for _, p := range plan {
+		p.symbolizer = c.symbolizer
+		p.symbolizerCtx = symbolizerCtx
 		md, compactionErr := p.Compact(ctx, c.destination, c.tempdir, c.sampleObserver)
 		if compactionErr != nil {
 			return nil, compactionErr
@@ -175,6 +190,9 @@ type CompactionPlan struct {
 	meta         *metastorev1.BlockMeta
 	strings      *metadata.StringTable
 	datasetIndex *datasetIndexWriter
+
+	symbolizer    Symbolizer
+	symbolizerCtx context.Context
 }
 
 func newBlockCompaction(
@@ -292,6 +310,8 @@ type datasetCompaction struct {
 	labels *metadata.LabelBuilder
 
 	datasets []*Dataset
+	// Datasets labeled as unsymbolized.
+	unsymbolized map[*Dataset]struct{}
 
 	indexRewriter   *indexRewriter
 	symbolsRewriter *symbolsRewriter
@@ -333,6 +353,14 @@ func (m *datasetCompaction) append(s *Dataset) {
 	if s.meta.MaxTime > m.meta.MaxTime {
 		m.meta.MaxTime = s.meta.MaxTime
 	}
+	// The labels are rewritten in place
+	// with the references to the new strings.
+	if isUnsymbolized(s.meta.Labels, s.obj.meta.StringTable) {
+		if m.unsymbolized == nil {
+			m.unsymbolized = make(map[*Dataset]struct{})
+		}
+		m.unsymbolized[s] = struct{}{}
+	}
 	m.labels.Put(s.meta.Labels, s.obj.meta.StringTable)
 }
 
@@ -366,6 +394,9 @@ func (m *datasetCompaction) compact(ctx context.Context, w *Writer) (err error)
 
 	m.meta.Size = w.Offset() - off
 	m.meta.Labels = m.labels.Build()
+	if m.symbolsRewriter.symbolized() {
+		m.meta.Labels = dropUnsymbolized(m.meta.Labels, m.parent.strings.Strings)
+	}
 	return nil
 }
 
@@ -383,6 +414,16 @@ func (m *datasetCompaction) open(ctx context.Context, w io.Writer) (err error) {
 
 	m.indexRewriter = newIndexRewriter()
 	m.symbolsRewriter = newSymbolsRewriter()
+	if m.parent.symbolizer != nil && len(m.unsymbolized) > 0 {
+		m.symbolsRewriter.symbolizer = m.parent.symbolizer
+		m.symbolsRewriter.symbolize = m.unsymbolized
+		symbolizerCtx := ctx
+		if m.parent.symbolizerCtx != nil {
+			symbolizerCtx = m.parent.symbolizerCtx
+		}
+		// The symbolizer looks up the debug files uploaded by the tenant.
+		m.symbolsRewriter.ctx = tenant.InjectTenantID(symbolizerCtx, m.parent.tenant)
+	}
 
 	g, ctx := errgroup.WithContext(ctx)
 	for _, s := range m.datasets {
@@ -554,6 +595,11 @@ type symbolsRewriter struct {
 	rw      map[*Dataset]*symdb.Rewriter
 	samples uint64
 
+	ctx        context.Context
+	symbolizer Symbolizer
+	symbolize  map[*Dataset]struct{}
+	readers    []*symbolizingReader
+
 	stacktraces []uint32
 }
 
@@ -594,12 +640,32 @@ func (s *symbolsRewriter) rewriteRow(e ProfileEntry) (err error) {
 func (s *symbolsRewriter) rewriterFor(x *Dataset) *symdb.Rewriter {
 	rw, ok := s.rw[x]
 	if !ok {
-		rw = symdb.NewRewriter(s.w, x.Symbols())
+		var reader symdb.SymbolsReader = x.Symbols()
+		if _, ok = s.symbolize[x]; ok {
+			r := newSymbolizingReader(s.ctx, s.symbolizer, reader)
+			s.readers = append(s.readers, r)
+			reader = r
+		}
+		rw = symdb.NewRewriter(s.w, reader)
 		s.rw[x] = rw
 	}
 	return rw
 }
 
+// symbolized reports whether the unsymbolized datasets
+// have been fully symbolized.
+func (s *symbolsRewriter) symbolized() bool {
+	if len(s.symbolize) == 0 {
+		return false
+	}
+	for _, r := range s.readers {
+		if r.unsymbolized() {
+			return false
+		}
+	}
+	return true
+}
+
 func (s *symbolsRewriter) loadStacktraceIDs(values []parquet.Value) {
 	s.stacktraces = slices.Grow(s.stacktraces[0:], len(values))[:len(values)]
 	for i := range values {
@@ -0,0 +1,232 @@
+package block
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/grafana/pyroscope/pkg/experiment/block/metadata"
+	schemav1 "github.com/grafana/pyroscope/pkg/phlaredb/schemas/v1"
+	"github.com/grafana/pyroscope/pkg/phlaredb/symdb"
+)
+
+// Symbolizer symbolizes the unsymbolized locations of a symdb partition
+// in place. The locations of the binaries the debug information of which
+// is not available are expected to be left unsymbolized.
+type Symbolizer interface {
+	SymbolizeSymbols(ctx context.Context, symbols *symdb.Symbols) error
+}
+
+// WithCompactionSymbolizer makes the compaction symbolize the datasets
+// labeled as unsymbolized, so that the compacted blocks carry function
+// names and line numbers. The label is removed from the datasets that
+// are fully symbolized.
+//
+// If the timeout is greater than zero, it limits the time spent on
+// symbolization in the compaction: once it is exceeded, the partitions
+// are left unsymbolized, and the compaction proceeds.
+func WithCompactionSymbolizer(symbolizer Symbolizer, timeout time.Duration) CompactionOption {
+	return func(p *compactionConfig) {
+		p.symbolizer = symbolizer
+		p.symbolizerTimeout = timeout
+	}
+}
+
+var errSymbolizationTimeout = errors.New("symbolization timeout exceeded")
+
+// symbolizationTimedOut reports whether the context has been
+// canceled because the symbolization timeout was exceeded.
+func symbolizationTimedOut(ctx context.Context) bool {
+	return errors.Is(context.Cause(ctx), errSymbolizationTimeout)
+}
+
+// symbolizingReader symbolizes the partitions of the dataset symbols as the
+// symbols rewriter reads them. The rewriter may open a partition more than
+// once: the symbolization results are kept, and applied to the partition
+// each time it is opened.
+type symbolizingReader struct {
+	ctx        context.Context
+	symbolizer Symbolizer
+	reader     symdb.SymbolsReader
+	partitions map[uint64]*symbolizedPartition
+}
+
+func newSymbolizingReader(ctx context.Context, symbolizer Symbolizer, reader symdb.SymbolsReader) *symbolizingReader {
+	return &symbolizingReader{
+		ctx:        ctx,
+		symbolizer: symbolizer,
+		reader:     reader,
+		partitions: make(map[uint64]*symbolizedPartition),
+	}
+}
+
+func (r *symbolizingReader) Partition(ctx context.Context, partition uint64) (symdb.PartitionReader, error) {
+	p, err := r.reader.Partition(ctx, partition)
+	if err != nil {
+		return nil, err
+	}
+	sp, ok := r.partitions[partition]
+	if !ok {
+		if sp, err = r.symbolize(p.Symbols()); err != nil {
+			p.Release()
+			return nil, err
+		}
+		r.partitions[partition] = sp
+	}
+	if sp.isEmpty() {
+		return p, nil
+	}
+	return &symbolizedPartitionReader{
+		PartitionReader: p,
+		symbols:         sp.apply(p.Symbols()),
+	}, nil
+}
+
+// unsymbolized reports whether any of the partitions read
+// still has unsymbolized locations.
+func (r *symbolizingReader) unsymbolized() bool {
+	for _, sp := range r.partitions {
+		if sp.unsymbolized {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *symbolizingReader) symbolize(src *symdb.Symbols) (*symbolizedPartition, error) {
+	if !hasUnsymbolizedLocations(src) {
+		return new(symbolizedPartition), nil
+	}
+	if symbolizationTimedOut(r.ctx) {
+		return &symbolizedPartition{unsymbolized: true}, nil
+	}
+	// The symbolizer modifies the locations and the mappings,
+	// and appends functions and strings.
+	dst := &symdb.Symbols{
+		Stacktraces: src.Stacktraces,
+		Locations:   slices.Clone(src.Locations),
+		Mappings:    slices.Clone(src.Mappings),
+		Functions:   slices.Clip(src.Functions),
+		Strings:     slices.Clip(src.Strings),
+	}
+	if err := r.symbolizer.SymbolizeSymbols(r.ctx, dst); err != nil {
+		if symbolizationTimedOut(r.ctx) {
+			// The partial results are discarded.
+			return &symbolizedPartition{unsymbolized: true}, nil
+		}
+		return nil, err
+	}
+	sp := &symbolizedPartition{
+		strings:      dst.Strings[len(src.Strings):],
+		functions:    dst.Functions[len(src.Functions):],
+		unsymbolized: hasUnsymbolizedLocations(dst),
+	}
+	for i := range src.Mappings {
+		if !src.Mappings[i].HasFunctions && dst.Mappings[i].HasFunctions {
+			sp.mappings = append(sp.mappings, uint32(i))
+		}
+	}
+	for i := range src.Locations {
+		if len(src.Locations[i].Line) == 0 && len(dst.Locations[i].Line) > 0 {
+			sp.locations = append(sp.locations, uint32(i))
+			sp.lines = append(sp.lines, dst.Locations[i].Line)
+		}
+	}
+	return sp, nil
+}
+
+// symbolizedPartition is the difference between the symbols of a partition
+// and the symbols of the partition symbolized.
+type symbolizedPartition struct {
+	strings   []string
+	functions []schemav1.InMemoryFunction
+	mappings  []uint32
+	locations []uint32
+	lines     [][]schemav1.InMemoryLine
+	// Whether the partition still has unsymbolized locations.
+	unsymbolized bool
+}
+
+func (sp *symbolizedPartition) isEmpty() bool {
+	return len(sp.locations) == 0 && len(sp.mappings) == 0
+}
+
+func (sp *symbolizedPartition) apply(src *symdb.Symbols) *symdb.Symbols {
+	dst := &symdb.Symbols{
+		Stacktraces: src.Stacktraces,
+		Locations:   slices.Clone(src.Locations),
+		Mappings:    slices.Clone(src.Mappings),
+		Functions:   append(slices.Clip(src.Functions), sp.functions...),
+		Strings:     append(slices.Clip(src.Strings), sp.strings...),
+	}
+	for _, i := range sp.mappings {
+		dst.Mappings[i].HasFunctions = true
+	}
+	for i, loc := range sp.locations {
+		dst.Locations[loc].Line = sp.lines[i]
+	}
+	return dst
+}
+
+type symbolizedPartitionReader struct {
+	symdb.PartitionReader
+	symbols *symdb.Symbols
+}
+
+func (p *symbolizedPartitionReader) Symbols() *symdb.Symbols { return p.symbols }
+
+func (p *symbolizedPartitionReader) WriteStats(s *symdb.PartitionStats) {
+	p.PartitionReader.WriteStats(s)
+	s.LocationsTotal = len(p.symbols.Locations)
+	s.MappingsTotal = len(p.symbols.Mappings)
+	s.FunctionsTotal = len(p.symbols.Functions)
+	s.StringsTotal = len(p.symbols.Strings)
+}
+
+func hasUnsymbolizedLocations(symbols *symdb.Symbols) bool {
+	for _, loc := range symbols.Locations {
+		if len(loc.Line) > 0 || int(loc.MappingId) >= len(symbols.Mappings) {
+			continue
+		}
+		if !symbols.Mappings[loc.MappingId].HasFunctions {
+			return true
+		}
+	}
+	return false
+}
+
+// isUnsymbolized reports whether the dataset labels mark it as unsymbolized.
+func isUnsymbolized(labels []int32, strings []string) bool {
+	pairs := metadata.LabelPairs(labels)
+	for pairs.Next() {
+		if marksUnsymbolized(pairs.At(), strings) {
+			return true
+		}
+	}
+	return false
+}
+
+// dropUnsymbolized removes the label sets marking the dataset as unsymbolized.
+func dropUnsymbolized(labels []int32, strings []string) []int32 {
+	dst := make([]int32, 0, len(labels))
+	pairs := metadata.LabelPairs(labels)
+	for pairs.Next() {
+		p := pairs.At()
+		if marksUnsymbolized(p, strings) {
+			continue
+		}
+		dst = append(dst, int32(len(p)/2))
+		dst = append(dst, p...)
+	}
+	return dst
+}
+
+func marksUnsymbolized(pairs []int32, strings []string) bool {
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if strings[pairs[i]] == metadata.LabelNameUnsymbolized && strings[pairs[i+1]] == "true" {
+			return true
+		}
+	}
+	return false
+}
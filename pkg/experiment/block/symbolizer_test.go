@@ -0,0 +1,161 @@
+package block
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	googlev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
+	"github.com/grafana/pyroscope/pkg/experiment/block/metadata"
+	schemav1 "github.com/grafana/pyroscope/pkg/phlaredb/schemas/v1"
+	"github.com/grafana/pyroscope/pkg/phlaredb/symdb"
+)
+
+// addressSymbolizer names the functions after the location addresses,
+// for the mappings with the "found" build ID.
+type addressSymbolizer struct{ calls int }
+
+func (s *addressSymbolizer) SymbolizeSymbols(_ context.Context, symbols *symdb.Symbols) error {
+	s.calls++
+	for i, loc := range symbols.Locations {
+		m := &symbols.Mappings[loc.MappingId]
+		if len(loc.Line) > 0 || symbols.Strings[m.BuildId] != "found" {
+			continue
+		}
+		symbols.Strings = append(symbols.Strings, fmt.Sprintf("0x%x", loc.Address))
+		symbols.Functions = append(symbols.Functions, schemav1.InMemoryFunction{
+			Id:   uint64(len(symbols.Functions)),
+			Name: uint32(len(symbols.Strings) - 1),
+		})
+		symbols.Locations[i].Line = []schemav1.InMemoryLine{{FunctionId: uint32(len(symbols.Functions) - 1), Line: 1}}
+		m.HasFunctions = true
+	}
+	return nil
+}
+
+func Test_symbolizingReader(t *testing.T) {
+	profile := &googlev1.Profile{
+		StringTable: []string{"", "found", "missing", "a.out"},
+		Mapping: []*googlev1.Mapping{
+			{Id: 1, BuildId: 1, Filename: 3},
+			{Id: 2, BuildId: 2, Filename: 3},
+		},
+		Location: []*googlev1.Location{
+			{Id: 1, MappingId: 1, Address: 0x10},
+			{Id: 2, MappingId: 1, Address: 0x20},
+			{Id: 3, MappingId: 2, Address: 0x30},
+		},
+		Sample: []*googlev1.Sample{
+			{LocationId: []uint64{2, 1}, Value: []int64{1}},
+			{LocationId: []uint64{3, 1}, Value: []int64{1}},
+		},
+	}
+	src := symdb.NewSymDB(symdb.DefaultConfig().WithDirectory(t.TempDir()))
+	samples := src.WriteProfileSymbols(0, profile)[0].Samples
+
+	symbolizer := new(addressSymbolizer)
+	reader := newSymbolizingReader(context.Background(), symbolizer, src)
+	dst := symdb.NewSymDB(symdb.DefaultConfig().WithDirectory(t.TempDir()))
+	rw := symdb.NewRewriter(dst, reader)
+
+	for i := 0; i < 2; i++ {
+		p, err := reader.Partition(context.Background(), 0)
+		require.NoError(t, err)
+		var stats symdb.PartitionStats
+		p.WriteStats(&stats)
+		require.Equal(t, len(p.Symbols().Functions), stats.FunctionsTotal)
+		require.Equal(t, len(p.Symbols().Strings), stats.StringsTotal)
+		p.Release()
+	}
+	require.Equal(t, 1, symbolizer.calls)
+	require.True(t, reader.unsymbolized())
+
+	stacktraces := append([]uint32(nil), samples.StacktraceIDs...)
+	require.NoError(t, rw.Rewrite(0, stacktraces))
+
+	r := symdb.NewResolver(context.Background(), dst)
+	defer r.Release()
+	r.AddSamples(0, schemav1.Samples{StacktraceIDs: stacktraces, Values: samples.Values})
+	actual, err := r.Pprof()
+	require.NoError(t, err)
+
+	names := make(map[uint64][]string)
+	for _, loc := range actual.Location {
+		for _, line := range loc.Line {
+			fn := actual.Function[line.FunctionId-1]
+			names[loc.Address] = append(names[loc.Address], actual.StringTable[fn.Name])
+		}
+	}
+	require.Equal(t, map[uint64][]string{
+		0x10: {"0x10"},
+		0x20: {"0x20"},
+	}, names)
+
+	// The source symbols are not modified.
+	p, err := src.Partition(context.Background(), 0)
+	require.NoError(t, err)
+	for _, loc := range p.Symbols().Locations {
+		require.Empty(t, loc.Line)
+	}
+}
+
+// blockingSymbolizer blocks until the context is done.
+type blockingSymbolizer struct{ calls int }
+
+func (s *blockingSymbolizer) SymbolizeSymbols(ctx context.Context, _ *symdb.Symbols) error {
+	s.calls++
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func Test_symbolizingReader_timeout(t *testing.T) {
+	profile := &googlev1.Profile{
+		StringTable: []string{"", "found", "a.out"},
+		Mapping:     []*googlev1.Mapping{{Id: 1, BuildId: 1, Filename: 2}},
+		Location:    []*googlev1.Location{{Id: 1, MappingId: 1, Address: 0x10}},
+		Sample:      []*googlev1.Sample{{LocationId: []uint64{1}, Value: []int64{1}}},
+	}
+	src := symdb.NewSymDB(symdb.DefaultConfig().WithDirectory(t.TempDir()))
+	src.WriteProfileSymbols(0, profile.CloneVT())
+	src.WriteProfileSymbols(1, profile)
+
+	ctx, cancel := context.WithTimeoutCause(context.Background(), 10*time.Millisecond, errSymbolizationTimeout)
+	defer cancel()
+	symbolizer := new(blockingSymbolizer)
+	reader := newSymbolizingReader(ctx, symbolizer, src)
+	for _, partition := range []uint64{0, 1} {
+		p, err := reader.Partition(context.Background(), partition)
+		require.NoError(t, err)
+		require.Empty(t, p.Symbols().Locations[0].Line)
+		p.Release()
+	}
+	// The partition opened after the timeout is not symbolized.
+	require.Equal(t, 1, symbolizer.calls)
+	require.True(t, reader.unsymbolized())
+
+	// Other errors are not ignored.
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	reader = newSymbolizingReader(ctx, new(blockingSymbolizer), src)
+	_, err := reader.Partition(context.Background(), 0)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func Test_dropUnsymbolized(t *testing.T) {
+	strings := metadata.NewStringTable()
+	labels := metadata.NewLabelBuilder(strings).
+		WithLabelSet("service_name", "svc", "__profile_type__", "cpu").
+		WithLabelSet("service_name", "svc", metadata.LabelNameUnsymbolized, "true").
+		Build()
+	require.True(t, isUnsymbolized(labels, strings.Strings))
+
+	dropped := dropUnsymbolized(labels, strings.Strings)
+	require.False(t, isUnsymbolized(dropped, strings.Strings))
+	expected := metadata.NewLabelBuilder(strings).
+		WithLabelSet("service_name", "svc", "__profile_type__", "cpu").
+		Build()
+	require.Equal(t, expected, dropped)
+}
@@ -54,15 +54,20 @@ type Worker struct {
 
 	exporter metrics.Exporter
 	ruler    metrics.Ruler
+
+	symbolizer block.Symbolizer
+	limits     Limits
 }
 
 type Config struct {
-	JobConcurrency  int            `yaml:"job_capacity"`
-	JobPollInterval time.Duration  `yaml:"job_poll_interval"`
-	SmallObjectSize int            `yaml:"small_object_size_bytes"`
-	TempDir         string         `yaml:"temp_dir"`
-	RequestTimeout  time.Duration  `yaml:"request_timeout"`
-	MetricsExporter metrics.Config `yaml:"metrics_exporter"`
+	JobConcurrency   int            `yaml:"job_capacity"`
+	JobPollInterval  time.Duration  `yaml:"job_poll_interval"`
+	SmallObjectSize  int            `yaml:"small_object_size_bytes"`
+	TempDir          string         `yaml:"temp_dir"`
+	RequestTimeout   time.Duration  `yaml:"request_timeout"`
+	MetricsExporter  metrics.Config `yaml:"metrics_exporter"`
+	Symbolize        bool           `yaml:"symbolize"`
+	SymbolizeTimeout time.Duration  `yaml:"symbolize_timeout"`
 }
 
 func (cfg *Config) RegisterFlags(f *flag.FlagSet) {
@@ -72,6 +77,8 @@ func (cfg *Config) RegisterFlags(f *flag.FlagSet) {
 	f.DurationVar(&cfg.RequestTimeout, prefix+"request-timeout", 5*time.Second, "Job request timeout.")
 	f.IntVar(&cfg.SmallObjectSize, prefix+"small-object-size-bytes", 8<<20, "Size of the object that can be loaded in memory.")
 	f.StringVar(&cfg.TempDir, prefix+"temp-dir", os.TempDir(), "Temporary directory for compaction jobs.")
+	f.BoolVar(&cfg.Symbolize, prefix+"symbolize", false, "Symbolize the unsymbolized profiles of the tenants the symbolizer is enabled for, when compacting their blocks.")
+	f.DurationVar(&cfg.SymbolizeTimeout, prefix+"symbolize-timeout", time.Minute, "Maximum time spent symbolizing the profiles of a compaction job. The profiles not symbolized in time are left unsymbolized. 0 means no limit.")
 	cfg.MetricsExporter.RegisterFlags(f)
 }
 
@@ -104,6 +111,10 @@ type MetastoreClient interface {
 	metastorev1.IndexServiceClient
 }
 
+type Limits interface {
+	SymbolizerEnabled(tenantID string) bool
+}
+
 func New(
 	logger log.Logger,
 	config Config,
@@ -112,6 +123,8 @@ func New(
 	reg prometheus.Registerer,
 	ruler metrics.Ruler,
 	exporter metrics.Exporter,
+	symbolizer block.Symbolizer,
+	limits Limits,
 ) (*Worker, error) {
 	config.TempDir = filepath.Join(filepath.Clean(config.TempDir), "pyroscope-compactor")
 	_ = os.RemoveAll(config.TempDir)
@@ -126,6 +139,9 @@ func New(
 		metrics:  newMetrics(reg),
 		ruler:    ruler,
 		exporter: exporter,
+
+		symbolizer: symbolizer,
+		limits:     limits,
 	}
 	w.threads = config.JobConcurrency
 	if w.threads < 1 {
@@ -426,6 +442,10 @@ func (w *Worker) runCompaction(job *compactionJob) {
 		options = append(options, block.WithSampleObserver(observer))
 	}
 
+	if w.symbolizer != nil && w.limits.SymbolizerEnabled(job.Tenant) {
+		options = append(options, block.WithCompactionSymbolizer(w.symbolizer, w.config.SymbolizeTimeout))
+	}
+
 	compacted, err := block.Compact(ctx, job.blocks, w.storage, options...)
 	defer func() {
 		if err = os.RemoveAll(tempdir); err != nil {
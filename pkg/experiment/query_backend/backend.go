@@ -2,8 +2,10 @@ package query_backend
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"time"
 
 	"github.com/go-kit/log"
 	"github.com/grafana/dskit/grpcclient"
@@ -79,14 +81,31 @@ func (q *QueryBackend) Invoke(
 	span, ctx := opentracing.StartSpanFromContext(ctx, "QueryBackend.Invoke")
 	defer span.Finish()
 
+	// The number of blocks is checked at every node of the plan,
+	// therefore the root node rejects the query as a whole.
+	if err := checkLimit("blocks", planBlocks(req.QueryPlan.Root), req.Options.GetMaxBlocks()); err != nil {
+		return nil, err
+	}
+	if d := req.Options.GetMaxWallTimeMs(); d > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, time.Duration(d)*time.Millisecond, errWallTimeExceeded)
+		defer cancel()
+	}
+
+	var resp *queryv1.InvokeResponse
+	var err error
 	switch r := req.QueryPlan.Root; r.Type {
 	case queryv1.QueryNode_MERGE:
-		return q.merge(ctx, req, r.Children)
+		resp, err = q.merge(ctx, req, r.Children)
 	case queryv1.QueryNode_READ:
-		return q.read(ctx, req, r.Blocks)
+		resp, err = q.read(ctx, req, r.Blocks)
 	default:
 		panic("query plan: unknown node type")
 	}
+	if err != nil && errors.Is(context.Cause(ctx), errWallTimeExceeded) {
+		return nil, errWallTimeExceeded
+	}
+	return resp, err
 }
 
 func (q *QueryBackend) merge(
@@ -96,21 +115,40 @@ func (q *QueryBackend) merge(
 ) (*queryv1.InvokeResponse, error) {
 	request.QueryPlan = nil
 	m := newAggregator(request)
+	limits := newQueryLimits(request.Options)
+	var blocks, size int64
+	for _, child := range children {
+		blocks += planBlocks(child)
+		size += planSize(child)
+	}
 	g, ctx := errgroup.WithContext(ctx)
 	for _, child := range children {
 		req := request.CloneVT()
 		req.QueryPlan = &queryv1.QueryPlan{
 			Root: child,
 		}
+		req.Options = splitOptions(request.Options, child, blocks, size)
 		g.Go(util.RecoverPanic(func() error {
 			// TODO: Speculative retry.
-			return m.aggregateResponse(q.backendClient.Invoke(ctx, req))
+			resp, err := q.backendClient.Invoke(ctx, req)
+			if err == nil {
+				err = limits.addStats(resp.GetDiagnostics().GetStats())
+			}
+			return m.aggregateResponse(resp, err)
 		}))
 	}
 	if err := g.Wait(); err != nil {
+		if limitErr := limits.error(); limitErr != nil {
+			return nil, limitErr
+		}
+		return nil, err
+	}
+	resp, err := m.response()
+	if err != nil {
 		return nil, err
 	}
-	return m.response()
+	resp.Diagnostics = &queryv1.Diagnostics{Stats: limits.stats()}
+	return resp, nil
 }
 
 func (q *QueryBackend) read(
@@ -79,6 +79,8 @@ func (b *BlockReader) Invoke(
 
 	g, ctx := errgroup.WithContext(ctx)
 	agg := newAggregator(req)
+	limits := newQueryLimits(req.Options)
+	storage := &limitedBucket{Bucket: b.storage, limits: limits}
 
 	tenantMap := make(map[string]struct{})
 	for _, tenant := range req.Tenant {
@@ -95,7 +97,10 @@ func (b *BlockReader) Invoke(
 		if len(md.Datasets) == 0 {
 			continue
 		}
-		obj := block.NewObject(b.storage, md)
+		if err = limits.addBlocks(1); err != nil {
+			return nil, err
+		}
+		obj := block.NewObject(storage, md)
 		g.Go(util.RecoverPanic((&blockContext{
 			ctx: ctx,
 			log: b.log,
@@ -103,13 +108,22 @@ func (b *BlockReader) Invoke(
 			agg: agg,
 			obj: obj,
 			grp: g,
+			lim: limits,
 		}).execute))
 	}
 
 	if err = g.Wait(); err != nil {
+		if limitErr := limits.error(); limitErr != nil {
+			return nil, limitErr
+		}
+		return nil, err
+	}
+	resp, err := agg.response()
+	if err != nil {
 		return nil, err
 	}
-	return agg.response()
+	resp.Diagnostics = &queryv1.Diagnostics{Stats: limits.stats()}
+	return resp, nil
 }
 
 type request struct {
@@ -7,14 +7,18 @@ import (
 	"os"
 	"path/filepath"
 	"slices"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	profilev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
 	metastorev1 "github.com/grafana/pyroscope/api/gen/proto/go/metastore/v1"
 	queryv1 "github.com/grafana/pyroscope/api/gen/proto/go/query/v1"
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
 	"github.com/grafana/pyroscope/pkg/experiment/block"
 	"github.com/grafana/pyroscope/pkg/experiment/block/metadata"
 	"github.com/grafana/pyroscope/pkg/experiment/query_backend/query_plan"
@@ -162,6 +166,37 @@ func (s *testSuite) Test_QueryTree_Filter() {
 	s.Assert().Equal(string(expected), tree.String())
 }
 
+func (s *testSuite) Test_QueryTree_FrameFilter() {
+	resp, err := s.reader.Invoke(s.ctx, &queryv1.InvokeRequest{
+		EndTime:       time.Now().UnixMilli(),
+		LabelSelector: "{}",
+		QueryPlan:     s.plan,
+		Query: []*queryv1.Query{{
+			QueryType: queryv1.QueryType_QUERY_TREE,
+			Tree: &queryv1.TreeQuery{
+				MaxNodes: 16,
+				FrameFilter: &typesv1.FrameFilter{
+					Focus:     `^main\.`,
+					PruneFrom: `^main\.main$`,
+				},
+			},
+		}},
+		Tenant: s.tenant,
+	})
+
+	s.Require().NoError(err)
+	s.Require().NotNil(resp)
+	s.Require().Len(resp.Reports, 1)
+	tree, err := phlaremodel.UnmarshalTree(resp.Reports[0].Tree.Tree)
+	s.Require().NoError(err)
+
+	expected := `.
+└── runtime.main: self 0 total 3867506245449
+    └── main.main: self 3867506245449 total 3867506245449
+`
+	s.Assert().Equal(expected, tree.String())
+}
+
 func (s *testSuite) Test_QueryPprof_Metadata() {
 	selector := `{service_name="test-app",__profile_type__="process_cpu:cpu:nanoseconds:cpu:nanoseconds"}`
 	resp, err := s.reader.Invoke(s.ctx, &queryv1.InvokeRequest{
@@ -284,3 +319,447 @@ func (s *testSuite) Test_QueryTree_All_Tenant_Isolation() {
 	s.Require().NotNil(resp)
 	s.Require().Len(resp.Reports, 0)
 }
+
+func (s *testSuite) Test_QueryTopTable() {
+	resp, err := s.reader.Invoke(s.ctx, &queryv1.InvokeRequest{
+		EndTime:       time.Now().UnixMilli(),
+		LabelSelector: "{}",
+		QueryPlan:     s.plan,
+		Query: []*queryv1.Query{
+			{
+				QueryType: queryv1.QueryType_QUERY_TREE,
+				Tree:      &queryv1.TreeQuery{},
+			},
+			{
+				QueryType: queryv1.QueryType_QUERY_TOP_TABLE,
+				TopTable: &queryv1.TopTableQuery{
+					OrderBy: typesv1.TopTableOrderBy_TOP_TABLE_ORDER_BY_TOTAL,
+				},
+			},
+		},
+		Tenant: s.tenant,
+	})
+
+	s.Require().NoError(err)
+	s.Require().NotNil(resp)
+	s.Require().Len(resp.Reports, 2)
+	var tree *phlaremodel.Tree
+	var table []*typesv1.TopTableEntry
+	for _, r := range resp.Reports {
+		switch r.ReportType {
+		case queryv1.ReportType_REPORT_TREE:
+			tree, err = phlaremodel.UnmarshalTree(r.Tree.Tree)
+			s.Require().NoError(err)
+		case queryv1.ReportType_REPORT_TOP_TABLE:
+			table = r.TopTable.Entries
+		}
+	}
+
+	expected := phlaremodel.SortTopTable(phlaremodel.TopTableFromTree(tree), typesv1.TopTableOrderBy_TOP_TABLE_ORDER_BY_TOTAL, 0)
+	s.Require().Len(table, len(expected))
+	for i, e := range table {
+		s.Assert().Equal(expected[i].Name, e.Name)
+		s.Assert().Equal(expected[i].Self, e.Self)
+		s.Assert().Equal(expected[i].Total, e.Total)
+		s.Assert().NotZero(e.Samples)
+	}
+}
+
+func (s *testSuite) Test_QueryCallGraph() {
+	resp, err := s.reader.Invoke(s.ctx, &queryv1.InvokeRequest{
+		EndTime:       time.Now().UnixMilli(),
+		LabelSelector: "{}",
+		QueryPlan:     s.plan,
+		Query: []*queryv1.Query{
+			{
+				QueryType: queryv1.QueryType_QUERY_TREE,
+				Tree:      &queryv1.TreeQuery{},
+			},
+			{
+				QueryType: queryv1.QueryType_QUERY_CALL_GRAPH,
+				CallGraph: &queryv1.CallGraphQuery{},
+			},
+		},
+		Tenant: s.tenant,
+	})
+
+	s.Require().NoError(err)
+	s.Require().NotNil(resp)
+	s.Require().Len(resp.Reports, 2)
+	var tree *phlaremodel.Tree
+	var graph *typesv1.CallGraph
+	for _, r := range resp.Reports {
+		switch r.ReportType {
+		case queryv1.ReportType_REPORT_TREE:
+			tree, err = phlaremodel.UnmarshalTree(r.Tree.Tree)
+			s.Require().NoError(err)
+		case queryv1.ReportType_REPORT_CALL_GRAPH:
+			graph = r.CallGraph.CallGraph
+		}
+	}
+
+	s.Require().NotEmpty(graph.Nodes)
+	s.Assert().Equal(phlaremodel.CallGraphFromTree(tree).String(), graph.String())
+}
+
+func (s *testSuite) Test_QueryFunctionDetails() {
+	query := func(q *queryv1.Query) *queryv1.Report {
+		resp, err := s.reader.Invoke(s.ctx, &queryv1.InvokeRequest{
+			EndTime:       time.Now().UnixMilli(),
+			LabelSelector: "{}",
+			QueryPlan:     s.plan,
+			Query:         []*queryv1.Query{q},
+			Tenant:        s.tenant,
+		})
+		s.Require().NoError(err)
+		s.Require().Len(resp.Reports, 1)
+		return resp.Reports[0]
+	}
+
+	table := query(&queryv1.Query{
+		QueryType: queryv1.QueryType_QUERY_TOP_TABLE,
+		TopTable: &queryv1.TopTableQuery{
+			OrderBy: typesv1.TopTableOrderBy_TOP_TABLE_ORDER_BY_SELF,
+			Limit:   1,
+		},
+	}).TopTable.Entries
+	s.Require().NotEmpty(table)
+	function := table[0]
+
+	r := query(&queryv1.Query{
+		QueryType:       queryv1.QueryType_QUERY_FUNCTION_DETAILS,
+		FunctionDetails: &queryv1.FunctionDetailsQuery{FunctionName: function.Name},
+	}).FunctionDetails
+	s.Require().NotNil(r)
+
+	callers, err := phlaremodel.UnmarshalTree(r.Callers)
+	s.Require().NoError(err)
+	callees, err := phlaremodel.UnmarshalTree(r.Callees)
+	s.Require().NoError(err)
+	s.Assert().Equal(function.Total, callers.Total())
+	s.Assert().Equal(function.Total, callees.Total())
+	var self int64
+	for _, l := range r.Lines {
+		self += l.Self
+	}
+	s.Assert().Equal(function.Self, self)
+}
+
+func (s *testSuite) Test_QueryDiffTree() {
+	const selector = `{service_name="test-app",function="slow"}`
+	query := func(selector string, q *queryv1.Query) *queryv1.Report {
+		resp, err := s.reader.Invoke(s.ctx, &queryv1.InvokeRequest{
+			EndTime:       time.Now().UnixMilli(),
+			LabelSelector: selector,
+			QueryPlan:     s.plan,
+			Query:         []*queryv1.Query{q},
+			Tenant:        s.tenant,
+		})
+		s.Require().NoError(err)
+		s.Require().Len(resp.Reports, 1)
+		return resp.Reports[0]
+	}
+	tree := func(b []byte) string {
+		t, err := phlaremodel.UnmarshalTree(b)
+		s.Require().NoError(err)
+		return t.String()
+	}
+
+	end := time.Now().UnixMilli()
+	r := query("{}", &queryv1.Query{
+		QueryType: queryv1.QueryType_QUERY_DIFF_TREE,
+		DiffTree: &queryv1.DiffTreeQuery{
+			Left:  &queryv1.DiffTreeTarget{EndTime: end, LabelSelector: "{}"},
+			Right: &queryv1.DiffTreeTarget{EndTime: end, LabelSelector: selector},
+		},
+	}).DiffTree
+	s.Require().NotNil(r)
+
+	left := query("{}", &queryv1.Query{
+		QueryType: queryv1.QueryType_QUERY_TREE,
+		Tree:      &queryv1.TreeQuery{},
+	}).Tree
+	right := query(selector, &queryv1.Query{
+		QueryType: queryv1.QueryType_QUERY_TREE,
+		Tree:      &queryv1.TreeQuery{},
+	}).Tree
+	s.Assert().Equal(tree(left.Tree), tree(r.Left))
+	s.Assert().Equal(tree(right.Tree), tree(r.Right))
+}
+
+func (s *testSuite) Test_QueryExemplars() {
+	query := func(q *queryv1.Query) *queryv1.Report {
+		resp, err := s.reader.Invoke(s.ctx, &queryv1.InvokeRequest{
+			EndTime:       time.Now().UnixMilli(),
+			LabelSelector: "{}",
+			QueryPlan:     s.plan,
+			Query:         []*queryv1.Query{q},
+			Tenant:        s.tenant,
+		})
+		s.Require().NoError(err)
+		s.Require().Len(resp.Reports, 1)
+		return resp.Reports[0]
+	}
+
+	exemplars := query(&queryv1.Query{
+		QueryType: queryv1.QueryType_QUERY_EXEMPLARS,
+		Exemplars: &queryv1.ExemplarsQuery{Limit: 3},
+	}).Exemplars.Exemplars
+	s.Require().Len(exemplars, 3)
+	s.Assert().True(slices.IsSortedFunc(exemplars, func(a, b *typesv1.ProfileExemplar) int {
+		return int(b.Value - a.Value)
+	}))
+
+	all := query(&queryv1.Query{
+		QueryType: queryv1.QueryType_QUERY_EXEMPLARS,
+		Exemplars: &queryv1.ExemplarsQuery{},
+	}).Exemplars.Exemplars
+	s.Require().Greater(len(all), 3)
+	for i, e := range exemplars {
+		s.Assert().Equal(all[i].Value, e.Value)
+	}
+
+	// A profile might be split into multiple series at ingestion.
+	id := exemplars[0].ProfileId
+	var expected int64
+	for _, e := range all {
+		if e.ProfileId == id {
+			expected += e.Value
+		}
+	}
+
+	r := query(&queryv1.Query{
+		QueryType: queryv1.QueryType_QUERY_PPROF,
+		Pprof:     &queryv1.PprofQuery{ProfileIdSelector: []string{id}},
+	})
+	var p profilev1.Profile
+	s.Require().NoError(pprof.Unmarshal(r.Pprof.Pprof, &p))
+	var total int64
+	for _, x := range p.Sample {
+		total += x.Value[0]
+	}
+	s.Assert().Equal(expected, total)
+}
+
+func (s *testSuite) Test_QueryHeatmap() {
+	query := func(q *queryv1.Query) *queryv1.Report {
+		resp, err := s.reader.Invoke(s.ctx, &queryv1.InvokeRequest{
+			EndTime:       time.Now().UnixMilli(),
+			LabelSelector: "{}",
+			QueryPlan:     s.plan,
+			Query:         []*queryv1.Query{q},
+			Tenant:        s.tenant,
+		})
+		s.Require().NoError(err)
+		s.Require().Len(resp.Reports, 1)
+		return resp.Reports[0]
+	}
+
+	heatmap := query(&queryv1.Query{
+		QueryType: queryv1.QueryType_QUERY_HEATMAP,
+		Heatmap:   &queryv1.HeatmapQuery{Step: 60},
+	}).Heatmap.Heatmap
+	s.Require().NotEmpty(heatmap.Cells)
+	s.Assert().Equal(int64(60000), heatmap.Step)
+	s.Assert().Equal(int32(phlaremodel.DefaultHeatmapValueResolution), heatmap.ValueResolution)
+
+	// Each profile is accounted in exactly one cell.
+	exemplars := query(&queryv1.Query{
+		QueryType: queryv1.QueryType_QUERY_EXEMPLARS,
+		Exemplars: &queryv1.ExemplarsQuery{},
+	}).Exemplars.Exemplars
+	var count int64
+	for _, c := range heatmap.Cells {
+		s.Assert().Zero(c.Timestamp % heatmap.Step)
+		count += c.Count
+	}
+	s.Assert().Equal(int64(len(exemplars)), count)
+}
+
+func (s *testSuite) Test_QueryLimits() {
+	query := func(options *queryv1.InvokeOptions) (*queryv1.InvokeResponse, error) {
+		return s.reader.Invoke(s.ctx, &queryv1.InvokeRequest{
+			EndTime:       time.Now().UnixMilli(),
+			LabelSelector: "{}",
+			QueryPlan:     s.plan,
+			Query: []*queryv1.Query{{
+				QueryType: queryv1.QueryType_QUERY_TREE,
+				Tree:      &queryv1.TreeQuery{MaxNodes: 16},
+			}},
+			Tenant:  s.tenant,
+			Options: options,
+		})
+	}
+
+	resp, err := query(nil)
+	s.Require().NoError(err)
+	stats := resp.Diagnostics.GetStats()
+	s.Require().NotNil(stats)
+	s.Assert().Equal(planBlocks(s.plan.Root), stats.BlocksRead)
+	s.Assert().NotZero(stats.DatasetsRead)
+	s.Assert().NotZero(stats.BytesRead)
+	s.Assert().NotZero(stats.SeriesRead)
+
+	for _, options := range []*queryv1.InvokeOptions{
+		{MaxBlocks: stats.BlocksRead - 1},
+		{MaxBytesRead: stats.BytesRead / 2},
+		{MaxSeries: stats.SeriesRead - 1},
+	} {
+		_, err = query(options)
+		s.Assert().Equal(codes.ResourceExhausted, status.Code(err), options.String())
+	}
+
+	// The limits are not exceeded.
+	_, err = query(&queryv1.InvokeOptions{
+		MaxBlocks:    stats.BlocksRead,
+		MaxBytesRead: stats.BytesRead,
+		MaxSeries:    stats.SeriesRead,
+	})
+	s.Assert().NoError(err)
+}
+
+// readOptionsRecorder records the options of the requests sent to read nodes.
+type readOptionsRecorder struct {
+	QueryHandler
+	mu      sync.Mutex
+	options []*queryv1.InvokeOptions
+}
+
+func (r *readOptionsRecorder) Invoke(ctx context.Context, req *queryv1.InvokeRequest) (*queryv1.InvokeResponse, error) {
+	r.mu.Lock()
+	r.options = append(r.options, req.Options)
+	r.mu.Unlock()
+	return r.QueryHandler.Invoke(ctx, req)
+}
+
+func (s *testSuite) Test_QueryLimits_MultipleReadNodes() {
+	reader := &readOptionsRecorder{QueryHandler: s.reader}
+	backend, err := New(Config{}, s.logger, nil, nil, reader)
+	s.Require().NoError(err)
+	backend.backendClient = backend
+
+	// Each read node references at most two blocks.
+	plan := query_plan.Build(s.meta, 2, 2)
+	s.Require().Equal(queryv1.QueryNode_MERGE, plan.Root.Type)
+	query := func(options *queryv1.InvokeOptions) (*queryv1.InvokeResponse, error) {
+		reader.options = nil
+		return backend.Invoke(s.ctx, &queryv1.InvokeRequest{
+			EndTime:       time.Now().UnixMilli(),
+			LabelSelector: "{}",
+			QueryPlan:     plan.CloneVT(),
+			Query: []*queryv1.Query{{
+				QueryType: queryv1.QueryType_QUERY_TREE,
+				Tree:      &queryv1.TreeQuery{MaxNodes: 16},
+			}},
+			Tenant:  s.tenant,
+			Options: options,
+		})
+	}
+
+	resp, err := query(nil)
+	s.Require().NoError(err)
+	stats := resp.Diagnostics.GetStats()
+	s.Require().NotNil(stats)
+	s.Assert().Equal(planBlocks(plan.Root), stats.BlocksRead)
+
+	limits := &queryv1.InvokeOptions{
+		MaxBlocks:    stats.BlocksRead,
+		MaxBytesRead: stats.BytesRead,
+		MaxSeries:    stats.SeriesRead,
+	}
+	_, _ = query(limits)
+	s.Require().Greater(len(reader.options), 1)
+	var maxBlocks, maxBytes, maxSeries int64
+	for _, o := range reader.options {
+		s.Assert().Positive(o.MaxBlocks)
+		s.Assert().Positive(o.MaxBytesRead)
+		s.Assert().Positive(o.MaxSeries)
+		maxBlocks += o.MaxBlocks
+		maxBytes += o.MaxBytesRead
+		maxSeries += o.MaxSeries
+	}
+	// The read nodes share the budget of the query.
+	s.Assert().LessOrEqual(maxBlocks, limits.MaxBlocks)
+	s.Assert().LessOrEqual(maxBytes, limits.MaxBytesRead)
+	s.Assert().LessOrEqual(maxSeries, limits.MaxSeries)
+
+	for _, options := range []*queryv1.InvokeOptions{
+		{MaxBytesRead: stats.BytesRead - 1},
+		{MaxSeries: stats.SeriesRead - 1},
+	} {
+		_, err = query(options)
+		s.Assert().Equal(codes.ResourceExhausted, status.Code(err), options.String())
+	}
+}
+
+func (s *testSuite) Test_QueryProfileExport() {
+	query := func(q *queryv1.Query) (*queryv1.Report, error) {
+		resp, err := s.reader.Invoke(s.ctx, &queryv1.InvokeRequest{
+			EndTime:       time.Now().UnixMilli(),
+			LabelSelector: "{}",
+			QueryPlan:     s.plan,
+			Query:         []*queryv1.Query{q},
+			Tenant:        s.tenant,
+		})
+		if err != nil {
+			return nil, err
+		}
+		s.Require().Len(resp.Reports, 1)
+		return resp.Reports[0], nil
+	}
+
+	r, err := query(&queryv1.Query{
+		QueryType:     queryv1.QueryType_QUERY_PROFILE_EXPORT,
+		ProfileExport: &queryv1.ProfileExportQuery{},
+	})
+	s.Require().NoError(err)
+	export := r.ProfileExport
+	s.Require().NotEmpty(export.Profiles)
+	s.Assert().True(slices.IsSortedFunc(export.Profiles, func(a, b *queryv1.ExportedProfile) int {
+		return int(a.Timestamp - b.Timestamp)
+	}))
+
+	// The exported stacks make up the same tree.
+	actual := new(phlaremodel.Tree)
+	stack := make([]string, 0, 64)
+	for _, p := range export.Profiles {
+		s.Assert().Len(p.Id, 16)
+		s.Assert().NotEmpty(p.Labels)
+		for _, x := range p.Samples {
+			stack = stack[:0]
+			for _, n := range x.Stack {
+				stack = append(stack, export.Strings[n])
+			}
+			actual.InsertStack(x.Value, stack...)
+		}
+	}
+	r, err = query(&queryv1.Query{
+		QueryType: queryv1.QueryType_QUERY_TREE,
+		Tree:      &queryv1.TreeQuery{MaxNodes: -1},
+	})
+	s.Require().NoError(err)
+	expected, err := phlaremodel.UnmarshalTree(r.Tree.Tree)
+	s.Require().NoError(err)
+	s.Assert().Equal(expected.String(), actual.String())
+
+	_, err = query(&queryv1.Query{
+		QueryType:     queryv1.QueryType_QUERY_PROFILE_EXPORT,
+		ProfileExport: &queryv1.ProfileExportQuery{MaxProfiles: int64(len(export.Profiles) - 1)},
+	})
+	s.Assert().Equal(codes.ResourceExhausted, status.Code(err))
+
+	var samples int64
+	for _, p := range export.Profiles {
+		samples += int64(len(p.Samples))
+	}
+	_, err = query(&queryv1.Query{
+		QueryType:     queryv1.QueryType_QUERY_PROFILE_EXPORT,
+		ProfileExport: &queryv1.ProfileExportQuery{MaxSamples: samples - 1},
+	})
+	s.Assert().Equal(codes.ResourceExhausted, status.Code(err))
+	_, err = query(&queryv1.Query{
+		QueryType:     queryv1.QueryType_QUERY_PROFILE_EXPORT,
+		ProfileExport: &queryv1.ProfileExportQuery{MaxSamples: samples},
+	})
+	s.Assert().NoError(err)
+}
@@ -0,0 +1,226 @@
+package query_backend
+
+import (
+	"context"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	queryv1 "github.com/grafana/pyroscope/api/gen/proto/go/query/v1"
+	"github.com/grafana/pyroscope/pkg/objstore"
+)
+
+var errWallTimeExceeded = status.Error(codes.ResourceExhausted, "query limit exceeded: max wall time")
+
+func errLimitExceeded(resource string, limit int64) error {
+	return status.Errorf(codes.ResourceExhausted,
+		"query limit exceeded: max %s is %d; narrow down the time range or the label selector", resource, limit)
+}
+
+// queryLimits enforces the resource limits of the query specified in the
+// invoke options, and collects the query execution statistics.
+//
+// Merge nodes split the limits across their children, in proportion to
+// the blocks they reference (see splitOptions): this way, the read nodes
+// together can't read more than the query budget. Read nodes enforce their
+// share on the data they read, while merge nodes enforce theirs on the
+// statistics reported by their children.
+type queryLimits struct {
+	options  *queryv1.InvokeOptions
+	blocks   atomic.Int64
+	datasets atomic.Int64
+	bytes    atomic.Int64
+	series   atomic.Int64
+
+	// The error is retained, as it may be wrapped
+	// and lose its status code down the call stack.
+	mu  sync.Mutex
+	err error
+}
+
+func newQueryLimits(options *queryv1.InvokeOptions) *queryLimits {
+	if options == nil {
+		options = new(queryv1.InvokeOptions)
+	}
+	return &queryLimits{options: options}
+}
+
+func checkLimit(resource string, v, limit int64) error {
+	if limit > 0 && v > limit {
+		return errLimitExceeded(resource, limit)
+	}
+	return nil
+}
+
+func (l *queryLimits) check(resource string, v, limit int64) error {
+	err := checkLimit(resource, v, limit)
+	if err != nil {
+		l.mu.Lock()
+		if l.err == nil {
+			l.err = err
+		}
+		l.mu.Unlock()
+	}
+	return err
+}
+
+// error returns the first limit exceeded error, if any.
+func (l *queryLimits) error() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.err
+}
+
+func (l *queryLimits) addBlocks(n int64) error {
+	return l.check("blocks", l.blocks.Add(n), l.options.MaxBlocks)
+}
+
+func (l *queryLimits) addDatasets(n int64) { l.datasets.Add(n) }
+
+func (l *queryLimits) addBytes(n int64) error {
+	return l.check("bytes read", l.bytes.Add(n), l.options.MaxBytesRead)
+}
+
+func (l *queryLimits) addSeries(n int64) error {
+	return l.check("series", l.series.Add(n), l.options.MaxSeries)
+}
+
+func (l *queryLimits) addStats(s *queryv1.ExecutionStats) error {
+	if s == nil {
+		return nil
+	}
+	l.addDatasets(s.DatasetsRead)
+	if err := l.addBlocks(s.BlocksRead); err != nil {
+		return err
+	}
+	if err := l.addBytes(s.BytesRead); err != nil {
+		return err
+	}
+	return l.addSeries(s.SeriesRead)
+}
+
+func (l *queryLimits) stats() *queryv1.ExecutionStats {
+	return &queryv1.ExecutionStats{
+		BlocksRead:   l.blocks.Load(),
+		DatasetsRead: l.datasets.Load(),
+		BytesRead:    l.bytes.Load(),
+		SeriesRead:   l.series.Load(),
+	}
+}
+
+// planBlocks returns the number of blocks referenced by the query plan node.
+func planBlocks(n *queryv1.QueryNode) int64 {
+	if n == nil {
+		return 0
+	}
+	c := int64(len(n.Blocks))
+	for _, child := range n.Children {
+		c += planBlocks(child)
+	}
+	return c
+}
+
+// planSize returns the size of the blocks referenced by the query plan node.
+func planSize(n *queryv1.QueryNode) int64 {
+	if n == nil {
+		return 0
+	}
+	var c int64
+	for _, b := range n.Blocks {
+		c += int64(b.Size)
+	}
+	for _, child := range n.Children {
+		c += planSize(child)
+	}
+	return c
+}
+
+// splitOptions returns the options of a child node of the plan. The number
+// of blocks and series is split in proportion to the number of blocks the
+// child references, and the number of bytes in proportion to their size.
+func splitOptions(options *queryv1.InvokeOptions, child *queryv1.QueryNode, blocks, size int64) *queryv1.InvokeOptions {
+	if options == nil {
+		return nil
+	}
+	o := options.CloneVT()
+	childBlocks := planBlocks(child)
+	o.MaxBlocks = limitShare(options.MaxBlocks, childBlocks, blocks)
+	o.MaxSeries = limitShare(options.MaxSeries, childBlocks, blocks)
+	o.MaxBytesRead = limitShare(options.MaxBytesRead, planSize(child), size)
+	return o
+}
+
+// limitShare returns the part of the limit; the share of a limited
+// resource is never zero, as zero means no limit.
+func limitShare(limit, part, total int64) int64 {
+	if limit <= 0 || part >= total || total <= 0 {
+		return limit
+	}
+	return max(1, int64(float64(limit)*float64(part)/float64(total)))
+}
+
+// limitedBucket accounts the bytes read from the bucket. Ranges of known
+// size are accounted before they are fetched: the query fails without
+// reading the data, if the range does not fit in the limit.
+type limitedBucket struct {
+	objstore.Bucket
+	limits *queryLimits
+}
+
+func (b *limitedBucket) Get(ctx context.Context, name string) (io.ReadCloser, error) {
+	rc, err := b.Bucket.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return &limitedReader{ReadCloser: rc, limits: b.limits}, nil
+}
+
+func (b *limitedBucket) GetRange(ctx context.Context, name string, off, length int64) (io.ReadCloser, error) {
+	if length > 0 {
+		if err := b.limits.addBytes(length); err != nil {
+			return nil, err
+		}
+		return b.Bucket.GetRange(ctx, name, off, length)
+	}
+	rc, err := b.Bucket.GetRange(ctx, name, off, length)
+	if err != nil {
+		return nil, err
+	}
+	return &limitedReader{ReadCloser: rc, limits: b.limits}, nil
+}
+
+func (b *limitedBucket) ReaderAt(ctx context.Context, name string) (objstore.ReaderAtCloser, error) {
+	r, err := b.Bucket.ReaderAt(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return &limitedReaderAt{ReaderAtCloser: r, limits: b.limits}, nil
+}
+
+type limitedReader struct {
+	io.ReadCloser
+	limits *queryLimits
+}
+
+func (r *limitedReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if limitErr := r.limits.addBytes(int64(n)); limitErr != nil {
+		return n, limitErr
+	}
+	return n, err
+}
+
+type limitedReaderAt struct {
+	objstore.ReaderAtCloser
+	limits *queryLimits
+}
+
+func (r *limitedReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	if err := r.limits.addBytes(int64(len(p))); err != nil {
+		return 0, err
+	}
+	return r.ReaderAtCloser.ReadAt(p, off)
+}
@@ -82,6 +82,7 @@ type blockContext struct {
 	agg *reportAggregator
 	obj *block.Object
 	grp *errgroup.Group
+	lim *queryLimits
 }
 
 func (b *blockContext) execute() error {
@@ -100,6 +101,7 @@ func (b *blockContext) execute() error {
 	}
 
 	for _, ds := range b.obj.Metadata().Datasets {
+		b.lim.addDatasets(1)
 		q := b.newQueryContext(ds)
 		for _, query := range b.req.src.Query {
 			q.grp.Go(util.RecoverPanic(func() error {
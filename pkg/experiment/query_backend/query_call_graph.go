@@ -0,0 +1,99 @@
+package query_backend
+
+import (
+	"sync"
+
+	"github.com/grafana/dskit/runutil"
+
+	queryv1 "github.com/grafana/pyroscope/api/gen/proto/go/query/v1"
+	"github.com/grafana/pyroscope/pkg/experiment/block"
+	"github.com/grafana/pyroscope/pkg/model"
+	parquetquery "github.com/grafana/pyroscope/pkg/phlaredb/query"
+	v1 "github.com/grafana/pyroscope/pkg/phlaredb/schemas/v1"
+	"github.com/grafana/pyroscope/pkg/phlaredb/symdb"
+)
+
+func init() {
+	registerQueryType(
+		queryv1.QueryType_QUERY_CALL_GRAPH,
+		queryv1.ReportType_REPORT_CALL_GRAPH,
+		queryCallGraph,
+		newCallGraphAggregator,
+		[]block.Section{
+			block.SectionTSDB,
+			block.SectionProfiles,
+			block.SectionSymbols,
+		}...,
+	)
+}
+
+func queryCallGraph(q *queryContext, query *queryv1.Query) (*queryv1.Report, error) {
+	entries, err := profileEntryIterator(q)
+	if err != nil {
+		return nil, err
+	}
+	defer runutil.CloseWithErrCapture(&err, entries, "failed to close profile entry iterator")
+
+	var columns v1.SampleColumns
+	if err = columns.Resolve(q.ds.Profiles().Schema()); err != nil {
+		return nil, err
+	}
+
+	profiles := parquetquery.NewRepeatedRowIterator(q.ctx, entries, q.ds.Profiles().RowGroups(),
+		columns.StacktraceID.ColumnIndex,
+		columns.Value.ColumnIndex)
+	defer runutil.CloseWithErrCapture(&err, profiles, "failed to close profile stream")
+
+	resolver := symdb.NewResolver(q.ctx, q.ds.Symbols())
+	defer resolver.Release()
+
+	for profiles.Next() {
+		p := profiles.At()
+		resolver.AddSamplesFromParquetRow(p.Row.Partition, p.Values[0], p.Values[1])
+	}
+	if err = profiles.Err(); err != nil {
+		return nil, err
+	}
+
+	graph, err := resolver.CallGraph()
+	if err != nil {
+		return nil, err
+	}
+
+	// Partial reports must not be truncated:
+	// the limit is applied to the final result.
+	resp := &queryv1.Report{
+		CallGraph: &queryv1.CallGraphReport{
+			Query:     query.CallGraph.CloneVT(),
+			CallGraph: graph,
+		},
+	}
+	return resp, nil
+}
+
+type callGraphAggregator struct {
+	init  sync.Once
+	query *queryv1.CallGraphQuery
+	graph *model.CallGraphMerger
+}
+
+func newCallGraphAggregator(*queryv1.InvokeRequest) aggregator { return new(callGraphAggregator) }
+
+func (a *callGraphAggregator) aggregate(report *queryv1.Report) error {
+	r := report.CallGraph
+	a.init.Do(func() {
+		a.graph = model.NewCallGraphMerger()
+		a.query = r.Query.CloneVT()
+	})
+	a.graph.MergeCallGraph(r.CallGraph)
+	return nil
+}
+
+func (a *callGraphAggregator) build() *queryv1.Report {
+	return &queryv1.Report{
+		CallGraph: &queryv1.CallGraphReport{
+			Query:     a.query,
+			CallGraph: a.graph.CallGraph(0),
+		},
+	}
+}
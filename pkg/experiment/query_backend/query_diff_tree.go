@@ -0,0 +1,131 @@
+package query_backend
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/grafana/dskit/runutil"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/promql/parser"
+
+	queryv1 "github.com/grafana/pyroscope/api/gen/proto/go/query/v1"
+	"github.com/grafana/pyroscope/pkg/experiment/block"
+	phlaremodel "github.com/grafana/pyroscope/pkg/model"
+	parquetquery "github.com/grafana/pyroscope/pkg/phlaredb/query"
+	v1 "github.com/grafana/pyroscope/pkg/phlaredb/schemas/v1"
+	"github.com/grafana/pyroscope/pkg/phlaredb/symdb"
+)
+
+func init() {
+	registerQueryType(
+		queryv1.QueryType_QUERY_DIFF_TREE,
+		queryv1.ReportType_REPORT_DIFF_TREE,
+		queryDiffTree,
+		newDiffTreeAggregator,
+		[]block.Section{
+			block.SectionTSDB,
+			block.SectionProfiles,
+			block.SectionSymbols,
+		}...,
+	)
+}
+
+func queryDiffTree(q *queryContext, query *queryv1.Query) (*queryv1.Report, error) {
+	left, err := diffTreeTarget(q, query.DiffTree.GetLeft())
+	if err != nil {
+		return nil, fmt.Errorf("left: %w", err)
+	}
+	right, err := diffTreeTarget(q, query.DiffTree.GetRight())
+	if err != nil {
+		return nil, fmt.Errorf("right: %w", err)
+	}
+	maxNodes := query.DiffTree.GetMaxNodes()
+	phlaremodel.TruncateDiffTrees(left, right, maxNodes)
+	resp := &queryv1.Report{
+		DiffTree: &queryv1.DiffTreeReport{
+			Query: query.DiffTree.CloneVT(),
+			Left:  left.Bytes(0),
+			Right: right.Bytes(0),
+		},
+	}
+	return resp, nil
+}
+
+func diffTreeTarget(q *queryContext, target *queryv1.DiffTreeTarget) (tree *phlaremodel.Tree, err error) {
+	if target == nil {
+		return nil, fmt.Errorf("target is not specified")
+	}
+	matchers, err := parser.ParseMetricSelector(target.LabelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("label selection is invalid: %w", err)
+	}
+	entries, err := profileEntryIteratorWithSelector(q, profileEntrySelector{
+		matchers:  matchers,
+		startTime: model.Time(target.StartTime).UnixNano(),
+		endTime:   model.Time(target.EndTime).UnixNano(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer runutil.CloseWithErrCapture(&err, entries, "failed to close profile entry iterator")
+
+	var columns v1.SampleColumns
+	if err = columns.Resolve(q.ds.Profiles().Schema()); err != nil {
+		return nil, err
+	}
+
+	profiles := parquetquery.NewRepeatedRowIterator(q.ctx, entries, q.ds.Profiles().RowGroups(),
+		columns.StacktraceID.ColumnIndex,
+		columns.Value.ColumnIndex)
+	defer runutil.CloseWithErrCapture(&err, profiles, "failed to close profile stream")
+
+	// The tree is not truncated: truncation is
+	// only applied to the combined tree.
+	resolver := symdb.NewResolver(q.ctx, q.ds.Symbols(),
+		symdb.WithResolverFrameFilter(target.FrameFilter))
+	defer resolver.Release()
+
+	for profiles.Next() {
+		p := profiles.At()
+		resolver.AddSamplesFromParquetRow(p.Row.Partition, p.Values[0], p.Values[1])
+	}
+	if err = profiles.Err(); err != nil {
+		return nil, err
+	}
+
+	return resolver.Tree()
+}
+
+type diffTreeAggregator struct {
+	init  sync.Once
+	query *queryv1.DiffTreeQuery
+	left  *phlaremodel.TreeMerger
+	right *phlaremodel.TreeMerger
+}
+
+func newDiffTreeAggregator(*queryv1.InvokeRequest) aggregator { return new(diffTreeAggregator) }
+
+func (a *diffTreeAggregator) aggregate(report *queryv1.Report) error {
+	r := report.DiffTree
+	a.init.Do(func() {
+		a.left = phlaremodel.NewTreeMerger()
+		a.right = phlaremodel.NewTreeMerger()
+		a.query = r.Query.CloneVT()
+	})
+	if err := a.left.MergeTreeBytes(r.Left); err != nil {
+		return err
+	}
+	return a.right.MergeTreeBytes(r.Right)
+}
+
+func (a *diffTreeAggregator) build() *queryv1.Report {
+	left, right := a.left.Tree(), a.right.Tree()
+	phlaremodel.TruncateDiffTrees(left, right, a.query.GetMaxNodes())
+	return &queryv1.Report{
+		DiffTree: &queryv1.DiffTreeReport{
+			Query: a.query,
+			Left:  left.Bytes(0),
+			Right: right.Bytes(0),
+		},
+	}
+}
@@ -0,0 +1,150 @@
+package query_backend
+
+import (
+	"cmp"
+	"container/heap"
+	"slices"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/grafana/dskit/runutil"
+
+	queryv1 "github.com/grafana/pyroscope/api/gen/proto/go/query/v1"
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+	"github.com/grafana/pyroscope/pkg/experiment/block"
+	phlaremodel "github.com/grafana/pyroscope/pkg/model"
+	parquetquery "github.com/grafana/pyroscope/pkg/phlaredb/query"
+	schemav1 "github.com/grafana/pyroscope/pkg/phlaredb/schemas/v1"
+)
+
+func init() {
+	registerQueryType(
+		queryv1.QueryType_QUERY_EXEMPLARS,
+		queryv1.ReportType_REPORT_EXEMPLARS,
+		queryExemplars,
+		newExemplarsAggregator,
+		[]block.Section{
+			block.SectionTSDB,
+			block.SectionProfiles,
+		}...,
+	)
+}
+
+type exemplar struct {
+	id    uuid.UUID
+	entry ProfileEntry
+	value int64
+}
+
+func queryExemplars(q *queryContext, query *queryv1.Query) (r *queryv1.Report, err error) {
+	entries, err := profileEntryIterator(q)
+	if err != nil {
+		return nil, err
+	}
+	defer runutil.CloseWithErrCapture(&err, entries, "failed to close profile entry iterator")
+
+	schema := q.ds.Profiles().Schema()
+	idColumn, err := schemav1.ResolveColumnByPath(schema, []string{schemav1.IDColumnName})
+	if err != nil {
+		return nil, err
+	}
+	valueColumn, err := schemav1.ResolveColumnByPath(schema, []string{schemav1.TotalValueColumnName})
+	if err != nil {
+		return nil, err
+	}
+
+	rows := parquetquery.NewRepeatedRowIteratorBatchSize(
+		q.ctx,
+		entries,
+		q.ds.Profiles().RowGroups(),
+		bigBatchSize,
+		idColumn.ColumnIndex,
+		valueColumn.ColumnIndex,
+	)
+	defer runutil.CloseWithErrCapture(&err, rows, "failed to close column iterator")
+
+	// Only the exemplars with the highest values are retained. Labels
+	// are only converted for the exemplars that make it to the report.
+	limit := query.Exemplars.GetLimit()
+	var exemplars exemplarHeap
+	for rows.Next() {
+		row := rows.At()
+		value := row.Values[1][0].Int64()
+		if limit > 0 && int64(len(exemplars)) >= limit {
+			if value <= exemplars[0].value {
+				continue
+			}
+			heap.Pop(&exemplars)
+		}
+		e := exemplar{
+			entry: row.Row,
+			value: value,
+		}
+		copy(e.id[:], row.Values[0][0].ByteArray())
+		heap.Push(&exemplars, e)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(exemplars, func(a, b exemplar) int {
+		return cmp.Compare(b.value, a.value)
+	})
+	report := &queryv1.ExemplarsReport{
+		Query:     query.Exemplars.CloneVT(),
+		Exemplars: make([]*typesv1.ProfileExemplar, len(exemplars)),
+	}
+	for i, e := range exemplars {
+		report.Exemplars[i] = &typesv1.ProfileExemplar{
+			ProfileId: e.id.String(),
+			Timestamp: int64(e.entry.Timestamp),
+			Value:     e.value,
+			Labels:    e.entry.Labels.Clone(),
+		}
+	}
+
+	return &queryv1.Report{Exemplars: report}, nil
+}
+
+// exemplarHeap is a min-heap of exemplars ordered by value.
+type exemplarHeap []exemplar
+
+func (h exemplarHeap) Len() int           { return len(h) }
+func (h exemplarHeap) Less(i, j int) bool { return h[i].value < h[j].value }
+func (h exemplarHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *exemplarHeap) Push(x any)        { *h = append(*h, x.(exemplar)) }
+
+func (h *exemplarHeap) Pop() any {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
+
+type exemplarsAggregator struct {
+	init      sync.Once
+	query     *queryv1.ExemplarsQuery
+	exemplars *phlaremodel.ExemplarsMerger
+}
+
+func newExemplarsAggregator(*queryv1.InvokeRequest) aggregator { return new(exemplarsAggregator) }
+
+func (a *exemplarsAggregator) aggregate(report *queryv1.Report) error {
+	r := report.Exemplars
+	a.init.Do(func() {
+		a.exemplars = phlaremodel.NewExemplarsMerger()
+		a.query = r.Query.CloneVT()
+	})
+	a.exemplars.MergeExemplars(r.Exemplars)
+	return nil
+}
+
+func (a *exemplarsAggregator) build() *queryv1.Report {
+	return &queryv1.Report{
+		Exemplars: &queryv1.ExemplarsReport{
+			Query:     a.query,
+			Exemplars: a.exemplars.Exemplars(a.query.GetLimit()),
+		},
+	}
+}
@@ -0,0 +1,106 @@
+package query_backend
+
+import (
+	"sync"
+
+	"github.com/grafana/dskit/runutil"
+
+	queryv1 "github.com/grafana/pyroscope/api/gen/proto/go/query/v1"
+	"github.com/grafana/pyroscope/pkg/experiment/block"
+	"github.com/grafana/pyroscope/pkg/model"
+	parquetquery "github.com/grafana/pyroscope/pkg/phlaredb/query"
+	v1 "github.com/grafana/pyroscope/pkg/phlaredb/schemas/v1"
+	"github.com/grafana/pyroscope/pkg/phlaredb/symdb"
+)
+
+func init() {
+	registerQueryType(
+		queryv1.QueryType_QUERY_FUNCTION_DETAILS,
+		queryv1.ReportType_REPORT_FUNCTION_DETAILS,
+		queryFunctionDetails,
+		newFunctionDetailsAggregator,
+		[]block.Section{
+			block.SectionTSDB,
+			block.SectionProfiles,
+			block.SectionSymbols,
+		}...,
+	)
+}
+
+func queryFunctionDetails(q *queryContext, query *queryv1.Query) (*queryv1.Report, error) {
+	entries, err := profileEntryIterator(q)
+	if err != nil {
+		return nil, err
+	}
+	defer runutil.CloseWithErrCapture(&err, entries, "failed to close profile entry iterator")
+
+	var columns v1.SampleColumns
+	if err = columns.Resolve(q.ds.Profiles().Schema()); err != nil {
+		return nil, err
+	}
+
+	profiles := parquetquery.NewRepeatedRowIterator(q.ctx, entries, q.ds.Profiles().RowGroups(),
+		columns.StacktraceID.ColumnIndex,
+		columns.Value.ColumnIndex)
+	defer runutil.CloseWithErrCapture(&err, profiles, "failed to close profile stream")
+
+	resolver := symdb.NewResolver(q.ctx, q.ds.Symbols(),
+		symdb.WithResolverMaxNodes(query.FunctionDetails.GetMaxNodes()))
+	defer resolver.Release()
+
+	for profiles.Next() {
+		p := profiles.At()
+		resolver.AddSamplesFromParquetRow(p.Row.Partition, p.Values[0], p.Values[1])
+	}
+	if err = profiles.Err(); err != nil {
+		return nil, err
+	}
+
+	d, err := resolver.FunctionDetails(query.FunctionDetails.GetFunctionName())
+	if err != nil {
+		return nil, err
+	}
+
+	maxNodes := query.FunctionDetails.GetMaxNodes()
+	resp := &queryv1.Report{
+		FunctionDetails: &queryv1.FunctionDetailsReport{
+			Query:   query.FunctionDetails.CloneVT(),
+			Callers: d.Callers.Bytes(maxNodes),
+			Callees: d.Callees.Bytes(maxNodes),
+			Lines:   d.Lines,
+		},
+	}
+	return resp, nil
+}
+
+type functionDetailsAggregator struct {
+	init    sync.Once
+	query   *queryv1.FunctionDetailsQuery
+	details *model.FunctionDetailsMerger
+}
+
+func newFunctionDetailsAggregator(*queryv1.InvokeRequest) aggregator {
+	return new(functionDetailsAggregator)
+}
+
+func (a *functionDetailsAggregator) aggregate(report *queryv1.Report) error {
+	r := report.FunctionDetails
+	a.init.Do(func() {
+		a.details = model.NewFunctionDetailsMerger()
+		a.query = r.Query.CloneVT()
+	})
+	return a.details.MergeFunctionDetailsBytes(r.Callers, r.Callees, r.Lines)
+}
+
+func (a *functionDetailsAggregator) build() *queryv1.Report {
+	d := a.details.FunctionDetails()
+	maxNodes := a.query.GetMaxNodes()
+	return &queryv1.Report{
+		FunctionDetails: &queryv1.FunctionDetailsReport{
+			Query:   a.query,
+			Callers: d.Callers.Bytes(maxNodes),
+			Callees: d.Callees.Bytes(maxNodes),
+			Lines:   d.Lines,
+		},
+	}
+}
@@ -0,0 +1,102 @@
+package query_backend
+
+import (
+	"sync"
+	"time"
+
+	"github.com/grafana/dskit/runutil"
+
+	queryv1 "github.com/grafana/pyroscope/api/gen/proto/go/query/v1"
+	"github.com/grafana/pyroscope/pkg/experiment/block"
+	phlaremodel "github.com/grafana/pyroscope/pkg/model"
+	parquetquery "github.com/grafana/pyroscope/pkg/phlaredb/query"
+	schemav1 "github.com/grafana/pyroscope/pkg/phlaredb/schemas/v1"
+)
+
+func init() {
+	registerQueryType(
+		queryv1.QueryType_QUERY_HEATMAP,
+		queryv1.ReportType_REPORT_HEATMAP,
+		queryHeatmap,
+		newHeatmapAggregator,
+		[]block.Section{
+			block.SectionTSDB,
+			block.SectionProfiles,
+		}...,
+	)
+}
+
+func heatmapLayout(query *queryv1.HeatmapQuery) (step int64, resolution int32) {
+	step = time.Duration(query.GetStep() * float64(time.Second)).Milliseconds()
+	resolution = query.GetValueResolution()
+	if resolution <= 0 {
+		resolution = phlaremodel.DefaultHeatmapValueResolution
+	}
+	return step, resolution
+}
+
+func queryHeatmap(q *queryContext, query *queryv1.Query) (r *queryv1.Report, err error) {
+	entries, err := profileEntryIterator(q)
+	if err != nil {
+		return nil, err
+	}
+	defer runutil.CloseWithErrCapture(&err, entries, "failed to close profile entry iterator")
+
+	column, err := schemav1.ResolveColumnByPath(q.ds.Profiles().Schema(), []string{schemav1.TotalValueColumnName})
+	if err != nil {
+		return nil, err
+	}
+
+	rows := parquetquery.NewRepeatedRowIteratorBatchSize(
+		q.ctx,
+		entries,
+		q.ds.Profiles().RowGroups(),
+		bigBatchSize,
+		column.ColumnIndex,
+	)
+	defer runutil.CloseWithErrCapture(&err, rows, "failed to close column iterator")
+
+	builder := phlaremodel.NewHeatmapBuilder(heatmapLayout(query.Heatmap))
+	for rows.Next() {
+		row := rows.At()
+		builder.Add(int64(row.Row.Timestamp), float64(row.Values[0][0].Int64()))
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	resp := &queryv1.Report{
+		Heatmap: &queryv1.HeatmapReport{
+			Query:   query.Heatmap.CloneVT(),
+			Heatmap: builder.Build(),
+		},
+	}
+
+	return resp, nil
+}
+
+type heatmapAggregator struct {
+	init    sync.Once
+	query   *queryv1.HeatmapQuery
+	heatmap *phlaremodel.HeatmapMerger
+}
+
+func newHeatmapAggregator(*queryv1.InvokeRequest) aggregator { return new(heatmapAggregator) }
+
+func (a *heatmapAggregator) aggregate(report *queryv1.Report) error {
+	r := report.Heatmap
+	a.init.Do(func() {
+		a.query = r.Query.CloneVT()
+		a.heatmap = phlaremodel.NewHeatmapMerger(heatmapLayout(a.query))
+	})
+	return a.heatmap.MergeHeatmap(r.Heatmap)
+}
+
+func (a *heatmapAggregator) build() *queryv1.Report {
+	return &queryv1.Report{
+		Heatmap: &queryv1.HeatmapReport{
+			Query:   a.query,
+			Heatmap: a.heatmap.Heatmap(),
+		},
+	}
+}
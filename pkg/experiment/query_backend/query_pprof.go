@@ -1,8 +1,10 @@
 package query_backend
 
 import (
+	"fmt"
 	"sync"
 
+	"github.com/google/uuid"
 	"github.com/grafana/dskit/runutil"
 	"github.com/prometheus/prometheus/model/labels"
 
@@ -30,7 +32,19 @@ func init() {
 }
 
 func queryPprof(q *queryContext, query *queryv1.Query) (*queryv1.Report, error) {
-	entries, err := profileEntryIterator(q)
+	selector := profileEntrySelector{
+		matchers:  q.req.matchers,
+		startTime: q.req.startTime,
+		endTime:   q.req.endTime,
+	}
+	for _, id := range query.Pprof.ProfileIdSelector {
+		u, err := uuid.Parse(id)
+		if err != nil {
+			return nil, fmt.Errorf("invalid profile ID %q: %w", id, err)
+		}
+		selector.profileIDs = append(selector.profileIDs, string(u[:]))
+	}
+	entries, err := profileEntryIteratorWithSelector(q, selector)
 	if err != nil {
 		return nil, err
 	}
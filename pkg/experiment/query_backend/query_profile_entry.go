@@ -27,18 +27,58 @@ type ProfileEntry struct {
 
 func (e ProfileEntry) RowNumber() int64 { return e.RowNum }
 
+// profileEntrySelector specifies the profiles to be selected.
+type profileEntrySelector struct {
+	matchers  []*labels.Matcher
+	startTime int64 // Unix nano.
+	endTime   int64 // Unix nano.
+	// Optional. Raw UUID bytes.
+	profileIDs []string
+	// Optional. If set, entries have all the series
+	// labels, instead of the group by labels only.
+	allLabels bool
+}
+
 func profileEntryIterator(q *queryContext, groupBy ...string) (iter.Iterator[ProfileEntry], error) {
-	series, err := getSeries(q.ds.Index(), q.req.matchers, groupBy...)
+	return profileEntryIteratorWithSelector(q, profileEntrySelector{
+		matchers:  q.req.matchers,
+		startTime: q.req.startTime,
+		endTime:   q.req.endTime,
+	}, groupBy...)
+}
+
+// profileEntryIteratorWithSelector is like profileEntryIterator, but
+// the profiles are selected with the selector specified, instead of
+// the matchers and the time range of the request.
+func profileEntryIteratorWithSelector(
+	q *queryContext,
+	selector profileEntrySelector,
+	groupBy ...string,
+) (iter.Iterator[ProfileEntry], error) {
+	series, err := getSeries(q.ds.Index(), selector.matchers, selector.allLabels, groupBy...)
 	if err != nil {
 		return nil, err
 	}
-	results := parquetquery.NewBinaryJoinIterator(0,
+	if err = q.lim.addSeries(int64(len(series))); err != nil {
+		return nil, err
+	}
+	var results parquetquery.Iterator = parquetquery.NewBinaryJoinIterator(0,
 		q.ds.Profiles().Column(q.ctx, "SeriesIndex", parquetquery.NewMapPredicate(series)),
-		q.ds.Profiles().Column(q.ctx, "TimeNanos", parquetquery.NewIntBetweenPredicate(q.req.startTime, q.req.endTime)),
+		q.ds.Profiles().Column(q.ctx, "TimeNanos", parquetquery.NewIntBetweenPredicate(selector.startTime, selector.endTime)),
 	)
 	results = parquetquery.NewBinaryJoinIterator(0, results,
 		q.ds.Profiles().Column(q.ctx, "StacktracePartition", nil),
 	)
+	if len(selector.profileIDs) > 0 {
+		// The join iterator does not support nested joins with selective
+		// predicates on the right side, therefore the profiles are filtered
+		// after the join.
+		results = newProfileIDIterator(
+			parquetquery.NewBinaryJoinIterator(0, results,
+				q.ds.Profiles().Column(q.ctx, schemav1.IDColumnName, nil)),
+			selector.profileIDs,
+		)
+	}
 
 	buf := make([][]parquet.Value, 3)
 	entries := iter.NewAsyncBatchIterator[*parquetquery.IteratorResult, ProfileEntry](
@@ -62,12 +102,38 @@ func profileEntryIterator(q *queryContext, groupBy ...string) (iter.Iterator[Pro
 	return entries, nil
 }
 
+type profileIDIterator struct {
+	parquetquery.Iterator
+	ids map[string]struct{}
+}
+
+func newProfileIDIterator(it parquetquery.Iterator, ids []string) *profileIDIterator {
+	x := &profileIDIterator{
+		Iterator: it,
+		ids:      make(map[string]struct{}, len(ids)),
+	}
+	for _, id := range ids {
+		x.ids[id] = struct{}{}
+	}
+	return x
+}
+
+func (x *profileIDIterator) Next() bool {
+	for x.Iterator.Next() {
+		id := x.At().ColumnValue(schemav1.IDColumnName).ByteArray()
+		if _, ok := x.ids[string(id)]; ok {
+			return true
+		}
+	}
+	return false
+}
+
 type series struct {
 	fingerprint model.Fingerprint
 	labels      phlaremodel.Labels
 }
 
-func getSeries(reader phlaredb.IndexReader, matchers []*labels.Matcher, by ...string) (map[uint32]series, error) {
+func getSeries(reader phlaredb.IndexReader, matchers []*labels.Matcher, allLabels bool, by ...string) (map[uint32]series, error) {
 	postings, err := getPostings(reader, matchers...)
 	if err != nil {
 		return nil, err
@@ -76,7 +142,12 @@ func getSeries(reader phlaredb.IndexReader, matchers []*labels.Matcher, by ...st
 	s := make(map[uint32]series)
 	l := make(phlaremodel.Labels, 0, 6)
 	for postings.Next() {
-		fp, err := reader.SeriesBy(postings.At(), &l, &chunks, by...)
+		var fp uint64
+		if allLabels {
+			fp, err = reader.Series(postings.At(), &l, &chunks)
+		} else {
+			fp, err = reader.SeriesBy(postings.At(), &l, &chunks, by...)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,259 @@
+package query_backend
+
+import (
+	"bytes"
+	"cmp"
+	"slices"
+	"sync"
+
+	"github.com/grafana/dskit/runutil"
+
+	queryv1 "github.com/grafana/pyroscope/api/gen/proto/go/query/v1"
+	"github.com/grafana/pyroscope/pkg/experiment/block"
+	parquetquery "github.com/grafana/pyroscope/pkg/phlaredb/query"
+	schemav1 "github.com/grafana/pyroscope/pkg/phlaredb/schemas/v1"
+	"github.com/grafana/pyroscope/pkg/phlaredb/symdb"
+)
+
+func init() {
+	registerQueryType(
+		queryv1.QueryType_QUERY_PROFILE_EXPORT,
+		queryv1.ReportType_REPORT_PROFILE_EXPORT,
+		queryProfileExport,
+		newProfileExportAggregator,
+		[]block.Section{
+			block.SectionTSDB,
+			block.SectionProfiles,
+			block.SectionSymbols,
+		}...,
+	)
+}
+
+type exportedProfile struct {
+	partition uint64
+	profile   *queryv1.ExportedProfile
+	// Stack trace identifiers of the samples:
+	// resolved once all the profiles are read.
+	stacktraces []uint32
+}
+
+func queryProfileExport(q *queryContext, query *queryv1.Query) (r *queryv1.Report, err error) {
+	entries, err := profileEntryIteratorWithSelector(q, profileEntrySelector{
+		matchers:  q.req.matchers,
+		startTime: q.req.startTime,
+		endTime:   q.req.endTime,
+		allLabels: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer runutil.CloseWithErrCapture(&err, entries, "failed to close profile entry iterator")
+
+	schema := q.ds.Profiles().Schema()
+	idColumn, err := schemav1.ResolveColumnByPath(schema, []string{schemav1.IDColumnName})
+	if err != nil {
+		return nil, err
+	}
+	var columns schemav1.SampleColumns
+	if err = columns.Resolve(schema); err != nil {
+		return nil, err
+	}
+
+	rows := parquetquery.NewRepeatedRowIterator(q.ctx, entries, q.ds.Profiles().RowGroups(),
+		idColumn.ColumnIndex,
+		columns.StacktraceID.ColumnIndex,
+		columns.Value.ColumnIndex)
+	defer runutil.CloseWithErrCapture(&err, rows, "failed to close profile stream")
+
+	maxProfiles := query.ProfileExport.GetMaxProfiles()
+	maxSamples := query.ProfileExport.GetMaxSamples()
+	var profiles []exportedProfile
+	var samples int64
+	for rows.Next() {
+		if err = checkLimit("exported profiles", int64(len(profiles)+1), maxProfiles); err != nil {
+			return nil, err
+		}
+		row := rows.At()
+		samples += int64(len(row.Values[1]))
+		if err = checkLimit("exported samples", samples, maxSamples); err != nil {
+			return nil, err
+		}
+		p := exportedProfile{
+			partition: row.Row.Partition,
+			profile: &queryv1.ExportedProfile{
+				Id:        bytes.Clone(row.Values[0][0].ByteArray()),
+				Timestamp: row.Row.Timestamp.UnixNano(),
+				Labels:    row.Row.Labels.Clone(),
+				Samples:   make([]*queryv1.ExportedSample, len(row.Values[1])),
+			},
+			stacktraces: make([]uint32, len(row.Values[1])),
+		}
+		for i, v := range row.Values[1] {
+			p.stacktraces[i] = v.Uint32()
+			p.profile.Samples[i] = &queryv1.ExportedSample{Value: row.Values[2][i].Int64()}
+		}
+		profiles = append(profiles, p)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	report := &queryv1.ProfileExportReport{
+		Query:    query.ProfileExport.CloneVT(),
+		Profiles: make([]*queryv1.ExportedProfile, len(profiles)),
+	}
+	stacks := newExportedStacks(report)
+	// Profiles are grouped by partition, so that each
+	// partition is only fetched once.
+	slices.SortStableFunc(profiles, func(a, b exportedProfile) int {
+		return cmp.Compare(a.partition, b.partition)
+	})
+	for i := 0; i < len(profiles); {
+		j := i + 1
+		for j < len(profiles) && profiles[j].partition == profiles[i].partition {
+			j++
+		}
+		if err = stacks.resolve(q, profiles[i].partition, profiles[i:j]); err != nil {
+			return nil, err
+		}
+		i = j
+	}
+	for i, p := range profiles {
+		report.Profiles[i] = p.profile
+	}
+	sortExportedProfiles(report.Profiles)
+	return &queryv1.Report{ProfileExport: report}, nil
+}
+
+// exportedStacks resolves the stack traces of the exported samples
+// into function names, referenced by the report string table.
+type exportedStacks struct {
+	report  *queryv1.ProfileExportReport
+	strings map[string]int32
+
+	symbols *symdb.Symbols
+	stacks  map[uint32][]int32
+}
+
+func newExportedStacks(report *queryv1.ProfileExportReport) *exportedStacks {
+	return &exportedStacks{
+		report:  report,
+		strings: make(map[string]int32),
+	}
+}
+
+func (s *exportedStacks) resolve(q *queryContext, partition uint64, profiles []exportedProfile) error {
+	pr, err := q.ds.Symbols().Partition(q.ctx, partition)
+	if err != nil {
+		return err
+	}
+	defer pr.Release()
+
+	var ids []uint32
+	for _, p := range profiles {
+		ids = append(ids, p.stacktraces...)
+	}
+	slices.Sort(ids)
+	ids = slices.Compact(ids)
+
+	s.symbols = pr.Symbols()
+	s.stacks = make(map[uint32][]int32, len(ids))
+	if err = s.symbols.Stacktraces.ResolveStacktraceLocations(q.ctx, s, ids); err != nil {
+		return err
+	}
+	for _, p := range profiles {
+		for i, id := range p.stacktraces {
+			p.profile.Samples[i].Stack = s.stacks[id]
+		}
+	}
+	return nil
+}
+
+func (s *exportedStacks) InsertStacktrace(id uint32, locations []int32) {
+	// Locations are leaf first, and so are the lines
+	// of a location: the stack is reversed.
+	stack := make([]int32, 0, len(locations))
+	for i := len(locations) - 1; i >= 0; i-- {
+		lines := s.symbols.Locations[locations[i]].Line
+		for j := len(lines) - 1; j >= 0; j-- {
+			f := s.symbols.Functions[lines[j].FunctionId]
+			stack = append(stack, s.string(s.symbols.Strings[f.Name]))
+		}
+	}
+	s.stacks[id] = stack
+}
+
+func (s *exportedStacks) string(v string) int32 {
+	i, ok := s.strings[v]
+	if !ok {
+		i = int32(len(s.report.Strings))
+		s.report.Strings = append(s.report.Strings, v)
+		s.strings[v] = i
+	}
+	return i
+}
+
+type profileExportAggregator struct {
+	init     sync.Once
+	mu       sync.Mutex
+	query    *queryv1.ProfileExportQuery
+	report   *queryv1.ProfileExportReport
+	strings  *exportedStacks
+	profiles int64
+	samples  int64
+}
+
+func newProfileExportAggregator(*queryv1.InvokeRequest) aggregator {
+	return new(profileExportAggregator)
+}
+
+func (a *profileExportAggregator) aggregate(report *queryv1.Report) error {
+	r := report.ProfileExport
+	a.init.Do(func() {
+		a.query = r.Query.CloneVT()
+		a.report = &queryv1.ProfileExportReport{Query: a.query}
+		a.strings = newExportedStacks(a.report)
+	})
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.profiles += int64(len(r.Profiles))
+	if err := checkLimit("exported profiles", a.profiles, a.query.GetMaxProfiles()); err != nil {
+		return err
+	}
+	for _, p := range r.Profiles {
+		a.samples += int64(len(p.Samples))
+	}
+	if err := checkLimit("exported samples", a.samples, a.query.GetMaxSamples()); err != nil {
+		return err
+	}
+	remap := make([]int32, len(r.Strings))
+	for i, v := range r.Strings {
+		remap[i] = a.strings.string(v)
+	}
+	for _, p := range r.Profiles {
+		for _, s := range p.Samples {
+			// Samples of a report may share the stack slice.
+			stack := make([]int32, len(s.Stack))
+			for i, x := range s.Stack {
+				stack[i] = remap[x]
+			}
+			s.Stack = stack
+		}
+		a.report.Profiles = append(a.report.Profiles, p)
+	}
+	return nil
+}
+
+func (a *profileExportAggregator) build() *queryv1.Report {
+	sortExportedProfiles(a.report.Profiles)
+	return &queryv1.Report{ProfileExport: a.report}
+}
+
+func sortExportedProfiles(profiles []*queryv1.ExportedProfile) {
+	slices.SortFunc(profiles, func(a, b *queryv1.ExportedProfile) int {
+		if c := cmp.Compare(a.Timestamp, b.Timestamp); c != 0 {
+			return c
+		}
+		return bytes.Compare(a.Id, b.Id)
+	})
+}
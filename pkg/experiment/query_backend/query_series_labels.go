@@ -30,6 +30,9 @@ func querySeriesLabels(q *queryContext, query *queryv1.Query) (*queryv1.Report,
 	if err != nil {
 		return nil, err
 	}
+	if err = q.lim.addSeries(int64(len(series))); err != nil {
+		return nil, err
+	}
 	resp := &queryv1.Report{
 		SeriesLabels: &queryv1.SeriesLabelsReport{
 			Query:        query.SeriesLabels.CloneVT(),
@@ -0,0 +1,303 @@
+package symbolizer
+
+import (
+	"bytes"
+	"context"
+	"debug/elf"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dgraph-io/ristretto/v2"
+	"github.com/go-kit/log/level"
+
+	"github.com/grafana/pyroscope/lidia"
+	"github.com/grafana/pyroscope/pkg/tenant"
+)
+
+// tenantsPrefix is the object store prefix of the lidia tables of the debug
+// files uploaded by the tenants. The lidia tables of the debug files fetched
+// from debuginfod are shared by all the tenants, and stored by build ID at
+// the root of the symbolizer bucket.
+const tenantsPrefix = "tenants"
+
+// debuginfoPrefix is the object store prefix of the debug files uploaded by
+// the tenants, kept when the debuginfod-compatible endpoint is enabled.
+const debuginfoPrefix = "debuginfo"
+
+// The build IDs with no debug file uploaded are cached for a short time
+// only: the debug files may be uploaded through another instance.
+const (
+	uploadsNotFoundCacheMaxItems = 100000
+	uploadsNotFoundCacheTTL      = time.Minute
+)
+
+const (
+	noteTypeGNUBuildID = 3
+	noteNameGNU        = "GNU\x00"
+)
+
+// DebuginfoMeta describes a debug file uploaded by a tenant.
+type DebuginfoMeta struct {
+	BuildID string `json:"build_id"`
+	// LidiaSize is the size of the lidia table generated from the debug
+	// file, which is what is stored, not the size of the debug file.
+	LidiaSize  int64     `json:"lidia_size"`
+	UploadedAt time.Time `json:"uploaded_at"`
+}
+
+func newUploadsNotFoundCache() (*ristretto.Cache[string, bool], error) {
+	cache, err := ristretto.NewCache(&ristretto.Config[string, bool]{
+		NumCounters: uploadsNotFoundCacheMaxItems * 10,
+		MaxCost:     uploadsNotFoundCacheMaxItems,
+		BufferItems: 64,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create uploads not-found cache: %w", err)
+	}
+	return cache, nil
+}
+
+func tenantObjectPath(tenantID, buildID string) string {
+	return path.Join(tenantsPrefix, tenantID, buildID)
+}
+
+func debuginfoObjectPath(tenantID, buildID string) string {
+	return path.Join(debuginfoPrefix, tenantID, buildID)
+}
+
+// UploadDebuginfo converts the ELF file to a lidia table, stored for the
+// tenant under the GNU build ID of the file. The file may be compressed
+// with gzip or zstd, and is subject to the upload size limit once
+// decompressed. A previous upload with the same build ID is replaced.
+// The decompressed ELF file is stored as well if the debuginfod-compatible
+// endpoint is enabled.
+func (s *Symbolizer) UploadDebuginfo(ctx context.Context, tenantID string, data []byte) (DebuginfoMeta, error) {
+	decompressedData, err := detectCompression(data, s.cfg.MaxDebuginfoUploadSize)
+	if err != nil {
+		var tooLarge debuginfoTooLargeError
+		if errors.As(err, &tooLarge) {
+			return DebuginfoMeta{}, tooLarge
+		}
+		return DebuginfoMeta{}, invalidDebuginfoError{err: err}
+	}
+
+	elfFile, err := elf.NewFile(bytes.NewReader(decompressedData))
+	if err != nil {
+		return DebuginfoMeta{}, invalidDebuginfoError{err: fmt.Errorf("parse ELF file: %w", err)}
+	}
+	defer elfFile.Close()
+
+	buildID, err := gnuBuildID(elfFile)
+	if err != nil {
+		return DebuginfoMeta{}, invalidDebuginfoError{err: err}
+	}
+
+	lidiaData, err := createLidia(elfFile, len(data)*2)
+	if err != nil {
+		return DebuginfoMeta{}, invalidDebuginfoError{err: err}
+	}
+
+	if s.cfg.DebuginfodServerEnabled {
+		if err = s.bucket.Upload(ctx, debuginfoObjectPath(tenantID, buildID), bytes.NewReader(decompressedData)); err != nil {
+			return DebuginfoMeta{}, fmt.Errorf("store debug file: %w", err)
+		}
+	}
+	if err = s.bucket.Upload(ctx, tenantObjectPath(tenantID, buildID), bytes.NewReader(lidiaData)); err != nil {
+		return DebuginfoMeta{}, fmt.Errorf("store debug info: %w", err)
+	}
+	s.uploadsNotFound.Del(tenantObjectPath(tenantID, buildID))
+	level.Info(s.logger).Log("msg", "Stored uploaded debug info", "tenant", tenantID, "buildID", buildID, "lidiaSize", len(lidiaData))
+
+	return DebuginfoMeta{
+		BuildID:    buildID,
+		LidiaSize:  int64(len(lidiaData)),
+		UploadedAt: time.Now().UTC(),
+	}, nil
+}
+
+// ListDebuginfo returns the debug files uploaded by the tenant, sorted by build ID.
+func (s *Symbolizer) ListDebuginfo(ctx context.Context, tenantID string) ([]DebuginfoMeta, error) {
+	dir := tenantObjectPath(tenantID, "") + "/"
+	var result []DebuginfoMeta
+	err := s.bucket.Iter(ctx, dir, func(name string) error {
+		if strings.HasSuffix(name, "/") {
+			return nil
+		}
+		attrs, err := s.bucket.Attributes(ctx, name)
+		if err != nil {
+			if s.bucket.IsObjNotFoundErr(err) {
+				return nil
+			}
+			return err
+		}
+		result = append(result, DebuginfoMeta{
+			BuildID:    strings.TrimPrefix(name, dir),
+			LidiaSize:  attrs.Size,
+			UploadedAt: attrs.LastModified.UTC(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list debug info: %w", err)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].BuildID < result[j].BuildID
+	})
+	return result, nil
+}
+
+// DeleteDebuginfo deletes the debug file uploaded by the tenant.
+func (s *Symbolizer) DeleteDebuginfo(ctx context.Context, tenantID, buildID string) error {
+	sanitizedBuildID, err := sanitizeBuildID(buildID)
+	if err != nil {
+		return err
+	}
+	if sanitizedBuildID == "" {
+		return invalidBuildIDError{buildID: buildID}
+	}
+	name := tenantObjectPath(tenantID, sanitizedBuildID)
+	exists, err := s.bucket.Exists(ctx, name)
+	if err != nil {
+		return fmt.Errorf("check debug info: %w", err)
+	}
+	if !exists {
+		return buildIDNotFoundError{buildID: sanitizedBuildID}
+	}
+	if err = s.bucket.Delete(ctx, name); err != nil {
+		return fmt.Errorf("delete debug info: %w", err)
+	}
+	// The debug file is only there if the debuginfod-compatible
+	// endpoint was enabled when it was uploaded.
+	err = s.bucket.Delete(ctx, debuginfoObjectPath(tenantID, sanitizedBuildID))
+	if err != nil && !s.bucket.IsObjNotFoundErr(err) {
+		return fmt.Errorf("delete debug file: %w", err)
+	}
+	return nil
+}
+
+// OpenDebuginfo opens the debug file uploaded by any of the tenants of the
+// request, as served by debuginfod servers.
+func (s *Symbolizer) OpenDebuginfo(ctx context.Context, buildID string) (io.ReadCloser, error) {
+	sanitizedBuildID, err := sanitizeBuildID(buildID)
+	if err != nil {
+		return nil, err
+	}
+	if sanitizedBuildID == "" {
+		return nil, invalidBuildIDError{buildID: buildID}
+	}
+	tenantIDs, err := tenant.TenantIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, tenantID := range tenantIDs {
+		r, err := s.bucket.Get(ctx, debuginfoObjectPath(tenantID, sanitizedBuildID))
+		if err == nil {
+			return r, nil
+		}
+		if !s.bucket.IsObjNotFoundErr(err) {
+			return nil, fmt.Errorf("get debug file: %w", err)
+		}
+	}
+	return nil, buildIDNotFoundError{buildID: sanitizedBuildID}
+}
+
+// fetchLidiaFromTenants retrieves the Lidia data of the debug file uploaded
+// by any of the tenants of the request. The build IDs the tenants have not
+// uploaded a debug file for are cached.
+func (s *Symbolizer) fetchLidiaFromTenants(ctx context.Context, buildID string) ([]byte, error) {
+	tenantIDs, err := tenant.TenantIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, tenantID := range tenantIDs {
+		name := tenantObjectPath(tenantID, buildID)
+		if notFound, _ := s.uploadsNotFound.Get(name); notFound {
+			continue
+		}
+		lidiaBytes, err := s.fetchLidiaFromObjectStore(ctx, name)
+		if err == nil {
+			return lidiaBytes, nil
+		}
+		if s.bucket.IsObjNotFoundErr(err) {
+			s.uploadsNotFound.SetWithTTL(name, true, 1, uploadsNotFoundCacheTTL)
+		} else {
+			level.Warn(s.logger).Log("msg", "Failed to get uploaded debug info", "tenant", tenantID, "buildID", buildID, "err", err)
+		}
+	}
+	return nil, buildIDNotFoundError{buildID: buildID}
+}
+
+func createLidia(elfFile *elf.File, initialSize int) ([]byte, error) {
+	memBuffer := newMemoryBuffer(initialSize)
+
+	err := lidia.CreateLidiaFromELF(elfFile, memBuffer, lidia.WithCRC(), lidia.WithFiles(), lidia.WithLines())
+	if err != nil {
+		return nil, fmt.Errorf("create lidia file: %w", err)
+	}
+
+	return memBuffer.Bytes(), nil
+}
+
+// gnuBuildID returns the GNU build ID of the ELF file, hex encoded.
+func gnuBuildID(f *elf.File) (string, error) {
+	for _, section := range f.Sections {
+		if section.Type != elf.SHT_NOTE {
+			continue
+		}
+		data, err := section.Data()
+		if err != nil {
+			return "", fmt.Errorf("read section %s: %w", section.Name, err)
+		}
+		if id, ok := findGNUBuildID(data, f.ByteOrder); ok {
+			return id, nil
+		}
+	}
+	return "", errors.New("no GNU build ID found")
+}
+
+// findGNUBuildID returns the GNU build ID in the notes of a note section.
+func findGNUBuildID(data []byte, order binary.ByteOrder) (string, bool) {
+	align := func(n uint32) int { return (int(n) + 3) &^ 3 }
+	for len(data) >= 12 {
+		nameSize := order.Uint32(data[0:])
+		descSize := order.Uint32(data[4:])
+		noteType := order.Uint32(data[8:])
+		data = data[12:]
+		if int(nameSize) > len(data) {
+			return "", false
+		}
+		name := string(data[:nameSize])
+		data = data[min(align(nameSize), len(data)):]
+		if int(descSize) > len(data) {
+			return "", false
+		}
+		desc := data[:descSize]
+		data = data[min(align(descSize), len(data)):]
+		if noteType == noteTypeGNUBuildID && name == noteNameGNU && len(desc) > 0 {
+			return hex.EncodeToString(desc), true
+		}
+	}
+	return "", false
+}
+
+// readDebuginfo reads the debug file, up to maxSize bytes if positive.
+func readDebuginfo(r io.Reader, maxSize int64) ([]byte, error) {
+	if maxSize <= 0 {
+		return io.ReadAll(r)
+	}
+	data, err := io.ReadAll(io.LimitReader(r, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxSize {
+		return nil, debuginfoTooLargeError{maxSize: maxSize}
+	}
+	return data, nil
+}
@@ -0,0 +1,126 @@
+package symbolizer
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/gorilla/mux"
+
+	"github.com/grafana/pyroscope/pkg/tenant"
+)
+
+// DebuginfoHandler serves the API the tenants upload their debug files with,
+// for the binaries debuginfod servers do not know about.
+type DebuginfoHandler struct {
+	logger     log.Logger
+	symbolizer *Symbolizer
+}
+
+func NewDebuginfoHandler(logger log.Logger, symbolizer *Symbolizer) *DebuginfoHandler {
+	return &DebuginfoHandler{
+		logger:     logger,
+		symbolizer: symbolizer,
+	}
+}
+
+// Upload stores the ELF file of the request body.
+func (h *DebuginfoHandler) Upload(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := tenant.ExtractTenantIDFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	data, err := readDebuginfo(r.Body, h.symbolizer.cfg.MaxDebuginfoUploadSize)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	meta, err := h.symbolizer.UploadDebuginfo(r.Context(), tenantID, data)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	h.writeJSON(w, meta)
+}
+
+// List lists the debug files uploaded by the tenant.
+func (h *DebuginfoHandler) List(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := tenant.ExtractTenantIDFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	list, err := h.symbolizer.ListDebuginfo(r.Context(), tenantID)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	if list == nil {
+		list = []DebuginfoMeta{}
+	}
+	h.writeJSON(w, list)
+}
+
+// Delete deletes the debug file uploaded by the tenant with the build ID of the path.
+func (h *DebuginfoHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := tenant.ExtractTenantIDFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if err = h.symbolizer.DeleteDebuginfo(r.Context(), tenantID, mux.Vars(r)["build_id"]); err != nil {
+		h.writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Debuginfod serves the debug file uploaded by the tenant with the build ID
+// of the path, like the /buildid/<build-id>/debuginfo endpoint of debuginfod
+// servers does.
+func (h *DebuginfoHandler) Debuginfod(w http.ResponseWriter, r *http.Request) {
+	if _, err := tenant.ExtractTenantIDFromContext(r.Context()); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	rc, err := h.symbolizer.OpenDebuginfo(r.Context(), mux.Vars(r)["build_id"])
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	defer rc.Close()
+	w.Header().Set("Content-Type", "application/octet-stream")
+	if _, err = io.Copy(w, rc); err != nil {
+		level.Error(h.logger).Log("msg", "failed to write response", "err", err)
+	}
+}
+
+func (h *DebuginfoHandler) writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		level.Error(h.logger).Log("msg", "failed to write response", "err", err)
+	}
+}
+
+func (h *DebuginfoHandler) writeError(w http.ResponseWriter, err error) {
+	var (
+		invalidDebuginfo invalidDebuginfoError
+		tooLarge         debuginfoTooLargeError
+		notFound         buildIDNotFoundError
+	)
+	switch {
+	case errors.As(err, &invalidDebuginfo), isInvalidBuildIDError(err):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.As(err, &tooLarge):
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+	case errors.As(err, &notFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		level.Error(h.logger).Log("msg", "debug info request failed", "err", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,217 @@
+package symbolizer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	googlev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
+	phlareobj "github.com/grafana/pyroscope/pkg/objstore"
+	"github.com/grafana/pyroscope/pkg/objstore/providers/memory"
+	"github.com/grafana/pyroscope/pkg/tenant"
+	"github.com/grafana/pyroscope/pkg/test/mocks/mocksymbolizer"
+)
+
+const testBuildID = "2fa2055ef20fabc972d5751147e093275514b142"
+
+func newDebuginfoTestHandler(t *testing.T, maxSize int64) (*DebuginfoHandler, *Symbolizer) {
+	t.Helper()
+	uploadsNotFound, err := newUploadsNotFoundCache()
+	require.NoError(t, err)
+	s := &Symbolizer{
+		cfg:             Config{MaxDebuginfoUploadSize: maxSize},
+		logger:          log.NewNopLogger(),
+		client:          mocksymbolizer.NewMockDebuginfodClient(t),
+		bucket:          phlareobj.NewBucket(memory.NewInMemBucket()),
+		metrics:         newMetrics(prometheus.NewRegistry()),
+		uploadsNotFound: uploadsNotFound,
+	}
+	return NewDebuginfoHandler(log.NewNopLogger(), s), s
+}
+
+func debuginfoRequest(tenantID, method, target string, body []byte) *http.Request {
+	req := httptest.NewRequest(method, target, bytes.NewReader(body))
+	return req.WithContext(tenant.InjectTenantID(req.Context(), tenantID))
+}
+
+func TestDebuginfoHandler(t *testing.T) {
+	h, s := newDebuginfoTestHandler(t, 1<<20)
+	data, err := os.ReadFile("testdata/symbols.debug")
+	require.NoError(t, err)
+
+	// Upload.
+	w := httptest.NewRecorder()
+	h.Upload(w, debuginfoRequest("tenant-a", http.MethodPost, "/debuginfo/v1/upload", data))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var meta DebuginfoMeta
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &meta))
+	require.Equal(t, testBuildID, meta.BuildID)
+	require.Greater(t, meta.LidiaSize, int64(0))
+
+	// List: the debug file is only visible to the tenant it was uploaded by.
+	list := func(tenantID string) []DebuginfoMeta {
+		w := httptest.NewRecorder()
+		h.List(w, debuginfoRequest(tenantID, http.MethodGet, "/debuginfo/v1/list", nil))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var result []DebuginfoMeta
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+		return result
+	}
+	listed := list("tenant-a")
+	require.Len(t, listed, 1)
+	require.Equal(t, testBuildID, listed[0].BuildID)
+	require.Equal(t, meta.LidiaSize, listed[0].LidiaSize)
+	require.Empty(t, list("tenant-b"))
+
+	// Symbolization uses the debug file of the tenant,
+	// without falling back to debuginfod.
+	p := &googlev1.Profile{
+		Mapping:     []*googlev1.Mapping{{Id: 1, BuildId: 1, MemoryLimit: 0x1000000}},
+		Location:    []*googlev1.Location{{Id: 1, MappingId: 1, Address: 0x3c5a}},
+		StringTable: []string{"", testBuildID},
+	}
+	ctx := tenant.InjectTenantID(context.Background(), "tenant-a")
+	require.NoError(t, s.SymbolizePprof(ctx, p))
+	require.Len(t, p.Location[0].Line, 2)
+	require.Equal(t, "atoll_b", p.StringTable[p.Function[p.Location[0].Line[1].FunctionId-1].Name])
+
+	// Delete.
+	del := func(tenantID, buildID string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := debuginfoRequest(tenantID, http.MethodDelete, "/debuginfo/v1/"+buildID, nil)
+		h.Delete(w, mux.SetURLVars(req, map[string]string{"build_id": buildID}))
+		return w
+	}
+	require.Equal(t, http.StatusNotFound, del("tenant-b", testBuildID).Code)
+	require.Equal(t, http.StatusNoContent, del("tenant-a", testBuildID).Code)
+	require.Equal(t, http.StatusNotFound, del("tenant-a", testBuildID).Code)
+	require.Equal(t, http.StatusBadRequest, del("tenant-a", "..%2Fx").Code)
+	require.Empty(t, list("tenant-a"))
+}
+
+type countingBucket struct {
+	phlareobj.Bucket
+	gets int
+}
+
+func (b *countingBucket) Get(ctx context.Context, name string) (io.ReadCloser, error) {
+	b.gets++
+	return b.Bucket.Get(ctx, name)
+}
+
+func TestFetchLidiaFromTenantsNotFoundCache(t *testing.T) {
+	_, s := newDebuginfoTestHandler(t, 1<<20)
+	bucket := &countingBucket{Bucket: s.bucket}
+	s.bucket = bucket
+	ctx := tenant.InjectTenantID(context.Background(), "tenant-a")
+
+	_, err := s.fetchLidiaFromTenants(ctx, testBuildID)
+	require.ErrorAs(t, err, new(buildIDNotFoundError))
+	s.uploadsNotFound.Wait()
+	_, err = s.fetchLidiaFromTenants(ctx, testBuildID)
+	require.ErrorAs(t, err, new(buildIDNotFoundError))
+	require.Equal(t, 1, bucket.gets)
+
+	// The upload invalidates the cached entry.
+	data, err := os.ReadFile("testdata/symbols.debug")
+	require.NoError(t, err)
+	_, err = s.UploadDebuginfo(ctx, "tenant-a", data)
+	require.NoError(t, err)
+	s.uploadsNotFound.Wait()
+	_, err = s.fetchLidiaFromTenants(ctx, testBuildID)
+	require.NoError(t, err)
+	require.Equal(t, 2, bucket.gets)
+}
+
+func TestDebuginfoHandlerDebuginfod(t *testing.T) {
+	h, s := newDebuginfoTestHandler(t, 1<<20)
+	s.cfg.DebuginfodServerEnabled = true
+	data, err := os.ReadFile("testdata/symbols.debug")
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	h.Upload(w, debuginfoRequest("tenant-a", http.MethodPost, "/debuginfo/v1/upload", data))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	get := func(tenantID, buildID string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := debuginfoRequest(tenantID, http.MethodGet, "/buildid/"+buildID+"/debuginfo", nil)
+		h.Debuginfod(w, mux.SetURLVars(req, map[string]string{"build_id": buildID}))
+		return w
+	}
+	w = get("tenant-a", testBuildID)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, data, w.Body.Bytes())
+	require.Equal(t, http.StatusNotFound, get("tenant-b", testBuildID).Code)
+	require.Equal(t, http.StatusBadRequest, get("tenant-a", "..%2Fx").Code)
+
+	w = httptest.NewRecorder()
+	req := debuginfoRequest("tenant-a", http.MethodDelete, "/debuginfo/v1/"+testBuildID, nil)
+	h.Delete(w, mux.SetURLVars(req, map[string]string{"build_id": testBuildID}))
+	require.Equal(t, http.StatusNoContent, w.Code)
+	require.Equal(t, http.StatusNotFound, get("tenant-a", testBuildID).Code)
+}
+
+func TestDebuginfoHandlerUploadErrors(t *testing.T) {
+	h, _ := newDebuginfoTestHandler(t, 1<<10)
+
+	w := httptest.NewRecorder()
+	h.Upload(w, debuginfoRequest("tenant-a", http.MethodPost, "/debuginfo/v1/upload", []byte("not an ELF file")))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	h.Upload(w, debuginfoRequest("tenant-a", http.MethodPost, "/debuginfo/v1/upload", make([]byte, 1<<10+1)))
+	require.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+
+	// The limit applies to the decompressed debug file as well.
+	var compressed bytes.Buffer
+	gw := gzip.NewWriter(&compressed)
+	_, err := gw.Write(make([]byte, 64<<10))
+	require.NoError(t, err)
+	require.NoError(t, gw.Close())
+	require.Less(t, compressed.Len(), 1<<10)
+	w = httptest.NewRecorder()
+	h.Upload(w, debuginfoRequest("tenant-a", http.MethodPost, "/debuginfo/v1/upload", compressed.Bytes()))
+	require.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+
+	w = httptest.NewRecorder()
+	h.Upload(w, httptest.NewRequest(http.MethodPost, "/debuginfo/v1/upload", nil))
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestFindGNUBuildID(t *testing.T) {
+	note := func(name string, typ uint32, desc []byte) []byte {
+		var b []byte
+		b = append(b, byte(len(name)), 0, 0, 0, byte(len(desc)), 0, 0, 0, byte(typ), 0, 0, 0)
+		b = append(b, name...)
+		for len(b)%4 != 0 {
+			b = append(b, 0)
+		}
+		b = append(b, desc...)
+		for len(b)%4 != 0 {
+			b = append(b, 0)
+		}
+		return b
+	}
+	data := append(note("GNU\x00", 1, []byte{0, 0, 0, 0, 3, 0, 0, 0}), note("GNU\x00", 3, []byte{0xca, 0xfe, 0xba, 0xbe, 0x01})...)
+	id, ok := findGNUBuildID(data, binary.LittleEndian)
+	require.True(t, ok)
+	require.Equal(t, "cafebabe01", id)
+
+	_, ok = findGNUBuildID(note("Go\x00\x00", 4, []byte("go-build-id")), binary.LittleEndian)
+	require.False(t, ok)
+	_, ok = findGNUBuildID(data[:len(data)-8], binary.LittleEndian)
+	require.False(t, ok)
+}
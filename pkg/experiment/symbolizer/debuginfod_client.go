@@ -149,6 +149,17 @@ func (c *DebuginfodHTTPClient) FetchDebuginfo(ctx context.Context, buildID strin
 	return io.NopCloser(bytes.NewReader(data)), nil
 }
 
+// isNotFound tells whether debuginfod recently responded that it does
+// not have the build ID.
+func (c *DebuginfodHTTPClient) isNotFound(buildID string) bool {
+	sanitizedBuildID, err := sanitizeBuildID(buildID)
+	if err != nil {
+		return false
+	}
+	found, _ := c.notFoundCache.Get(sanitizedBuildID)
+	return found
+}
+
 // doRequest performs an HTTP request to the specified URL and returns the response body.
 func (c *DebuginfodHTTPClient) doRequest(ctx context.Context, url string) ([]byte, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
@@ -21,6 +21,26 @@ func (e buildIDNotFoundError) Error() string {
 	return fmt.Sprintf("build ID not found: %s", e.buildID)
 }
 
+type invalidDebuginfoError struct {
+	err error
+}
+
+func (e invalidDebuginfoError) Error() string {
+	return fmt.Sprintf("invalid debug info: %v", e.err)
+}
+
+func (e invalidDebuginfoError) Unwrap() error {
+	return e.err
+}
+
+type debuginfoTooLargeError struct {
+	maxSize int64
+}
+
+func (e debuginfoTooLargeError) Error() string {
+	return fmt.Sprintf("debug info exceeds the maximum size of %d bytes", e.maxSize)
+}
+
 type httpStatusError struct {
 	statusCode int
 	body       string
@@ -0,0 +1,266 @@
+package symbolizer
+
+import (
+	"bytes"
+	"context"
+	"debug/elf"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+)
+
+const (
+	buildIDDir          = ".build-id"
+	debugFileSuffix     = ".debug"
+	debuglinkDir        = ".debug"
+	debuglinkSection    = ".gnu_debuglink"
+	minLocalBuildIDSize = 3
+)
+
+// LocalDebuginfoClient implements the DebuginfodClient interface with the
+// debug files of local directories, like /usr/lib/debug. The debug files
+// are looked up by build ID in the .build-id tree of the directories. The
+// binaries of the tree whose debug information has been stripped to a side
+// file are followed to it through their .gnu_debuglink section.
+type LocalDebuginfoClient struct {
+	dirs   []string
+	logger log.Logger
+}
+
+// NewLocalDebuginfoClient creates a new client for fetching debug information
+// from local directories.
+func NewLocalDebuginfoClient(logger log.Logger, dirs []string) *LocalDebuginfoClient {
+	return &LocalDebuginfoClient{
+		dirs:   dirs,
+		logger: logger,
+	}
+}
+
+// FetchDebuginfo opens the debug file for a specific build ID.
+func (c *LocalDebuginfoClient) FetchDebuginfo(ctx context.Context, buildID string) (io.ReadCloser, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	sanitizedBuildID, err := sanitizeBuildID(buildID)
+	if err != nil {
+		return nil, err
+	}
+	sanitizedBuildID = strings.ToLower(sanitizedBuildID)
+	if len(sanitizedBuildID) < minLocalBuildIDSize {
+		return nil, buildIDNotFoundError{buildID: buildID}
+	}
+	prefix, suffix := sanitizedBuildID[:2], sanitizedBuildID[2:]
+
+	// <dir>/.build-id/xx/yyyy.debug is the debug file itself.
+	for _, dir := range c.dirs {
+		f, err := os.Open(filepath.Join(dir, buildIDDir, prefix, suffix+debugFileSuffix))
+		if err == nil {
+			return f, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			level.Warn(c.logger).Log("msg", "Failed to open debug file", "buildID", sanitizedBuildID, "err", err)
+		}
+	}
+
+	// <dir>/.build-id/xx/yyyy is the binary, which either has its debug
+	// information, or links to the side file it has been stripped to.
+	for _, dir := range c.dirs {
+		f, err := c.openBinary(filepath.Join(dir, buildIDDir, prefix, suffix))
+		if err == nil {
+			return f, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			level.Warn(c.logger).Log("msg", "Failed to open binary", "buildID", sanitizedBuildID, "err", err)
+		}
+	}
+
+	return nil, buildIDNotFoundError{buildID: sanitizedBuildID}
+}
+
+// isNotFound tells whether none of the directories has a debug file or a
+// binary with the build ID.
+func (c *LocalDebuginfoClient) isNotFound(buildID string) bool {
+	sanitizedBuildID, err := sanitizeBuildID(buildID)
+	if err != nil {
+		return false
+	}
+	sanitizedBuildID = strings.ToLower(sanitizedBuildID)
+	if len(sanitizedBuildID) < minLocalBuildIDSize {
+		return true
+	}
+	prefix, suffix := sanitizedBuildID[:2], sanitizedBuildID[2:]
+	for _, dir := range c.dirs {
+		for _, name := range []string{suffix + debugFileSuffix, suffix} {
+			if _, err = os.Stat(filepath.Join(dir, buildIDDir, prefix, name)); !errors.Is(err, fs.ErrNotExist) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// openBinary opens the debug file of the binary: the side file of its
+// .gnu_debuglink section, if any is found, or the binary itself if it
+// has symbols.
+func (c *LocalDebuginfoClient) openBinary(name string) (*os.File, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	elfFile, err := elf.NewFile(f)
+	if err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("parse ELF file %s: %w", name, err)
+	}
+
+	if link, crc, ok := debuglink(elfFile); ok {
+		if side, ok := c.findDebuglink(name, link, crc); ok {
+			_ = f.Close()
+			return side, nil
+		}
+	}
+
+	if hasSymbols(elfFile) {
+		if _, err = f.Seek(0, io.SeekStart); err != nil {
+			_ = f.Close()
+			return nil, err
+		}
+		return f, nil
+	}
+	_ = f.Close()
+	return nil, fs.ErrNotExist
+}
+
+// findDebuglink opens the side file the binary links to. Like GDB, it looks
+// for the file next to the binary, in the .debug subdirectory of the binary
+// directory, and in the tree of the binary directory under the local
+// directories. The file is also looked for at the root of the local
+// directories, which side files are commonly collected in. The checksum of
+// the file must match the one of the link.
+func (c *LocalDebuginfoClient) findDebuglink(binary, link string, crc uint32) (*os.File, bool) {
+	if resolved, err := filepath.EvalSymlinks(binary); err == nil {
+		binary = resolved
+	}
+	binaryDir, err := filepath.Abs(filepath.Dir(binary))
+	if err != nil {
+		binaryDir = filepath.Dir(binary)
+	}
+
+	candidates := []string{
+		filepath.Join(binaryDir, link),
+		filepath.Join(binaryDir, debuglinkDir, link),
+	}
+	for _, dir := range c.dirs {
+		candidates = append(candidates,
+			filepath.Join(dir, binaryDir, link),
+			filepath.Join(dir, link),
+		)
+	}
+
+	for _, candidate := range candidates {
+		if candidate == binary {
+			continue
+		}
+		f, err := os.Open(candidate)
+		if err != nil {
+			continue
+		}
+		sum, err := fileCRC(f)
+		if err == nil && sum == crc {
+			if _, err = f.Seek(0, io.SeekStart); err == nil {
+				return f, true
+			}
+		} else if err == nil {
+			level.Debug(c.logger).Log("msg", "Debug link checksum mismatch", "binary", binary, "file", candidate)
+		}
+		_ = f.Close()
+	}
+	return nil, false
+}
+
+// debuglink returns the name and the checksum of the side file the debug
+// information of the ELF file has been stripped to.
+func debuglink(f *elf.File) (string, uint32, bool) {
+	section := f.Section(debuglinkSection)
+	if section == nil {
+		return "", 0, false
+	}
+	data, err := section.Data()
+	if err != nil {
+		return "", 0, false
+	}
+	// The name is null terminated, and the checksum is 4 bytes aligned.
+	end := bytes.IndexByte(data, 0)
+	if end <= 0 {
+		return "", 0, false
+	}
+	offset := (end + 4) &^ 3
+	if offset+4 > len(data) {
+		return "", 0, false
+	}
+	name := string(data[:end])
+	if name != filepath.Base(name) {
+		return "", 0, false
+	}
+	return name, f.ByteOrder.Uint32(data[offset:]), true
+}
+
+// hasSymbols tells whether the ELF file has debug information or a symbol table.
+func hasSymbols(f *elf.File) bool {
+	for _, name := range []string{".debug_info", ".zdebug_info", ".symtab"} {
+		if s := f.Section(name); s != nil && s.Type != elf.SHT_NOBITS {
+			return true
+		}
+	}
+	return false
+}
+
+func fileCRC(r io.Reader) (uint32, error) {
+	h := crc32.NewIEEE()
+	if _, err := io.Copy(h, r); err != nil {
+		return 0, err
+	}
+	return h.Sum32(), nil
+}
+
+// debuginfodClients tries the clients in order, until one of them
+// knows the build ID.
+type debuginfodClients []DebuginfodClient
+
+func (clients debuginfodClients) FetchDebuginfo(ctx context.Context, buildID string) (io.ReadCloser, error) {
+	for _, client := range clients {
+		r, err := client.FetchDebuginfo(ctx, buildID)
+		if err == nil {
+			return r, nil
+		}
+		var bnfErr buildIDNotFoundError
+		if statusCode, ok := isHTTPStatusError(err); !errors.As(err, &bnfErr) && !(ok && statusCode == http.StatusNotFound) {
+			return nil, err
+		}
+	}
+	return nil, buildIDNotFoundError{buildID: buildID}
+}
+
+// isNotFound tells whether all the clients know they don't have
+// the build ID.
+func (clients debuginfodClients) isNotFound(buildID string) bool {
+	if len(clients) == 0 {
+		return false
+	}
+	for _, client := range clients {
+		c, ok := client.(debuginfoNotFoundChecker)
+		if !ok || !c.isNotFound(buildID) {
+			return false
+		}
+	}
+	return true
+}
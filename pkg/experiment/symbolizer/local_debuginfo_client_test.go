@@ -0,0 +1,171 @@
+package symbolizer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/pyroscope/pkg/test/mocks/mocksymbolizer"
+)
+
+// The testdata/stripped binary has no symbols, and links to testdata/symbols.debug.
+const strippedBuildID = "8720d52b09d4f1ddf650bd0cbe9fd19f3e5c9178"
+
+func writeTestFile(t *testing.T, name string, data []byte) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(name), 0o755))
+	require.NoError(t, os.WriteFile(name, data, 0o644))
+}
+
+func fetchLocal(t *testing.T, c DebuginfodClient, buildID string) ([]byte, error) {
+	t.Helper()
+	r, err := c.FetchDebuginfo(context.Background(), buildID)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+func TestLocalDebuginfoClient(t *testing.T) {
+	debugFile, err := os.ReadFile("testdata/symbols.debug")
+	require.NoError(t, err)
+	stripped, err := os.ReadFile("testdata/stripped")
+	require.NoError(t, err)
+
+	t.Run("build-id tree", func(t *testing.T) {
+		dir := t.TempDir()
+		writeTestFile(t, filepath.Join(dir, ".build-id", testBuildID[:2], testBuildID[2:]+".debug"), debugFile)
+
+		data, err := fetchLocal(t, NewLocalDebuginfoClient(log.NewNopLogger(), []string{t.TempDir(), dir}), testBuildID)
+		require.NoError(t, err)
+		require.Equal(t, debugFile, data)
+	})
+
+	t.Run("binary with symbols", func(t *testing.T) {
+		dir := t.TempDir()
+		writeTestFile(t, filepath.Join(dir, ".build-id", testBuildID[:2], testBuildID[2:]), debugFile)
+
+		data, err := fetchLocal(t, NewLocalDebuginfoClient(log.NewNopLogger(), []string{dir}), testBuildID)
+		require.NoError(t, err)
+		require.Equal(t, debugFile, data)
+	})
+
+	t.Run("debug link next to the binary", func(t *testing.T) {
+		dir := t.TempDir()
+		binary := filepath.Join(dir, "usr", "bin", "app")
+		writeTestFile(t, binary, stripped)
+		writeTestFile(t, filepath.Join(dir, "usr", "bin", ".debug", "symbols.debug"), debugFile)
+		link := filepath.Join(dir, ".build-id", strippedBuildID[:2], strippedBuildID[2:])
+		require.NoError(t, os.MkdirAll(filepath.Dir(link), 0o755))
+		require.NoError(t, os.Symlink(binary, link))
+
+		data, err := fetchLocal(t, NewLocalDebuginfoClient(log.NewNopLogger(), []string{dir}), strippedBuildID)
+		require.NoError(t, err)
+		require.Equal(t, debugFile, data)
+	})
+
+	t.Run("debug link in a debug directory", func(t *testing.T) {
+		binaries, debugDir := t.TempDir(), t.TempDir()
+		writeTestFile(t, filepath.Join(binaries, ".build-id", strippedBuildID[:2], strippedBuildID[2:]), stripped)
+		writeTestFile(t, filepath.Join(debugDir, "symbols.debug"), debugFile)
+
+		data, err := fetchLocal(t, NewLocalDebuginfoClient(log.NewNopLogger(), []string{binaries, debugDir}), strippedBuildID)
+		require.NoError(t, err)
+		require.Equal(t, debugFile, data)
+	})
+
+	t.Run("debug link checksum mismatch", func(t *testing.T) {
+		dir := t.TempDir()
+		writeTestFile(t, filepath.Join(dir, ".build-id", strippedBuildID[:2], strippedBuildID[2:]), stripped)
+		writeTestFile(t, filepath.Join(dir, "symbols.debug"), []byte("not the debug file"))
+
+		_, err := fetchLocal(t, NewLocalDebuginfoClient(log.NewNopLogger(), []string{dir}), strippedBuildID)
+		var bnfErr buildIDNotFoundError
+		require.ErrorAs(t, err, &bnfErr)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := fetchLocal(t, NewLocalDebuginfoClient(log.NewNopLogger(), []string{t.TempDir()}), testBuildID)
+		var bnfErr buildIDNotFoundError
+		require.ErrorAs(t, err, &bnfErr)
+	})
+
+	t.Run("invalid build ID", func(t *testing.T) {
+		_, err := fetchLocal(t, NewLocalDebuginfoClient(log.NewNopLogger(), []string{t.TempDir()}), "../etc/passwd")
+		require.True(t, isInvalidBuildIDError(err))
+	})
+}
+
+func TestDebuginfodClients(t *testing.T) {
+	first := mocksymbolizer.NewMockDebuginfodClient(t)
+	second := mocksymbolizer.NewMockDebuginfodClient(t)
+	clients := debuginfodClients{first, second}
+
+	first.EXPECT().FetchDebuginfo(mock.Anything, "a").Return(nil, buildIDNotFoundError{buildID: "a"}).Once()
+	second.EXPECT().FetchDebuginfo(mock.Anything, "a").Return(io.NopCloser(bytes.NewReader([]byte("elf"))), nil).Once()
+	data, err := fetchLocal(t, clients, "a")
+	require.NoError(t, err)
+	require.Equal(t, []byte("elf"), data)
+
+	first.EXPECT().FetchDebuginfo(mock.Anything, "b").Return(nil, buildIDNotFoundError{buildID: "b"}).Once()
+	second.EXPECT().FetchDebuginfo(mock.Anything, "b").Return(nil, httpStatusError{statusCode: 404}).Once()
+	_, err = fetchLocal(t, clients, "b")
+	var bnfErr buildIDNotFoundError
+	require.ErrorAs(t, err, &bnfErr)
+
+	// Other errors are not hidden by the next clients.
+	first.EXPECT().FetchDebuginfo(mock.Anything, "c").Return(nil, errors.New("unavailable")).Once()
+	_, err = fetchLocal(t, clients, "c")
+	require.EqualError(t, err, "unavailable")
+}
+
+func TestDebuginfodClients_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+	httpClient, err := NewDebuginfodClientWithConfig(log.NewNopLogger(), DebuginfodClientConfig{
+		BaseURL:               server.URL,
+		NotFoundCacheMaxItems: 100,
+		NotFoundCacheTTL:      time.Minute,
+	}, newMetrics(nil))
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, buildIDDir, "ab", "cdef.debug"), []byte("elf"))
+	clients := debuginfodClients{NewLocalDebuginfoClient(log.NewNopLogger(), []string{dir}), httpClient}
+
+	var checker DebuginfodClient = clients
+	require.Implements(t, (*debuginfoNotFoundChecker)(nil), checker)
+	// The build IDs are unknown until debuginfod responds.
+	require.False(t, clients.isNotFound("abcdef"))
+	require.False(t, clients.isNotFound("012345"))
+
+	_, err = fetchLocal(t, clients, "012345")
+	var bnfErr buildIDNotFoundError
+	require.ErrorAs(t, err, &bnfErr)
+	httpClient.notFoundCache.Wait()
+	require.True(t, clients.isNotFound("012345"))
+
+	// The local directory has the debug file.
+	_, err = fetchLocal(t, httpClient, "abcdef")
+	require.ErrorAs(t, err, &bnfErr)
+	httpClient.notFoundCache.Wait()
+	require.True(t, httpClient.isNotFound("abcdef"))
+	require.False(t, clients.isNotFound("abcdef"))
+
+	// Other clients can't tell.
+	require.False(t, debuginfodClients{mocksymbolizer.NewMockDebuginfodClient(t), httpClient}.isNotFound("012345"))
+}
@@ -80,8 +80,9 @@ func (m *memoryBuffer) Bytes() []byte {
 	return m.data
 }
 
-// detectCompression checks if data is compressed and decompresses it if needed
-func detectCompression(data []byte) ([]byte, error) {
+// detectCompression checks if data is compressed and decompresses it if needed.
+// The decompressed data is limited to maxSize bytes if positive.
+func detectCompression(data []byte, maxSize int64) ([]byte, error) {
 	if len(data) >= 2 && data[0] == 0x1f && data[1] == 0x8b {
 		r, err := gzip.NewReader(bytes.NewReader(data))
 		if err != nil {
@@ -89,7 +90,7 @@ func detectCompression(data []byte) ([]byte, error) {
 		}
 		defer r.Close()
 
-		decompressed, err := io.ReadAll(r)
+		decompressed, err := readDebuginfo(r, maxSize)
 		if err != nil {
 			return nil, fmt.Errorf("decompress gzip data: %w", err)
 		}
@@ -105,7 +106,7 @@ func detectCompression(data []byte) ([]byte, error) {
 		}
 		defer r.Close()
 
-		decompressed, err := io.ReadAll(r)
+		decompressed, err := readDebuginfo(r, maxSize)
 		if err != nil {
 			return nil, fmt.Errorf("decompress zstd data: %w", err)
 		}
@@ -12,47 +12,95 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/dgraph-io/ristretto/v2"
 	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
+	"github.com/grafana/dskit/flagext"
 	"github.com/prometheus/client_golang/prometheus"
 
 	googlev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
 	"github.com/grafana/pyroscope/lidia"
 	"github.com/grafana/pyroscope/pkg/objstore"
+	"github.com/grafana/pyroscope/pkg/pprof"
+	"github.com/grafana/pyroscope/pkg/tenant"
 )
 
 type DebuginfodClient interface {
 	FetchDebuginfo(ctx context.Context, buildID string) (io.ReadCloser, error)
 }
 
+// debuginfoNotFoundChecker is implemented by the clients which can tell,
+// without fetching it, that they don't have the debug info of a build ID.
+type debuginfoNotFoundChecker interface {
+	isNotFound(buildID string) bool
+}
+
+// Limits are the per-tenant limits of the symbolizer.
+type Limits interface {
+	SymbolizerDemangle(tenantID string) pprof.DemangleMode
+}
+
 type Config struct {
-	DebuginfodURL string `yaml:"debuginfod_url"`
+	DebuginfodURL           string                 `yaml:"debuginfod_url"`
+	DebugDirs               flagext.StringSliceCSV `yaml:"debug_dirs"`
+	MaxDebuginfoUploadSize  int64                  `yaml:"max_debuginfo_upload_size"`
+	DebuginfodServerEnabled bool                   `yaml:"debuginfod_server_enabled"`
 }
 
 func (cfg *Config) RegisterFlags(f *flag.FlagSet) {
-	f.StringVar(&cfg.DebuginfodURL, "symbolizer.debuginfod-url", "https://debuginfod.elfutils.org", "URL of the debuginfod server")
+	f.StringVar(&cfg.DebuginfodURL, "symbolizer.debuginfod-url", "https://debuginfod.elfutils.org", "URL of the debuginfod server. Empty to disable debuginfod.")
+	f.Var(&cfg.DebugDirs, "symbolizer.debug-dirs", "Comma-separated list of local directories to look up debug files in before debuginfod, like /usr/lib/debug. The debug files are looked up in the .build-id tree of the directories, following the .gnu_debuglink sections of the binaries.")
+	f.Int64Var(&cfg.MaxDebuginfoUploadSize, "symbolizer.max-debuginfo-upload-size", 1<<30, "Maximum size in bytes of the debug files uploaded by the tenants. 0 to disable the limit.")
+	f.BoolVar(&cfg.DebuginfodServerEnabled, "symbolizer.debuginfod-server-enabled", false, "Serve the debug files uploaded by the tenants at the debuginfod-compatible /buildid/<build-id>/debuginfo endpoint. The debug files are stored along with their lidia tables when enabled.")
 }
 
 type Symbolizer struct {
+	cfg     Config
 	logger  log.Logger
 	client  DebuginfodClient
 	bucket  objstore.Bucket
+	limits  Limits
 	metrics *metrics
+
+	// uploadsNotFound caches the tenant build IDs with no debug file
+	// uploaded, to not look them up in the object store on every
+	// symbolization.
+	uploadsNotFound *ristretto.Cache[string, bool]
 }
 
-func New(logger log.Logger, cfg Config, reg prometheus.Registerer, bucket objstore.Bucket) (*Symbolizer, error) {
+func New(logger log.Logger, cfg Config, reg prometheus.Registerer, bucket objstore.Bucket, limits Limits) (*Symbolizer, error) {
 	metrics := newMetrics(reg)
 
-	client, err := NewDebuginfodClient(logger, cfg.DebuginfodURL, metrics)
+	var clients debuginfodClients
+	if len(cfg.DebugDirs) > 0 {
+		clients = append(clients, NewLocalDebuginfoClient(logger, cfg.DebugDirs))
+	}
+	if cfg.DebuginfodURL != "" {
+		httpClient, err := NewDebuginfodClient(logger, cfg.DebuginfodURL, metrics)
+		if err != nil {
+			return nil, err
+		}
+		clients = append(clients, httpClient)
+	}
+
+	var client DebuginfodClient = clients
+	if len(clients) == 1 {
+		client = clients[0]
+	}
+
+	uploadsNotFound, err := newUploadsNotFoundCache()
 	if err != nil {
 		return nil, err
 	}
 
 	return &Symbolizer{
-		logger:  logger,
-		client:  client,
-		bucket:  bucket,
-		metrics: metrics,
+		cfg:             cfg,
+		logger:          logger,
+		client:          client,
+		bucket:          bucket,
+		limits:          limits,
+		metrics:         metrics,
+		uploadsNotFound: uploadsNotFound,
 	}, nil
 }
 
@@ -116,7 +164,7 @@ func (s *Symbolizer) SymbolizePprof(ctx context.Context, profile *googlev1.Profi
 		}
 	}
 
-	s.updateAllSymbolsInProfile(profile, allSymbolizedLocs, stringMap)
+	s.updateAllSymbolsInProfile(profile, allSymbolizedLocs, stringMap, s.demangleMode(ctx))
 
 	return nil
 }
@@ -189,9 +237,19 @@ func (s *Symbolizer) updateAllSymbolsInProfile(
 	profile *googlev1.Profile,
 	symbolizedLocs []symbolizedLocation,
 	stringMap map[string]int64,
+	demangle pprof.DemangleMode,
 ) {
 	funcMap := make(map[funcKey]uint64)
 	maxFuncID := uint64(len(profile.Function))
+	str := func(v string) int64 {
+		idx, ok := stringMap[v]
+		if !ok {
+			idx = int64(len(profile.StringTable))
+			profile.StringTable = append(profile.StringTable, v)
+			stringMap[v] = idx
+		}
+		return idx
+	}
 
 	for _, item := range symbolizedLocs {
 		loc := item.loc
@@ -206,34 +264,27 @@ func (s *Symbolizer) updateAllSymbolsInProfile(
 		profile.Location[locIdx].Line = make([]*googlev1.Line, len(symLoc.lines))
 
 		for j, line := range symLoc.lines {
-			nameIdx, ok := stringMap[line.FunctionName]
-			if !ok {
-				nameIdx = int64(len(profile.StringTable))
-				profile.StringTable = append(profile.StringTable, line.FunctionName)
-				stringMap[line.FunctionName] = nameIdx
-			}
+			systemNameIdx := str(line.FunctionName)
+			nameIdx := str(demangle.Demangle(line.FunctionName))
+			filenameIdx := str(line.FilePath)
 
-			filenameIdx, ok := stringMap[line.FilePath]
-			if !ok {
-				filenameIdx = int64(len(profile.StringTable))
-				profile.StringTable = append(profile.StringTable, line.FilePath)
-				stringMap[line.FilePath] = filenameIdx
-			}
-
-			key := funcKey{nameIdx, filenameIdx}
+			key := funcKey{systemNameIdx, filenameIdx}
 			funcID, ok := funcMap[key]
 			if !ok {
 				maxFuncID++
 				funcID = maxFuncID
 				profile.Function = append(profile.Function, &googlev1.Function{
-					Id:   funcID,
-					Name: nameIdx,
+					Id:         funcID,
+					Name:       nameIdx,
+					SystemName: systemNameIdx,
+					Filename:   filenameIdx,
 				})
 				funcMap[key] = funcID
 			}
 
 			profile.Location[locIdx].Line[j] = &googlev1.Line{
 				FunctionId: funcID,
+				Line:       int64(line.LineNumber),
 			}
 		}
 
@@ -241,32 +292,46 @@ func (s *Symbolizer) updateAllSymbolsInProfile(
 	}
 }
 
+// demangleMode returns the demangle mode of the tenant of the context.
+func (s *Symbolizer) demangleMode(ctx context.Context) pprof.DemangleMode {
+	if s.limits == nil {
+		return pprof.DemangleNone
+	}
+	tenantIDs, err := tenant.TenantIDs(ctx)
+	if err != nil || len(tenantIDs) == 0 {
+		return pprof.DemangleNone
+	}
+	return s.limits.SymbolizerDemangle(tenantIDs[0])
+}
+
 func (s *Symbolizer) symbolize(ctx context.Context, req *request) {
-	var table *lidia.Table
-	var err error
+	if !s.resolve(ctx, req) {
+		for _, loc := range req.locations {
+			loc.lines = s.createNotFoundSymbols(req.binaryName, loc)
+		}
+	}
+}
 
+// resolve looks up the locations of the request in the debug information
+// of the binary. It reports whether the debug information has been found.
+func (s *Symbolizer) resolve(ctx context.Context, req *request) bool {
 	lidiaBytes, err := s.getLidiaBytes(ctx, req.buildID)
 	if err != nil {
 		level.Warn(s.logger).Log("msg", "Failed to get debug info", "buildID", req.buildID, "err", err)
-		for _, loc := range req.locations {
-			loc.lines = s.createNotFoundSymbols(req.binaryName, loc)
-		}
-		return
+		return false
 	}
 
 	lidiaReader := NewReaderAtCloser(lidiaBytes)
-	table, err = lidia.OpenReader(lidiaReader, lidia.WithCRC())
+	table, err := lidia.OpenReader(lidiaReader, lidia.WithCRC())
 	if err != nil {
 		s.metrics.debugSymbolResolutionErrors.WithLabelValues("lidia_error").Inc()
 		level.Warn(s.logger).Log("msg", "Failed to open Lidia file", "err", err)
-		for _, loc := range req.locations {
-			loc.lines = s.createNotFoundSymbols(req.binaryName, loc)
-		}
-		return
+		return false
 	}
 	defer table.Close()
 
 	s.symbolizeWithTable(table, req)
+	return true
 }
 
 func (s *Symbolizer) symbolizeWithTable(table *lidia.Table, req *request) {
@@ -294,10 +359,13 @@ func (s *Symbolizer) symbolizeWithTable(table *lidia.Table, req *request) {
 }
 
 func (s *Symbolizer) getLidiaBytes(ctx context.Context, buildID string) ([]byte, error) {
-	if client, ok := s.client.(*DebuginfodHTTPClient); ok {
-		if found, _ := client.notFoundCache.Get(buildID); found {
-			return nil, buildIDNotFoundError{buildID: buildID}
-		}
+	// Debug files uploaded by the tenants take precedence over debuginfod.
+	if lidiaBytes, err := s.fetchLidiaFromTenants(ctx, buildID); err == nil {
+		return lidiaBytes, nil
+	}
+
+	if client, ok := s.client.(debuginfoNotFoundChecker); ok && client.isNotFound(buildID) {
+		return nil, buildIDNotFoundError{buildID: buildID}
 	}
 
 	lidiaBytes, err := s.fetchLidiaFromObjectStore(ctx, buildID)
@@ -318,8 +386,8 @@ func (s *Symbolizer) getLidiaBytes(ctx context.Context, buildID string) ([]byte,
 }
 
 // fetchLidiaFromObjectStore retrieves Lidia data from the object store
-func (s *Symbolizer) fetchLidiaFromObjectStore(ctx context.Context, buildID string) ([]byte, error) {
-	objstoreReader, err := s.bucket.Get(ctx, buildID)
+func (s *Symbolizer) fetchLidiaFromObjectStore(ctx context.Context, name string) ([]byte, error) {
+	objstoreReader, err := s.bucket.Get(ctx, name)
 	if err != nil {
 		return nil, err
 	}
@@ -375,7 +443,7 @@ func (s *Symbolizer) fetchFromDebuginfod(ctx context.Context, buildID string) (i
 }
 
 func (s *Symbolizer) processELFData(data []byte) (lidiaData []byte, err error) {
-	decompressedData, err := detectCompression(data)
+	decompressedData, err := detectCompression(data, 0)
 	if err != nil {
 		s.metrics.debugSymbolResolutionErrors.WithLabelValues("compression_error").Inc()
 		return nil, fmt.Errorf("detect compression: %w", err)
@@ -391,14 +459,7 @@ func (s *Symbolizer) processELFData(data []byte) (lidiaData []byte, err error) {
 	defer elfFile.Close()
 
 	initialSize := len(data) * 2 // A simple heuristic: twice the compressed size
-	memBuffer := newMemoryBuffer(initialSize)
-
-	err = lidia.CreateLidiaFromELF(elfFile, memBuffer, lidia.WithCRC(), lidia.WithFiles(), lidia.WithLines())
-	if err != nil {
-		return nil, fmt.Errorf("create lidia file: %w", err)
-	}
-
-	return memBuffer.Bytes(), nil
+	return createLidia(elfFile, initialSize)
 }
 
 func (s *Symbolizer) createNotFoundSymbols(binaryName string, loc *location) []lidia.SourceInfoFrame {
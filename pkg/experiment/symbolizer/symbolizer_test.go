@@ -10,7 +10,10 @@ import (
 	"testing"
 
 	googlev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
+	"github.com/grafana/pyroscope/lidia"
 	"github.com/grafana/pyroscope/pkg/model"
+	"github.com/grafana/pyroscope/pkg/pprof"
+	"github.com/grafana/pyroscope/pkg/tenant"
 	"github.com/grafana/pyroscope/pkg/test/mocks/mockobjstore"
 	"github.com/grafana/pyroscope/pkg/test/mocks/mocksymbolizer"
 
@@ -23,12 +26,14 @@ import (
 
 // TestSymbolizePprof tests symbolization using testdata/symbols.debug which contains:
 //
-// 0x1500 -> (contains both functions)
-//   - main (/usr/src/stress-1.0.7-1/src/stress.c:87)
-//   - fprintf (/usr/include/x86_64-linux-gnu/bits/stdio2.h:77)
+// 0x1500 -> main (/usr/src/stress-1.0.7-1/src/stress.c:116)
+// 0x2745 -> (fprintf inlined into main)
+//   - fprintf (/usr/include/x86_64-linux-gnu/bits/stdio2.h:79)
+//   - main (/usr/src/stress-1.0.7-1/src/stress.c:442)
 //
-// 0x3c5a -> atoll_b (/usr/src/stress-1.0.7-1/src/stress.c:632)
-// 0x2745 -> main (/usr/src/stress-1.0.7-1/src/stress.c:87)
+// 0x3c5a -> (fprintf inlined into atoll_b)
+//   - fprintf (/usr/include/x86_64-linux-gnu/bits/stdio2.h:79)
+//   - atoll_b (/usr/src/stress-1.0.7-1/src/stress.c:665)
 func TestSymbolizePprof(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -94,6 +99,43 @@ func TestSymbolizePprof(t *testing.T) {
 				assertLocationHasFunction(t, p, p.Location[0], "main", "main")
 			},
 		},
+		{
+			name: "inlined function",
+			profile: &googlev1.Profile{
+				Mapping: []*googlev1.Mapping{{
+					BuildId:     1,
+					MemoryStart: 0x0,
+					MemoryLimit: 0x1000000,
+					FileOffset:  0x0,
+				}},
+				Location: []*googlev1.Location{{
+					Id:        1,
+					MappingId: 1,
+					Address:   0x2745,
+				}},
+				StringTable: []string{"", "build-id"},
+			},
+			setupMock: func(mockClient *mocksymbolizer.MockDebuginfodClient, mockBucket *mockobjstore.MockBucket) {
+				mockClient.On("FetchDebuginfo", mock.Anything, "build-id").Return(openTestFile(t), nil).Once()
+				mockBucket.On("Get", mock.Anything, "build-id").Return(nil, fmt.Errorf("not found")).Once()
+				mockBucket.On("Upload", mock.Anything, "build-id", mock.Anything).Return(nil).Once()
+			},
+			validate: func(t *testing.T, p *googlev1.Profile) {
+				require.True(t, p.Mapping[0].HasFunctions)
+
+				lines := p.Location[0].Line
+				require.Len(t, lines, 2)
+				// The inlined function comes first.
+				fprintf := p.Function[lines[0].FunctionId-1]
+				require.Equal(t, "fprintf", p.StringTable[fprintf.Name])
+				require.Equal(t, "/usr/include/x86_64-linux-gnu/bits/stdio2.h", p.StringTable[fprintf.Filename])
+				require.Equal(t, int64(79), lines[0].Line)
+				main := p.Function[lines[1].FunctionId-1]
+				require.Equal(t, "main", p.StringTable[main.Name])
+				require.Equal(t, "/usr/src/stress-1.0.7-1/src/stress.c", p.StringTable[main.Filename])
+				require.Equal(t, int64(442), lines[1].Line)
+			},
+		},
 		{
 			name: "empty build ID",
 			profile: &googlev1.Profile{
@@ -533,3 +575,48 @@ func createRequest(t *testing.T, buildID string, address uint64) *request {
 		},
 	}
 }
+
+type demangleLimits pprof.DemangleMode
+
+func (l demangleLimits) SymbolizerDemangle(string) pprof.DemangleMode { return pprof.DemangleMode(l) }
+
+func TestSymbolizationDemangle(t *testing.T) {
+	s := &Symbolizer{
+		logger:  log.NewNopLogger(),
+		limits:  demangleLimits(pprof.DemangleTemplatesStripped),
+		metrics: newMetrics(nil),
+	}
+
+	profile := &googlev1.Profile{
+		Mapping:     []*googlev1.Mapping{{Id: 1, BuildId: 1}},
+		Location:    []*googlev1.Location{{Id: 1, MappingId: 1, Address: 0x10}},
+		StringTable: []string{"", "build-id"},
+	}
+	symbolized := []symbolizedLocation{{
+		loc:     profile.Location[0],
+		mapping: profile.Mapping[0],
+		symLoc: &location{lines: []lidia.SourceInfoFrame{
+			{FunctionName: "_ZN3foo3barIiEEvT_", FilePath: "foo.cpp", LineNumber: 1},
+			{FunctionName: "_ZN3foo3barIlEEvT_", FilePath: "foo.cpp", LineNumber: 2},
+			{FunctionName: "main", FilePath: "main.cpp", LineNumber: 3},
+		}},
+	}}
+
+	ctx := tenant.InjectTenantID(context.Background(), "tenant-a")
+	s.updateAllSymbolsInProfile(profile, symbolized, map[string]int64{"": 0, "build-id": 1}, s.demangleMode(ctx))
+
+	type function struct{ name, systemName string }
+	var functions []function
+	for _, line := range profile.Location[0].Line {
+		fn := profile.Function[line.FunctionId-1]
+		functions = append(functions, function{profile.StringTable[fn.Name], profile.StringTable[fn.SystemName]})
+	}
+	// The instantiations are kept apart, by their mangled names.
+	require.Equal(t, []function{
+		{"foo::bar", "_ZN3foo3barIiEEvT_"},
+		{"foo::bar", "_ZN3foo3barIlEEvT_"},
+		{"main", "main"},
+	}, functions)
+
+	require.Equal(t, pprof.DemangleNone, s.demangleMode(context.Background()))
+}
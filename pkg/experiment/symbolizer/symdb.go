@@ -0,0 +1,116 @@
+package symbolizer
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+
+	schemav1 "github.com/grafana/pyroscope/pkg/phlaredb/schemas/v1"
+	"github.com/grafana/pyroscope/pkg/phlaredb/symdb"
+)
+
+type symdbFuncKey struct {
+	systemName, filename uint32
+}
+
+// SymbolizeSymbols symbolizes the locations of the mappings without functions
+// of a symdb partition, in place: the lines of the locations are set, and the
+// functions and the strings they refer to are appended to the symbols.
+//
+// Unlike SymbolizePprof, the locations of the binaries whose debug information
+// is not found are left unsymbolized, so they can be symbolized later, once the
+// debug information is available.
+//
+// The caller must own the locations and the mappings, and must clip the
+// capacity of the functions and the strings if they are shared.
+func (s *Symbolizer) SymbolizeSymbols(ctx context.Context, symbols *symdb.Symbols) error {
+	locationsByMapping := make(map[uint32][]uint32)
+	for i, loc := range symbols.Locations {
+		if len(loc.Line) > 0 || int(loc.MappingId) >= len(symbols.Mappings) {
+			continue
+		}
+		if symbols.Mappings[loc.MappingId].HasFunctions {
+			continue
+		}
+		locationsByMapping[loc.MappingId] = append(locationsByMapping[loc.MappingId], uint32(i))
+	}
+	if len(locationsByMapping) == 0 {
+		return nil
+	}
+
+	mappingIDs := make([]uint32, 0, len(locationsByMapping))
+	for mappingID := range locationsByMapping {
+		mappingIDs = append(mappingIDs, mappingID)
+	}
+	slices.Sort(mappingIDs)
+
+	demangle := s.demangleMode(ctx)
+	stringMap := make(map[string]uint32)
+	funcMap := make(map[symdbFuncKey]uint32)
+	str := func(v string) uint32 {
+		if v == "" {
+			return 0
+		}
+		idx, ok := stringMap[v]
+		if !ok {
+			idx = uint32(len(symbols.Strings))
+			symbols.Strings = append(symbols.Strings, v)
+			stringMap[v] = idx
+		}
+		return idx
+	}
+
+	for _, mappingID := range mappingIDs {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		mapping := &symbols.Mappings[mappingID]
+		if int(mapping.BuildId) >= len(symbols.Strings) || int(mapping.Filename) >= len(symbols.Strings) {
+			continue
+		}
+		buildID, err := sanitizeBuildID(symbols.Strings[mapping.BuildId])
+		if err != nil || buildID == "" {
+			continue
+		}
+
+		locs := locationsByMapping[mappingID]
+		req := request{
+			buildID:    buildID,
+			binaryName: filepath.Base(symbols.Strings[mapping.Filename]),
+			locations:  make([]*location, len(locs)),
+		}
+		for i, locIdx := range locs {
+			req.locations[i] = &location{address: symbols.Locations[locIdx].Address}
+		}
+		if !s.resolve(ctx, &req) {
+			continue
+		}
+
+		for i, locIdx := range locs {
+			frames := req.locations[i].lines
+			lines := make([]schemav1.InMemoryLine, len(frames))
+			for j, frame := range frames {
+				key := symdbFuncKey{systemName: str(frame.FunctionName), filename: str(frame.FilePath)}
+				funcID, ok := funcMap[key]
+				if !ok {
+					funcID = uint32(len(symbols.Functions))
+					symbols.Functions = append(symbols.Functions, schemav1.InMemoryFunction{
+						Id:         uint64(funcID),
+						Name:       str(demangle.Demangle(frame.FunctionName)),
+						SystemName: key.systemName,
+						Filename:   key.filename,
+					})
+					funcMap[key] = funcID
+				}
+				lines[j] = schemav1.InMemoryLine{
+					FunctionId: funcID,
+					Line:       int32(frame.LineNumber),
+				}
+			}
+			symbols.Locations[locIdx].Line = lines
+		}
+		mapping.HasFunctions = true
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package symbolizer
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	schemav1 "github.com/grafana/pyroscope/pkg/phlaredb/schemas/v1"
+	"github.com/grafana/pyroscope/pkg/phlaredb/symdb"
+	"github.com/grafana/pyroscope/pkg/tenant"
+	"github.com/grafana/pyroscope/pkg/test/mocks/mocksymbolizer"
+)
+
+func TestSymbolizeSymbols(t *testing.T) {
+	_, s := newDebuginfoTestHandler(t, 1<<20)
+	data, err := os.ReadFile("testdata/symbols.debug")
+	require.NoError(t, err)
+	_, err = s.UploadDebuginfo(context.Background(), "tenant-a", data)
+	require.NoError(t, err)
+
+	const missingBuildID = "deadbeef"
+	s.client.(*mocksymbolizer.MockDebuginfodClient).EXPECT().
+		FetchDebuginfo(mock.Anything, missingBuildID).
+		Return(nil, buildIDNotFoundError{buildID: missingBuildID})
+
+	symbols := &symdb.Symbols{
+		Strings: []string{"", testBuildID, "/usr/bin/app", missingBuildID, "main"},
+		Mappings: []schemav1.InMemoryMapping{
+			{},
+			{Id: 1, BuildId: 1, Filename: 2},
+			{Id: 2, BuildId: 3, Filename: 2},
+			{Id: 3, Filename: 2, HasFunctions: true},
+		},
+		Functions: []schemav1.InMemoryFunction{{Id: 0, Name: 4}},
+		Locations: []schemav1.InMemoryLocation{
+			{Id: 0, MappingId: 1, Address: 0x3c5a},
+			{Id: 1, MappingId: 2, Address: 0x3c5a},
+			{Id: 2, MappingId: 3, Address: 0x10, Line: []schemav1.InMemoryLine{{FunctionId: 0, Line: 1}}},
+		},
+	}
+
+	ctx := tenant.InjectTenantID(context.Background(), "tenant-a")
+	require.NoError(t, s.SymbolizeSymbols(ctx, symbols))
+
+	// fprintf is inlined into atoll_b.
+	lines := symbols.Locations[0].Line
+	require.Len(t, lines, 2)
+	require.Equal(t, "atoll_b", symbols.Strings[symbols.Functions[lines[1].FunctionId].Name])
+	require.NotZero(t, lines[1].Line)
+	require.True(t, symbols.Mappings[1].HasFunctions)
+
+	// The binary without debug information is left unsymbolized.
+	require.Empty(t, symbols.Locations[1].Line)
+	require.False(t, symbols.Mappings[2].HasFunctions)
+
+	// Symbolized locations are not modified.
+	require.Equal(t, []schemav1.InMemoryLine{{FunctionId: 0, Line: 1}}, symbols.Locations[2].Line)
+}
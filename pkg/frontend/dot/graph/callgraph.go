@@ -0,0 +1,34 @@
+package graph
+
+import (
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+)
+
+// NewFromCallGraph builds a graph from the call graph. Unlike graphs
+// built from profile samples, a call graph does not include residual
+// edges: calls going through the nodes that were not kept are dropped.
+// Sample value functions, tags, and the CallTree option are ignored.
+func NewFromCallGraph(cg *typesv1.CallGraph, o *Options) *Graph {
+	nm := make(NodeMap, len(cg.Nodes))
+	nodes := make(Nodes, len(cg.Nodes))
+	for i, n := range cg.Nodes {
+		node := nm.FindOrInsertNode(NodeInfo{Name: n.Name}, o.KeptNodes)
+		if node == nil {
+			continue
+		}
+		node.Flat += n.Self
+		node.Cum += n.Total
+		nodes[i] = node
+	}
+	for _, e := range cg.Edges {
+		if e.Caller < 0 || int(e.Caller) >= len(nodes) || e.Callee < 0 || int(e.Callee) >= len(nodes) {
+			continue
+		}
+		caller, callee := nodes[e.Caller], nodes[e.Callee]
+		if caller == nil || callee == nil || caller == callee {
+			continue
+		}
+		caller.AddToEdge(callee, e.Value, false, false)
+	}
+	return selectNodesForGraph(nm.nodes(), o.DropNegative)
+}
@@ -25,6 +25,7 @@ import (
 
 	"github.com/google/pprof/profile"
 
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
 	"github.com/grafana/pyroscope/pkg/frontend/dot/graph"
 	"github.com/grafana/pyroscope/pkg/frontend/dot/measurement"
 )
@@ -160,6 +161,12 @@ func (rpt *Report) selectOutputUnit(g *graph.Graph) {
 // are included, without trimming.
 func (rpt *Report) newGraph(nodes graph.NodeSet) *graph.Graph {
 	o := rpt.options
+	if rpt.callGraph != nil {
+		return graph.NewFromCallGraph(rpt.callGraph, &graph.Options{
+			DropNegative: o.DropNegative,
+			KeptNodes:    nodes,
+		})
+	}
 
 	// Clean up file paths using heuristics.
 	prof := rpt.prof
@@ -238,6 +245,14 @@ func ProfileLabels(rpt *Report) []string {
 	var label []string
 	prof := rpt.prof
 	o := rpt.options
+	if prof == nil {
+		// Reports built from a call graph
+		// only include the sample type.
+		if o.SampleType != "" {
+			label = append(label, "Type: "+o.SampleType)
+		}
+		return label
+	}
 	if len(prof.Mapping) > 0 {
 		if prof.Mapping[0].File != "" {
 			label = append(label, "File: "+filepath.Base(prof.Mapping[0].File))
@@ -343,15 +358,34 @@ func genLabel(d int, n, l, f string) string {
 // New builds a new report indexing the sample values interpreting the
 // samples with the provided function.
 func New(prof *profile.Profile, o *Options) *Report {
-	format := func(v int64) string {
+	return &Report{prof, nil, computeTotal(prof, o.SampleValue, o.SampleMeanDivisor),
+		o, valueFormatter(o)}
+}
+
+// NewCallGraph builds a new report from the call graph. The sample
+// type and unit must be specified in the options. Sample value
+// functions are ignored.
+func NewCallGraph(cg *typesv1.CallGraph, options Options) *Report {
+	o := &options
+	o.SampleUnit = strings.ToLower(o.SampleUnit)
+	// The call graph might be truncated, therefore the sum of the
+	// self values can be less than the total value of the root nodes.
+	var total, maxTotal int64
+	for _, n := range cg.Nodes {
+		total += abs64(n.Self)
+		maxTotal = max(maxTotal, abs64(n.Total))
+	}
+	return &Report{nil, cg, max(total, maxTotal), o, valueFormatter(o)}
+}
+
+func valueFormatter(o *Options) func(int64) string {
+	return func(v int64) string {
 		if r := o.Ratio; r > 0 && r != 1 {
 			fv := float64(v) * r
 			v = int64(fv)
 		}
 		return measurement.ScaledLabel(v, o.SampleUnit, o.OutputUnit)
 	}
-	return &Report{prof, computeTotal(prof, o.SampleValue, o.SampleMeanDivisor),
-		o, format}
 }
 
 // NewDefault builds a new report indexing the last sample value
@@ -405,6 +439,7 @@ func computeTotal(prof *profile.Profile, value, meanDiv func(v []int64) int64) i
 // report from a profile.
 type Report struct {
 	prof        *profile.Profile
+	callGraph   *typesv1.CallGraph
 	total       int64
 	options     *Options
 	formatValue func(int64) string
@@ -33,6 +33,7 @@ import (
 
 	"github.com/grafana/pyroscope/api/gen/proto/go/vcs/v1/vcsv1connect"
 	"github.com/grafana/pyroscope/pkg/frontend/frontendpb"
+	"github.com/grafana/pyroscope/pkg/frontend/resultscache"
 	"github.com/grafana/pyroscope/pkg/frontend/vcs"
 	"github.com/grafana/pyroscope/pkg/querier/stats"
 	"github.com/grafana/pyroscope/pkg/scheduler/schedulerdiscovery"
@@ -60,6 +61,10 @@ type Config struct {
 	// The parameter is replaced with `instance_addr`.
 	AddrOld string `yaml:"address" category:"advanced" doc:"hidden"`
 
+	ResultsCache resultscache.Config `yaml:"results_cache" doc:"description=Configures the cache of query results. Only applies to the query backend read path."`
+
+	TenantFederation bool `yaml:"tenant_federation_enabled" category:"experimental"`
+
 	// This configuration is injected internally.
 	QuerySchedulerDiscovery schedulerdiscovery.Config `yaml:"-"`
 	MaxLoopDuration         time.Duration             `yaml:"-"`
@@ -74,6 +79,8 @@ func (cfg *Config) RegisterFlags(f *flag.FlagSet, logger log.Logger) {
 	f.BoolVar(&cfg.EnableIPv6, "query-frontend.instance-enable-ipv6", false, "Enable using a IPv6 instance address. (default false)")
 	f.IntVar(&cfg.Port, "query-frontend.instance-port", 0, "Port to advertise to query-scheduler and querier (defaults to -server.http-listen-port).")
 	cfg.GRPCClientConfig.RegisterFlagsWithPrefix("query-frontend.grpc-client-config", f)
+	cfg.ResultsCache.RegisterFlagsWithPrefix("query-frontend.results-cache.", f)
+	f.BoolVar(&cfg.TenantFederation, "query-frontend.tenant-federation.enabled", false, "If enabled, queries can span multiple tenants: the tenant IDs are to be separated by '|' in the X-Scope-OrgID header. The __tenant__ label selects and groups the tenants.")
 }
 
 func (cfg *Config) Validate() error {
@@ -81,6 +88,9 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("scheduler address cannot be specified when query-scheduler service discovery mode is set to '%s'", cfg.QuerySchedulerDiscovery.Mode)
 	}
 
+	if err := cfg.ResultsCache.Validate(); err != nil {
+		return err
+	}
 	return cfg.GRPCClientConfig.Validate()
 }
 
@@ -113,6 +123,8 @@ type Limits interface {
 	MaxQueryLookback(tenantID string) time.Duration
 	QueryAnalysisEnabled(string) bool
 	SymbolizerEnabled(string) bool
+	QueryBackendLimits(string) validation.QueryBackendLimits
+	ExportLimits(string) validation.ExportLimits
 	validation.FlameGraphLimits
 }
 
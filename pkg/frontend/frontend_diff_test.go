@@ -15,6 +15,7 @@ import (
 	"github.com/grafana/pyroscope/pkg/model"
 	"github.com/grafana/pyroscope/pkg/util/connectgrpc"
 	"github.com/grafana/pyroscope/pkg/util/httpgrpc"
+	"github.com/grafana/pyroscope/pkg/validation"
 )
 
 type mockLimits struct{}
@@ -49,6 +50,14 @@ func (m *mockLimits) MaxFlameGraphNodesMax(_ string) int {
 
 func (m *mockLimits) SymbolizerEnabled(s string) bool { return true }
 
+func (m *mockLimits) QueryBackendLimits(string) validation.QueryBackendLimits {
+	return validation.QueryBackendLimits{}
+}
+
+func (m *mockLimits) ExportLimits(string) validation.ExportLimits {
+	return validation.ExportLimits{}
+}
+
 type mockRoundTripper struct {
 	callback func(ctx context.Context, req *httpgrpc.HTTPRequest) (*httpgrpc.HTTPResponse, error)
 }
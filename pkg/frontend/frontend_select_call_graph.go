@@ -0,0 +1,82 @@
+package frontend
+
+import (
+	"context"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/grafana/dskit/tenant"
+	"github.com/opentracing/opentracing-go"
+	"github.com/prometheus/common/model"
+	"golang.org/x/sync/errgroup"
+
+	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
+	"github.com/grafana/pyroscope/api/gen/proto/go/querier/v1/querierv1connect"
+	phlaremodel "github.com/grafana/pyroscope/pkg/model"
+	"github.com/grafana/pyroscope/pkg/util/connectgrpc"
+	validationutil "github.com/grafana/pyroscope/pkg/util/validation"
+	"github.com/grafana/pyroscope/pkg/validation"
+)
+
+func (f *Frontend) SelectCallGraph(
+	ctx context.Context,
+	c *connect.Request[querierv1.SelectCallGraphRequest],
+) (*connect.Response[querierv1.SelectCallGraphResponse], error) {
+	opentracing.SpanFromContext(ctx).
+		SetTag("start", model.Time(c.Msg.Start).Time().String()).
+		SetTag("end", model.Time(c.Msg.End).Time().String()).
+		SetTag("selector", c.Msg.LabelSelector).
+		SetTag("max_nodes", c.Msg.GetMaxNodes()).
+		SetTag("profile_type", c.Msg.ProfileTypeID)
+
+	ctx = connectgrpc.WithProcedure(ctx, querierv1connect.QuerierServiceSelectCallGraphProcedure)
+	tenantIDs, err := tenant.TenantIDs(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	validated, err := validation.ValidateRangeRequest(f.limits, tenantIDs, model.Interval{Start: model.Time(c.Msg.Start), End: model.Time(c.Msg.End)}, model.Now())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if validated.IsEmpty {
+		return connect.NewResponse(&querierv1.SelectCallGraphResponse{}), nil
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	if maxConcurrent := validationutil.SmallestPositiveNonZeroIntPerTenant(tenantIDs, f.limits.MaxQueryParallelism); maxConcurrent > 0 {
+		g.SetLimit(maxConcurrent)
+	}
+
+	m := phlaremodel.NewCallGraphMerger()
+	interval := validationutil.MaxDurationOrZeroPerTenant(tenantIDs, f.limits.QuerySplitDuration)
+	intervals := NewTimeIntervalIterator(time.UnixMilli(int64(validated.Start)), time.UnixMilli(int64(validated.End)), interval)
+
+	for intervals.Next() {
+		r := intervals.At()
+		g.Go(func() error {
+			// The node limit is only applied to the merged result.
+			req := connectgrpc.CloneRequest(c, &querierv1.SelectCallGraphRequest{
+				ProfileTypeID: c.Msg.ProfileTypeID,
+				LabelSelector: c.Msg.LabelSelector,
+				Start:         r.Start.UnixMilli(),
+				End:           r.End.UnixMilli(),
+			})
+			resp, err := connectgrpc.RoundTripUnary[
+				querierv1.SelectCallGraphRequest,
+				querierv1.SelectCallGraphResponse](ctx, f, req)
+			if err != nil {
+				return err
+			}
+			m.MergeCallGraph(resp.Msg.CallGraph)
+			return nil
+		})
+	}
+
+	if err = g.Wait(); err != nil {
+		return nil, err
+	}
+
+	graph := m.CallGraph(c.Msg.GetMaxNodes())
+	return connect.NewResponse(&querierv1.SelectCallGraphResponse{CallGraph: graph}), nil
+}
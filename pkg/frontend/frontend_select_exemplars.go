@@ -0,0 +1,31 @@
+package frontend
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+
+	profilev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
+	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
+	"github.com/grafana/pyroscope/api/gen/proto/go/querier/v1/querierv1connect"
+	"github.com/grafana/pyroscope/pkg/util/connectgrpc"
+)
+
+// Exemplars are not available in the ingesters and store-gateways:
+// the requests are not split, and are passed through as is.
+
+func (f *Frontend) SelectExemplars(
+	ctx context.Context,
+	c *connect.Request[querierv1.SelectExemplarsRequest],
+) (*connect.Response[querierv1.SelectExemplarsResponse], error) {
+	ctx = connectgrpc.WithProcedure(ctx, querierv1connect.QuerierServiceSelectExemplarsProcedure)
+	return connectgrpc.RoundTripUnary[querierv1.SelectExemplarsRequest, querierv1.SelectExemplarsResponse](ctx, f, c)
+}
+
+func (f *Frontend) SelectProfileByID(
+	ctx context.Context,
+	c *connect.Request[querierv1.SelectProfileByIDRequest],
+) (*connect.Response[profilev1.Profile], error) {
+	ctx = connectgrpc.WithProcedure(ctx, querierv1connect.QuerierServiceSelectProfileByIDProcedure)
+	return connectgrpc.RoundTripUnary[querierv1.SelectProfileByIDRequest, profilev1.Profile](ctx, f, c)
+}
@@ -0,0 +1,88 @@
+package frontend
+
+import (
+	"context"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/grafana/dskit/tenant"
+	"github.com/opentracing/opentracing-go"
+	"github.com/prometheus/common/model"
+	"golang.org/x/sync/errgroup"
+
+	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
+	"github.com/grafana/pyroscope/api/gen/proto/go/querier/v1/querierv1connect"
+	phlaremodel "github.com/grafana/pyroscope/pkg/model"
+	"github.com/grafana/pyroscope/pkg/util/connectgrpc"
+	validationutil "github.com/grafana/pyroscope/pkg/util/validation"
+	"github.com/grafana/pyroscope/pkg/validation"
+)
+
+func (f *Frontend) SelectFunctionDetails(
+	ctx context.Context,
+	c *connect.Request[querierv1.SelectFunctionDetailsRequest],
+) (*connect.Response[querierv1.SelectFunctionDetailsResponse], error) {
+	opentracing.SpanFromContext(ctx).
+		SetTag("start", model.Time(c.Msg.Start).Time().String()).
+		SetTag("end", model.Time(c.Msg.End).Time().String()).
+		SetTag("selector", c.Msg.LabelSelector).
+		SetTag("function_name", c.Msg.FunctionName).
+		SetTag("max_nodes", c.Msg.GetMaxNodes()).
+		SetTag("profile_type", c.Msg.ProfileTypeID)
+
+	ctx = connectgrpc.WithProcedure(ctx, querierv1connect.QuerierServiceSelectFunctionDetailsProcedure)
+	tenantIDs, err := tenant.TenantIDs(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	validated, err := validation.ValidateRangeRequest(f.limits, tenantIDs, model.Interval{Start: model.Time(c.Msg.Start), End: model.Time(c.Msg.End)}, model.Now())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if validated.IsEmpty {
+		return connect.NewResponse(&querierv1.SelectFunctionDetailsResponse{}), nil
+	}
+	maxNodes, err := validation.ValidateMaxNodes(f.limits, tenantIDs, c.Msg.GetMaxNodes())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	if maxConcurrent := validationutil.SmallestPositiveNonZeroIntPerTenant(tenantIDs, f.limits.MaxQueryParallelism); maxConcurrent > 0 {
+		g.SetLimit(maxConcurrent)
+	}
+
+	m := phlaremodel.NewFunctionDetailsMerger()
+	interval := validationutil.MaxDurationOrZeroPerTenant(tenantIDs, f.limits.QuerySplitDuration)
+	intervals := NewTimeIntervalIterator(time.UnixMilli(int64(validated.Start)), time.UnixMilli(int64(validated.End)), interval)
+
+	for intervals.Next() {
+		r := intervals.At()
+		g.Go(func() error {
+			req := connectgrpc.CloneRequest(c, &querierv1.SelectFunctionDetailsRequest{
+				ProfileTypeID: c.Msg.ProfileTypeID,
+				LabelSelector: c.Msg.LabelSelector,
+				Start:         r.Start.UnixMilli(),
+				End:           r.End.UnixMilli(),
+				FunctionName:  c.Msg.FunctionName,
+				MaxNodes:      &maxNodes,
+				Format:        querierv1.ProfileFormat_PROFILE_FORMAT_TREE,
+			})
+			resp, err := connectgrpc.RoundTripUnary[
+				querierv1.SelectFunctionDetailsRequest,
+				querierv1.SelectFunctionDetailsResponse](ctx, f, req)
+			if err != nil {
+				return err
+			}
+			return m.MergeFunctionDetailsBytes(resp.Msg.CallersTree, resp.Msg.CalleesTree, resp.Msg.Lines)
+		})
+	}
+
+	if err = g.Wait(); err != nil {
+		return nil, err
+	}
+
+	d := m.FunctionDetails()
+	return connect.NewResponse(phlaremodel.NewFunctionDetailsResponse(d, c.Msg.Format, maxNodes)), nil
+}
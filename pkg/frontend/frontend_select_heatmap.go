@@ -0,0 +1,19 @@
+package frontend
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/connect"
+
+	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
+)
+
+// SelectHeatmap is not implemented: the queriers can't build heatmaps from
+// the profiles stored by the ingesters and store-gateways.
+func (f *Frontend) SelectHeatmap(
+	context.Context,
+	*connect.Request[querierv1.SelectHeatmapRequest],
+) (*connect.Response[querierv1.SelectHeatmapResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("heatmaps are only available with the query backend"))
+}
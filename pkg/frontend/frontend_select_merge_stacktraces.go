@@ -64,6 +64,9 @@ func (f *Frontend) selectMergeStacktracesTree(
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInvalidArgument, err)
 	}
+	if _, err = phlaremodel.NewFrameFilter(c.Msg.FrameFilter); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
 
 	g, ctx := errgroup.WithContext(ctx)
 	if maxConcurrent := validationutil.SmallestPositiveNonZeroIntPerTenant(tenantIDs, f.limits.MaxQueryParallelism); maxConcurrent > 0 {
@@ -84,6 +87,7 @@ func (f *Frontend) selectMergeStacktracesTree(
 				End:           r.End.UnixMilli(),
 				MaxNodes:      &maxNodes,
 				Format:        querierv1.ProfileFormat_PROFILE_FORMAT_TREE,
+				FrameFilter:   c.Msg.FrameFilter,
 			})
 			resp, err := connectgrpc.RoundTripUnary[
 				querierv1.SelectMergeStacktracesRequest,
@@ -0,0 +1,214 @@
+package read_path
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql/parser"
+	"golang.org/x/sync/errgroup"
+
+	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+	phlaremodel "github.com/grafana/pyroscope/pkg/model"
+	"github.com/grafana/pyroscope/pkg/tenant"
+)
+
+var errTenantFederationDisabled = errors.New("queries across multiple tenants require tenant federation to be enabled")
+
+// queryTenants splits the query into per-tenant queries, and aggregates
+// the responses. Each tenant query is routed independently, therefore
+// the tenant limits and overrides apply.
+//
+// The __tenant__ label can be used to select tenants and to group the
+// results: the label is not stored and is removed from the queries.
+func queryTenants[Req, Resp any](
+	ctx context.Context,
+	router *Router,
+	tenantIDs []string,
+	req *connect.Request[Req],
+	sanitize func(a, b *Req),
+	aggregate func(a, b *Resp) (*Resp, error),
+) (*connect.Response[Resp], error) {
+	c, ok := (any)(req.Msg).(interface{ CloneVT() *Req })
+	if !ok {
+		return nil, connect.NewError(connect.CodeUnimplemented, nil)
+	}
+	requests := make(map[string]*Req, len(tenantIDs))
+	for _, tenantID := range tenantIDs {
+		r := c.CloneVT()
+		selected, err := selectTenant(r, tenantID)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		if selected {
+			requests[tenantID] = r
+		}
+	}
+	if len(requests) > 1 {
+		// Just like with split queries, the limits must
+		// be applied after the responses are aggregated.
+		for _, r := range requests {
+			sanitize(r, c.CloneVT())
+		}
+	}
+
+	responses := make([]*Resp, len(tenantIDs))
+	g, ctx := errgroup.WithContext(ctx)
+	for i, tenantID := range tenantIDs {
+		r, ok := requests[tenantID]
+		if !ok {
+			continue
+		}
+		g.Go(func() error {
+			resp, err := queryTenant(tenant.InjectTenantID(ctx, tenantID), router, tenantID, connect.NewRequest(r), sanitize, aggregate)
+			if err != nil {
+				return err
+			}
+			setTenantLabel(req.Msg, resp.Msg, tenantID)
+			responses[i] = resp.Msg
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	var resp *Resp
+	for _, r := range responses {
+		switch {
+		case r == nil:
+		case resp == nil:
+			resp = r
+		default:
+			var err error
+			if resp, err = aggregate(resp, r); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if resp == nil {
+		resp = new(Resp)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// selectTenant reports whether the request selects the tenant,
+// and removes the __tenant__ label from the request.
+func selectTenant(req any, tenantID string) (bool, error) {
+	v := reflect.ValueOf(req).Elem()
+	if f := v.FieldByName("LabelSelector"); f.IsValid() && f.Kind() == reflect.String && f.String() != "" {
+		s, ok, err := tenantSelector(f.String(), tenantID)
+		if err != nil || !ok {
+			return false, err
+		}
+		f.SetString(s)
+	}
+	if f, ok := stringsField(v, "Matchers"); ok && f.Len() > 0 {
+		m, ok, err := tenantMatchers(f.Interface().([]string), tenantID)
+		if err != nil || !ok {
+			return false, err
+		}
+		f.Set(reflect.ValueOf(m))
+	}
+	if f, ok := stringsField(v, "GroupBy"); ok {
+		groupBy := slices.DeleteFunc(slices.Clone(f.Interface().([]string)), func(n string) bool {
+			return n == phlaremodel.LabelNameTenant
+		})
+		f.Set(reflect.ValueOf(groupBy))
+	}
+	return true, nil
+}
+
+func stringsField(v reflect.Value, name string) (reflect.Value, bool) {
+	f := v.FieldByName(name)
+	return f, f.IsValid() && f.Type() == reflect.TypeOf([]string(nil))
+}
+
+// tenantMatchers returns the selectors that match the tenant.
+// If any of them selects all the series, no selectors are returned.
+func tenantMatchers(selectors []string, tenantID string) ([]string, bool, error) {
+	matching := make([]string, 0, len(selectors))
+	for _, s := range selectors {
+		x, ok, err := tenantSelector(s, tenantID)
+		if err != nil {
+			return nil, false, err
+		}
+		if !ok {
+			continue
+		}
+		if x == "{}" {
+			return nil, true, nil
+		}
+		matching = append(matching, x)
+	}
+	return matching, len(matching) > 0, nil
+}
+
+// tenantSelector reports whether the label selector matches the
+// tenant, and returns the selector without the __tenant__ matchers.
+func tenantSelector(selector string, tenantID string) (string, bool, error) {
+	matchers, err := parser.ParseMetricSelector(selector)
+	if err != nil {
+		return "", false, err
+	}
+	rest := make([]*labels.Matcher, 0, len(matchers))
+	for _, m := range matchers {
+		if m.Name != phlaremodel.LabelNameTenant {
+			rest = append(rest, m)
+			continue
+		}
+		if !m.Matches(tenantID) {
+			return "", false, nil
+		}
+	}
+	if len(rest) == len(matchers) {
+		return selector, true, nil
+	}
+	s := make([]string, len(rest))
+	for i, m := range rest {
+		s[i] = m.String()
+	}
+	return "{" + strings.Join(s, ",") + "}", true, nil
+}
+
+// setTenantLabel adds the __tenant__ label to the response,
+// if the request refers to the label.
+func setTenantLabel(req, resp any, tenantID string) {
+	switch r := resp.(type) {
+	case *typesv1.LabelNamesResponse:
+		if !slices.Contains(r.Names, phlaremodel.LabelNameTenant) {
+			r.Names = append(r.Names, phlaremodel.LabelNameTenant)
+			slices.Sort(r.Names)
+		}
+
+	case *typesv1.LabelValuesResponse:
+		if req.(*typesv1.LabelValuesRequest).Name == phlaremodel.LabelNameTenant {
+			r.Names = []string{tenantID}
+		}
+
+	case *querierv1.SeriesResponse:
+		names := req.(*querierv1.SeriesRequest).LabelNames
+		if len(names) == 0 || slices.Contains(names, phlaremodel.LabelNameTenant) {
+			for _, s := range r.LabelsSet {
+				s.Labels = phlaremodel.Labels(s.Labels).InsertSorted(phlaremodel.LabelNameTenant, tenantID)
+			}
+		}
+
+	case *querierv1.SelectSeriesResponse:
+		if slices.Contains(req.(*querierv1.SelectSeriesRequest).GroupBy, phlaremodel.LabelNameTenant) {
+			for _, s := range r.Series {
+				s.Labels = phlaremodel.Labels(s.Labels).InsertSorted(phlaremodel.LabelNameTenant, tenantID)
+			}
+		}
+
+	case *querierv1.SelectExemplarsResponse:
+		for _, e := range r.Exemplars {
+			e.Labels = phlaremodel.Labels(e.Labels).InsertSorted(phlaremodel.LabelNameTenant, tenantID)
+		}
+	}
+}
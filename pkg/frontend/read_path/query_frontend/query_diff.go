@@ -5,9 +5,12 @@ import (
 
 	"connectrpc.com/connect"
 	"github.com/grafana/dskit/tenant"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql/parser"
 	"golang.org/x/sync/errgroup"
 
 	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
+	queryv1 "github.com/grafana/pyroscope/api/gen/proto/go/query/v1"
 	phlaremodel "github.com/grafana/pyroscope/pkg/model"
 	"github.com/grafana/pyroscope/pkg/validation"
 )
@@ -32,6 +35,123 @@ func (q *QueryFrontend) Diff(
 	c.Msg.Left.MaxNodes = &maxNodes
 	c.Msg.Right.MaxNodes = &maxNodes
 
+	left, err := q.diffTreeTarget(tenantIDs, c.Msg.Left)
+	if err != nil {
+		return nil, err
+	}
+	right, err := q.diffTreeTarget(tenantIDs, c.Msg.Right)
+	if err != nil {
+		return nil, err
+	}
+	req := &queryv1.QueryRequest{
+		Query: []*queryv1.Query{{
+			QueryType: queryv1.QueryType_QUERY_DIFF_TREE,
+			DiffTree: &queryv1.DiffTreeQuery{
+				MaxNodes: maxNodes,
+				Left:     left,
+				Right:    right,
+			},
+		}},
+	}
+	// The request must select profiles of both targets. A target
+	// with an empty time range does not select any profiles.
+	switch {
+	case left == nil && right == nil:
+		return newDiffResponse(nil, nil, maxNodes)
+	case left == nil:
+		req.StartTime, req.EndTime, req.LabelSelector = right.StartTime, right.EndTime, right.LabelSelector
+		req.Query[0].DiffTree.Left = &queryv1.DiffTreeTarget{LabelSelector: right.LabelSelector}
+	case right == nil:
+		req.StartTime, req.EndTime, req.LabelSelector = left.StartTime, left.EndTime, left.LabelSelector
+		req.Query[0].DiffTree.Right = &queryv1.DiffTreeTarget{LabelSelector: left.LabelSelector}
+	default:
+		// If the time ranges do not overlap, a single query would
+		// read the blocks in between in vain.
+		if max(left.StartTime, right.StartTime) > min(left.EndTime, right.EndTime) {
+			return q.diffSeparately(ctx, c, maxNodes)
+		}
+		req.StartTime = min(left.StartTime, right.StartTime)
+		req.EndTime = max(left.EndTime, right.EndTime)
+		if req.LabelSelector, err = commonLabelSelector(left.LabelSelector, right.LabelSelector); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+	}
+
+	// Profiles can only be symbolized in the pprof format,
+	// therefore the trees have to be queried separately.
+	if q.symbolizer != nil {
+		blocks, err := q.QueryMetadata(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		if q.shouldSymbolize(tenantIDs, blocks) {
+			return q.diffSeparately(ctx, c, maxNodes)
+		}
+	}
+
+	report, err := q.querySingle(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return newDiffResponse(report.GetDiffTree().GetLeft(), report.GetDiffTree().GetRight(), maxNodes)
+}
+
+// diffTreeTarget returns the target of the diff tree query,
+// or nil, if the time range of the request is empty.
+func (q *QueryFrontend) diffTreeTarget(
+	tenantIDs []string,
+	req *querierv1.SelectMergeStacktracesRequest,
+) (*queryv1.DiffTreeTarget, error) {
+	empty, err := validation.SanitizeTimeRange(q.limits, tenantIDs, &req.Start, &req.End)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if empty {
+		return nil, nil
+	}
+	labelSelector, err := buildLabelSelectorWithProfileType(req.LabelSelector, req.ProfileTypeID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if _, err = phlaremodel.NewFrameFilter(req.FrameFilter); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	return &queryv1.DiffTreeTarget{
+		StartTime:     req.Start,
+		EndTime:       req.End,
+		LabelSelector: labelSelector,
+		FrameFilter:   req.FrameFilter,
+	}, nil
+}
+
+// commonLabelSelector returns the label selector that only
+// includes matchers present in both selectors specified.
+func commonLabelSelector(a, b string) (string, error) {
+	x, err := parser.ParseMetricSelector(a)
+	if err != nil {
+		return "", err
+	}
+	y, err := parser.ParseMetricSelector(b)
+	if err != nil {
+		return "", err
+	}
+	common := make([]*labels.Matcher, 0, len(x))
+	for _, m := range x {
+		for _, n := range y {
+			if m.String() == n.String() {
+				common = append(common, m)
+				break
+			}
+		}
+	}
+	return matchersToLabelSelector(common), nil
+}
+
+func (q *QueryFrontend) diffSeparately(
+	ctx context.Context,
+	c *connect.Request[querierv1.DiffRequest],
+	maxNodes int64,
+) (*connect.Response[querierv1.DiffResponse], error) {
 	var left, right []byte
 	g, ctx := errgroup.WithContext(ctx)
 	g.Go(func() error {
@@ -44,10 +164,13 @@ func (q *QueryFrontend) Diff(
 		right, rightErr = q.selectMergeStacktracesTree(ctx, connect.NewRequest(c.Msg.Right))
 		return rightErr
 	})
-	if err = g.Wait(); err != nil {
+	if err := g.Wait(); err != nil {
 		return nil, err
 	}
+	return newDiffResponse(left, right, maxNodes)
+}
 
+func newDiffResponse(left, right []byte, maxNodes int64) (*connect.Response[querierv1.DiffResponse], error) {
 	diff, err := phlaremodel.NewFlamegraphDiffFromBytes(left, right, maxNodes)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInvalidArgument, err)
@@ -0,0 +1,71 @@
+package query_frontend
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	"github.com/grafana/dskit/tenant"
+	"github.com/opentracing/opentracing-go"
+	"github.com/prometheus/common/model"
+
+	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
+	queryv1 "github.com/grafana/pyroscope/api/gen/proto/go/query/v1"
+	validationutil "github.com/grafana/pyroscope/pkg/util/validation"
+	"github.com/grafana/pyroscope/pkg/validation"
+)
+
+// ExportProfiles returns the raw profiles selected by the request, with
+// their samples and the resolved stack traces. The request fails, if the
+// number of profiles or samples exceeds the export limits of the tenant.
+func (q *QueryFrontend) ExportProfiles(
+	ctx context.Context,
+	req *querierv1.SelectMergeStacktracesRequest,
+) (*queryv1.ProfileExportReport, error) {
+	opentracing.SpanFromContext(ctx).
+		SetTag("start", model.Time(req.Start).Time().String()).
+		SetTag("end", model.Time(req.End).Time().String()).
+		SetTag("selector", req.LabelSelector).
+		SetTag("profile_type", req.ProfileTypeID)
+
+	tenantIDs, err := tenant.TenantIDs(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	empty, err := validation.SanitizeTimeRange(q.limits, tenantIDs, &req.Start, &req.End)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if empty {
+		return new(queryv1.ProfileExportReport), nil
+	}
+
+	maxProfiles := validationutil.SmallestPositiveNonZeroIntPerTenant(tenantIDs, func(tenant string) int {
+		return q.limits.ExportLimits(tenant).MaxProfiles
+	})
+	maxSamples := validationutil.SmallestPositiveNonZeroIntPerTenant(tenantIDs, func(tenant string) int {
+		return q.limits.ExportLimits(tenant).MaxSamples
+	})
+	labelSelector, err := buildLabelSelectorWithProfileType(req.LabelSelector, req.ProfileTypeID)
+	if err != nil {
+		return nil, err
+	}
+	report, err := q.querySingle(ctx, &queryv1.QueryRequest{
+		StartTime:     req.Start,
+		EndTime:       req.End,
+		LabelSelector: labelSelector,
+		Query: []*queryv1.Query{{
+			QueryType: queryv1.QueryType_QUERY_PROFILE_EXPORT,
+			ProfileExport: &queryv1.ProfileExportQuery{
+				MaxProfiles: int64(maxProfiles),
+				MaxSamples:  int64(maxSamples),
+			},
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if report == nil {
+		return new(queryv1.ProfileExportReport), nil
+	}
+	return report.ProfileExport, nil
+}
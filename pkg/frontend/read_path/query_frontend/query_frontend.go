@@ -6,6 +6,7 @@ import (
 	"math/rand"
 	"slices"
 	"sync"
+	"time"
 
 	googlev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
 	profilev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
@@ -18,8 +19,11 @@ import (
 	"github.com/grafana/pyroscope/pkg/model"
 	phlaremodel "github.com/grafana/pyroscope/pkg/model"
 	"github.com/grafana/pyroscope/pkg/pprof"
+	validationutil "github.com/grafana/pyroscope/pkg/util/validation"
+	"github.com/grafana/pyroscope/pkg/validation"
 
 	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
 	"github.com/grafana/dskit/tenant"
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/promql/parser"
@@ -45,6 +49,7 @@ type QueryFrontend struct {
 	tenantServiceClient metastorev1.TenantServiceClient
 	querybackend        QueryBackend
 	symbolizer          Symbolizer
+	resultsCache        *ResultsCache
 }
 
 func NewQueryFrontend(
@@ -54,6 +59,7 @@ func NewQueryFrontend(
 	tenantServiceClient metastorev1.TenantServiceClient,
 	querybackendClient QueryBackend,
 	sym Symbolizer,
+	resultsCache *ResultsCache,
 ) *QueryFrontend {
 	return &QueryFrontend{
 		logger:              logger,
@@ -62,6 +68,7 @@ func NewQueryFrontend(
 		tenantServiceClient: tenantServiceClient,
 		querybackend:        querybackendClient,
 		symbolizer:          sym,
+		resultsCache:        resultsCache,
 	}
 }
 
@@ -79,6 +86,21 @@ func (q *QueryFrontend) Query(
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
+	if q.resultsCache != nil && isCacheable(req) {
+		parallelism := validationutil.SmallestPositiveNonZeroIntPerTenant(tenants, q.limits.MaxQueryParallelism)
+		return q.resultsCache.query(ctx, tenants, req, parallelism, q.query)
+	}
+	return q.query(ctx, req)
+}
+
+func (q *QueryFrontend) query(
+	ctx context.Context,
+	req *queryv1.QueryRequest,
+) (*queryv1.QueryResponse, error) {
+	tenants, err := tenant.TenantIDs(ctx)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 
 	blocks, err := q.QueryMetadata(ctx, req)
 	if err != nil {
@@ -103,13 +125,20 @@ func (q *QueryFrontend) Query(
 	for i, originalQuery := range req.Query {
 		modifiedQueries[i] = originalQuery.CloneVT()
 
-		// If we need symbolization and this is a TREE query, convert it to PPROF
-		if shouldSymbolize && originalQuery.QueryType == queryv1.QueryType_QUERY_TREE {
+		// If we need symbolization and this is a TREE, TOP_TABLE,
+		// CALL_GRAPH, or FUNCTION_DETAILS query, convert it to PPROF
+		if shouldSymbolize && (originalQuery.QueryType == queryv1.QueryType_QUERY_TREE ||
+			originalQuery.QueryType == queryv1.QueryType_QUERY_TOP_TABLE ||
+			originalQuery.QueryType == queryv1.QueryType_QUERY_CALL_GRAPH ||
+			originalQuery.QueryType == queryv1.QueryType_QUERY_FUNCTION_DETAILS) {
 			modifiedQueries[i].QueryType = queryv1.QueryType_QUERY_PPROF
 			modifiedQueries[i].Pprof = &queryv1.PprofQuery{
 				MaxNodes: 0,
 			}
 			modifiedQueries[i].Tree = nil
+			modifiedQueries[i].TopTable = nil
+			modifiedQueries[i].CallGraph = nil
+			modifiedQueries[i].FunctionDetails = nil
 		}
 	}
 
@@ -118,7 +147,7 @@ func (q *QueryFrontend) Query(
 		StartTime:     req.StartTime,
 		EndTime:       req.EndTime,
 		LabelSelector: req.LabelSelector,
-		Options:       &queryv1.InvokeOptions{},
+		Options:       q.invokeOptions(tenants),
 		QueryPlan:     p,
 		Query:         modifiedQueries,
 	})
@@ -133,15 +162,62 @@ func (q *QueryFrontend) Query(
 		}
 	}
 
+	for _, r := range resp.Reports {
+		if r.TopTable != nil {
+			// Partial top table reports are never truncated,
+			// therefore the limit is to be applied here.
+			r.TopTable.Entries = model.SortTopTable(
+				r.TopTable.Entries,
+				r.TopTable.Query.GetOrderBy(),
+				r.TopTable.Query.GetLimit(),
+			)
+		}
+		if r.CallGraph != nil {
+			// Likewise, partial call graphs are not truncated.
+			r.CallGraph.CallGraph = model.TruncateCallGraph(
+				r.CallGraph.CallGraph,
+				r.CallGraph.Query.GetMaxNodes(),
+			)
+		}
+	}
+
 	// TODO(kolesnikovae): Extend diagnostics
 	if resp.Diagnostics == nil {
 		resp.Diagnostics = new(queryv1.Diagnostics)
 	}
 
 	resp.Diagnostics.QueryPlan = p
+	if stats := resp.Diagnostics.Stats; stats != nil {
+		level.Debug(q.logger).Log(
+			"msg", "query executed",
+			"blocks_read", stats.BlocksRead,
+			"datasets_read", stats.DatasetsRead,
+			"bytes_read", stats.BytesRead,
+			"series_read", stats.SeriesRead,
+		)
+	}
 	return &queryv1.QueryResponse{Reports: resp.Reports}, nil
 }
 
+// invokeOptions returns the query options with the resource limits:
+// the most restrictive limit of the tenants is applied.
+func (q *QueryFrontend) invokeOptions(tenants []string) *queryv1.InvokeOptions {
+	limit := func(f func(validation.QueryBackendLimits) int) int64 {
+		return int64(validationutil.SmallestPositiveNonZeroIntPerTenant(tenants, func(tenant string) int {
+			return f(q.limits.QueryBackendLimits(tenant))
+		}))
+	}
+	maxWallTime := validationutil.SmallestPositiveNonZeroDurationPerTenant(tenants, func(tenant string) time.Duration {
+		return time.Duration(q.limits.QueryBackendLimits(tenant).MaxWallTime)
+	})
+	return &queryv1.InvokeOptions{
+		MaxBlocks:     limit(func(l validation.QueryBackendLimits) int { return l.MaxBlocks }),
+		MaxBytesRead:  limit(func(l validation.QueryBackendLimits) int { return l.MaxBytesRead }),
+		MaxSeries:     limit(func(l validation.QueryBackendLimits) int { return l.MaxSeries }),
+		MaxWallTimeMs: maxWallTime.Milliseconds(),
+	}
+}
+
 func (q *QueryFrontend) QueryMetadata(
 	ctx context.Context,
 	req *queryv1.QueryRequest,
@@ -244,8 +320,14 @@ func (q *QueryFrontend) processAndSymbolizeProfiles(
 			return fmt.Errorf("failed to symbolize profile: %w", err)
 		}
 
-		// Convert back to tree if originally a tree
-		if i < len(originalQueries) && originalQueries[i].QueryType == queryv1.QueryType_QUERY_TREE {
+		var queryType queryv1.QueryType
+		if i < len(originalQueries) {
+			queryType = originalQueries[i].QueryType
+		}
+
+		switch queryType {
+		case queryv1.QueryType_QUERY_TREE:
+			// Convert back to tree if originally a tree
 			treeBytes, err := model.TreeFromBackendProfile(&prof, originalQueries[i].Tree.MaxNodes)
 			if err != nil {
 				return fmt.Errorf("failed to build tree: %w", err)
@@ -253,7 +335,48 @@ func (q *QueryFrontend) processAndSymbolizeProfiles(
 			r.Tree = &queryv1.TreeReport{Tree: treeBytes}
 			r.ReportType = queryv1.ReportType_REPORT_TREE
 			r.Pprof = nil
-		} else {
+
+		case queryv1.QueryType_QUERY_TOP_TABLE:
+			// Sample counts are not available in the profile.
+			tree, err := treeFromSymbolizedProfile(&prof)
+			if err != nil {
+				return err
+			}
+			r.TopTable = &queryv1.TopTableReport{
+				Query:   originalQueries[i].TopTable.CloneVT(),
+				Entries: model.TopTableFromTree(tree),
+			}
+			r.ReportType = queryv1.ReportType_REPORT_TOP_TABLE
+			r.Pprof = nil
+
+		case queryv1.QueryType_QUERY_CALL_GRAPH:
+			tree, err := treeFromSymbolizedProfile(&prof)
+			if err != nil {
+				return err
+			}
+			r.CallGraph = &queryv1.CallGraphReport{
+				Query:     originalQueries[i].CallGraph.CloneVT(),
+				CallGraph: model.CallGraphFromTree(tree),
+			}
+			r.ReportType = queryv1.ReportType_REPORT_CALL_GRAPH
+			r.Pprof = nil
+
+		case queryv1.QueryType_QUERY_FUNCTION_DETAILS:
+			query := originalQueries[i].FunctionDetails
+			d, err := model.FunctionDetailsFromProfile(&prof, query.GetFunctionName(), query.GetMaxNodes())
+			if err != nil {
+				return fmt.Errorf("failed to build function details: %w", err)
+			}
+			r.FunctionDetails = &queryv1.FunctionDetailsReport{
+				Query:   query.CloneVT(),
+				Callers: d.Callers.Bytes(query.GetMaxNodes()),
+				Callees: d.Callees.Bytes(query.GetMaxNodes()),
+				Lines:   d.Lines,
+			}
+			r.ReportType = queryv1.ReportType_REPORT_FUNCTION_DETAILS
+			r.Pprof = nil
+
+		default:
 			symbolizedBytes, err := pprof.Marshal(&prof, true)
 			if err != nil {
 				return fmt.Errorf("failed to marshal symbolized profile: %w", err)
@@ -264,3 +387,15 @@ func (q *QueryFrontend) processAndSymbolizeProfiles(
 
 	return nil
 }
+
+func treeFromSymbolizedProfile(prof *profilev1.Profile) (*model.Tree, error) {
+	treeBytes, err := model.TreeFromBackendProfile(prof, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build tree: %w", err)
+	}
+	tree, err := model.UnmarshalTree(treeBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build tree: %w", err)
+	}
+	return tree, nil
+}
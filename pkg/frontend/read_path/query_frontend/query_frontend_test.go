@@ -4,21 +4,27 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
+	"connectrpc.com/connect"
 	"github.com/go-kit/log"
 	"github.com/grafana/dskit/user"
+	"github.com/opentracing/opentracing-go"
+	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	profilev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
 	metastorev1 "github.com/grafana/pyroscope/api/gen/proto/go/metastore/v1"
+	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
 	queryv1 "github.com/grafana/pyroscope/api/gen/proto/go/query/v1"
 	"github.com/grafana/pyroscope/pkg/experiment/block/metadata"
 	"github.com/grafana/pyroscope/pkg/tenant"
 	"github.com/grafana/pyroscope/pkg/test/mocks/mockfrontend"
 	"github.com/grafana/pyroscope/pkg/test/mocks/mockmetastorev1"
 	"github.com/grafana/pyroscope/pkg/test/mocks/mockquery_frontend"
+	"github.com/grafana/pyroscope/pkg/validation"
 )
 
 func Test_QueryFrontend_QueryMetadata(t *testing.T) {
@@ -142,6 +148,7 @@ func TestQueryFrontendSymbolization(t *testing.T) {
 			mockLimits := mockfrontend.NewMockLimits(t)
 			mockSymbolizer := mockquery_frontend.NewMockSymbolizer(t)
 			tt.setupMocks(mockLimits, mockSymbolizer)
+			mockLimits.On("QueryBackendLimits", tt.tenantID).Return(validation.QueryBackendLimits{})
 
 			mockQueryBackend := mockquery_frontend.NewMockQueryBackend(t)
 			mockQueryBackend.On("Invoke", mock.Anything, mock.Anything).Return(&queryv1.InvokeResponse{
@@ -176,6 +183,7 @@ func TestQueryFrontendSymbolization(t *testing.T) {
 				nil,
 				mockQueryBackend,
 				mockSymbolizer,
+				nil,
 			)
 
 			ctx := tenant.InjectTenantID(context.Background(), tt.tenantID)
@@ -196,6 +204,28 @@ func TestQueryFrontendSymbolization(t *testing.T) {
 	}
 }
 
+func Test_QueryFrontend_InvokeOptions(t *testing.T) {
+	mockLimits := mockfrontend.NewMockLimits(t)
+	mockLimits.On("QueryBackendLimits", "tenant-a").Return(validation.QueryBackendLimits{
+		MaxBlocks:    10,
+		MaxBytesRead: 100 << 20,
+		MaxWallTime:  model.Duration(time.Minute),
+	})
+	mockLimits.On("QueryBackendLimits", "tenant-b").Return(validation.QueryBackendLimits{
+		MaxBlocks:   20,
+		MaxSeries:   1000,
+		MaxWallTime: model.Duration(30 * time.Second),
+	})
+
+	qf := NewQueryFrontend(log.NewNopLogger(), mockLimits, nil, nil, nil, nil, nil)
+	assert.Equal(t, &queryv1.InvokeOptions{
+		MaxBlocks:     10,
+		MaxBytesRead:  100 << 20,
+		MaxSeries:     1000,
+		MaxWallTimeMs: 30000,
+	}, qf.invokeOptions([]string{"tenant-a", "tenant-b"}))
+}
+
 func createProfile(t *testing.T) []byte {
 	t.Helper()
 
@@ -221,3 +251,79 @@ func createProfile(t *testing.T) []byte {
 	require.NoError(t, err)
 	return bytes
 }
+
+func Test_commonLabelSelector(t *testing.T) {
+	s, err := commonLabelSelector(
+		`{service_name="a",env="prod",__profile_type__="cpu"}`,
+		`{service_name="a",env="dev",__profile_type__="cpu"}`,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, `{service_name="a",__profile_type__="cpu"}`, s)
+
+	s, err = commonLabelSelector(`{service_name="a"}`, `{service_name="b"}`)
+	require.NoError(t, err)
+	assert.Equal(t, `{}`, s)
+}
+
+func Test_QueryFrontend_Diff_TimeRanges(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		right    [2]int64
+		expected [][2]int64
+	}{
+		{
+			name:     "overlapping",
+			right:    [2]int64{1500, 3000},
+			expected: [][2]int64{{1000, 3000}},
+		},
+		{
+			name:     "adjacent",
+			right:    [2]int64{2000, 3000},
+			expected: [][2]int64{{1000, 3000}},
+		},
+		{
+			name:     "disjoint",
+			right:    [2]int64{5000, 6000},
+			expected: [][2]int64{{1000, 2000}, {5000, 6000}},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			mockLimits := mockfrontend.NewMockLimits(t)
+			mockLimits.On("MaxQueryLength", "tenant").Return(time.Duration(0)).Maybe()
+			mockLimits.On("MaxQueryLookback", "tenant").Return(time.Duration(0)).Maybe()
+			mockLimits.On("MaxFlameGraphNodesMax", "tenant").Return(0).Maybe()
+
+			mockMetadataClient := new(mockmetastorev1.MockMetadataQueryServiceClient)
+			var ranges [][2]int64
+			mockMetadataClient.On("QueryMetadata", mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					req := args.Get(1).(*metastorev1.QueryMetadataRequest)
+					ranges = append(ranges, [2]int64{req.StartTime, req.EndTime})
+				}).
+				Return(&metastorev1.QueryMetadataResponse{}, nil)
+
+			qf := NewQueryFrontend(log.NewNopLogger(), mockLimits, mockMetadataClient, nil, nil, nil, nil)
+			_, ctx := opentracing.StartSpanFromContext(context.Background(), "test")
+			ctx = tenant.InjectTenantID(ctx, "tenant")
+			maxNodes := int64(10)
+			_, err := qf.Diff(ctx, connect.NewRequest(&querierv1.DiffRequest{
+				Left: &querierv1.SelectMergeStacktracesRequest{
+					ProfileTypeID: "process_cpu:cpu:nanoseconds:cpu:nanoseconds",
+					LabelSelector: `{service_name="a"}`,
+					Start:         1000,
+					End:           2000,
+					MaxNodes:      &maxNodes,
+				},
+				Right: &querierv1.SelectMergeStacktracesRequest{
+					ProfileTypeID: "process_cpu:cpu:nanoseconds:cpu:nanoseconds",
+					LabelSelector: `{service_name="a"}`,
+					Start:         test.right[0],
+					End:           test.right[1],
+					MaxNodes:      &maxNodes,
+				},
+			}))
+			require.NoError(t, err)
+			assert.ElementsMatch(t, test.expected, ranges)
+		})
+	}
+}
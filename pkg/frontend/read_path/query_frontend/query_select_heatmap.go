@@ -0,0 +1,79 @@
+package query_frontend
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/grafana/dskit/tenant"
+	"github.com/opentracing/opentracing-go"
+	"github.com/prometheus/common/model"
+
+	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
+	queryv1 "github.com/grafana/pyroscope/api/gen/proto/go/query/v1"
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+	phlaremodel "github.com/grafana/pyroscope/pkg/model"
+	"github.com/grafana/pyroscope/pkg/validation"
+)
+
+func (q *QueryFrontend) SelectHeatmap(
+	ctx context.Context,
+	c *connect.Request[querierv1.SelectHeatmapRequest],
+) (*connect.Response[querierv1.SelectHeatmapResponse], error) {
+	opentracing.SpanFromContext(ctx).
+		SetTag("start", model.Time(c.Msg.Start).Time().String()).
+		SetTag("end", model.Time(c.Msg.End).Time().String()).
+		SetTag("selector", c.Msg.LabelSelector).
+		SetTag("step", c.Msg.Step).
+		SetTag("value_resolution", c.Msg.ValueResolution).
+		SetTag("profile_type", c.Msg.ProfileTypeID)
+
+	tenantIDs, err := tenant.TenantIDs(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if c.Msg.Step <= 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("step must be positive"))
+	}
+	resolution, err := phlaremodel.HeatmapValueResolution(c.Msg.ValueResolution)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	step := time.Duration(c.Msg.Step * float64(time.Second)).Milliseconds()
+	empty, err := validation.SanitizeTimeRange(q.limits, tenantIDs, &c.Msg.Start, &c.Msg.End)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if empty {
+		return newHeatmapResponse(phlaremodel.NewHeatmapBuilder(step, resolution).Build()), nil
+	}
+
+	labelSelector, err := buildLabelSelectorWithProfileType(c.Msg.LabelSelector, c.Msg.ProfileTypeID)
+	if err != nil {
+		return nil, err
+	}
+	report, err := q.querySingle(ctx, &queryv1.QueryRequest{
+		StartTime:     c.Msg.Start,
+		EndTime:       c.Msg.End,
+		LabelSelector: labelSelector,
+		Query: []*queryv1.Query{{
+			QueryType: queryv1.QueryType_QUERY_HEATMAP,
+			Heatmap: &queryv1.HeatmapQuery{
+				Step:            c.Msg.Step,
+				ValueResolution: resolution,
+			},
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if report == nil {
+		return newHeatmapResponse(phlaremodel.NewHeatmapBuilder(step, resolution).Build()), nil
+	}
+	return newHeatmapResponse(report.Heatmap.Heatmap), nil
+}
+
+func newHeatmapResponse(h *typesv1.Heatmap) *connect.Response[querierv1.SelectHeatmapResponse] {
+	return connect.NewResponse(&querierv1.SelectHeatmapResponse{Heatmap: h})
+}
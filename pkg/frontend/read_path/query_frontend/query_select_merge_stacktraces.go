@@ -67,13 +67,19 @@ func (q *QueryFrontend) selectMergeStacktracesTree(
 	if err != nil {
 		return nil, err
 	}
+	if _, err = phlaremodel.NewFrameFilter(c.Msg.FrameFilter); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
 	report, err := q.querySingle(ctx, &queryv1.QueryRequest{
 		StartTime:     c.Msg.Start,
 		EndTime:       c.Msg.End,
 		LabelSelector: labelSelector,
 		Query: []*queryv1.Query{{
 			QueryType: queryv1.QueryType_QUERY_TREE,
-			Tree:      &queryv1.TreeQuery{MaxNodes: maxNodes},
+			Tree: &queryv1.TreeQuery{
+				MaxNodes:    maxNodes,
+				FrameFilter: c.Msg.FrameFilter,
+			},
 		}},
 	})
 	if err != nil {
@@ -0,0 +1,82 @@
+package query_frontend
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/grafana/dskit/tenant"
+	"github.com/opentracing/opentracing-go"
+	"github.com/prometheus/common/model"
+
+	profilev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
+	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
+	queryv1 "github.com/grafana/pyroscope/api/gen/proto/go/query/v1"
+	"github.com/grafana/pyroscope/pkg/pprof"
+	"github.com/grafana/pyroscope/pkg/validation"
+)
+
+var errProfileNotFound = errors.New("profile not found")
+
+func (q *QueryFrontend) SelectProfileByID(
+	ctx context.Context,
+	c *connect.Request[querierv1.SelectProfileByIDRequest],
+) (*connect.Response[profilev1.Profile], error) {
+	opentracing.SpanFromContext(ctx).
+		SetTag("start", model.Time(c.Msg.Start).Time().String()).
+		SetTag("end", model.Time(c.Msg.End).Time().String()).
+		SetTag("selector", c.Msg.LabelSelector).
+		SetTag("profile_id", c.Msg.ProfileId).
+		SetTag("profile_type", c.Msg.ProfileTypeID)
+
+	tenantIDs, err := tenant.TenantIDs(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if _, err = uuid.Parse(c.Msg.ProfileId); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	empty, err := validation.SanitizeTimeRange(q.limits, tenantIDs, &c.Msg.Start, &c.Msg.End)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if empty {
+		return nil, connect.NewError(connect.CodeNotFound, errProfileNotFound)
+	}
+
+	selector := c.Msg.LabelSelector
+	if selector == "" {
+		selector = "{}"
+	}
+	labelSelector, err := buildLabelSelectorWithProfileType(selector, c.Msg.ProfileTypeID)
+	if err != nil {
+		return nil, err
+	}
+	report, err := q.querySingle(ctx, &queryv1.QueryRequest{
+		StartTime:     c.Msg.Start,
+		EndTime:       c.Msg.End,
+		LabelSelector: labelSelector,
+		Query: []*queryv1.Query{{
+			QueryType: queryv1.QueryType_QUERY_PPROF,
+			Pprof: &queryv1.PprofQuery{
+				ProfileIdSelector: []string{c.Msg.ProfileId},
+			},
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if report == nil {
+		return nil, connect.NewError(connect.CodeNotFound, errProfileNotFound)
+	}
+	var p profilev1.Profile
+	if err = pprof.Unmarshal(report.Pprof.Pprof, &p); err != nil {
+		return nil, err
+	}
+	if len(p.Sample) == 0 {
+		return nil, connect.NewError(connect.CodeNotFound, errProfileNotFound)
+	}
+
+	return connect.NewResponse(&p), nil
+}
@@ -0,0 +1,64 @@
+package query_frontend
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	"github.com/grafana/dskit/tenant"
+	"github.com/opentracing/opentracing-go"
+	"github.com/prometheus/common/model"
+
+	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
+	queryv1 "github.com/grafana/pyroscope/api/gen/proto/go/query/v1"
+	phlaremodel "github.com/grafana/pyroscope/pkg/model"
+	"github.com/grafana/pyroscope/pkg/validation"
+)
+
+func (q *QueryFrontend) SelectTopTable(
+	ctx context.Context,
+	c *connect.Request[querierv1.SelectTopTableRequest],
+) (*connect.Response[querierv1.SelectTopTableResponse], error) {
+	opentracing.SpanFromContext(ctx).
+		SetTag("start", model.Time(c.Msg.Start).Time().String()).
+		SetTag("end", model.Time(c.Msg.End).Time().String()).
+		SetTag("selector", c.Msg.LabelSelector).
+		SetTag("limit", c.Msg.Limit).
+		SetTag("profile_type", c.Msg.ProfileTypeID)
+
+	tenantIDs, err := tenant.TenantIDs(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	empty, err := validation.SanitizeTimeRange(q.limits, tenantIDs, &c.Msg.Start, &c.Msg.End)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if empty {
+		return connect.NewResponse(&querierv1.SelectTopTableResponse{}), nil
+	}
+
+	labelSelector, err := buildLabelSelectorWithProfileType(c.Msg.LabelSelector, c.Msg.ProfileTypeID)
+	if err != nil {
+		return nil, err
+	}
+	report, err := q.querySingle(ctx, &queryv1.QueryRequest{
+		StartTime:     c.Msg.Start,
+		EndTime:       c.Msg.End,
+		LabelSelector: labelSelector,
+		Query: []*queryv1.Query{{
+			QueryType: queryv1.QueryType_QUERY_TOP_TABLE,
+			TopTable: &queryv1.TopTableQuery{
+				Limit:   c.Msg.Limit,
+				OrderBy: c.Msg.OrderBy,
+			},
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if report == nil {
+		return connect.NewResponse(&querierv1.SelectTopTableResponse{}), nil
+	}
+	entries := phlaremodel.SortTopTable(report.TopTable.Entries, c.Msg.OrderBy, c.Msg.Limit)
+	return connect.NewResponse(&querierv1.SelectTopTableResponse{Entries: entries}), nil
+}
@@ -0,0 +1,301 @@
+package query_frontend
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/grafana/dskit/cache"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sync/errgroup"
+
+	queryv1 "github.com/grafana/pyroscope/api/gen/proto/go/query/v1"
+	querybackend "github.com/grafana/pyroscope/pkg/experiment/query_backend"
+	"github.com/grafana/pyroscope/pkg/frontend"
+	"github.com/grafana/pyroscope/pkg/frontend/resultscache"
+	phlaremodel "github.com/grafana/pyroscope/pkg/model"
+)
+
+// The version is to be bumped when the format of
+// the cached reports or the key changes.
+const resultsCacheKeyPrefix = "qf:v2:"
+
+// ResultsCache caches results of the queries that can be split by time,
+// and merged: trees and time series. The query time range is split into
+// intervals aligned to multiples of the split interval, and reports of
+// the intervals older than the ingestion delay are cached in the
+// serialized form. Intervals missing in the cache are queried, and all
+// the partial reports are merged together. The trees of the intervals
+// are not truncated: the merged tree is truncated as the query requests.
+type ResultsCache struct {
+	logger         log.Logger
+	cache          cache.Cache
+	splitInterval  time.Duration
+	ingestionDelay time.Duration
+	ttl            time.Duration
+	now            func() time.Time
+}
+
+// NewResultsCache creates the results cache. If no cache
+// backend is configured, the function returns nil.
+func NewResultsCache(cfg resultscache.Config, logger log.Logger, reg prometheus.Registerer) (*ResultsCache, error) {
+	c, err := resultscache.New(cfg, logger, reg)
+	if err != nil || c == nil {
+		return nil, err
+	}
+	return newResultsCache(c, cfg, logger), nil
+}
+
+func newResultsCache(c cache.Cache, cfg resultscache.Config, logger log.Logger) *ResultsCache {
+	return &ResultsCache{
+		logger:         logger,
+		cache:          c,
+		splitInterval:  cfg.SplitInterval,
+		ingestionDelay: cfg.IngestionDelay,
+		ttl:            cfg.TTL,
+		now:            time.Now,
+	}
+}
+
+type queryFunc func(context.Context, *queryv1.QueryRequest) (*queryv1.QueryResponse, error)
+
+type resultsCacheInterval struct {
+	// The time range of the report.
+	start, end int64
+	request    *queryv1.QueryRequest
+	key        string
+	report     *queryv1.Report
+}
+
+func isCacheable(req *queryv1.QueryRequest) bool {
+	if len(req.Query) != 1 {
+		return false
+	}
+	switch req.Query[0].QueryType {
+	case queryv1.QueryType_QUERY_TREE,
+		queryv1.QueryType_QUERY_TIME_SERIES:
+		return true
+	}
+	return false
+}
+
+func (c *ResultsCache) query(
+	ctx context.Context,
+	tenants []string,
+	req *queryv1.QueryRequest,
+	parallelism int,
+	fn queryFunc,
+) (*queryv1.QueryResponse, error) {
+	intervals, ok := c.intervals(tenants, req)
+	if !ok {
+		return fn(ctx, req)
+	}
+
+	keys := make([]string, 0, len(intervals))
+	for _, x := range intervals {
+		if x.key != "" {
+			keys = append(keys, x.key)
+		}
+	}
+	var found map[string][]byte
+	if len(keys) > 0 {
+		found = c.cache.Fetch(ctx, keys)
+	}
+
+	t := req.Query[0].QueryType
+	var mu sync.Mutex
+	store := make(map[string][]byte)
+	g, ctx := errgroup.WithContext(ctx)
+	if parallelism > 0 {
+		g.SetLimit(parallelism)
+	}
+	for _, x := range intervals {
+		if b, ok := found[x.key]; ok {
+			report := new(queryv1.Report)
+			err := report.UnmarshalVT(b)
+			if err == nil {
+				x.report = report
+				continue
+			}
+			level.Warn(c.logger).Log("msg", "failed to decode cached report", "err", err)
+		}
+		g.Go(func() error {
+			resp, err := fn(ctx, x.request)
+			if err != nil {
+				return err
+			}
+			// The report is to be cached even if it is empty.
+			x.report = &queryv1.Report{ReportType: querybackend.QueryReportType(t)}
+			for _, r := range resp.Reports {
+				if r.ReportType == x.report.ReportType {
+					x.report = r
+					break
+				}
+			}
+			trimReport(x.report, x.start, x.end)
+			if x.key == "" {
+				return nil
+			}
+			b, err := x.report.MarshalVT()
+			if err != nil {
+				return err
+			}
+			mu.Lock()
+			store[x.key] = b
+			mu.Unlock()
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	if len(store) > 0 {
+		c.cache.StoreAsync(store, c.ttl)
+	}
+
+	report, err := mergeReports(req.Query[0], intervals)
+	if err != nil {
+		return nil, err
+	}
+	if report == nil {
+		return new(queryv1.QueryResponse), nil
+	}
+	return &queryv1.QueryResponse{Reports: []*queryv1.Report{report}}, nil
+}
+
+// intervals splits the request time range. The function returns false,
+// if the request can't be split in a way that the partial reports could
+// be merged without loss of precision.
+func (c *ResultsCache) intervals(tenants []string, req *queryv1.QueryRequest) ([]*resultsCacheInterval, bool) {
+	query := req.Query[0]
+	// Time series points are spaced by the step; the first point
+	// aggregates profiles of the step preceding the start time,
+	// therefore the actual start time is shifted by the step.
+	var step int64
+	start := req.StartTime
+	if query.QueryType == queryv1.QueryType_QUERY_TIME_SERIES {
+		step = time.Duration(query.TimeSeries.GetStep() * float64(time.Second)).Milliseconds()
+		if step <= 0 {
+			return nil, false
+		}
+		// Interval boundaries must match the points.
+		start += step
+		if start%step != 0 || c.splitInterval.Milliseconds()%step != 0 {
+			return nil, false
+		}
+	}
+	if start > req.EndTime {
+		return nil, false
+	}
+	if query.QueryType == queryv1.QueryType_QUERY_TREE {
+		// The truncated trees of the intervals would not merge into
+		// the tree of the whole time range. This also allows queries
+		// that only differ in the number of nodes to share the cache.
+		query = query.CloneVT()
+		query.Tree.MaxNodes = 0
+	}
+
+	queryBytes, err := query.MarshalVT()
+	if err != nil {
+		return nil, false
+	}
+	cacheBefore := c.now().Add(-c.ingestionDelay).UnixMilli()
+	var intervals []*resultsCacheInterval
+	it := frontend.NewTimeIntervalIterator(time.UnixMilli(start), time.UnixMilli(req.EndTime), c.splitInterval)
+	for it.Next() {
+		r := it.At()
+		x := &resultsCacheInterval{
+			start:   r.Start.UnixMilli(),
+			end:     r.End.UnixMilli(),
+			request: req.CloneVT(),
+		}
+		x.request.StartTime = x.start - step
+		x.request.EndTime = x.end
+		x.request.Query[0] = query
+		if x.end < cacheBefore {
+			x.key = resultsCacheKey(tenants, req.LabelSelector, queryBytes, x.start, x.end)
+		}
+		intervals = append(intervals, x)
+	}
+	return intervals, len(intervals) > 0
+}
+
+func resultsCacheKey(tenants []string, selector string, query []byte, start, end int64) string {
+	h := sha256.New()
+	_, _ = h.Write([]byte(strings.Join(tenants, ",")))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(selector))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write(query)
+	var b [16]byte
+	binary.LittleEndian.PutUint64(b[:8], uint64(start))
+	binary.LittleEndian.PutUint64(b[8:], uint64(end))
+	_, _ = h.Write(b[:])
+	return resultsCacheKeyPrefix + hex.EncodeToString(h.Sum(nil))
+}
+
+// trimReport removes time series points outside the interval:
+// the first point of the partial series precedes the interval.
+func trimReport(r *queryv1.Report, start, end int64) {
+	if r.TimeSeries == nil {
+		return
+	}
+	series := r.TimeSeries.TimeSeries[:0]
+	for _, s := range r.TimeSeries.TimeSeries {
+		points := s.Points[:0]
+		for _, p := range s.Points {
+			if start <= p.Timestamp && p.Timestamp <= end {
+				points = append(points, p)
+			}
+		}
+		if s.Points = points; len(points) > 0 {
+			series = append(series, s)
+		}
+	}
+	r.TimeSeries.TimeSeries = series
+}
+
+func mergeReports(query *queryv1.Query, intervals []*resultsCacheInterval) (*queryv1.Report, error) {
+	switch query.QueryType {
+	case queryv1.QueryType_QUERY_TREE:
+		m := phlaremodel.NewTreeMerger()
+		for _, x := range intervals {
+			if err := m.MergeTreeBytes(x.report.GetTree().GetTree()); err != nil {
+				return nil, err
+			}
+		}
+		if m.IsEmpty() {
+			return nil, nil
+		}
+		return &queryv1.Report{
+			ReportType: queryv1.ReportType_REPORT_TREE,
+			Tree: &queryv1.TreeReport{
+				Query: query.Tree.CloneVT(),
+				Tree:  m.Tree().Bytes(query.Tree.GetMaxNodes()),
+			},
+		}, nil
+
+	case queryv1.QueryType_QUERY_TIME_SERIES:
+		m := phlaremodel.NewTimeSeriesMerger(true)
+		for _, x := range intervals {
+			m.MergeTimeSeries(x.report.GetTimeSeries().GetTimeSeries())
+		}
+		if m.IsEmpty() {
+			return nil, nil
+		}
+		return &queryv1.Report{
+			ReportType: queryv1.ReportType_REPORT_TIME_SERIES,
+			TimeSeries: &queryv1.TimeSeriesReport{
+				Query:      query.TimeSeries.CloneVT(),
+				TimeSeries: m.TimeSeries(),
+			},
+		}, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,150 @@
+package query_frontend
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/grafana/dskit/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	queryv1 "github.com/grafana/pyroscope/api/gen/proto/go/query/v1"
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+	"github.com/grafana/pyroscope/pkg/frontend/resultscache"
+	phlaremodel "github.com/grafana/pyroscope/pkg/model"
+)
+
+type resultsCacheQueryRecorder struct {
+	sync.Mutex
+	requests [][2]int64
+	fn       queryFunc
+}
+
+func (r *resultsCacheQueryRecorder) query(ctx context.Context, req *queryv1.QueryRequest) (*queryv1.QueryResponse, error) {
+	r.Lock()
+	r.requests = append(r.requests, [2]int64{req.StartTime, req.EndTime})
+	r.Unlock()
+	return r.fn(ctx, req)
+}
+
+func (r *resultsCacheQueryRecorder) reset() [][2]int64 {
+	r.Lock()
+	defer r.Unlock()
+	requests := r.requests
+	r.requests = nil
+	return requests
+}
+
+func newTestResultsCache() *ResultsCache {
+	c := newResultsCache(cache.NewMockCache(), resultscache.Config{
+		SplitInterval:  time.Minute,
+		IngestionDelay: time.Minute,
+		TTL:            time.Hour,
+	}, log.NewNopLogger())
+	c.now = func() time.Time { return time.UnixMilli(300_000) }
+	return c
+}
+
+func Test_ResultsCache_Tree(t *testing.T) {
+	c := newTestResultsCache()
+	// Each millisecond of the time range contributes one sample.
+	// In all but the last interval, baz is lighter than bar, and is
+	// truncated if the trees of the intervals are, whereas it is heavier
+	// over the whole time range.
+	r := &resultsCacheQueryRecorder{fn: func(_ context.Context, req *queryv1.QueryRequest) (*queryv1.QueryResponse, error) {
+		tree := new(phlaremodel.Tree)
+		tree.InsertStack(req.EndTime-req.StartTime+1, "main", "foo")
+		if req.StartTime < 240_000 {
+			tree.InsertStack(2, "main", "bar")
+			tree.InsertStack(1, "main", "baz")
+		} else {
+			tree.InsertStack(6, "main", "baz")
+		}
+		return &queryv1.QueryResponse{Reports: []*queryv1.Report{{
+			ReportType: queryv1.ReportType_REPORT_TREE,
+			Tree:       &queryv1.TreeReport{Tree: tree.Bytes(req.Query[0].Tree.MaxNodes)},
+		}}}, nil
+	}}
+
+	req := &queryv1.QueryRequest{
+		StartTime:     30_000,
+		EndTime:       270_000,
+		LabelSelector: "{}",
+		Query: []*queryv1.Query{{
+			QueryType: queryv1.QueryType_QUERY_TREE,
+			Tree:      &queryv1.TreeQuery{MaxNodes: 3},
+		}},
+	}
+	exact := new(phlaremodel.Tree)
+	exact.InsertStack(240_001, "main", "foo")
+	exact.InsertStack(8, "main", "bar")
+	exact.InsertStack(10, "main", "baz")
+	expected, err := phlaremodel.UnmarshalTree(exact.Bytes(3))
+	require.NoError(t, err)
+
+	for _, queried := range [][][2]int64{
+		{{30_000, 59_999}, {60_000, 119_999}, {120_000, 179_999}, {180_000, 239_999}, {240_000, 270_000}},
+		// Intervals that end less than the ingestion delay ago are not cached.
+		{{240_000, 270_000}},
+	} {
+		resp, err := c.query(context.Background(), []string{"tenant"}, req.CloneVT(), 2, r.query)
+		require.NoError(t, err)
+		require.Len(t, resp.Reports, 1)
+		tree, err := phlaremodel.UnmarshalTree(resp.Reports[0].Tree.Tree)
+		require.NoError(t, err)
+		assert.Equal(t, expected.String(), tree.String())
+		assert.ElementsMatch(t, queried, r.reset())
+	}
+
+	// Results are cached per tenant.
+	_, err = c.query(context.Background(), []string{"another"}, req.CloneVT(), 2, r.query)
+	require.NoError(t, err)
+	assert.Len(t, r.reset(), 5)
+}
+
+func Test_ResultsCache_TimeSeries(t *testing.T) {
+	c := newTestResultsCache()
+	// The query backend returns a point per step, starting with the
+	// start time of the request, which precedes the first step.
+	r := &resultsCacheQueryRecorder{fn: func(_ context.Context, req *queryv1.QueryRequest) (*queryv1.QueryResponse, error) {
+		s := &typesv1.Series{}
+		for ts := req.StartTime; ts <= req.EndTime; ts += 15_000 {
+			s.Points = append(s.Points, &typesv1.Point{Timestamp: ts, Value: 1})
+		}
+		return &queryv1.QueryResponse{Reports: []*queryv1.Report{{
+			ReportType: queryv1.ReportType_REPORT_TIME_SERIES,
+			TimeSeries: &queryv1.TimeSeriesReport{TimeSeries: []*typesv1.Series{s}},
+		}}}, nil
+	}}
+
+	req := &queryv1.QueryRequest{
+		StartTime:     45_000,
+		EndTime:       150_000,
+		LabelSelector: "{}",
+		Query: []*queryv1.Query{{
+			QueryType:  queryv1.QueryType_QUERY_TIME_SERIES,
+			TimeSeries: &queryv1.TimeSeriesQuery{Step: 15},
+		}},
+	}
+
+	expected := &typesv1.Series{}
+	for ts := int64(60_000); ts <= 150_000; ts += 15_000 {
+		expected.Points = append(expected.Points, &typesv1.Point{Timestamp: ts, Value: 1})
+	}
+	for i := 0; i < 2; i++ {
+		resp, err := c.query(context.Background(), []string{"tenant"}, req.CloneVT(), 2, r.query)
+		require.NoError(t, err)
+		require.Len(t, resp.Reports, 1)
+		assert.Equal(t, []*typesv1.Series{expected}, resp.Reports[0].TimeSeries.TimeSeries)
+	}
+	assert.ElementsMatch(t, [][2]int64{{45_000, 119_999}, {105_000, 150_000}}, r.reset())
+
+	// The step grid does not match the interval boundaries.
+	req.StartTime = 50_000
+	_, err := c.query(context.Background(), []string{"tenant"}, req.CloneVT(), 2, r.query)
+	require.NoError(t, err)
+	assert.Equal(t, [][2]int64{{50_000, 150_000}}, r.reset())
+}
@@ -2,6 +2,7 @@ package read_path
 
 import (
 	"context"
+	"errors"
 	"slices"
 
 	"connectrpc.com/connect"
@@ -161,10 +162,173 @@ func (r *Router) SelectSeries(
 		})
 }
 
+func (r *Router) SelectTopTable(
+	ctx context.Context,
+	c *connect.Request[querierv1.SelectTopTableRequest],
+) (*connect.Response[querierv1.SelectTopTableResponse], error) {
+	limit := c.Msg.Limit
+	return Query[querierv1.SelectTopTableRequest, querierv1.SelectTopTableResponse](ctx, r, c,
+		func(a, b *querierv1.SelectTopTableRequest) {
+			// If both frontends are queried, the limit must
+			// be applied after merging the tables.
+			if a != nil && b != nil {
+				a.Limit = 0
+				b.Limit = 0
+			}
+		},
+		func(a, b *querierv1.SelectTopTableResponse) (*querierv1.SelectTopTableResponse, error) {
+			m := phlaremodel.NewTopTableMerger()
+			m.MergeEntries(a.Entries)
+			m.MergeEntries(b.Entries)
+			return &querierv1.SelectTopTableResponse{Entries: m.Entries(c.Msg.OrderBy, limit)}, nil
+		})
+}
+
+func (r *Router) SelectCallGraph(
+	ctx context.Context,
+	c *connect.Request[querierv1.SelectCallGraphRequest],
+) (*connect.Response[querierv1.SelectCallGraphResponse], error) {
+	maxNodes := c.Msg.GetMaxNodes()
+	return Query[querierv1.SelectCallGraphRequest, querierv1.SelectCallGraphResponse](ctx, r, c,
+		func(a, b *querierv1.SelectCallGraphRequest) {
+			// If both frontends are queried, the node limit
+			// must be applied after merging the graphs.
+			if a != nil && b != nil {
+				a.MaxNodes = nil
+				b.MaxNodes = nil
+			}
+		},
+		func(a, b *querierv1.SelectCallGraphResponse) (*querierv1.SelectCallGraphResponse, error) {
+			m := phlaremodel.NewCallGraphMerger()
+			m.MergeCallGraph(a.CallGraph)
+			m.MergeCallGraph(b.CallGraph)
+			return &querierv1.SelectCallGraphResponse{CallGraph: m.CallGraph(maxNodes)}, nil
+		})
+}
+
+func (r *Router) SelectFunctionDetails(
+	ctx context.Context,
+	c *connect.Request[querierv1.SelectFunctionDetailsRequest],
+) (*connect.Response[querierv1.SelectFunctionDetailsResponse], error) {
+	// We always query data in the tree format and
+	// return it in the format requested by the client.
+	f := c.Msg.Format
+	c.Msg.Format = querierv1.ProfileFormat_PROFILE_FORMAT_TREE
+	resp, err := Query[querierv1.SelectFunctionDetailsRequest, querierv1.SelectFunctionDetailsResponse](ctx, r, c,
+		func(_, _ *querierv1.SelectFunctionDetailsRequest) {},
+		func(a, b *querierv1.SelectFunctionDetailsResponse) (*querierv1.SelectFunctionDetailsResponse, error) {
+			m := phlaremodel.NewFunctionDetailsMerger()
+			if err := m.MergeFunctionDetailsBytes(a.CallersTree, a.CalleesTree, a.Lines); err != nil {
+				return nil, err
+			}
+			if err := m.MergeFunctionDetailsBytes(b.CallersTree, b.CalleesTree, b.Lines); err != nil {
+				return nil, err
+			}
+			d := m.FunctionDetails()
+			return phlaremodel.NewFunctionDetailsResponse(d, c.Msg.Format, c.Msg.GetMaxNodes()), nil
+		},
+	)
+	if err == nil && f != c.Msg.Format {
+		d := &phlaremodel.FunctionDetails{
+			Callers: phlaremodel.MustUnmarshalTree(resp.Msg.CallersTree),
+			Callees: phlaremodel.MustUnmarshalTree(resp.Msg.CalleesTree),
+			Lines:   resp.Msg.Lines,
+		}
+		resp.Msg = phlaremodel.NewFunctionDetailsResponse(d, f, c.Msg.GetMaxNodes())
+	}
+	return resp, err
+}
+
+func (r *Router) SelectHeatmap(
+	ctx context.Context,
+	c *connect.Request[querierv1.SelectHeatmapRequest],
+) (*connect.Response[querierv1.SelectHeatmapResponse], error) {
+	return Query[querierv1.SelectHeatmapRequest, querierv1.SelectHeatmapResponse](ctx, r, c,
+		func(_, _ *querierv1.SelectHeatmapRequest) {},
+		func(a, b *querierv1.SelectHeatmapResponse) (*querierv1.SelectHeatmapResponse, error) {
+			if a.Heatmap == nil {
+				return b, nil
+			}
+			m := phlaremodel.NewHeatmapMerger(a.Heatmap.Step, a.Heatmap.ValueResolution)
+			if err := m.MergeHeatmap(a.Heatmap); err != nil {
+				return nil, err
+			}
+			if err := m.MergeHeatmap(b.Heatmap); err != nil {
+				return nil, err
+			}
+			return &querierv1.SelectHeatmapResponse{Heatmap: m.Heatmap()}, nil
+		})
+}
+
+func (r *Router) SelectExemplars(
+	ctx context.Context,
+	c *connect.Request[querierv1.SelectExemplarsRequest],
+) (*connect.Response[querierv1.SelectExemplarsResponse], error) {
+	return Query[querierv1.SelectExemplarsRequest, querierv1.SelectExemplarsResponse](ctx, r, c,
+		func(_, _ *querierv1.SelectExemplarsRequest) {},
+		func(a, b *querierv1.SelectExemplarsResponse) (*querierv1.SelectExemplarsResponse, error) {
+			m := phlaremodel.NewExemplarsMerger()
+			m.MergeExemplars(a.Exemplars)
+			m.MergeExemplars(b.Exemplars)
+			limit := int64(max(len(a.Exemplars), len(b.Exemplars)))
+			if c.Msg.Limit > 0 {
+				limit = c.Msg.Limit
+			}
+			return &querierv1.SelectExemplarsResponse{Exemplars: m.Exemplars(limit)}, nil
+		})
+}
+
+var errProfileNotFound = errors.New("profile not found")
+
+// SelectProfileByID only fails if the profile is found by none of the
+// read paths and tenants queried: the old read path does not support the
+// query, and the profile belongs to one tenant only.
+func (r *Router) SelectProfileByID(
+	ctx context.Context,
+	c *connect.Request[querierv1.SelectProfileByIDRequest],
+) (*connect.Response[profilev1.Profile], error) {
+	if r.newFrontend == nil {
+		return r.oldFrontend.SelectProfileByID(ctx, c)
+	}
+	resp, err := Query[querierv1.SelectProfileByIDRequest, profilev1.Profile](ctx, r, c,
+		func(_, _ *querierv1.SelectProfileByIDRequest) {},
+		func(a, b *profilev1.Profile) (*profilev1.Profile, error) {
+			// The parts the profile is not found in are empty,
+			// and can't be merged: their sample types are unset.
+			switch {
+			case len(a.Sample) == 0:
+				return b, nil
+			case len(b.Sample) == 0:
+				return a, nil
+			}
+			var m pprof.ProfileMerge
+			if err := m.Merge(a); err != nil {
+				return nil, err
+			}
+			if err := m.Merge(b); err != nil {
+				return nil, err
+			}
+			return m.Profile(), nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Msg.Sample) == 0 {
+		return nil, connect.NewError(connect.CodeNotFound, errProfileNotFound)
+	}
+	return resp, nil
+}
+
 func (r *Router) Diff(
 	ctx context.Context,
 	c *connect.Request[querierv1.DiffRequest],
 ) (*connect.Response[querierv1.DiffResponse], error) {
+	// If both trees are to be queried from the new read path, the
+	// diff is built by the query backend, which allows to truncate
+	// the trees together.
+	if r.isNewReadPath(ctx, c.Msg.Left, c.Msg.Right) {
+		return query[querierv1.DiffRequest, querierv1.DiffResponse](ctx, r.newFrontend, c)
+	}
 	g, ctx := errgroup.WithContext(ctx)
 	getTree := func(dst *phlaremodel.Tree, req *querierv1.SelectMergeStacktracesRequest) func() error {
 		return func() error {
@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 
+	profilev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
 	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
 	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
 	"github.com/grafana/pyroscope/pkg/model"
@@ -52,6 +53,7 @@ func (s *routerTestSuite) SetupTest() {
 		s.overrides,
 		s.oldFrontend,
 		s.newFrontend,
+		true,
 	)
 	s.ctx = tenant.InjectTenantID(context.Background(), "tenant-a")
 }
@@ -300,3 +302,250 @@ func (s *routerTestSuite) Test_TimeSeries_NoLimit() {
 	s.Require().NoError(err)
 	s.Assert().Equal(expected, resp)
 }
+
+func (s *routerTestSuite) Test_TopTable_Limit() {
+	s.overrides.On("ReadPathOverrides", "tenant-a").Return(Config{
+		EnableQueryBackend:     true,
+		EnableQueryBackendFrom: time.Unix(5, 0),
+	})
+
+	req := connect.NewRequest(&querierv1.SelectTopTableRequest{Start: 10, End: 10000, Limit: 1})
+	expected := connect.NewResponse(&querierv1.SelectTopTableResponse{
+		Entries: []*typesv1.TopTableEntry{{Name: "foo", Self: 3, Total: 5}},
+	})
+
+	s.oldFrontend.On("SelectTopTable",
+		mock.Anything, connect.NewRequest(&querierv1.SelectTopTableRequest{Start: 10, End: 4999})).
+		Return(connect.NewResponse(&querierv1.SelectTopTableResponse{
+			Entries: []*typesv1.TopTableEntry{
+				{Name: "bar", Self: 2, Total: 2},
+				{Name: "foo", Self: 1, Total: 3},
+			}}), nil).Once()
+
+	s.newFrontend.On("SelectTopTable",
+		mock.Anything, connect.NewRequest(&querierv1.SelectTopTableRequest{Start: 5000, End: 10000})).
+		Return(connect.NewResponse(&querierv1.SelectTopTableResponse{
+			Entries: []*typesv1.TopTableEntry{
+				{Name: "foo", Self: 2, Total: 2},
+			}}), nil).Once()
+
+	resp, err := s.router.SelectTopTable(s.ctx, req)
+	s.Require().NoError(err)
+	s.Assert().Equal(expected, resp)
+}
+
+func (s *routerTestSuite) Test_Diff_NewFrontendOnly() {
+	s.overrides.On("ReadPathOverrides", "tenant-a").Return(Config{
+		EnableQueryBackend:     true,
+		EnableQueryBackendFrom: time.Unix(20, 0),
+	})
+
+	req := connect.NewRequest(&querierv1.DiffRequest{
+		Left:  &querierv1.SelectMergeStacktracesRequest{Start: 20001, End: 30000},
+		Right: &querierv1.SelectMergeStacktracesRequest{Start: 30000, End: 40000},
+	})
+	expected := connect.NewResponse(&querierv1.DiffResponse{
+		Flamegraph: &querierv1.FlameGraphDiff{Total: 1},
+	})
+	s.newFrontend.On("Diff", mock.Anything, req).Return(expected, nil).Once()
+
+	resp, err := s.router.Diff(s.ctx, req)
+	s.Require().NoError(err)
+	s.Assert().Equal(expected, resp)
+}
+
+func (s *routerTestSuite) Test_Exemplars_Limit() {
+	s.overrides.On("ReadPathOverrides", "tenant-a").Return(Config{
+		EnableQueryBackend:     true,
+		EnableQueryBackendFrom: time.Unix(5, 0),
+	})
+
+	req := connect.NewRequest(&querierv1.SelectExemplarsRequest{Start: 10, End: 10000, Limit: 2})
+	expected := connect.NewResponse(&querierv1.SelectExemplarsResponse{
+		Exemplars: []*typesv1.ProfileExemplar{
+			{ProfileId: "b", Timestamp: 6000, Value: 5},
+			{ProfileId: "a", Timestamp: 3000, Value: 3},
+		},
+	})
+
+	s.oldFrontend.On("SelectExemplars",
+		mock.Anything, connect.NewRequest(&querierv1.SelectExemplarsRequest{Start: 10, End: 4999, Limit: 2})).
+		Return(connect.NewResponse(&querierv1.SelectExemplarsResponse{}), nil).Once()
+
+	s.newFrontend.On("SelectExemplars",
+		mock.Anything, connect.NewRequest(&querierv1.SelectExemplarsRequest{Start: 5000, End: 10000, Limit: 2})).
+		Return(connect.NewResponse(&querierv1.SelectExemplarsResponse{
+			Exemplars: []*typesv1.ProfileExemplar{
+				{ProfileId: "a", Timestamp: 3000, Value: 3},
+				{ProfileId: "b", Timestamp: 6000, Value: 5},
+				{ProfileId: "c", Timestamp: 7000, Value: 1},
+			}}), nil).Once()
+
+	resp, err := s.router.SelectExemplars(s.ctx, req)
+	s.Require().NoError(err)
+	s.Assert().Equal(expected, resp)
+}
+
+func (s *routerTestSuite) Test_ProfileByID_NotFound() {
+	s.overrides.On("ReadPathOverrides", "tenant-a").Return(Config{
+		EnableQueryBackend:     true,
+		EnableQueryBackendFrom: time.Unix(5, 0),
+	})
+
+	req := connect.NewRequest(&querierv1.SelectProfileByIDRequest{Start: 10, End: 10000, ProfileId: "a"})
+	s.oldFrontend.On("SelectProfileByID", mock.Anything, mock.Anything).
+		Return(nil, connect.NewError(connect.CodeUnimplemented, nil))
+	expected := &profilev1.Profile{
+		SampleType:  []*profilev1.ValueType{{Type: 1, Unit: 2}},
+		Sample:      []*profilev1.Sample{{Value: []int64{1}}},
+		StringTable: []string{"", "cpu", "nanoseconds"},
+	}
+	s.newFrontend.On("SelectProfileByID", mock.Anything,
+		connect.NewRequest(&querierv1.SelectProfileByIDRequest{Start: 5000, End: 10000, ProfileId: "a"})).
+		Return(connect.NewResponse(expected), nil).Once()
+
+	// The old read path does not support the query.
+	resp, err := s.router.SelectProfileByID(s.ctx, req)
+	s.Require().NoError(err)
+	s.Assert().Len(resp.Msg.Sample, 1)
+
+	s.newFrontend.On("SelectProfileByID", mock.Anything, mock.Anything).
+		Return(nil, connect.NewError(connect.CodeNotFound, nil)).Once()
+	_, err = s.router.SelectProfileByID(s.ctx, connect.NewRequest(&querierv1.SelectProfileByIDRequest{Start: 10, End: 10000, ProfileId: "b"}))
+	s.Assert().Equal(connect.CodeNotFound, connect.CodeOf(err))
+}
+
+func (s *routerTestSuite) Test_Heatmap_Merge() {
+	s.overrides.On("ReadPathOverrides", "tenant-a").Return(Config{
+		EnableQueryBackend:     true,
+		EnableQueryBackendFrom: time.Unix(5, 0),
+	})
+
+	req := connect.NewRequest(&querierv1.SelectHeatmapRequest{Start: 10, End: 10000, Step: 10})
+	expected := connect.NewResponse(&querierv1.SelectHeatmapResponse{
+		Heatmap: &typesv1.Heatmap{
+			Step:            10000,
+			ValueResolution: 1,
+			Cells: []*typesv1.HeatmapCell{
+				{Timestamp: 0, ValueBucket: 0, MinValue: 0, MaxValue: 1, Count: 3},
+				{Timestamp: 0, ValueBucket: 2, MinValue: 2, MaxValue: 4, Count: 1},
+			},
+		},
+	})
+
+	s.oldFrontend.On("SelectHeatmap",
+		mock.Anything, connect.NewRequest(&querierv1.SelectHeatmapRequest{Start: 10, End: 4999, Step: 10})).
+		Return(connect.NewResponse(&querierv1.SelectHeatmapResponse{
+			Heatmap: &typesv1.Heatmap{
+				Step:            10000,
+				ValueResolution: 1,
+				Cells: []*typesv1.HeatmapCell{
+					{Timestamp: 0, ValueBucket: 0, MinValue: 0, MaxValue: 1, Count: 1},
+				},
+			}}), nil).Once()
+
+	s.newFrontend.On("SelectHeatmap",
+		mock.Anything, connect.NewRequest(&querierv1.SelectHeatmapRequest{Start: 5000, End: 10000, Step: 10})).
+		Return(connect.NewResponse(&querierv1.SelectHeatmapResponse{
+			Heatmap: &typesv1.Heatmap{
+				Step:            10000,
+				ValueResolution: 1,
+				Cells: []*typesv1.HeatmapCell{
+					{Timestamp: 0, ValueBucket: 0, MinValue: 0, MaxValue: 1, Count: 2},
+					{Timestamp: 0, ValueBucket: 2, MinValue: 2, MaxValue: 4, Count: 1},
+				},
+			}}), nil).Once()
+
+	resp, err := s.router.SelectHeatmap(s.ctx, req)
+	s.Require().NoError(err)
+	s.Assert().Equal(expected, resp)
+}
+
+func (s *routerTestSuite) Test_Federation_Disabled() {
+	router := NewRouter(s.logger, s.overrides, s.oldFrontend, s.newFrontend, false)
+	ctx := tenant.InjectTenantID(context.Background(), "tenant-a|tenant-b")
+	_, err := router.LabelNames(ctx, connect.NewRequest(&typesv1.LabelNamesRequest{}))
+	s.Require().Error(err)
+	s.Assert().Equal(connect.CodeInvalidArgument, connect.CodeOf(err))
+}
+
+func (s *routerTestSuite) Test_Federation_TimeSeries() {
+	s.overrides.On("ReadPathOverrides", "tenant-a").Return(Config{})
+	s.overrides.On("ReadPathOverrides", "tenant-b").Return(Config{EnableQueryBackend: true})
+
+	one := int64(1)
+	req := connect.NewRequest(&querierv1.SelectSeriesRequest{
+		Start:         10,
+		End:           10000,
+		LabelSelector: `{service_name="foo"}`,
+		GroupBy:       []string{model.LabelNameTenant},
+		Limit:         &one,
+	})
+	expected := connect.NewResponse(&querierv1.SelectSeriesResponse{
+		Series: []*typesv1.Series{
+			{Labels: model.LabelsFromStrings(model.LabelNameTenant, "tenant-b"), Points: []*typesv1.Point{{Timestamp: 1, Value: 2}}},
+		},
+	})
+
+	// The limit is applied after the responses are merged, and the
+	// tenant label is not sent to the query frontends.
+	expectedRequest := connect.NewRequest(&querierv1.SelectSeriesRequest{
+		Start:         10,
+		End:           10000,
+		LabelSelector: `{service_name="foo"}`,
+		GroupBy:       []string{},
+	})
+	s.oldFrontend.On("SelectSeries", mock.Anything, expectedRequest).
+		Return(connect.NewResponse(&querierv1.SelectSeriesResponse{
+			Series: []*typesv1.Series{{Points: []*typesv1.Point{{Timestamp: 1, Value: 1}}}},
+		}), nil).Once()
+	s.newFrontend.On("SelectSeries", mock.Anything, expectedRequest).
+		Return(connect.NewResponse(&querierv1.SelectSeriesResponse{
+			Series: []*typesv1.Series{{Points: []*typesv1.Point{{Timestamp: 1, Value: 2}}}},
+		}), nil).Once()
+
+	ctx := tenant.InjectTenantID(context.Background(), "tenant-b|tenant-a")
+	resp, err := s.router.SelectSeries(ctx, req)
+	s.Require().NoError(err)
+	s.Assert().Equal(expected, resp)
+}
+
+func (s *routerTestSuite) Test_Federation_TenantSelector() {
+	s.overrides.On("ReadPathOverrides", "tenant-b").Return(Config{})
+
+	s.oldFrontend.On("SelectMergeStacktraces", mock.Anything,
+		connect.NewRequest(&querierv1.SelectMergeStacktracesRequest{
+			LabelSelector: `{service_name="foo"}`,
+			Format:        querierv1.ProfileFormat_PROFILE_FORMAT_TREE,
+		})).
+		Return(connect.NewResponse(&querierv1.SelectMergeStacktracesResponse{}), nil).Once()
+
+	ctx := tenant.InjectTenantID(context.Background(), "tenant-a|tenant-b|tenant-c")
+	_, err := s.router.SelectMergeStacktraces(ctx, connect.NewRequest(&querierv1.SelectMergeStacktracesRequest{
+		LabelSelector: `{service_name="foo", __tenant__=~"tenant-(b|d)"}`,
+		Format:        querierv1.ProfileFormat_PROFILE_FORMAT_TREE,
+	}))
+	s.Require().NoError(err)
+}
+
+func (s *routerTestSuite) Test_Federation_Labels() {
+	s.overrides.On("ReadPathOverrides", "tenant-a").Return(Config{})
+	s.overrides.On("ReadPathOverrides", "tenant-b").Return(Config{})
+
+	s.oldFrontend.On("LabelNames", mock.Anything, mock.Anything).
+		Return(connect.NewResponse(&typesv1.LabelNamesResponse{Names: []string{"foo"}}), nil).Twice()
+	s.oldFrontend.On("LabelValues", mock.Anything, mock.Anything).
+		Return(connect.NewResponse(&typesv1.LabelValuesResponse{}), nil).Once()
+
+	ctx := tenant.InjectTenantID(context.Background(), "tenant-a|tenant-b")
+	names, err := s.router.LabelNames(ctx, connect.NewRequest(&typesv1.LabelNamesRequest{}))
+	s.Require().NoError(err)
+	s.Assert().Equal([]string{model.LabelNameTenant, "foo"}, names.Msg.Names)
+
+	values, err := s.router.LabelValues(ctx, connect.NewRequest(&typesv1.LabelValuesRequest{
+		Name:     model.LabelNameTenant,
+		Matchers: []string{`{__tenant__!="tenant-a"}`},
+	}))
+	s.Require().NoError(err)
+	s.Assert().Equal([]string{"tenant-b"}, values.Msg.Names)
+}
@@ -6,11 +6,11 @@ import (
 
 	"connectrpc.com/connect"
 	"github.com/go-kit/log"
-	"github.com/go-kit/log/level"
 	"github.com/grafana/dskit/tenant"
 	"github.com/prometheus/common/model"
 	"golang.org/x/sync/errgroup"
 
+	profilev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
 	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
 	"github.com/grafana/pyroscope/api/gen/proto/go/querier/v1/querierv1connect"
 	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
@@ -30,9 +30,13 @@ type Overrides interface {
 // If the query targets a time range that spans the enablement of
 // the new query backend, it splits the query into two parts and
 // sends them to the old and new query frontends.
+//
+// If tenant federation is enabled, queries that span multiple tenants
+// are split into per-tenant queries, which are routed independently.
 type Router struct {
-	logger    log.Logger
-	overrides Overrides
+	logger     log.Logger
+	overrides  Overrides
+	federation bool
 
 	oldFrontend querierv1connect.QuerierServiceClient
 	newFrontend querierv1connect.QuerierServiceClient
@@ -43,10 +47,12 @@ func NewRouter(
 	overrides Overrides,
 	oldFrontend querierv1connect.QuerierServiceClient,
 	newFrontend querierv1connect.QuerierServiceClient,
+	tenantFederation bool,
 ) *Router {
 	return &Router{
 		logger:      logger,
 		overrides:   overrides,
+		federation:  tenantFederation,
 		oldFrontend: oldFrontend,
 		newFrontend: newFrontend,
 	}
@@ -67,10 +73,32 @@ func Query[Req, Resp any](
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInvalidArgument, err)
 	}
-	if len(tenantIDs) != 1 {
-		level.Warn(router.logger).Log("msg", "ignoring inter-tenant query overrides", "tenants", tenantIDs)
+	if len(tenantIDs) > 1 {
+		if !router.federation {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errTenantFederationDisabled)
+		}
+		return queryTenants(ctx, router, tenantIDs, req, sanitize, aggregate)
+	}
+	return queryTenant(ctx, router, tenantIDs[0], req, sanitize, aggregate)
+}
+
+func queryTenant[Req, Resp any](
+	ctx context.Context,
+	router *Router,
+	tenantID string,
+	req *connect.Request[Req],
+	sanitize func(a, b *Req),
+	aggregate func(a, b *Resp) (*Resp, error),
+) (*connect.Response[Resp], error) {
+	// Only one of the frontends may be configured.
+	switch {
+	case router.newFrontend == nil:
+		sanitize(req.Msg, nil)
+		return query[Req, Resp](ctx, router.oldFrontend, req)
+	case router.oldFrontend == nil:
+		sanitize(nil, req.Msg)
+		return query[Req, Resp](ctx, router.newFrontend, req)
 	}
-	tenantID := tenantIDs[0]
 
 	// Verbose but explicit. Note that limits, error handling, etc.,
 	// are delegated to the callee.
@@ -116,7 +144,7 @@ func Query[Req, Resp any](
 		b, err = query[Req, Resp](ctx, router.newFrontend, connect.NewRequest(cloned))
 		return err
 	})
-	if err = g.Wait(); err != nil {
+	if err := g.Wait(); err != nil {
 		return nil, err
 	}
 
@@ -128,6 +156,30 @@ func Query[Req, Resp any](
 	return connect.NewResponse(resp), nil
 }
 
+// isNewReadPath reports whether all the requests
+// are to be served by the new query frontend only.
+func (r *Router) isNewReadPath(ctx context.Context, requests ...any) bool {
+	tenantIDs, err := tenant.TenantIDs(ctx)
+	if err != nil || len(tenantIDs) != 1 || r.newFrontend == nil {
+		return false
+	}
+	if r.oldFrontend == nil {
+		return true
+	}
+	overrides := r.overrides.ReadPathOverrides(tenantIDs[0])
+	if !overrides.EnableQueryBackend {
+		return false
+	}
+	split := model.TimeFromUnixNano(overrides.EnableQueryBackendFrom.UnixNano())
+	now := time.Now()
+	for _, req := range requests {
+		if !split.Before(phlaremodel.GetSafeTimeRange(now, req).Start) {
+			return false
+		}
+	}
+	return true
+}
+
 func query[Req, Resp any](
 	ctx context.Context,
 	svc querierv1connect.QuerierServiceClient,
@@ -161,6 +213,23 @@ func query[Req, Resp any](
 		resp, err = svc.SelectSeries(ctx, r)
 	case *connect.Request[querierv1.DiffRequest]:
 		resp, err = svc.Diff(ctx, r)
+	case *connect.Request[querierv1.SelectTopTableRequest]:
+		resp, err = svc.SelectTopTable(ctx, r)
+	case *connect.Request[querierv1.SelectCallGraphRequest]:
+		resp, err = svc.SelectCallGraph(ctx, r)
+	case *connect.Request[querierv1.SelectFunctionDetailsRequest]:
+		resp, err = svc.SelectFunctionDetails(ctx, r)
+	case *connect.Request[querierv1.SelectHeatmapRequest]:
+		resp, err = svc.SelectHeatmap(ctx, r)
+	case *connect.Request[querierv1.SelectExemplarsRequest]:
+		resp, err = svc.SelectExemplars(ctx, r)
+	case *connect.Request[querierv1.SelectProfileByIDRequest]:
+		resp, err = svc.SelectProfileByID(ctx, r)
+		// The profile may be found in the other read path, or tenant.
+		switch connect.CodeOf(err) {
+		case connect.CodeNotFound, connect.CodeUnimplemented:
+			resp, err = connect.NewResponse(&profilev1.Profile{}), nil
+		}
 
 	default:
 		return nil, connect.NewError(connect.CodeUnimplemented, nil)
@@ -0,0 +1,31 @@
+package resultscache
+
+import (
+	"fmt"
+
+	"github.com/go-kit/log"
+	"github.com/grafana/dskit/cache"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const cacheName = "frontend-results-cache"
+
+// New creates the cache backend configured. If no backend
+// is configured, the function returns nil.
+func New(cfg Config, logger log.Logger, reg prometheus.Registerer) (cache.Cache, error) {
+	reg = prometheus.WrapRegistererWithPrefix("pyroscope_", reg)
+	switch cfg.Backend {
+	case "":
+		return nil, nil
+	case BackendInMemory:
+		return NewInMemory(cacheName, cfg.InMemory.MaxSizeBytes, reg), nil
+	case BackendMemcached:
+		client, err := cache.NewMemcachedClientWithConfig(logger, cacheName, cfg.Memcached, reg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create memcached client: %w", err)
+		}
+		return cache.NewMemcachedCache(cacheName, logger, client, reg), nil
+	default:
+		return nil, fmt.Errorf("unsupported results cache backend: %q", cfg.Backend)
+	}
+}
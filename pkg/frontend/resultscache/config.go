@@ -0,0 +1,69 @@
+package resultscache
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/grafana/dskit/cache"
+
+	"github.com/grafana/pyroscope/pkg/util/fieldcategory"
+)
+
+const (
+	BackendInMemory  = "inmemory"
+	BackendMemcached = cache.BackendMemcached
+)
+
+// Config configures the cache of query results. Results are cached per
+// split interval, and only for intervals older than the ingestion delay:
+// data in such intervals is not expected to change.
+type Config struct {
+	Backend        string                      `yaml:"backend" category:"experimental"`
+	InMemory       InMemoryConfig              `yaml:"inmemory" category:"experimental"`
+	Memcached      cache.MemcachedClientConfig `yaml:"memcached" category:"experimental"`
+	SplitInterval  time.Duration               `yaml:"split_interval" category:"experimental"`
+	IngestionDelay time.Duration               `yaml:"ingestion_delay" category:"experimental"`
+	TTL            time.Duration               `yaml:"ttl" category:"experimental"`
+}
+
+type InMemoryConfig struct {
+	MaxSizeBytes int `yaml:"max_size_bytes" category:"experimental"`
+}
+
+func (cfg *Config) RegisterFlagsWithPrefix(prefix string, f *flag.FlagSet) {
+	f.StringVar(&cfg.Backend, prefix+"backend", "", fmt.Sprintf("Backend of the query results cache. Supported values: %s, %s. If empty, the results are not cached.", BackendInMemory, BackendMemcached))
+	f.IntVar(&cfg.InMemory.MaxSizeBytes, prefix+"inmemory.max-size-bytes", 256<<20, "Maximum size of the in-memory cache in bytes.")
+	// The memcached client flags are registered by a third party
+	// library, and can't be categorized with struct tags.
+	memcached := flag.NewFlagSet("", flag.ContinueOnError)
+	cfg.Memcached.RegisterFlagsWithPrefix(prefix+"memcached.", memcached)
+	memcached.VisitAll(func(fl *flag.Flag) {
+		fieldcategory.AddOverrides(map[string]fieldcategory.Category{fl.Name: fieldcategory.Experimental})
+		f.Var(fl.Value, fl.Name, fl.Usage)
+	})
+	f.DurationVar(&cfg.SplitInterval, prefix+"split-interval", time.Hour, "Queries are split into intervals of this duration, and results are cached per interval.")
+	f.DurationVar(&cfg.IngestionDelay, prefix+"ingestion-delay", 15*time.Minute, "Results of intervals that end less than this duration ago are not cached, as new data may still arrive.")
+	f.DurationVar(&cfg.TTL, prefix+"ttl", 24*time.Hour, "Time to live of the cached results.")
+}
+
+func (cfg *Config) Validate() error {
+	switch cfg.Backend {
+	case "":
+		return nil
+	case BackendInMemory:
+		if cfg.InMemory.MaxSizeBytes <= 0 {
+			return fmt.Errorf("results cache: in-memory cache size must be positive")
+		}
+	case BackendMemcached:
+		if err := cfg.Memcached.Validate(); err != nil {
+			return fmt.Errorf("results cache: %w", err)
+		}
+	default:
+		return fmt.Errorf("results cache: unsupported backend: %q", cfg.Backend)
+	}
+	if cfg.SplitInterval <= 0 {
+		return fmt.Errorf("results cache: split interval must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package resultscache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/grafana/dskit/cache"
+	"github.com/hashicorp/golang-lru/v2/simplelru"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var _ cache.Cache = (*InMemory)(nil)
+
+// InMemory is an in-process LRU cache limited by the total size of the
+// values stored. Unlike the dskit LRU cache, it does not require a remote
+// cache, and is suitable for items of varying size.
+type InMemory struct {
+	name    string
+	maxSize int
+	now     func() time.Time
+
+	mu   sync.Mutex
+	lru  *simplelru.LRU[string, inMemoryItem]
+	size int
+
+	requests prometheus.Counter
+	hits     prometheus.Counter
+}
+
+type inMemoryItem struct {
+	data      []byte
+	expiresAt time.Time
+}
+
+func NewInMemory(name string, maxSizeBytes int, reg prometheus.Registerer) *InMemory {
+	c := &InMemory{
+		name:    name,
+		maxSize: maxSizeBytes,
+		now:     time.Now,
+		requests: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Name:        "cache_memory_requests_total",
+			Help:        "Total number of requests to the in-memory cache.",
+			ConstLabels: prometheus.Labels{"name": name},
+		}),
+		hits: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Name:        "cache_memory_hits_total",
+			Help:        "Total number of requests to the in-memory cache that were a hit.",
+			ConstLabels: prometheus.Labels{"name": name},
+		}),
+	}
+	// The number of items is only limited by the total size.
+	c.lru, _ = simplelru.NewLRU[string, inMemoryItem](1<<31-1, func(_ string, v inMemoryItem) {
+		c.size -= len(v.data)
+	})
+	promauto.With(reg).NewGaugeFunc(prometheus.GaugeOpts{
+		Name:        "cache_memory_size_bytes",
+		Help:        "Total size of the items currently in the in-memory cache.",
+		ConstLabels: prometheus.Labels{"name": name},
+	}, func() float64 {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return float64(c.size)
+	})
+	return c
+}
+
+func (c *InMemory) StoreAsync(data map[string][]byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	expiresAt := c.now().Add(ttl)
+	for k, v := range data {
+		if len(v) > c.maxSize {
+			continue
+		}
+		// Eviction callback accounts the size of the replaced item.
+		c.lru.Remove(k)
+		c.lru.Add(k, inMemoryItem{data: v, expiresAt: expiresAt})
+		c.size += len(v)
+		for c.size > c.maxSize {
+			c.lru.RemoveOldest()
+		}
+	}
+}
+
+func (c *InMemory) Fetch(_ context.Context, keys []string, _ ...cache.Option) map[string][]byte {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requests.Add(float64(len(keys)))
+	now := c.now()
+	found := make(map[string][]byte, len(keys))
+	for _, k := range keys {
+		v, ok := c.lru.Get(k)
+		if !ok {
+			continue
+		}
+		if now.After(v.expiresAt) {
+			c.lru.Remove(k)
+			continue
+		}
+		found[k] = v.data
+	}
+	c.hits.Add(float64(len(found)))
+	return found
+}
+
+func (c *InMemory) Delete(_ context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lru.Remove(key)
+	return nil
+}
+
+func (c *InMemory) Name() string { return c.name }
@@ -0,0 +1,42 @@
+package resultscache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_InMemory(t *testing.T) {
+	ctx := context.Background()
+	c := NewInMemory("test", 10, prometheus.NewRegistry())
+	now := time.Unix(0, 0)
+	c.now = func() time.Time { return now }
+
+	c.StoreAsync(map[string][]byte{"a": []byte("aaaa")}, time.Minute)
+	c.StoreAsync(map[string][]byte{"b": []byte("bbbb")}, time.Hour)
+	assert.Equal(t, map[string][]byte{"a": []byte("aaaa"), "b": []byte("bbbb")}, c.Fetch(ctx, []string{"a", "b", "c"}))
+
+	// "b" is the least recently used item.
+	c.Fetch(ctx, []string{"a"})
+	c.StoreAsync(map[string][]byte{"c": []byte("cccc")}, time.Hour)
+	assert.Equal(t, map[string][]byte{"a": []byte("aaaa"), "c": []byte("cccc")}, c.Fetch(ctx, []string{"a", "b", "c"}))
+
+	// Items larger than the cache are not stored.
+	c.StoreAsync(map[string][]byte{"d": []byte("ddddddddddd")}, time.Hour)
+	assert.Empty(t, c.Fetch(ctx, []string{"d"}))
+
+	// Replaced items do not count twice.
+	c.StoreAsync(map[string][]byte{"c": []byte("cc")}, time.Hour)
+	assert.Equal(t, 6, c.size)
+
+	now = now.Add(2 * time.Minute)
+	assert.Equal(t, map[string][]byte{"c": []byte("cc")}, c.Fetch(ctx, []string{"a", "c"}))
+	assert.Equal(t, 2, c.size)
+
+	assert.NoError(t, c.Delete(ctx, "c"))
+	assert.Empty(t, c.Fetch(ctx, []string{"c"}))
+	assert.Zero(t, c.size)
+}
@@ -1,6 +1,7 @@
 package otlp
 
 import (
+	"encoding/hex"
 	"fmt"
 	"time"
 
@@ -9,6 +10,7 @@ import (
 	googleProfile "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
 	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
 	pyromodel "github.com/grafana/pyroscope/pkg/model"
+	"github.com/grafana/pyroscope/pkg/pprof"
 )
 
 const serviceNameKey = "service.name"
@@ -341,6 +343,9 @@ func (p *profileBuilder) convertSampleBack(os *otelProfile.Sample, dictionary *o
 	if err != nil {
 		return nil, err
 	}
+	if err = p.convertSampleLinkBack(os, dictionary, gs); err != nil {
+		return nil, err
+	}
 
 	for i := os.LocationsStartIndex; i < os.LocationsStartIndex+os.LocationsLength; i++ {
 		olocIdx, err := at(p.src.LocationIndices, i)
@@ -383,6 +388,36 @@ func (p *profileBuilder) convertSampleAttributesToLabelsBack(os *otelProfile.Sam
 	return nil
 }
 
+// convertSampleLinkBack maps the span of the sample link into the span_id
+// sample label, so that the profile can be queried as a span profile.
+// The trace ID is not preserved: span profiles are identified by span ID.
+func (p *profileBuilder) convertSampleLinkBack(os *otelProfile.Sample, dictionary *otelProfile.ProfilesDictionary, gs *googleProfile.Sample) error {
+	if os.LinkIndex == nil {
+		return nil
+	}
+	link, err := at(dictionary.LinkTable, *os.LinkIndex)
+	if err != nil {
+		return fmt.Errorf("could not access link: %w", err)
+	}
+	if len(link.SpanId) != 8 || isZero(link.SpanId) {
+		return nil
+	}
+	gs.Label = append(gs.Label, &googleProfile.Label{
+		Key: p.addstr(pprof.SpanIDLabelName),
+		Str: p.addstr(hex.EncodeToString(link.SpanId)),
+	})
+	return nil
+}
+
+func isZero(b []byte) bool {
+	for _, x := range b {
+		if x != 0 {
+			return false
+		}
+	}
+	return true
+}
+
 // convertMappingsBack converts a slice of OpenTelemetry Mapping entries to Google Mapping entries.
 func (p *profileBuilder) convertMappingBack(om *otelProfile.Mapping, dictionary *otelProfile.ProfilesDictionary) (uint64, error) {
 	if i, ok := p.mappingMap[om]; ok {
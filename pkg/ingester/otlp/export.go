@@ -0,0 +1,301 @@
+package otlp
+
+import (
+	"fmt"
+
+	v1 "go.opentelemetry.io/proto/otlp/common/v1"
+	otelProfile "go.opentelemetry.io/proto/otlp/profiles/v1development"
+	resourcev1 "go.opentelemetry.io/proto/otlp/resource/v1"
+
+	googleProfile "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+	pyromodel "github.com/grafana/pyroscope/pkg/model"
+)
+
+const (
+	scopeName  = "pyroscope"
+	buildIDKey = "process.executable.build_id.gnu"
+)
+
+// ConvertTreeToOtel converts a tree to OpenTelemetry profiles data.
+func ConvertTreeToOtel(t *pyromodel.Tree, profileType *typesv1.ProfileType) (*otelProfile.ProfilesData, error) {
+	return ConvertGoogleToOtel(pyromodel.TreeToPprof(t, profileType))
+}
+
+// ConvertGoogleToOtel converts a Google profile to OpenTelemetry profiles
+// data, which has a single profile. It is the reverse of ConvertOtelToGoogle.
+func ConvertGoogleToOtel(src *googleProfile.Profile) (*otelProfile.ProfilesData, error) {
+	b := &otelProfileBuilder{
+		src:        src,
+		dictionary: &otelProfile.ProfilesDictionary{StringTable: []string{""}},
+		dst: &otelProfile.Profile{
+			TimeNanos:     src.TimeNanos,
+			DurationNanos: src.DurationNanos,
+			Period:        src.Period,
+		},
+		stringMap:    map[string]int32{"": 0},
+		functions:    make(map[uint64]*googleProfile.Function, len(src.Function)),
+		locations:    make(map[uint64]*googleProfile.Location, len(src.Location)),
+		mappings:     make(map[uint64]*googleProfile.Mapping, len(src.Mapping)),
+		functionMap:  make(map[uint64]int32, len(src.Function)),
+		locationMap:  make(map[uint64]int32, len(src.Location)),
+		mappingMap:   make(map[uint64]int32, len(src.Mapping)),
+		attributeMap: make(map[attributeKey]int32),
+	}
+	for _, f := range src.Function {
+		b.functions[f.Id] = f
+	}
+	for _, l := range src.Location {
+		b.locations[l.Id] = l
+	}
+	for _, m := range src.Mapping {
+		b.mappings[m.Id] = m
+	}
+	if err := b.convert(); err != nil {
+		return nil, err
+	}
+	return &otelProfile.ProfilesData{
+		Dictionary: b.dictionary,
+		ResourceProfiles: []*otelProfile.ResourceProfiles{{
+			Resource: &resourcev1.Resource{},
+			ScopeProfiles: []*otelProfile.ScopeProfiles{{
+				Scope:    &v1.InstrumentationScope{Name: scopeName},
+				Profiles: []*otelProfile.Profile{b.dst},
+			}},
+		}},
+	}, nil
+}
+
+type attributeKey struct {
+	key string
+	str string
+	num int64
+}
+
+type otelProfileBuilder struct {
+	src        *googleProfile.Profile
+	dst        *otelProfile.Profile
+	dictionary *otelProfile.ProfilesDictionary
+
+	functions map[uint64]*googleProfile.Function
+	locations map[uint64]*googleProfile.Location
+	mappings  map[uint64]*googleProfile.Mapping
+
+	stringMap    map[string]int32
+	functionMap  map[uint64]int32
+	locationMap  map[uint64]int32
+	mappingMap   map[uint64]int32
+	attributeMap map[attributeKey]int32
+	// Locations without a mapping refer to the empty mapping:
+	// a mapping is required by ConvertOtelToGoogle.
+	emptyMapping *int32
+}
+
+func (b *otelProfileBuilder) convert() error {
+	var err error
+	for _, st := range b.src.SampleType {
+		vt, err := b.convertValueType(st)
+		if err != nil {
+			return err
+		}
+		b.dst.SampleType = append(b.dst.SampleType, vt)
+	}
+	if b.src.PeriodType != nil {
+		if b.dst.PeriodType, err = b.convertValueType(b.src.PeriodType); err != nil {
+			return err
+		}
+	}
+	for i, st := range b.src.SampleType {
+		if b.src.DefaultSampleType != 0 && st.Type == b.src.DefaultSampleType {
+			b.dst.DefaultSampleTypeIndex = int32(i)
+			break
+		}
+	}
+	for _, c := range b.src.Comment {
+		s, err := b.convertString(c)
+		if err != nil {
+			return err
+		}
+		b.dst.CommentStrindices = append(b.dst.CommentStrindices, s)
+	}
+	for i, s := range b.src.Sample {
+		if err = b.convertSample(s); err != nil {
+			return fmt.Errorf("could not process sample at index %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (b *otelProfileBuilder) addstr(s string) int32 {
+	if i, ok := b.stringMap[s]; ok {
+		return i
+	}
+	i := int32(len(b.dictionary.StringTable))
+	b.stringMap[s] = i
+	b.dictionary.StringTable = append(b.dictionary.StringTable, s)
+	return i
+}
+
+func (b *otelProfileBuilder) convertString(i int64) (int32, error) {
+	s, err := at(b.src.StringTable, int32(i))
+	if err != nil {
+		return 0, fmt.Errorf("could not access string: %w", err)
+	}
+	return b.addstr(s), nil
+}
+
+func (b *otelProfileBuilder) convertValueType(vt *googleProfile.ValueType) (*otelProfile.ValueType, error) {
+	typ, err := b.convertString(vt.Type)
+	if err != nil {
+		return nil, err
+	}
+	unit, err := b.convertString(vt.Unit)
+	if err != nil {
+		return nil, err
+	}
+	return &otelProfile.ValueType{
+		TypeStrindex:           typ,
+		UnitStrindex:           unit,
+		AggregationTemporality: otelProfile.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA,
+	}, nil
+}
+
+func (b *otelProfileBuilder) convertSample(s *googleProfile.Sample) error {
+	os := &otelProfile.Sample{
+		Value:               s.Value,
+		LocationsStartIndex: int32(len(b.dst.LocationIndices)),
+		LocationsLength:     int32(len(s.LocationId)),
+	}
+	for _, id := range s.LocationId {
+		loc, err := b.convertLocation(id)
+		if err != nil {
+			return err
+		}
+		b.dst.LocationIndices = append(b.dst.LocationIndices, loc)
+	}
+	for _, l := range s.Label {
+		key, err := at(b.src.StringTable, int32(l.Key))
+		if err != nil {
+			return fmt.Errorf("could not access label key: %w", err)
+		}
+		str, err := at(b.src.StringTable, int32(l.Str))
+		if err != nil {
+			return fmt.Errorf("could not access label value: %w", err)
+		}
+		os.AttributeIndices = append(os.AttributeIndices, b.attribute(attributeKey{key: key, str: str, num: l.Num}))
+	}
+	b.dst.Sample = append(b.dst.Sample, os)
+	return nil
+}
+
+func (b *otelProfileBuilder) attribute(k attributeKey) int32 {
+	if i, ok := b.attributeMap[k]; ok {
+		return i
+	}
+	value := &v1.AnyValue{Value: &v1.AnyValue_StringValue{StringValue: k.str}}
+	if k.str == "" {
+		value.Value = &v1.AnyValue_IntValue{IntValue: k.num}
+	}
+	i := int32(len(b.dictionary.AttributeTable))
+	b.dictionary.AttributeTable = append(b.dictionary.AttributeTable, &v1.KeyValue{Key: k.key, Value: value})
+	b.attributeMap[k] = i
+	return i
+}
+
+func (b *otelProfileBuilder) convertLocation(id uint64) (int32, error) {
+	if i, ok := b.locationMap[id]; ok {
+		return i, nil
+	}
+	gl, ok := b.locations[id]
+	if !ok {
+		return 0, fmt.Errorf("location %d not found", id)
+	}
+	mapping, err := b.convertMapping(gl.MappingId)
+	if err != nil {
+		return 0, err
+	}
+	ol := &otelProfile.Location{
+		MappingIndex: &mapping,
+		Address:      gl.Address,
+		IsFolded:     gl.IsFolded,
+		Line:         make([]*otelProfile.Line, len(gl.Line)),
+	}
+	for i, line := range gl.Line {
+		fn, err := b.convertFunction(line.FunctionId)
+		if err != nil {
+			return 0, err
+		}
+		ol.Line[i] = &otelProfile.Line{FunctionIndex: fn, Line: line.Line}
+	}
+	i := int32(len(b.dictionary.LocationTable))
+	b.dictionary.LocationTable = append(b.dictionary.LocationTable, ol)
+	b.locationMap[id] = i
+	return i, nil
+}
+
+func (b *otelProfileBuilder) convertFunction(id uint64) (int32, error) {
+	if i, ok := b.functionMap[id]; ok {
+		return i, nil
+	}
+	gf, ok := b.functions[id]
+	if !ok {
+		return 0, fmt.Errorf("function %d not found", id)
+	}
+	of := &otelProfile.Function{StartLine: gf.StartLine}
+	var err error
+	if of.NameStrindex, err = b.convertString(gf.Name); err != nil {
+		return 0, err
+	}
+	if of.SystemNameStrindex, err = b.convertString(gf.SystemName); err != nil {
+		return 0, err
+	}
+	if of.FilenameStrindex, err = b.convertString(gf.Filename); err != nil {
+		return 0, err
+	}
+	i := int32(len(b.dictionary.FunctionTable))
+	b.dictionary.FunctionTable = append(b.dictionary.FunctionTable, of)
+	b.functionMap[id] = i
+	return i, nil
+}
+
+func (b *otelProfileBuilder) convertMapping(id uint64) (int32, error) {
+	if id == 0 {
+		if b.emptyMapping == nil {
+			i := int32(len(b.dictionary.MappingTable))
+			b.dictionary.MappingTable = append(b.dictionary.MappingTable, &otelProfile.Mapping{})
+			b.emptyMapping = &i
+		}
+		return *b.emptyMapping, nil
+	}
+	if i, ok := b.mappingMap[id]; ok {
+		return i, nil
+	}
+	gm, ok := b.mappings[id]
+	if !ok {
+		return 0, fmt.Errorf("mapping %d not found", id)
+	}
+	om := &otelProfile.Mapping{
+		MemoryStart:     gm.MemoryStart,
+		MemoryLimit:     gm.MemoryLimit,
+		FileOffset:      gm.FileOffset,
+		HasFunctions:    gm.HasFunctions,
+		HasFilenames:    gm.HasFilenames,
+		HasLineNumbers:  gm.HasLineNumbers,
+		HasInlineFrames: gm.HasInlineFrames,
+	}
+	var err error
+	if om.FilenameStrindex, err = b.convertString(gm.Filename); err != nil {
+		return 0, err
+	}
+	buildID, err := at(b.src.StringTable, int32(gm.BuildId))
+	if err != nil {
+		return 0, fmt.Errorf("could not access mapping build id: %w", err)
+	}
+	if buildID != "" {
+		om.AttributeIndices = []int32{b.attribute(attributeKey{key: buildIDKey, str: buildID})}
+	}
+	i := int32(len(b.dictionary.MappingTable))
+	b.dictionary.MappingTable = append(b.dictionary.MappingTable, om)
+	b.mappingMap[id] = i
+	return i, nil
+}
@@ -0,0 +1,65 @@
+package otlp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	googleProfile "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+	phlaremodel "github.com/grafana/pyroscope/pkg/model"
+	"github.com/grafana/pyroscope/pkg/pprof/testhelper"
+)
+
+func TestConvertGoogleToOtel(t *testing.T) {
+	p := testhelper.NewProfileBuilder(1e9).CPUProfile()
+	p.ForStacktraceString("foo", "bar", "main").AddSamples(10)
+	p.ForStacktraceString("baz", "main").AddSamples(20)
+	p.Sample[1].Label = []*googleProfile.Label{{Key: p.AddString("thread"), Str: p.AddString("worker")}}
+	p.Mapping[0].BuildId = p.AddString("deadbeef")
+
+	data, err := ConvertGoogleToOtel(p.Profile)
+	require.NoError(t, err)
+	require.Len(t, data.ResourceProfiles, 1)
+	require.Len(t, data.ResourceProfiles[0].ScopeProfiles, 1)
+	require.Len(t, data.ResourceProfiles[0].ScopeProfiles[0].Profiles, 1)
+	assert.Equal(t, "", data.Dictionary.StringTable[0])
+	assert.Len(t, data.Dictionary.LocationTable, 4)
+	assert.Len(t, data.Dictionary.FunctionTable, 4)
+	assert.Len(t, data.Dictionary.MappingTable, 1)
+
+	converted, err := ConvertOtelToGoogle(data.ResourceProfiles[0].ScopeProfiles[0].Profiles[0], data.Dictionary)
+	require.NoError(t, err)
+	require.Len(t, converted, 1)
+	actual := converted[""].profile
+
+	expectedTree, err := phlaremodel.TreeFromBackendProfile(p.Profile, -1)
+	require.NoError(t, err)
+	actualTree, err := phlaremodel.TreeFromBackendProfile(actual, -1)
+	require.NoError(t, err)
+	assert.Equal(t, expectedTree, actualTree)
+
+	assert.Equal(t, p.TimeNanos, actual.TimeNanos)
+	assert.Equal(t, "worker", actual.StringTable[actual.Sample[1].Label[0].Str])
+	assert.Equal(t, "deadbeef", actual.StringTable[actual.Mapping[0].BuildId])
+}
+
+func TestConvertTreeToOtel(t *testing.T) {
+	tree := new(phlaremodel.Tree)
+	tree.InsertStack(3, "main", "foo")
+	tree.InsertStack(1, "main", "bar")
+
+	data, err := ConvertTreeToOtel(tree, &typesv1.ProfileType{SampleType: "alloc_space", SampleUnit: "bytes"})
+	require.NoError(t, err)
+	p := data.ResourceProfiles[0].ScopeProfiles[0].Profiles[0]
+	require.Len(t, p.SampleType, 1)
+	assert.Equal(t, "alloc_space", data.Dictionary.StringTable[p.SampleType[0].TypeStrindex])
+	assert.Equal(t, "bytes", data.Dictionary.StringTable[p.SampleType[0].UnitStrindex])
+	require.Len(t, p.Sample, 2)
+	var total int64
+	for _, s := range p.Sample {
+		total += s.Value[0]
+	}
+	assert.Equal(t, int64(4), total)
+}
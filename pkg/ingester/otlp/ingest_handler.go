@@ -26,11 +26,13 @@ import (
 	pyromodel "github.com/grafana/pyroscope/pkg/model"
 	"github.com/grafana/pyroscope/pkg/pprof"
 	"github.com/grafana/pyroscope/pkg/tenant"
+	"github.com/grafana/pyroscope/pkg/validation"
 )
 
 type ingestHandler struct {
 	pprofileotlp.UnimplementedProfilesServiceServer
 	svc                 PushService
+	limits              Limits
 	log                 log.Logger
 	handler             http.Handler
 	multitenancyEnabled bool
@@ -45,9 +47,14 @@ type PushService interface {
 	PushParsed(ctx context.Context, req *distirbutormodel.PushRequest) (*connect.Response[pushv1.PushResponse], error)
 }
 
-func NewOTLPIngestHandler(svc PushService, l log.Logger, me bool) Handler {
+type Limits interface {
+	OTLPAttributeRules(tenantID string) []*validation.OTLPAttributeRule
+}
+
+func NewOTLPIngestHandler(svc PushService, limits Limits, l log.Logger, me bool) Handler {
 	h := &ingestHandler{
 		svc:                 svc,
+		limits:              limits,
 		log:                 l,
 		multitenancyEnabled: me,
 	}
@@ -60,11 +67,7 @@ func NewOTLPIngestHandler(svc PushService, l log.Logger, me bool) Handler {
 			grpcServer.ServeHTTP(w, r)
 			return
 		}
-
-		// Handle HTTP requests (if we want to support HTTP/Protobuf in future)
-		//if r.URL.Path == "/v1/profiles" {}
-
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		h.serveOTLPHTTP(w, r)
 	})
 
 	return h
@@ -87,7 +90,14 @@ func (h *ingestHandler) Export(ctx context.Context, er *pprofileotlp.ExportProfi
 			return &pprofileotlp.ExportProfilesServiceResponse{}, fmt.Errorf("failed to extract tenant ID from GRPC request: %w", err)
 		}
 	}
+	return h.export(ctx, er)
+}
 
+// export pushes the profiles of the request. Profiles that can't be
+// converted or are rejected by the distributor as invalid are reported
+// in the partial success of the response, unless all the profiles of
+// the request are rejected: then the request fails.
+func (h *ingestHandler) export(ctx context.Context, er *pprofileotlp.ExportProfilesServiceRequest) (*pprofileotlp.ExportProfilesServiceResponse, error) {
 	dc := er.Dictionary
 	if dc == nil {
 		return &pprofileotlp.ExportProfilesServiceResponse{}, status.Errorf(codes.InvalidArgument, "missing profile metadata dictionary")
@@ -98,6 +108,21 @@ func (h *ingestHandler) Export(ctx context.Context, er *pprofileotlp.ExportProfi
 		return &pprofileotlp.ExportProfilesServiceResponse{}, status.Errorf(codes.InvalidArgument, "missing resource profiles")
 	}
 
+	tenantID, err := tenant.ExtractTenantIDFromContext(ctx)
+	if err != nil {
+		return &pprofileotlp.ExportProfilesServiceResponse{}, status.Error(codes.Unauthenticated, err.Error())
+	}
+	rules := h.limits.OTLPAttributeRules(tenantID)
+
+	var total, rejected int64
+	var rejectedMsg string
+	reject := func(msg string) {
+		if rejected == 0 {
+			rejectedMsg = msg
+		}
+		rejected++
+	}
+
 	for i := 0; i < len(rps); i++ {
 		rp := rps[i]
 
@@ -109,11 +134,12 @@ func (h *ingestHandler) Export(ctx context.Context, er *pprofileotlp.ExportProfi
 
 			for k := 0; k < len(sp.Profiles); k++ {
 				p := sp.Profiles[k]
+				total++
 
 				pprofProfiles, err := ConvertOtelToGoogle(p, dc)
 				if err != nil {
-					grpcError := status.Errorf(codes.InvalidArgument, "failed to convert otel profile: %s", err.Error())
-					return &pprofileotlp.ExportProfilesServiceResponse{}, grpcError
+					reject(fmt.Sprintf("failed to convert otel profile: %s", err))
+					continue
 				}
 
 				req := &distirbutormodel.PushRequest{
@@ -125,8 +151,8 @@ func (h *ingestHandler) Export(ctx context.Context, er *pprofileotlp.ExportProfi
 					labels := getDefaultLabels()
 					labels = append(labels, pprofProfile.name)
 					processedKeys := map[string]bool{pyromodel.LabelNameProfileName: true}
-					labels = appendAttributesUnique(labels, rp.Resource.GetAttributes(), processedKeys)
-					labels = appendAttributesUnique(labels, sp.Scope.GetAttributes(), processedKeys)
+					labels = appendAttributesUnique(labels, rp.Resource.GetAttributes(), validation.OTLPAttributeSourceResource, rules, processedKeys)
+					labels = appendAttributesUnique(labels, sp.Scope.GetAttributes(), validation.OTLPAttributeSourceScope, rules, processedKeys)
 					svc := samplesServiceName
 					if svc == "" {
 						svc = serviceName
@@ -154,13 +180,29 @@ func (h *ingestHandler) Export(ctx context.Context, er *pprofileotlp.ExportProfi
 				_, err = h.svc.PushParsed(ctx, req)
 				if err != nil {
 					h.log.Log("msg", "failed to push profile", "err", err)
+					if connect.CodeOf(err) == connect.CodeInvalidArgument {
+						// The profile is invalid: retrying won't help.
+						reject(err.Error())
+						continue
+					}
 					return &pprofileotlp.ExportProfilesServiceResponse{}, fmt.Errorf("failed to make a GRPC request: %w", err)
 				}
 			}
 		}
 	}
 
-	return &pprofileotlp.ExportProfilesServiceResponse{}, nil
+	switch {
+	case rejected == 0:
+		return &pprofileotlp.ExportProfilesServiceResponse{}, nil
+	case rejected == total:
+		return &pprofileotlp.ExportProfilesServiceResponse{}, status.Error(codes.InvalidArgument, rejectedMsg)
+	}
+	return &pprofileotlp.ExportProfilesServiceResponse{
+		PartialSuccess: &pprofileotlp.ExportProfilesPartialSuccess{
+			RejectedProfiles: rejected,
+			ErrorMessage:     rejectedMsg,
+		},
+	}, nil
 }
 
 // getServiceNameFromAttributes extracts service name from OTLP resource attributes.
@@ -201,21 +243,47 @@ func getDefaultLabels() []*typesv1.LabelPair {
 	}
 }
 
-func appendAttributesUnique(labels []*typesv1.LabelPair, attrs []*v1.KeyValue, processedKeys map[string]bool) []*typesv1.LabelPair {
+// appendAttributesUnique appends the attributes as labels. The name of
+// the label is determined by the first rule matching the attribute key,
+// if any; otherwise, the key is used as is.
+func appendAttributesUnique(
+	labels []*typesv1.LabelPair,
+	attrs []*v1.KeyValue,
+	source validation.OTLPAttributeSource,
+	rules []*validation.OTLPAttributeRule,
+	processedKeys map[string]bool,
+) []*typesv1.LabelPair {
 	for _, attr := range attrs {
+		name, ok := attributeLabelName(attr.Key, source, rules)
 		// Skip if we've already seen this key at any level
-		if processedKeys[attr.Key] {
+		if !ok || name == "" || processedKeys[name] {
 			continue
 		}
 
 		val := attr.GetValue()
 		if sv := val.GetStringValue(); sv != "" {
 			labels = append(labels, &typesv1.LabelPair{
-				Name:  attr.Key,
+				Name:  name,
 				Value: sv,
 			})
-			processedKeys[attr.Key] = true
+			processedKeys[name] = true
 		}
 	}
 	return labels
 }
+
+// attributeLabelName returns the name of the label the attribute is
+// mapped into, and false if the attribute is dropped.
+func attributeLabelName(key string, source validation.OTLPAttributeSource, rules []*validation.OTLPAttributeRule) (string, bool) {
+	for _, r := range rules {
+		name, ok := r.Label(source, key)
+		if !ok {
+			continue
+		}
+		if r.Action == validation.OTLPAttributeActionDrop {
+			return "", false
+		}
+		return name, true
+	}
+	return key, true
+}
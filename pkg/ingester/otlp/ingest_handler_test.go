@@ -2,6 +2,7 @@ package otlp
 
 import (
 	"context"
+	"encoding/binary"
 	"os"
 	"sort"
 	"strings"
@@ -11,9 +12,12 @@ import (
 	"github.com/grafana/pyroscope/pkg/distributor/model"
 	phlaremodel "github.com/grafana/pyroscope/pkg/model"
 	"github.com/grafana/pyroscope/pkg/og/convert/pprof/strprofile"
+	"github.com/grafana/pyroscope/pkg/pprof"
 	"github.com/grafana/pyroscope/pkg/test"
 	"github.com/grafana/pyroscope/pkg/test/mocks/mockotlp"
+	"github.com/grafana/pyroscope/pkg/validation"
 
+	"github.com/prometheus/prometheus/model/relabel"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -128,6 +132,7 @@ func TestAppendAttributesUnique(t *testing.T) {
 		name          string
 		existingAttrs []*typesv1.LabelPair
 		newAttrs      []*v1.KeyValue
+		rules         []*validation.OTLPAttributeRule
 		processedKeys map[string]bool
 		expected      []*typesv1.LabelPair
 	}{
@@ -179,11 +184,32 @@ func TestAppendAttributesUnique(t *testing.T) {
 				{Name: "key1", Value: "value1"},
 			},
 		},
+		{
+			name:          "attributes mapped by rules",
+			existingAttrs: []*typesv1.LabelPair{},
+			newAttrs: []*v1.KeyValue{
+				{Key: "k8s.pod.name", Value: &v1.AnyValue{Value: &v1.AnyValue_StringValue{StringValue: "pod"}}},
+				{Key: "k8s.namespace.name", Value: &v1.AnyValue{Value: &v1.AnyValue_StringValue{StringValue: "ns"}}},
+				{Key: "process.pid", Value: &v1.AnyValue{Value: &v1.AnyValue_StringValue{StringValue: "1"}}},
+				{Key: "host.name", Value: &v1.AnyValue{Value: &v1.AnyValue_StringValue{StringValue: "host"}}},
+			},
+			rules: []*validation.OTLPAttributeRule{
+				{Regex: relabel.MustNewRegexp("process\\..*"), Action: validation.OTLPAttributeActionDrop},
+				{Regex: relabel.MustNewRegexp("k8s\\.(pod|namespace)\\.name"), TargetLabel: "$1"},
+				{Regex: relabel.MustNewRegexp("host\\.name"), TargetLabel: "scope_host", Source: validation.OTLPAttributeSourceScope},
+			},
+			processedKeys: make(map[string]bool),
+			expected: []*typesv1.LabelPair{
+				{Name: "pod", Value: "pod"},
+				{Name: "namespace", Value: "ns"},
+				{Name: "host.name", Value: "host"},
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := appendAttributesUnique(tt.existingAttrs, tt.newAttrs, tt.processedKeys)
+			result := appendAttributesUnique(tt.existingAttrs, tt.newAttrs, validation.OTLPAttributeSourceResource, tt.rules, tt.processedKeys)
 			assert.Equal(t, tt.expected, result)
 		})
 	}
@@ -334,7 +360,7 @@ func TestConversion(t *testing.T) {
 						}}}}},
 				Dictionary: &b.dictionary}
 			logger := test.NewTestingLogger(t)
-			h := NewOTLPIngestHandler(svc, logger, false)
+			h := NewOTLPIngestHandler(svc, validation.MockLimits{}, logger, false)
 			_, err := h.Export(context.Background(), req)
 
 			if td.expectedError == "" {
@@ -429,7 +455,7 @@ func TestSampleAttributes(t *testing.T) {
 				}}}}},
 		Dictionary: &otlpb.dictionary}
 	logger := test.NewTestingLogger(t)
-	h := NewOTLPIngestHandler(svc, logger, false)
+	h := NewOTLPIngestHandler(svc, validation.MockLimits{}, logger, false)
 	_, err := h.Export(context.Background(), req)
 	assert.NoError(t, err)
 	require.Equal(t, 1, len(profiles))
@@ -452,6 +478,57 @@ func TestSampleAttributes(t *testing.T) {
 
 }
 
+func TestSampleLinks(t *testing.T) {
+	// Create a profile with two samples, one of them linked to a span:
+	// expect the span ID to be preserved in the span_id sample label.
+	svc := mockotlp.NewMockPushService(t)
+	var profiles []*model.PushRequest
+	svc.On("PushParsed", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		profiles = append(profiles, args.Get(1).(*model.PushRequest))
+	}).Return(nil, nil)
+
+	spanID := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	otlpb := new(otlpbuilder)
+	otlpb.dictionary.MappingTable = []*v1experimental.Mapping{{
+		MemoryStart:      0x1000,
+		MemoryLimit:      0x1000,
+		FilenameStrindex: otlpb.addstr("app.so"),
+	}}
+	otlpb.dictionary.LocationTable = []*v1experimental.Location{{
+		MappingIndex: int32ptr(0),
+		Address:      0x1e,
+	}}
+	otlpb.dictionary.LinkTable = []*v1experimental.Link{{}, {
+		TraceId: []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		SpanId:  spanID,
+	}}
+	otlpb.profile.LocationIndices = []int32{0}
+	otlpb.profile.Sample = []*v1experimental.Sample{{
+		LocationsLength: 1,
+		Value:           []int64{1},
+		LinkIndex:       int32ptr(1),
+	}, {
+		LocationsLength: 1,
+		Value:           []int64{2},
+		LinkIndex:       int32ptr(0),
+	}}
+	req := &v1experimental2.ExportProfilesServiceRequest{
+		ResourceProfiles: []*v1experimental.ResourceProfiles{{
+			ScopeProfiles: []*v1experimental.ScopeProfiles{{
+				Profiles: []*v1experimental.Profile{
+					&otlpb.profile,
+				}}}}},
+		Dictionary: &otlpb.dictionary}
+	h := NewOTLPIngestHandler(svc, validation.MockLimits{}, test.NewTestingLogger(t), false)
+	_, err := h.Export(context.Background(), req)
+	require.NoError(t, err)
+	require.Len(t, profiles, 1)
+	require.Len(t, profiles[0].Series, 1)
+
+	gp := profiles[0].Series[0].Samples[0].Profile.Profile
+	assert.Equal(t, []uint64{binary.LittleEndian.Uint64(spanID), 0}, pprof.ProfileSpans(gp))
+}
+
 func TestDifferentServiceNames(t *testing.T) {
 	// Create a profile with two samples having different service.name attributes
 	// Expect them to be pushed as separate profiles
@@ -593,7 +670,7 @@ func TestDifferentServiceNames(t *testing.T) {
 		Dictionary: &otlpb.dictionary}
 
 	logger := test.NewTestingLogger(t)
-	h := NewOTLPIngestHandler(svc, logger, false)
+	h := NewOTLPIngestHandler(svc, validation.MockLimits{}, logger, false)
 	_, err := h.Export(context.Background(), req)
 	require.NoError(t, err)
 
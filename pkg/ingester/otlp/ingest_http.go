@@ -0,0 +1,162 @@
+package otlp
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+
+	"connectrpc.com/connect"
+	"github.com/go-kit/log/level"
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/zstd"
+	pprofileotlp "go.opentelemetry.io/proto/otlp/collector/profiles/v1development"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/grafana/pyroscope/pkg/util/connectgrpc"
+)
+
+const (
+	contentTypeProtobuf = "application/x-protobuf"
+	contentTypeJSON     = "application/json"
+)
+
+// maxRequestBodySize limits the size of OTLP/HTTP requests, both compressed
+// and decompressed. It matches the default gRPC message size limit of the
+// server.
+const maxRequestBodySize = 100 << 20
+
+// otlpCodec encodes and decodes OTLP/HTTP messages:
+// binary protobuf and JSON encodings are supported.
+type otlpCodec struct {
+	contentType string
+	unmarshal   func([]byte, proto.Message) error
+	marshal     func(proto.Message) ([]byte, error)
+}
+
+var (
+	protobufCodec = otlpCodec{
+		contentType: contentTypeProtobuf,
+		unmarshal:   proto.Unmarshal,
+		marshal:     proto.Marshal,
+	}
+	jsonCodec = otlpCodec{
+		contentType: contentTypeJSON,
+		unmarshal:   protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal,
+		marshal:     protojson.Marshal,
+	}
+)
+
+// serveOTLPHTTP handles OTLP/HTTP export requests, as specified in
+// https://opentelemetry.io/docs/specs/otlp/#otlphttp. The tenant is
+// expected to be injected into the request context by the middleware.
+func (h *ingestHandler) serveOTLPHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	var codec otlpCodec
+	switch mediaType {
+	case contentTypeProtobuf:
+		codec = protobufCodec
+	case contentTypeJSON:
+		codec = jsonCodec
+	default:
+		http.Error(w, fmt.Sprintf("unsupported content type: %q", mediaType), http.StatusUnsupportedMediaType)
+		return
+	}
+
+	body, err := readOTLPBody(w, r, maxRequestBodySize)
+	if err != nil {
+		httpStatus := http.StatusBadRequest
+		var maxBytesErr *http.MaxBytesError
+		switch {
+		case errors.Is(err, errUnsupportedEncoding):
+			httpStatus = http.StatusUnsupportedMediaType
+		case errors.Is(err, errRequestTooLarge), errors.As(err, &maxBytesErr):
+			httpStatus = http.StatusRequestEntityTooLarge
+		}
+		h.writeOTLPResponse(w, codec, httpStatus, status.New(codes.InvalidArgument, err.Error()).Proto())
+		return
+	}
+	var req pprofileotlp.ExportProfilesServiceRequest
+	if err = codec.unmarshal(body, &req); err != nil {
+		h.writeOTLPError(w, codec, status.Errorf(codes.InvalidArgument, "failed to decode request: %s", err))
+		return
+	}
+	resp, err := h.export(r.Context(), &req)
+	if err != nil {
+		h.writeOTLPError(w, codec, err)
+		return
+	}
+	h.writeOTLPResponse(w, codec, http.StatusOK, resp)
+}
+
+var (
+	errUnsupportedEncoding = errors.New("unsupported content encoding")
+	errRequestTooLarge     = errors.New("request body too large")
+)
+
+// readOTLPBody reads the request body, decompressed. Both the compressed
+// and the decompressed body are limited to maxSize bytes.
+func readOTLPBody(w http.ResponseWriter, r *http.Request, maxSize int64) ([]byte, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxSize)
+	var body io.Reader = r.Body
+	switch enc := r.Header.Get("Content-Encoding"); enc {
+	case "", "identity":
+	case "gzip":
+		gr, err := gzip.NewReader(r.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read gzip body: %w", err)
+		}
+		defer gr.Close()
+		body = gr
+	case "zstd":
+		zr, err := zstd.NewReader(r.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read zstd body: %w", err)
+		}
+		defer zr.Close()
+		body = zr
+	default:
+		return nil, fmt.Errorf("%w: %q", errUnsupportedEncoding, enc)
+	}
+	b, err := io.ReadAll(io.LimitReader(body, maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	if int64(len(b)) > maxSize {
+		return nil, fmt.Errorf("%w: the limit is %d bytes", errRequestTooLarge, maxSize)
+	}
+	return b, nil
+}
+
+// writeOTLPError writes the error as a google.rpc.Status message,
+// with the HTTP status code corresponding to the error code.
+func (h *ingestHandler) writeOTLPError(w http.ResponseWriter, codec otlpCodec, err error) {
+	code := connect.CodeOf(err)
+	if code == connect.CodeUnknown {
+		code = connect.Code(status.Code(err))
+	}
+	httpStatus := int(connectgrpc.CodeToHTTP(code))
+	if httpStatus >= http.StatusInternalServerError {
+		level.Error(h.log).Log("msg", "failed to export profiles", "err", err)
+	}
+	h.writeOTLPResponse(w, codec, httpStatus, status.New(codes.Code(code), err.Error()).Proto())
+}
+
+func (h *ingestHandler) writeOTLPResponse(w http.ResponseWriter, codec otlpCodec, httpStatus int, m proto.Message) {
+	b, err := codec.marshal(m)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", codec.contentType)
+	w.WriteHeader(httpStatus)
+	_, _ = w.Write(b)
+}
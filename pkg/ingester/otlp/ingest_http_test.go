@@ -0,0 +1,234 @@
+package otlp
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/grafana/dskit/user"
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	pprofileotlp "go.opentelemetry.io/proto/otlp/collector/profiles/v1development"
+	otelProfile "go.opentelemetry.io/proto/otlp/profiles/v1development"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+	"github.com/grafana/pyroscope/pkg/distributor/model"
+	pyromodel "github.com/grafana/pyroscope/pkg/model"
+	"github.com/grafana/pyroscope/pkg/test"
+	"github.com/grafana/pyroscope/pkg/test/mocks/mockotlp"
+	"github.com/grafana/pyroscope/pkg/validation"
+)
+
+func newTestExportRequest(t *testing.T, invalid int) *pprofileotlp.ExportProfilesServiceRequest {
+	tree := new(pyromodel.Tree)
+	tree.InsertStack(10, "main", "foo")
+	tree.InsertStack(20, "main", "bar")
+	data, err := ConvertTreeToOtel(tree, &typesv1.ProfileType{
+		SampleType: "cpu",
+		SampleUnit: "nanoseconds",
+		PeriodType: "cpu",
+		PeriodUnit: "nanoseconds",
+	})
+	require.NoError(t, err)
+	sp := data.ResourceProfiles[0].ScopeProfiles[0]
+	for i := 0; i < invalid; i++ {
+		// The number of values does not match the sample types.
+		p := proto.Clone(sp.Profiles[0]).(*otelProfile.Profile)
+		p.Period = 100
+		p.Sample[1].Value = []int64{1, 2}
+		sp.Profiles = append(sp.Profiles, p)
+	}
+	return &pprofileotlp.ExportProfilesServiceRequest{
+		ResourceProfiles: data.ResourceProfiles,
+		Dictionary:       data.Dictionary,
+	}
+}
+
+func gzipBody(b []byte) []byte {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, _ = w.Write(b)
+	_ = w.Close()
+	return buf.Bytes()
+}
+
+func zstdBody(b []byte) []byte {
+	var buf bytes.Buffer
+	w, _ := zstd.NewWriter(&buf)
+	_, _ = w.Write(b)
+	_ = w.Close()
+	return buf.Bytes()
+}
+
+func Test_OTLPHTTP(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		contentType     string
+		contentEncoding string
+		invalid         int
+		pushErr         error
+		expectedStatus  int
+		expectedPushed  int
+		expectedResp    *pprofileotlp.ExportProfilesServiceResponse
+		expectedCode    codes.Code
+	}{
+		{
+			name:           "protobuf",
+			contentType:    contentTypeProtobuf,
+			expectedStatus: http.StatusOK,
+			expectedPushed: 1,
+			expectedResp:   &pprofileotlp.ExportProfilesServiceResponse{},
+		},
+		{
+			name:           "json",
+			contentType:    contentTypeJSON,
+			expectedStatus: http.StatusOK,
+			expectedPushed: 1,
+			expectedResp:   &pprofileotlp.ExportProfilesServiceResponse{},
+		},
+		{
+			name:            "protobuf gzip",
+			contentType:     contentTypeProtobuf,
+			contentEncoding: "gzip",
+			expectedStatus:  http.StatusOK,
+			expectedPushed:  1,
+			expectedResp:    &pprofileotlp.ExportProfilesServiceResponse{},
+		},
+		{
+			name:            "json zstd",
+			contentType:     contentTypeJSON + "; charset=utf-8",
+			contentEncoding: "zstd",
+			expectedStatus:  http.StatusOK,
+			expectedPushed:  1,
+			expectedResp:    &pprofileotlp.ExportProfilesServiceResponse{},
+		},
+		{
+			name:           "partial success",
+			contentType:    contentTypeProtobuf,
+			invalid:        2,
+			expectedStatus: http.StatusOK,
+			expectedPushed: 1,
+			expectedResp: &pprofileotlp.ExportProfilesServiceResponse{
+				PartialSuccess: &pprofileotlp.ExportProfilesPartialSuccess{
+					RejectedProfiles: 2,
+					ErrorMessage:     "failed to convert otel profile: sample values length mismatch 2 1",
+				},
+			},
+		},
+		{
+			name:           "rejected by distributor",
+			contentType:    contentTypeJSON,
+			pushErr:        connect.NewError(connect.CodeInvalidArgument, assert.AnError),
+			expectedStatus: http.StatusBadRequest,
+			expectedPushed: 1,
+			expectedCode:   codes.InvalidArgument,
+		},
+		{
+			name:           "push failure",
+			contentType:    contentTypeProtobuf,
+			pushErr:        connect.NewError(connect.CodeUnavailable, assert.AnError),
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedPushed: 1,
+			expectedCode:   codes.Unavailable,
+		},
+		{
+			name:            "unsupported encoding",
+			contentType:     contentTypeProtobuf,
+			contentEncoding: "br",
+			expectedStatus:  http.StatusUnsupportedMediaType,
+			expectedCode:    codes.InvalidArgument,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := mockotlp.NewMockPushService(t)
+			var pushed int
+			svc.On("PushParsed", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				pushed += len(args.Get(1).(*model.PushRequest).Series)
+			}).Return(nil, tc.pushErr).Maybe()
+			h := NewOTLPIngestHandler(svc, validation.MockLimits{}, test.NewTestingLogger(t), false)
+
+			codec := protobufCodec
+			if tc.contentType != contentTypeProtobuf {
+				codec = jsonCodec
+			}
+			body, err := codec.marshal(newTestExportRequest(t, tc.invalid))
+			require.NoError(t, err)
+			switch tc.contentEncoding {
+			case "gzip":
+				body = gzipBody(body)
+			case "zstd":
+				body = zstdBody(body)
+			}
+
+			req := httptest.NewRequest("POST", "/v1/profiles", bytes.NewReader(body))
+			req = req.WithContext(user.InjectOrgID(req.Context(), "tenant"))
+			req.Header.Set("Content-Type", tc.contentType)
+			req.Header.Set("Content-Encoding", tc.contentEncoding)
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.expectedStatus, rec.Code, rec.Body.String())
+			assert.Equal(t, codec.contentType, rec.Header().Get("Content-Type"))
+			assert.Equal(t, tc.expectedPushed, pushed)
+			if tc.expectedResp != nil {
+				var resp pprofileotlp.ExportProfilesServiceResponse
+				require.NoError(t, codec.unmarshal(rec.Body.Bytes(), &resp))
+				assert.True(t, proto.Equal(tc.expectedResp, &resp), protojson.Format(&resp))
+				return
+			}
+			var s spb.Status
+			require.NoError(t, codec.unmarshal(rec.Body.Bytes(), &s))
+			assert.Equal(t, int32(tc.expectedCode), s.Code)
+		})
+	}
+
+	t.Run("unsupported content type", func(t *testing.T) {
+		h := NewOTLPIngestHandler(mockotlp.NewMockPushService(t), validation.MockLimits{}, test.NewTestingLogger(t), false)
+		req := httptest.NewRequest("POST", "/v1/profiles", bytes.NewReader([]byte("{}")))
+		req.Header.Set("Content-Type", "text/plain")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+	})
+}
+
+func Test_readOTLPBody_Limit(t *testing.T) {
+	const maxSize = 1 << 10
+	for _, tc := range []struct {
+		name            string
+		contentEncoding string
+		body            []byte
+		expectedErr     bool
+	}{
+		{name: "within the limit", body: make([]byte, maxSize)},
+		{name: "compressed within the limit", contentEncoding: "gzip", body: gzipBody(make([]byte, maxSize))},
+		{name: "too large", body: make([]byte, maxSize+1), expectedErr: true},
+		// The compressed body is within the limit.
+		{name: "decompressed too large", contentEncoding: "gzip", body: gzipBody(make([]byte, 64<<10)), expectedErr: true},
+		{name: "zstd decompressed too large", contentEncoding: "zstd", body: zstdBody(make([]byte, 64<<10)), expectedErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.LessOrEqual(t, len(tc.body), maxSize+1)
+			req := httptest.NewRequest("POST", "/v1/profiles", bytes.NewReader(tc.body))
+			req.Header.Set("Content-Encoding", tc.contentEncoding)
+			b, err := readOTLPBody(httptest.NewRecorder(), req, maxSize)
+			if !tc.expectedErr {
+				require.NoError(t, err)
+				assert.Len(t, b, maxSize)
+				return
+			}
+			var maxBytesErr *http.MaxBytesError
+			assert.True(t, errors.Is(err, errRequestTooLarge) || errors.As(err, &maxBytesErr), err)
+		})
+	}
+}
@@ -23,9 +23,14 @@ import (
 
 	"github.com/grafana/pyroscope/api/model/labelset"
 	"github.com/grafana/pyroscope/pkg/og/agent/types"
+	"github.com/grafana/pyroscope/pkg/og/convert/callgrind"
+	"github.com/grafana/pyroscope/pkg/og/convert/gecko"
 	"github.com/grafana/pyroscope/pkg/og/convert/jfr"
+	"github.com/grafana/pyroscope/pkg/og/convert/massif"
+	"github.com/grafana/pyroscope/pkg/og/convert/perf"
 	"github.com/grafana/pyroscope/pkg/og/convert/pprof"
 	"github.com/grafana/pyroscope/pkg/og/convert/profile"
+	"github.com/grafana/pyroscope/pkg/og/convert/v8"
 	"github.com/grafana/pyroscope/pkg/og/ingestion"
 	"github.com/grafana/pyroscope/pkg/og/storage/metadata"
 	"github.com/grafana/pyroscope/pkg/og/util/attime"
@@ -197,6 +202,42 @@ func readInputRawDataFromRequest(ctx context.Context, r *http.Request, input *in
 			RawData: b,
 		}
 
+	case format == "perf_script":
+		input.Format = ingestion.FormatPerfScript
+		input.Profile = &perf.RawProfile{
+			RawData: b,
+		}
+
+	case format == "perf_data":
+		input.Format = ingestion.FormatPerfData
+		input.Profile = &perf.RawPerfData{
+			RawData: b,
+		}
+
+	case format == "cpuprofile":
+		input.Format = ingestion.FormatCPUProfile
+		input.Profile = &v8.RawProfile{
+			RawData: b,
+		}
+
+	case format == "gecko":
+		input.Format = ingestion.FormatGecko
+		input.Profile = &gecko.RawProfile{
+			RawData: b,
+		}
+
+	case format == "callgrind":
+		input.Format = ingestion.FormatCallgrind
+		input.Profile = &callgrind.RawProfile{
+			RawData: b,
+		}
+
+	case format == "massif":
+		input.Format = ingestion.FormatMassif
+		input.Profile = &massif.RawProfile{
+			RawData: b,
+		}
+
 	case strings.Contains(contentType, "multipart/form-data"):
 		input.Profile = &pprof.RawProfile{
 			FormDataContentType: contentType,
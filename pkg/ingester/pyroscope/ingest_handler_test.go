@@ -9,6 +9,7 @@ import (
 	"os"
 	"slices"
 	"sort"
+	"strings"
 	"testing"
 
 	"connectrpc.com/connect"
@@ -163,6 +164,153 @@ func TestCorruptedJFR422(t *testing.T) {
 	require.Equal(t, 422, res.Code)
 }
 
+func TestIngestPerfScript(t *testing.T) {
+	const script = `# ========
+# captured on    : Thu Jan  1 00:00:00 2025
+# ========
+#
+app 100/101 [000] 1.000001:     250000 cpu-clock:ppp:
+	    7f0000001000 work+0x10 (/usr/bin/app)
+	    7f0000002000 main+0x20 (/usr/bin/app)
+
+app 100/102 [001] 1.000002:     250000 cpu-clock:ppp:
+	    7f0000001008 work+0x18 (/usr/bin/app)
+	    7f0000002000 main+0x20 (/usr/bin/app)
+
+db 200 [002] 1.000003:         16 cycles:
+	    ffffffffb377256a do_syscall_64+0x6a (/lib/modules/5.19.0/build/vmlinux)
+	              11aaff read+0x18f (/usr/lib/x86_64-linux-gnu/libc.so.6)
+`
+	svc := &MockPushService{Keep: true, T: t}
+	h := NewPyroscopeIngestHandler(svc, log.NewSyncLogger(log.NewLogfmtLogger(os.Stderr)))
+	res := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/ingest?name=host{env=prod}&format=perf_script&sampleRate=100", bytes.NewReader([]byte(script)))
+	h.ServeHTTP(res, req)
+	require.Equal(t, 200, res.Code, res.Body.String())
+
+	require.Len(t, svc.reqPprof, 1)
+	ls := phlaremodel.Labels(svc.reqPprof[0].Labels)
+	assert.Equal(t, "process_cpu", ls.Get(labels.MetricName))
+	assert.Equal(t, "host", ls.Get(phlaremodel.LabelNameServiceName))
+	assert.Equal(t, "prod", ls.Get("env"))
+
+	p := svc.reqPprof[0].Profile
+	assert.Equal(t, "cpu", p.StringTable[p.SampleType[0].Type])
+	assert.Equal(t, "nanoseconds", p.StringTable[p.SampleType[0].Unit])
+	assert.Equal(t, int64(10000000), p.Period)
+	assert.Equal(t, []string{
+		"main;work 500000",
+		"read;do_syscall_64 10000000",
+	}, bench.StackCollapseProto(p, 0, 1))
+	sampleLabels := make([]string, 0, len(p.Sample))
+	for _, s := range p.Sample {
+		var l []string
+		for _, x := range s.Label {
+			l = append(l, p.StringTable[x.Key]+"="+p.StringTable[x.Str])
+		}
+		sampleLabels = append(sampleLabels, strings.Join(l, ","))
+	}
+	assert.Equal(t, []string{"comm=app,pid=100", "comm=db,pid=200"}, sampleLabels)
+
+	res = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/ingest?name=host&format=perf_script", bytes.NewReader([]byte("app 100 1.0: cpu-clock:\nnot a frame\n")))
+	h.ServeHTTP(res, req)
+	assert.Equal(t, 422, res.Code)
+}
+
+func TestIngestPerfData(t *testing.T) {
+	data, err := os.ReadFile("../../og/convert/perf/testdata/app.perf.data")
+	require.NoError(t, err)
+	svc := &MockPushService{Keep: true, T: t}
+	h := NewPyroscopeIngestHandler(svc, log.NewSyncLogger(log.NewLogfmtLogger(os.Stderr)))
+	res := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/ingest?name=host&format=perf_data", bytes.NewReader(data))
+	h.ServeHTTP(res, req)
+	require.Equal(t, 200, res.Code, res.Body.String())
+
+	require.Len(t, svc.reqPprof, 1)
+	ls := phlaremodel.Labels(svc.reqPprof[0].Labels)
+	assert.Equal(t, "process_cpu", ls.Get(labels.MetricName))
+	assert.Equal(t, "host", ls.Get(phlaremodel.LabelNameServiceName))
+
+	p := svc.reqPprof[0].Profile
+	require.Len(t, p.Sample, 2)
+	assert.Equal(t, []int64{500000}, p.Sample[0].Value)
+	assert.Equal(t, []int64{250000}, p.Sample[1].Value)
+	mappings := make([]string, 0, len(p.Mapping))
+	for _, m := range p.Mapping {
+		assert.False(t, m.HasFunctions)
+		mappings = append(mappings, p.StringTable[m.Filename]+" "+p.StringTable[m.BuildId])
+	}
+	assert.Equal(t, []string{
+		"[kernel.kallsyms]_text 00112233445566778899aabbccddeeff00112233",
+		"/usr/bin/app aabbccddeeff00112233445566778899aabbccdd",
+		"/usr/lib/libc.so.6 0123456789abcdef0123456789abcdef01234567",
+		"[unknown] ",
+	}, mappings)
+
+	res = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/ingest?name=host&format=perf_data", bytes.NewReader(data[:200]))
+	h.ServeHTTP(res, req)
+	assert.Equal(t, 422, res.Code)
+}
+
+func TestIngestConvertedProfiles(t *testing.T) {
+	for _, tc := range []struct {
+		format, file, name string
+		stacks             []string
+	}{
+		{
+			format: "cpuprofile",
+			file:   "../../og/convert/v8/testdata/app.cpuprofile",
+			name:   "process_cpu",
+			stacks: []string{"(program) 1000000", "main;(anonymous) 1000000", "main;work 2000000"},
+		},
+		{
+			format: "gecko",
+			file:   "../../og/convert/gecko/testdata/app.gecko.json",
+			name:   "wall",
+			stacks: []string{"XRE_Main;0x7f0012345678 1000000", "XRE_Main;render 2000000", "render 1000000"},
+		},
+		{
+			format: "callgrind",
+			file:   "../../og/convert/callgrind/testdata/callgrind.out.1234",
+			name:   "callgrind",
+			stacks: []string{"main 10", "main;helper 100", "main;work 400", "main;work;helper 200"},
+		},
+		{
+			format: "massif",
+			file:   "../../og/convert/massif/testdata/massif.out.1234",
+			name:   "memory",
+			stacks: []string{"0x400700 600", "[below threshold] 200", "init;work 300", "main;work 800", "work 100"},
+		},
+	} {
+		t.Run(tc.format, func(t *testing.T) {
+			data, err := os.ReadFile(tc.file)
+			require.NoError(t, err)
+			svc := &MockPushService{Keep: true, T: t}
+			h := NewPyroscopeIngestHandler(svc, log.NewSyncLogger(log.NewLogfmtLogger(os.Stderr)))
+			res := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/ingest?name=app&format="+tc.format, bytes.NewReader(data))
+			h.ServeHTTP(res, req)
+			require.Equal(t, 200, res.Code, res.Body.String())
+
+			require.Len(t, svc.reqPprof, 1)
+			ls := phlaremodel.Labels(svc.reqPprof[0].Labels)
+			assert.Equal(t, tc.name, ls.Get(labels.MetricName))
+			assert.Equal(t, "app", ls.Get(phlaremodel.LabelNameServiceName))
+			stacks := bench.StackCollapseProto(svc.reqPprof[0].Profile, 0, 1)
+			sort.Strings(stacks)
+			assert.Equal(t, tc.stacks, stacks)
+
+			res = httptest.NewRecorder()
+			req = httptest.NewRequest("POST", "/ingest?name=app&format="+tc.format, bytes.NewReader([]byte("{")))
+			h.ServeHTTP(res, req)
+			assert.Equal(t, 422, res.Code)
+		})
+	}
+}
+
 func createJFRRequestBody(t *testing.T, jfr, labels []byte) ([]byte, string) {
 	var b bytes.Buffer
 	w := multipart.NewWriter(&b)
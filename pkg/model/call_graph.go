@@ -0,0 +1,179 @@
+package model
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"sync"
+
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+)
+
+type callGraphEdge struct{ caller, callee string }
+
+// CallGraphFromTree builds the call graph of the tree: nodes are
+// aggregated by function name, and an edge connects each caller with
+// its callee. Values of recursive calls are accounted once, and direct
+// recursive calls do not produce edges.
+func CallGraphFromTree(t *Tree) *typesv1.CallGraph {
+	m := NewCallGraphMerger()
+	// Number of occurrences of the function
+	// and the call on the current path.
+	onPath := make(map[string]int)
+	edgesOnPath := make(map[callGraphEdge]int)
+	type frame struct {
+		n      *node
+		parent *node
+		exit   bool
+	}
+	stack := make([]frame, 0, defaultDFSSize)
+	for i := len(t.root) - 1; i >= 0; i-- {
+		stack = append(stack, frame{n: t.root[i]})
+	}
+	var f frame
+	for len(stack) > 0 {
+		f, stack = stack[len(stack)-1], stack[:len(stack)-1]
+		e := callGraphEdge{callee: f.n.name}
+		hasEdge := f.parent != nil && f.parent.name != f.n.name
+		if hasEdge {
+			e.caller = f.parent.name
+		}
+		if f.exit {
+			onPath[f.n.name]--
+			if hasEdge {
+				edgesOnPath[e]--
+			}
+			continue
+		}
+		var total int64
+		if onPath[f.n.name] == 0 {
+			total = f.n.total
+		}
+		m.insertNode(f.n.name, f.n.self, total)
+		onPath[f.n.name]++
+		if hasEdge {
+			if edgesOnPath[e] == 0 {
+				m.edges[e] += f.n.total
+			}
+			edgesOnPath[e]++
+		}
+		f.exit = true
+		stack = append(stack, f)
+		for i := len(f.n.children) - 1; i >= 0; i-- {
+			stack = append(stack, frame{n: f.n.children[i], parent: f.n})
+		}
+	}
+	return m.CallGraph(0)
+}
+
+// CallGraphMerger merges call graphs by function name. Call graphs of
+// disjoint sample sets are additive, therefore partial graphs can be
+// merged without loss of precision, unless they have been truncated.
+type CallGraphMerger struct {
+	mu    sync.Mutex
+	nodes map[string]*typesv1.CallGraphNode
+	edges map[callGraphEdge]int64
+}
+
+func NewCallGraphMerger() *CallGraphMerger {
+	return &CallGraphMerger{
+		nodes: make(map[string]*typesv1.CallGraphNode),
+		edges: make(map[callGraphEdge]int64),
+	}
+}
+
+func (m *CallGraphMerger) MergeCallGraph(g *typesv1.CallGraph) {
+	if g == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, n := range g.Nodes {
+		m.insertNode(n.Name, n.Self, n.Total)
+	}
+	for _, e := range g.Edges {
+		if int(e.Caller) >= len(g.Nodes) || int(e.Callee) >= len(g.Nodes) || e.Caller < 0 || e.Callee < 0 {
+			continue
+		}
+		m.edges[callGraphEdge{
+			caller: g.Nodes[e.Caller].Name,
+			callee: g.Nodes[e.Callee].Name,
+		}] += e.Value
+	}
+}
+
+func (m *CallGraphMerger) insertNode(name string, self, total int64) {
+	n, ok := m.nodes[name]
+	if !ok {
+		n = &typesv1.CallGraphNode{Name: name}
+		m.nodes[name] = n
+	}
+	n.Self += self
+	n.Total += total
+}
+
+// CallGraph returns the merged call graph. Nodes are ordered by their
+// total value in descending order. If maxNodes is greater than zero,
+// at most maxNodes nodes with the biggest total values are returned,
+// and the edges of the nodes removed are dropped.
+func (m *CallGraphMerger) CallGraph(maxNodes int64) *typesv1.CallGraph {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	nodes := make([]*typesv1.CallGraphNode, 0, len(m.nodes))
+	for _, n := range m.nodes {
+		nodes = append(nodes, n.CloneVT())
+	}
+	slices.SortFunc(nodes, func(a, b *typesv1.CallGraphNode) int {
+		if c := cmp.Compare(b.Total, a.Total); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	if maxNodes > 0 && int64(len(nodes)) > maxNodes {
+		nodes = nodes[:maxNodes]
+	}
+	index := make(map[string]int32, len(nodes))
+	for i, n := range nodes {
+		index[n.Name] = int32(i)
+	}
+	edges := make([]*typesv1.CallGraphEdge, 0, len(m.edges))
+	for e, v := range m.edges {
+		caller, ok := index[e.caller]
+		if !ok {
+			continue
+		}
+		callee, ok := index[e.callee]
+		if !ok {
+			continue
+		}
+		edges = append(edges, &typesv1.CallGraphEdge{
+			Caller: caller,
+			Callee: callee,
+			Value:  v,
+		})
+	}
+	slices.SortFunc(edges, func(a, b *typesv1.CallGraphEdge) int {
+		if c := cmp.Compare(a.Caller, b.Caller); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Callee, b.Callee)
+	})
+	return &typesv1.CallGraph{Nodes: nodes, Edges: edges}
+}
+
+func (m *CallGraphMerger) IsEmpty() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.nodes) == 0
+}
+
+// TruncateCallGraph returns the call graph with at most maxNodes nodes
+// with the biggest total values.
+func TruncateCallGraph(g *typesv1.CallGraph, maxNodes int64) *typesv1.CallGraph {
+	if g == nil || maxNodes <= 0 || int64(len(g.Nodes)) <= maxNodes {
+		return g
+	}
+	m := NewCallGraphMerger()
+	m.MergeCallGraph(g)
+	return m.CallGraph(maxNodes)
+}
@@ -0,0 +1,83 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+)
+
+func Test_CallGraphFromTree(t *testing.T) {
+	tree := new(Tree)
+	tree.InsertStack(1, "main", "a", "b")
+	tree.InsertStack(2, "main", "a", "a", "c")
+	tree.InsertStack(3, "main", "b")
+	tree.InsertStack(4, "main", "b", "a", "b")
+
+	expected := &typesv1.CallGraph{
+		Nodes: []*typesv1.CallGraphNode{
+			{Name: "main", Self: 0, Total: 10},
+			{Name: "b", Self: 8, Total: 8},
+			{Name: "a", Self: 0, Total: 7},
+			{Name: "c", Self: 2, Total: 2},
+		},
+		Edges: []*typesv1.CallGraphEdge{
+			{Caller: 0, Callee: 1, Value: 7},
+			{Caller: 0, Callee: 2, Value: 3},
+			{Caller: 1, Callee: 2, Value: 4},
+			{Caller: 2, Callee: 1, Value: 5},
+			{Caller: 2, Callee: 3, Value: 2},
+		},
+	}
+	assert.Equal(t, expected, CallGraphFromTree(tree))
+
+	expected = &typesv1.CallGraph{
+		Nodes: []*typesv1.CallGraphNode{
+			{Name: "main", Self: 0, Total: 10},
+			{Name: "b", Self: 8, Total: 8},
+		},
+		Edges: []*typesv1.CallGraphEdge{
+			{Caller: 0, Callee: 1, Value: 7},
+		},
+	}
+	assert.Equal(t, expected, TruncateCallGraph(CallGraphFromTree(tree), 2))
+}
+
+func Test_CallGraphMerger(t *testing.T) {
+	m := NewCallGraphMerger()
+	assert.True(t, m.IsEmpty())
+	m.MergeCallGraph(&typesv1.CallGraph{
+		Nodes: []*typesv1.CallGraphNode{
+			{Name: "main", Self: 0, Total: 5},
+			{Name: "a", Self: 5, Total: 5},
+		},
+		Edges: []*typesv1.CallGraphEdge{
+			{Caller: 0, Callee: 1, Value: 5},
+		},
+	})
+	m.MergeCallGraph(&typesv1.CallGraph{
+		Nodes: []*typesv1.CallGraphNode{
+			{Name: "b", Self: 3, Total: 3},
+			{Name: "a", Self: 1, Total: 4},
+			{Name: "main", Self: 0, Total: 4},
+		},
+		Edges: []*typesv1.CallGraphEdge{
+			{Caller: 2, Callee: 1, Value: 4},
+			{Caller: 1, Callee: 0, Value: 3},
+		},
+	})
+
+	expected := &typesv1.CallGraph{
+		Nodes: []*typesv1.CallGraphNode{
+			{Name: "a", Self: 6, Total: 9},
+			{Name: "main", Self: 0, Total: 9},
+			{Name: "b", Self: 3, Total: 3},
+		},
+		Edges: []*typesv1.CallGraphEdge{
+			{Caller: 0, Callee: 2, Value: 3},
+			{Caller: 1, Callee: 0, Value: 9},
+		},
+	}
+	assert.Equal(t, expected, m.CallGraph(0))
+}
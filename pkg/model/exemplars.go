@@ -0,0 +1,61 @@
+package model
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"sync"
+
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+)
+
+// SortExemplars sorts the exemplars by value in descending order and
+// returns at most limit exemplars. If limit is zero or negative, all
+// the exemplars are returned. The slice is sorted in place.
+func SortExemplars(exemplars []*typesv1.ProfileExemplar, limit int64) []*typesv1.ProfileExemplar {
+	slices.SortFunc(exemplars, compareExemplars)
+	if limit > 0 && int64(len(exemplars)) > limit {
+		exemplars = exemplars[:limit]
+	}
+	return exemplars
+}
+
+func compareExemplars(a, b *typesv1.ProfileExemplar) int {
+	if c := cmp.Compare(b.Value, a.Value); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(b.Timestamp, a.Timestamp); c != 0 {
+		return c
+	}
+	return strings.Compare(a.ProfileId, b.ProfileId)
+}
+
+// ExemplarsMerger merges exemplars and retains the ones with the
+// highest values. As partial results are expected to be limited
+// already, the limit is only applied when the exemplars are built.
+type ExemplarsMerger struct {
+	mu        sync.Mutex
+	exemplars []*typesv1.ProfileExemplar
+}
+
+func NewExemplarsMerger() *ExemplarsMerger {
+	return new(ExemplarsMerger)
+}
+
+func (m *ExemplarsMerger) MergeExemplars(exemplars []*typesv1.ProfileExemplar) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.exemplars = append(m.exemplars, exemplars...)
+}
+
+func (m *ExemplarsMerger) Exemplars(limit int64) []*typesv1.ProfileExemplar {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return SortExemplars(slices.Clone(m.exemplars), limit)
+}
+
+func (m *ExemplarsMerger) IsEmpty() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.exemplars) == 0
+}
@@ -0,0 +1,30 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+)
+
+func Test_ExemplarsMerger(t *testing.T) {
+	m := NewExemplarsMerger()
+	assert.True(t, m.IsEmpty())
+	m.MergeExemplars([]*typesv1.ProfileExemplar{
+		{ProfileId: "a", Timestamp: 1, Value: 10},
+		{ProfileId: "b", Timestamp: 2, Value: 5},
+	})
+	m.MergeExemplars([]*typesv1.ProfileExemplar{
+		{ProfileId: "c", Timestamp: 3, Value: 10},
+		{ProfileId: "d", Timestamp: 3, Value: 1},
+	})
+
+	expected := []*typesv1.ProfileExemplar{
+		{ProfileId: "c", Timestamp: 3, Value: 10},
+		{ProfileId: "a", Timestamp: 1, Value: 10},
+		{ProfileId: "b", Timestamp: 2, Value: 5},
+	}
+	assert.Equal(t, expected, m.Exemplars(3))
+	assert.Len(t, m.Exemplars(0), 4)
+}
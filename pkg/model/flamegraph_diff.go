@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/grafana/pyroscope/pkg/og/structs/cappedarr"
+	"github.com/grafana/pyroscope/pkg/slices"
 
 	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
 )
@@ -158,6 +159,61 @@ func NewFlamegraphDiffFromBytes(left, right []byte, maxNodes int64) (*querierv1.
 	return NewFlamegraphDiff(l, r, maxNodes)
 }
 
+// TruncateDiffTrees truncates the trees to be compared, so that the number
+// of nodes in the combined tree does not exceed maxNodes. Unlike truncation
+// of each tree on its own, a node is removed from both trees or retained in
+// both, therefore the difference between the trees is preserved. The trees
+// are modified in place.
+func TruncateDiffTrees(left, right *Tree, maxNodes int64) {
+	if maxNodes < 1 {
+		return
+	}
+	leftTree, rightTree := combineTree(left, right)
+	minVal := int64(combineMinValues(leftTree, rightTree, int(maxNodes)))
+	type truncated struct {
+		n     *node
+		other int64
+	}
+	visited := make([]truncated, 0, defaultDFSSize)
+	leftNodes := []*node{leftTree.root[0]}
+	rghtNodes := []*node{rightTree.root[0]}
+	var l, r *node
+	for len(leftNodes) > 0 {
+		l, leftNodes = leftNodes[len(leftNodes)-1], leftNodes[:len(leftNodes)-1]
+		r, rghtNodes = rghtNodes[len(rghtNodes)-1], rghtNodes[:len(rghtNodes)-1]
+		var otherLeft, otherRght int64
+		var j int
+		for i := range l.children {
+			lc, rc := l.children[i], r.children[i]
+			if lc.total >= minVal || rc.total >= minVal || lc.name == truncatedNodeName {
+				l.children[j], r.children[j] = lc, rc
+				j++
+				continue
+			}
+			otherLeft += lc.total
+			otherRght += rc.total
+		}
+		l.children, r.children = l.children[:j], r.children[:j]
+		leftNodes = append(leftNodes, l.children...)
+		rghtNodes = append(rghtNodes, r.children...)
+		visited = append(visited, truncated{l, otherLeft}, truncated{r, otherRght})
+	}
+	// The nodes that were added to align the trees are
+	// only removed once the traversal is complete.
+	for _, t := range visited {
+		t.n.children = slices.RemoveInPlace(t.n.children, func(c *node, _ int) bool {
+			return c.total == 0
+		})
+		if t.other > 0 {
+			o := t.n.insert(truncatedNodeName)
+			o.total += t.other
+			o.self += t.other
+		}
+	}
+	left.root = leftTree.root[0].children
+	right.root = rightTree.root[0].children
+}
+
 // combineTree aligns 2 trees by making them having the same structure with the
 // same number of nodes
 // It also makes the tree have a single root
@@ -123,3 +123,27 @@ func Test_Diff_No_Common_Root(t *testing.T) {
 	_, err := NewFlamegraphDiff(tr, tr2, 1024)
 	assert.NoError(t, err)
 }
+
+func Test_TruncateDiffTrees(t *testing.T) {
+	left := new(Tree)
+	left.InsertStack(1, "a", "b")
+	left.InsertStack(10, "a", "c")
+
+	right := new(Tree)
+	right.InsertStack(9, "a", "b")
+	right.InsertStack(1, "a", "c")
+	right.InsertStack(1, "a", "d")
+
+	TruncateDiffTrees(left, right, 4)
+
+	expectedLeft := new(Tree)
+	expectedLeft.InsertStack(1, "a", "b")
+	expectedLeft.InsertStack(10, "a", "c")
+	assert.Equal(t, expectedLeft.String(), left.String())
+
+	expectedRight := new(Tree)
+	expectedRight.InsertStack(9, "a", "b")
+	expectedRight.InsertStack(1, "a", "c")
+	expectedRight.InsertStack(1, "a", "other")
+	assert.Equal(t, expectedRight.String(), right.String())
+}
@@ -0,0 +1,230 @@
+package model
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
+)
+
+const (
+	DefaultFlameGraphSVGWidth    = 1200
+	DefaultFlameGraphSVGMinWidth = 0.1
+
+	svgFrameHeight = 16
+	svgPadding     = 10
+	svgHeader      = 34
+	svgCharWidth   = 7
+	svgSearchColor = "rgb(230,0,230)"
+)
+
+// FlameGraphSVGOptions controls the SVG rendering of flame graphs.
+type FlameGraphSVGOptions struct {
+	// Title is rendered above the flame graph.
+	Title string
+	// Unit of the values, shown in the frame titles.
+	Unit string
+	// Width of the image, in pixels.
+	Width int
+	// MinWidth is the width of the narrowest frame to render,
+	// in pixels. Narrower frames and their callees are omitted.
+	MinWidth float64
+	// Search highlights the frames with matching names.
+	Search *regexp.Regexp
+}
+
+// WriteFlameGraphSVG renders the flame graph as a self-contained SVG
+// image: frame details are shown in the titles, no scripts are used.
+func WriteFlameGraphSVG(w io.Writer, fg *querierv1.FlameGraph, opts FlameGraphSVGOptions) error {
+	r := newSVGRenderer(w, opts, len(fg.Levels))
+	for level, l := range fg.Levels {
+		var prev int64
+		// i+0 = x offset (delta encoded)
+		// i+1 = total
+		// i+2 = self
+		// i+3 = index in names array
+		for i := 0; i+3 < len(l.Values); i += 4 {
+			x := prev + l.Values[i]
+			total, self := l.Values[i+1], l.Values[i+2]
+			prev = x + total
+			name := fg.Names[l.Values[i+3]]
+			title := fmt.Sprintf("%s\ntotal: %s (%s)\nself: %s (%s)",
+				name,
+				r.value(total), percent(total, fg.Total),
+				r.value(self), percent(self, fg.Total),
+			)
+			r.frame(level, x, total, fg.Total, name, title, nameColor(name))
+		}
+	}
+	return r.close()
+}
+
+// WriteFlameGraphDiffSVG renders the diff flame graph as a self-contained
+// SVG image. Frames are sized after the sum of both sides, and coloured
+// after the change of their share in the profile: red frames grew, and
+// green frames shrank.
+func WriteFlameGraphDiffSVG(w io.Writer, fg *querierv1.FlameGraphDiff, opts FlameGraphSVGOptions) error {
+	r := newSVGRenderer(w, opts, len(fg.Levels))
+	for level, l := range fg.Levels {
+		var prevLeft, prevRight int64
+		// i+0 = x offset, left  tree (delta encoded)
+		// i+1 = total   , left  tree
+		// i+2 = self    , left  tree
+		// i+3 = x offset, right tree (delta encoded)
+		// i+4 = total   , right tree
+		// i+5 = self    , right tree
+		// i+6 = index in the names array
+		for i := 0; i+6 < len(l.Values); i += 7 {
+			xLeft := prevLeft + l.Values[i]
+			xRight := prevRight + l.Values[i+3]
+			left, right := l.Values[i+1], l.Values[i+4]
+			prevLeft, prevRight = xLeft+left, xRight+right
+			name := fg.Names[l.Values[i+6]]
+			d := diffRatio(left, right, fg.LeftTicks, fg.RightTicks)
+			title := fmt.Sprintf("%s\nleft: %s (%s)\nright: %s (%s)\ndiff: %+.2f%%",
+				name,
+				r.value(left), percent(left, fg.LeftTicks),
+				r.value(right), percent(right, fg.RightTicks),
+				d*100,
+			)
+			r.frame(level, xLeft+xRight, left+right, fg.Total, name, title, diffColor(d))
+		}
+	}
+	return r.close()
+}
+
+type svgRenderer struct {
+	w     *bufio.Writer
+	opts  FlameGraphSVGOptions
+	width float64
+}
+
+func newSVGRenderer(w io.Writer, opts FlameGraphSVGOptions, levels int) *svgRenderer {
+	if opts.Width <= 2*svgPadding {
+		opts.Width = DefaultFlameGraphSVGWidth
+	}
+	if opts.MinWidth <= 0 {
+		opts.MinWidth = DefaultFlameGraphSVGMinWidth
+	}
+	r := &svgRenderer{
+		w:     bufio.NewWriter(w),
+		opts:  opts,
+		width: float64(opts.Width - 2*svgPadding),
+	}
+	height := svgHeader + levels*svgFrameHeight + svgPadding
+	_, _ = fmt.Fprintf(r.w, `<?xml version="1.0" standalone="no"?>
+<svg version="1.1" width="%[1]d" height="%[2]d" viewBox="0 0 %[1]d %[2]d" xmlns="http://www.w3.org/2000/svg">
+<style>text{font-family:Verdana,sans-serif;font-size:12px;fill:rgb(0,0,0)}</style>
+<rect x="0" y="0" width="100%%" height="100%%" fill="rgb(248,248,248)"/>
+<text x="%[3]d" y="22" text-anchor="middle" style="font-size:16px">%[4]s</text>
+`, opts.Width, height, opts.Width/2, escapeXML(opts.Title))
+	return r
+}
+
+func (r *svgRenderer) frame(level int, x, value, total int64, name, title, fill string) {
+	if total <= 0 {
+		return
+	}
+	w := float64(value) / float64(total) * r.width
+	if w < r.opts.MinWidth {
+		return
+	}
+	if r.opts.Search != nil && r.opts.Search.MatchString(name) {
+		fill = svgSearchColor
+	}
+	px := svgPadding + float64(x)/float64(total)*r.width
+	py := svgHeader + level*svgFrameHeight
+	_, _ = fmt.Fprintf(r.w, `<g><title>%s</title><rect x="%.2f" y="%d" width="%.2f" height="%d" fill="%s" rx="2" ry="2"/>`,
+		escapeXML(title), px, py, w, svgFrameHeight-1, fill)
+	if label := truncateLabel(name, w); label != "" {
+		_, _ = fmt.Fprintf(r.w, `<text x="%.2f" y="%.1f">%s</text>`, px+3, float64(py)+11.5, escapeXML(label))
+	}
+	_, _ = r.w.WriteString("</g>\n")
+}
+
+func (r *svgRenderer) value(v int64) string {
+	if r.opts.Unit == "" {
+		return fmt.Sprint(v)
+	}
+	return fmt.Sprintf("%d %s", v, r.opts.Unit)
+}
+
+func (r *svgRenderer) close() error {
+	_, _ = r.w.WriteString("</svg>\n")
+	return r.w.Flush()
+}
+
+func truncateLabel(name string, width float64) string {
+	n := int((width - 6) / svgCharWidth)
+	if n < 3 {
+		return ""
+	}
+	if utf8.RuneCountInString(name) <= n {
+		return name
+	}
+	return string([]rune(name)[:n-2]) + ".."
+}
+
+func escapeXML(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+func percent(v, total int64) string {
+	if total == 0 {
+		return "0%"
+	}
+	return fmt.Sprintf("%.2f%%", float64(v)/float64(total)*100)
+}
+
+// nameColor returns a warm colour, that is stable for the function name.
+func nameColor(name string) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name))
+	v := h.Sum32()
+	r := 205 + v%50
+	g := (v >> 8) % 230
+	b := (v >> 16) % 55
+	return fmt.Sprintf("rgb(%d,%d,%d)", r, g, b)
+}
+
+// diffRatio returns the relative change of the share of the
+// value in the profile, clamped to the [-1, 1] range.
+func diffRatio(left, right, leftTicks, rightTicks int64) float64 {
+	var l, r float64
+	if leftTicks > 0 {
+		l = float64(left) / float64(leftTicks)
+	}
+	if rightTicks > 0 {
+		r = float64(right) / float64(rightTicks)
+	}
+	switch {
+	case l == r:
+		return 0
+	case l == 0:
+		return 1
+	}
+	return math.Max(-1, math.Min(1, (r-l)/l))
+}
+
+func diffColor(d float64) string {
+	// Neutral grey, blended into red or green proportionally to the change.
+	base, target := [3]float64{200, 200, 200}, [3]float64{60, 180, 75}
+	if d > 0 {
+		target = [3]float64{230, 60, 50}
+	}
+	a := math.Abs(d)
+	return fmt.Sprintf("rgb(%d,%d,%d)",
+		int(base[0]+(target[0]-base[0])*a),
+		int(base[1]+(target[1]-base[1])*a),
+		int(base[2]+(target[2]-base[2])*a),
+	)
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"bytes"
+	"encoding/xml"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WriteFlameGraphSVG(t *testing.T) {
+	tree := new(Tree)
+	tree.InsertStack(990, "main", "foo")
+	tree.InsertStack(10, "main", "bar<T>")
+	fg := NewFlameGraph(tree, -1)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteFlameGraphSVG(&buf, fg, FlameGraphSVGOptions{
+		Title:  "cpu",
+		Unit:   "nanoseconds",
+		Search: regexp.MustCompile("^foo$"),
+	}))
+	svg := buf.String()
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), new(any)))
+	assert.Equal(t, 4, strings.Count(svg, "<g>"))
+	assert.Contains(t, svg, `<title>foo&#xA;total: 990 nanoseconds (99.00%)&#xA;self: 990 nanoseconds (99.00%)</title>`)
+	assert.Contains(t, svg, `bar&lt;T&gt;`)
+	assert.Equal(t, 1, strings.Count(svg, svgSearchColor))
+
+	buf.Reset()
+	require.NoError(t, WriteFlameGraphSVG(&buf, fg, FlameGraphSVGOptions{Width: 520, MinWidth: 10}))
+	svg = buf.String()
+	assert.Contains(t, svg, `width="520"`)
+	assert.Equal(t, 3, strings.Count(svg, "<g>"))
+	assert.NotContains(t, svg, "bar")
+}
+
+func Test_WriteFlameGraphDiffSVG(t *testing.T) {
+	left := new(Tree)
+	left.InsertStack(50, "main", "foo")
+	left.InsertStack(50, "main", "bar")
+	right := new(Tree)
+	right.InsertStack(75, "main", "foo")
+	right.InsertStack(25, "main", "bar")
+	fg, err := NewFlamegraphDiff(left, right, -1)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteFlameGraphDiffSVG(&buf, fg, FlameGraphSVGOptions{}))
+	svg := buf.String()
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), new(any)))
+	assert.Equal(t, 4, strings.Count(svg, "<g>"))
+	assert.Contains(t, svg, `<title>foo&#xA;left: 50 (50.00%)&#xA;right: 75 (75.00%)&#xA;diff: +50.00%</title>`)
+	assert.Contains(t, svg, `fill="`+diffColor(0.5)+`"`)
+	assert.Contains(t, svg, `fill="`+diffColor(-0.5)+`"`)
+	assert.Contains(t, svg, `fill="`+diffColor(0)+`"`)
+}
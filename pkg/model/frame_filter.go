@@ -0,0 +1,133 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+)
+
+// FrameFilter filters stack trace frames by function name, similarly
+// to the pprof -focus, -ignore, -hide, and -prune_from options.
+type FrameFilter struct {
+	focus     *regexp.Regexp
+	ignore    *regexp.Regexp
+	hide      *regexp.Regexp
+	pruneFrom *regexp.Regexp
+}
+
+type frameMatch uint8
+
+const (
+	frameMatchChecked frameMatch = 1 << iota
+	frameMatchFocus
+	frameMatchIgnore
+	frameMatchHide
+	frameMatchPruneFrom
+)
+
+// NewFrameFilter compiles the frame filter.
+// If no filters are specified, nil is returned.
+func NewFrameFilter(f *typesv1.FrameFilter) (*FrameFilter, error) {
+	if f == nil || (f.Focus == "" && f.Ignore == "" && f.Hide == "" && f.PruneFrom == "") {
+		return nil, nil
+	}
+	var ff FrameFilter
+	var err error
+	for _, x := range []struct {
+		name string
+		expr string
+		dst  **regexp.Regexp
+	}{
+		{"focus", f.Focus, &ff.focus},
+		{"ignore", f.Ignore, &ff.ignore},
+		{"hide", f.Hide, &ff.hide},
+		{"prune_from", f.PruneFrom, &ff.pruneFrom},
+	} {
+		if x.expr == "" {
+			continue
+		}
+		if *x.dst, err = regexp.Compile(x.expr); err != nil {
+			return nil, fmt.Errorf("invalid %s frame filter: %w", x.name, err)
+		}
+	}
+	return &ff, nil
+}
+
+func (f *FrameFilter) match(name string) frameMatch {
+	m := frameMatchChecked
+	if f.focus != nil && f.focus.MatchString(name) {
+		m |= frameMatchFocus
+	}
+	if f.ignore != nil && f.ignore.MatchString(name) {
+		m |= frameMatchIgnore
+	}
+	if f.hide != nil && f.hide.MatchString(name) {
+		m |= frameMatchHide
+	}
+	if f.pruneFrom != nil && f.pruneFrom.MatchString(name) {
+		m |= frameMatchPruneFrom
+	}
+	return m
+}
+
+// FrameMatcher applies the frame filter to stack traces of function
+// names, referenced by their index in the string table. Matches are
+// memoized, therefore a matcher must not be used concurrently.
+type FrameMatcher struct {
+	filter  *FrameFilter
+	names   []string
+	matches []frameMatch
+}
+
+// Matcher returns a new matcher for the string table.
+func (f *FrameFilter) Matcher(names []string) *FrameMatcher {
+	return &FrameMatcher{
+		filter:  f,
+		names:   names,
+		matches: make([]frameMatch, len(names)),
+	}
+}
+
+func (m *FrameMatcher) match(name int32) frameMatch {
+	x := m.matches[name]
+	if x == 0 {
+		x = m.filter.match(m.names[name])
+		m.matches[name] = x
+	}
+	return x
+}
+
+// Filter filters the stack trace, leaf first, in place. If the stack
+// trace is to be dropped, the function returns an empty slice.
+//
+// Stack traces are selected with focus and ignore first, then the callees
+// of the outermost frame matching prune_from are removed, and finally the
+// frames matching hide are removed.
+func (m *FrameMatcher) Filter(stack []int32) []int32 {
+	var matched frameMatch
+	prune := -1
+	for i, name := range stack {
+		x := m.match(name)
+		matched |= x
+		if x&frameMatchPruneFrom != 0 {
+			prune = i
+		}
+	}
+	if m.filter.focus != nil && matched&frameMatchFocus == 0 {
+		return stack[:0]
+	}
+	if matched&frameMatchIgnore != 0 {
+		return stack[:0]
+	}
+	if prune > 0 {
+		stack = stack[prune:]
+	}
+	if matched&frameMatchHide != 0 {
+		stack = slices.DeleteFunc(stack, func(name int32) bool {
+			return m.match(name)&frameMatchHide != 0
+		})
+	}
+	return stack
+}
@@ -0,0 +1,97 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+)
+
+func Test_FrameMatcher_Filter(t *testing.T) {
+	names := []string{"", "main", "net/http.Serve", "runtime.mallocgc", "runtime.main", "foo", "bar"}
+	// Stack traces are leaf first.
+	stacks := map[string][]int32{
+		"alloc": {3, 5, 2, 1, 4},
+		"serve": {6, 2, 1, 4},
+		"main":  {5, 1, 4},
+	}
+
+	for _, tc := range []struct {
+		name     string
+		filter   *typesv1.FrameFilter
+		expected map[string][]string
+	}{
+		{
+			name:   "focus",
+			filter: &typesv1.FrameFilter{Focus: `^net/http\.`},
+			expected: map[string][]string{
+				"alloc": {"runtime.mallocgc", "foo", "net/http.Serve", "main", "runtime.main"},
+				"serve": {"bar", "net/http.Serve", "main", "runtime.main"},
+			},
+		},
+		{
+			name:   "ignore",
+			filter: &typesv1.FrameFilter{Ignore: `^bar$`},
+			expected: map[string][]string{
+				"alloc": {"runtime.mallocgc", "foo", "net/http.Serve", "main", "runtime.main"},
+				"main":  {"foo", "main", "runtime.main"},
+			},
+		},
+		{
+			name:   "hide",
+			filter: &typesv1.FrameFilter{Hide: `^runtime\.`},
+			expected: map[string][]string{
+				"alloc": {"foo", "net/http.Serve", "main"},
+				"serve": {"bar", "net/http.Serve", "main"},
+				"main":  {"foo", "main"},
+			},
+		},
+		{
+			name:   "prune_from",
+			filter: &typesv1.FrameFilter{PruneFrom: `^(main|net/http\.Serve)$`},
+			expected: map[string][]string{
+				"alloc": {"main", "runtime.main"},
+				"serve": {"main", "runtime.main"},
+				"main":  {"main", "runtime.main"},
+			},
+		},
+		{
+			name:   "combined",
+			filter: &typesv1.FrameFilter{Focus: `^foo$`, Hide: `^runtime\.`, PruneFrom: `^foo$`},
+			expected: map[string][]string{
+				"alloc": {"foo", "net/http.Serve", "main"},
+				"main":  {"foo", "main"},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := NewFrameFilter(tc.filter)
+			require.NoError(t, err)
+			m := f.Matcher(names)
+			actual := make(map[string][]string)
+			for k, stack := range stacks {
+				filtered := m.Filter(append([]int32(nil), stack...))
+				if len(filtered) == 0 {
+					continue
+				}
+				s := make([]string, len(filtered))
+				for i, n := range filtered {
+					s[i] = names[n]
+				}
+				actual[k] = s
+			}
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func Test_NewFrameFilter(t *testing.T) {
+	f, err := NewFrameFilter(&typesv1.FrameFilter{})
+	require.NoError(t, err)
+	assert.Nil(t, f)
+
+	_, err = NewFrameFilter(&typesv1.FrameFilter{Hide: "("})
+	require.ErrorContains(t, err, "invalid hide frame filter")
+}
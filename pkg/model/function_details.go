@@ -0,0 +1,279 @@
+package model
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	profilev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
+	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+)
+
+// FunctionDetails describes the callers and callees of a function.
+type FunctionDetails struct {
+	// The callers tree is inverted: the function
+	// is the root, and its callers are children.
+	Callers *Tree
+	// The function is the root of the callees tree.
+	Callees *Tree
+	// Lines are ordered by file name and line number.
+	Lines []*typesv1.FunctionLine
+}
+
+// FunctionFrame is a stack trace frame. Function name and
+// file name are referenced by their string table indices.
+type FunctionFrame struct {
+	Name     int32
+	FileName int32
+	Line     int64
+}
+
+type functionLineRef struct {
+	fileName int32
+	line     int64
+}
+
+type functionLine struct {
+	self  int64
+	total int64
+	// The last stack trace the line has been accounted
+	// in: recursive calls are only counted once.
+	last int
+}
+
+// FunctionDetailsBuilder builds the callers and callees trees of
+// the function, and aggregates the cost of its source code lines.
+// Only the outermost call of the function is taken into account
+// when the trees are built.
+type FunctionDetailsBuilder struct {
+	function int32
+	callers  *StacktraceTree
+	callees  *StacktraceTree
+	lines    map[functionLineRef]*functionLine
+	stack    []int32
+	cur      int
+}
+
+// NewFunctionDetailsBuilder creates a new builder
+// for the function name string index specified.
+func NewFunctionDetailsBuilder(function int32) *FunctionDetailsBuilder {
+	return &FunctionDetailsBuilder{
+		function: function,
+		callers:  NewStacktraceTree(defaultDFSSize),
+		callees:  NewStacktraceTree(defaultDFSSize),
+		lines:    make(map[functionLineRef]*functionLine),
+	}
+}
+
+// InsertStack inserts the stack trace, ordered from the leaf
+// to the root. Stack traces that do not include the function
+// are ignored.
+func (b *FunctionDetailsBuilder) InsertStack(frames []FunctionFrame, v int64) {
+	if v == 0 {
+		return
+	}
+	outermost := -1
+	for i := len(frames) - 1; i >= 0; i-- {
+		if frames[i].Name == b.function {
+			outermost = i
+			break
+		}
+	}
+	if outermost < 0 {
+		return
+	}
+	b.cur++
+	for i := outermost; i >= 0; i-- {
+		f := frames[i]
+		if f.Name != b.function || f.Line == 0 {
+			continue
+		}
+		ref := functionLineRef{fileName: f.FileName, line: f.Line}
+		l, ok := b.lines[ref]
+		if !ok {
+			l = new(functionLine)
+			b.lines[ref] = l
+		}
+		if i == 0 {
+			l.self += v
+		}
+		if l.last != b.cur {
+			l.last = b.cur
+			l.total += v
+		}
+	}
+	b.stack = b.stack[:0]
+	for i := 0; i <= outermost; i++ {
+		b.stack = append(b.stack, frames[i].Name)
+	}
+	b.callees.Insert(b.stack, v)
+	// The stack is expected to be ordered from the leaf to the root,
+	// therefore the root of the callers tree should be at the end.
+	b.stack = b.stack[:0]
+	for i := len(frames) - 1; i >= outermost; i-- {
+		b.stack = append(b.stack, frames[i].Name)
+	}
+	b.callers.Insert(b.stack, v)
+}
+
+// Build returns the function details. Frames of the stack traces
+// inserted reference the string table specified.
+func (b *FunctionDetailsBuilder) Build(names []string, maxNodes int64) *FunctionDetails {
+	d := &FunctionDetails{
+		Callers: b.callers.Tree(maxNodes, names),
+		Callees: b.callees.Tree(maxNodes, names),
+		Lines:   make([]*typesv1.FunctionLine, 0, len(b.lines)),
+	}
+	for ref, l := range b.lines {
+		d.Lines = append(d.Lines, &typesv1.FunctionLine{
+			FileName: names[ref.fileName],
+			Line:     ref.line,
+			Self:     l.self,
+			Total:    l.total,
+		})
+	}
+	sortFunctionLines(d.Lines)
+	return d
+}
+
+func sortFunctionLines(lines []*typesv1.FunctionLine) {
+	slices.SortFunc(lines, func(a, b *typesv1.FunctionLine) int {
+		if c := strings.Compare(a.FileName, b.FileName); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Line, b.Line)
+	})
+}
+
+// FunctionDetailsFromProfile builds the function details from the profile.
+// Location IDs of the profile are expected to match their indices (+1).
+func FunctionDetailsFromProfile(p *profilev1.Profile, function string, maxNodes int64) (*FunctionDetails, error) {
+	name := int32(slices.Index(p.StringTable, function))
+	if name <= 0 {
+		return &FunctionDetails{Callers: new(Tree), Callees: new(Tree)}, nil
+	}
+	b := NewFunctionDetailsBuilder(name)
+	frames := make([]FunctionFrame, 0, 64)
+	for i, s := range p.Sample {
+		if len(s.Value) == 0 {
+			continue
+		}
+		frames = frames[:0]
+		for _, id := range s.LocationId {
+			idx := int(id) - 1
+			if idx < 0 || len(p.Location) <= idx {
+				return nil, fmt.Errorf("invalid location ID %d in sample %d", id, i)
+			}
+			for _, line := range p.Location[idx].Line {
+				fn := int(line.FunctionId) - 1
+				if fn < 0 || len(p.Function) <= fn {
+					return nil, fmt.Errorf("invalid function ID %d in location %d", line.FunctionId, id)
+				}
+				frames = append(frames, FunctionFrame{
+					Name:     int32(p.Function[fn].Name),
+					FileName: int32(p.Function[fn].Filename),
+					Line:     line.Line,
+				})
+			}
+		}
+		b.InsertStack(frames, s.Value[0])
+	}
+	return b.Build(p.StringTable, maxNodes), nil
+}
+
+// FunctionDetailsMerger merges function details. The caller and callee
+// trees are merged as is, while line values are summed up.
+type FunctionDetailsMerger struct {
+	callers *TreeMerger
+	callees *TreeMerger
+	mu      sync.Mutex
+	lines   map[functionLineKey]*typesv1.FunctionLine
+}
+
+type functionLineKey struct {
+	fileName string
+	line     int64
+}
+
+func NewFunctionDetailsMerger() *FunctionDetailsMerger {
+	return &FunctionDetailsMerger{
+		callers: NewTreeMerger(),
+		callees: NewTreeMerger(),
+		lines:   make(map[functionLineKey]*typesv1.FunctionLine),
+	}
+}
+
+func (m *FunctionDetailsMerger) MergeFunctionDetails(d *FunctionDetails) {
+	m.callers.MergeTree(d.Callers)
+	m.callees.MergeTree(d.Callees)
+	m.MergeLines(d.Lines)
+}
+
+// MergeFunctionDetailsBytes merges function details
+// with the caller and callee trees in the binary format.
+func (m *FunctionDetailsMerger) MergeFunctionDetailsBytes(callers, callees []byte, lines []*typesv1.FunctionLine) error {
+	if err := m.callers.MergeTreeBytes(callers); err != nil {
+		return err
+	}
+	if err := m.callees.MergeTreeBytes(callees); err != nil {
+		return err
+	}
+	m.MergeLines(lines)
+	return nil
+}
+
+func (m *FunctionDetailsMerger) MergeLines(lines []*typesv1.FunctionLine) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, l := range lines {
+		k := functionLineKey{fileName: l.FileName, line: l.Line}
+		x, ok := m.lines[k]
+		if !ok {
+			m.lines[k] = l.CloneVT()
+			continue
+		}
+		x.Self += l.Self
+		x.Total += l.Total
+	}
+}
+
+func (m *FunctionDetailsMerger) FunctionDetails() *FunctionDetails {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	d := &FunctionDetails{
+		Callers: m.callers.Tree(),
+		Callees: m.callees.Tree(),
+	}
+	d.Lines = make([]*typesv1.FunctionLine, 0, len(m.lines))
+	for _, l := range m.lines {
+		d.Lines = append(d.Lines, l)
+	}
+	sortFunctionLines(d.Lines)
+	return d
+}
+
+func (m *FunctionDetailsMerger) IsEmpty() bool {
+	return m.callers.IsEmpty() && m.callees.IsEmpty()
+}
+
+// NewFunctionDetailsResponse returns the function details with
+// the caller and callee trees in the format specified.
+func NewFunctionDetailsResponse(
+	d *FunctionDetails,
+	format querierv1.ProfileFormat,
+	maxNodes int64,
+) *querierv1.SelectFunctionDetailsResponse {
+	resp := &querierv1.SelectFunctionDetailsResponse{Lines: d.Lines}
+	switch format {
+	case querierv1.ProfileFormat_PROFILE_FORMAT_TREE:
+		resp.CallersTree = d.Callers.Bytes(maxNodes)
+		resp.CalleesTree = d.Callees.Bytes(maxNodes)
+	default:
+		resp.Callers = NewFlameGraph(d.Callers, maxNodes)
+		resp.Callees = NewFlameGraph(d.Callees, maxNodes)
+	}
+	return resp
+}
@@ -0,0 +1,79 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	profilev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+)
+
+func Test_FunctionDetailsFromProfile(t *testing.T) {
+	p := &profilev1.Profile{
+		StringTable: []string{"", "main", "a", "b", "c", "main.go", "a.go"},
+		Function: []*profilev1.Function{
+			{Id: 1, Name: 1, Filename: 5},
+			{Id: 2, Name: 2, Filename: 6},
+			{Id: 3, Name: 3, Filename: 6},
+			{Id: 4, Name: 4, Filename: 6},
+		},
+		Location: []*profilev1.Location{
+			{Id: 1, Line: []*profilev1.Line{{FunctionId: 1, Line: 10}}},
+			{Id: 2, Line: []*profilev1.Line{{FunctionId: 2, Line: 20}}},
+			{Id: 3, Line: []*profilev1.Line{{FunctionId: 2, Line: 21}}},
+			{Id: 4, Line: []*profilev1.Line{{FunctionId: 3, Line: 30}}},
+			// c is inlined into a.
+			{Id: 5, Line: []*profilev1.Line{{FunctionId: 4, Line: 40}, {FunctionId: 2, Line: 22}}},
+		},
+		Sample: []*profilev1.Sample{
+			{LocationId: []uint64{2, 1}, Value: []int64{1}},
+			{LocationId: []uint64{4, 2, 1}, Value: []int64{2}},
+			{LocationId: []uint64{3, 4, 2, 1}, Value: []int64{4}},
+			{LocationId: []uint64{5, 4, 1}, Value: []int64{8}},
+			{LocationId: []uint64{4, 1}, Value: []int64{16}},
+		},
+	}
+
+	d, err := FunctionDetailsFromProfile(p, "a", 0)
+	require.NoError(t, err)
+
+	callers := new(Tree)
+	callers.InsertStack(1, "a", "main")
+	callers.InsertStack(2, "a", "main")
+	callers.InsertStack(4, "a", "main")
+	callers.InsertStack(8, "a", "b", "main")
+	assert.Equal(t, callers.String(), d.Callers.String())
+
+	callees := new(Tree)
+	callees.InsertStack(1, "a")
+	callees.InsertStack(2, "a", "b")
+	callees.InsertStack(4, "a", "b", "a")
+	callees.InsertStack(8, "a", "c")
+	assert.Equal(t, callees.String(), d.Callees.String())
+
+	expected := []*typesv1.FunctionLine{
+		{FileName: "a.go", Line: 20, Self: 1, Total: 7},
+		{FileName: "a.go", Line: 21, Self: 4, Total: 4},
+		{FileName: "a.go", Line: 22, Self: 0, Total: 8},
+	}
+	assert.Equal(t, expected, d.Lines)
+
+	m := NewFunctionDetailsMerger()
+	m.MergeFunctionDetails(d)
+	require.NoError(t, m.MergeFunctionDetailsBytes(d.Callers.Bytes(0), d.Callees.Bytes(0), d.Lines))
+	merged := m.FunctionDetails()
+	assert.Equal(t, 2*callers.Total(), merged.Callers.Total())
+	assert.Equal(t, 2*callees.Total(), merged.Callees.Total())
+	assert.Equal(t, []*typesv1.FunctionLine{
+		{FileName: "a.go", Line: 20, Self: 2, Total: 14},
+		{FileName: "a.go", Line: 21, Self: 8, Total: 8},
+		{FileName: "a.go", Line: 22, Self: 0, Total: 16},
+	}, merged.Lines)
+
+	d, err = FunctionDetailsFromProfile(p, "unknown", 0)
+	require.NoError(t, err)
+	assert.Zero(t, d.Callers.Total())
+	assert.Empty(t, d.Lines)
+}
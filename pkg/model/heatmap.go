@@ -0,0 +1,162 @@
+package model
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+)
+
+const (
+	// DefaultHeatmapValueResolution is the number of value buckets per
+	// power of two: each bucket is ~19% wider than the previous one.
+	DefaultHeatmapValueResolution = 4
+	MaxHeatmapValueResolution     = 64
+)
+
+// HeatmapValueResolution returns the value resolution to be used
+// for the requested one: zero means the default resolution.
+func HeatmapValueResolution(r int32) (int32, error) {
+	switch {
+	case r == 0:
+		return DefaultHeatmapValueResolution, nil
+	case r < 0 || r > MaxHeatmapValueResolution:
+		return 0, fmt.Errorf("heatmap value resolution must be between 1 and %d", MaxHeatmapValueResolution)
+	}
+	return r, nil
+}
+
+// HeatmapValueBucket returns the index of the value bucket. Values
+// not greater than one, including negative ones, fall into bucket 0.
+func HeatmapValueBucket(v float64, resolution int32) int32 {
+	if v <= 1 || math.IsNaN(v) {
+		return 0
+	}
+	b := math.Ceil(math.Log2(v) * float64(resolution))
+	if b > math.MaxInt32 {
+		return math.MaxInt32
+	}
+	return int32(b)
+}
+
+// HeatmapValueBucketBounds returns the bounds of the value bucket.
+func HeatmapValueBucketBounds(bucket, resolution int32) (lo, hi float64) {
+	if bucket <= 0 {
+		return 0, 1
+	}
+	r := float64(resolution)
+	return math.Exp2(float64(bucket-1) / r), math.Exp2(float64(bucket) / r)
+}
+
+type heatmapCellKey struct {
+	timestamp int64
+	bucket    int32
+}
+
+type heatmapCells struct {
+	step       int64
+	resolution int32
+	cells      map[heatmapCellKey]int64
+}
+
+func newHeatmapCells(step int64, resolution int32) heatmapCells {
+	return heatmapCells{
+		step:       max(step, 1),
+		resolution: resolution,
+		cells:      make(map[heatmapCellKey]int64),
+	}
+}
+
+func (h *heatmapCells) add(ts int64, bucket int32, count int64) {
+	// The time bucket is aligned to a multiple of the step,
+	// regardless of the query time range.
+	r := ts % h.step
+	if r < 0 {
+		r += h.step
+	}
+	ts -= r
+	h.cells[heatmapCellKey{timestamp: ts, bucket: bucket}] += count
+}
+
+func (h *heatmapCells) heatmap() *typesv1.Heatmap {
+	cells := make([]*typesv1.HeatmapCell, 0, len(h.cells))
+	for k, count := range h.cells {
+		lo, hi := HeatmapValueBucketBounds(k.bucket, h.resolution)
+		cells = append(cells, &typesv1.HeatmapCell{
+			Timestamp:   k.timestamp,
+			ValueBucket: k.bucket,
+			MinValue:    lo,
+			MaxValue:    hi,
+			Count:       count,
+		})
+	}
+	slices.SortFunc(cells, func(a, b *typesv1.HeatmapCell) int {
+		if c := cmp.Compare(a.Timestamp, b.Timestamp); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ValueBucket, b.ValueBucket)
+	})
+	return &typesv1.Heatmap{
+		Step:            h.step,
+		ValueResolution: h.resolution,
+		Cells:           cells,
+	}
+}
+
+// HeatmapBuilder buckets individual profile values by time and value.
+// The step is specified in milliseconds.
+type HeatmapBuilder struct{ cells heatmapCells }
+
+func NewHeatmapBuilder(step int64, resolution int32) *HeatmapBuilder {
+	return &HeatmapBuilder{cells: newHeatmapCells(step, resolution)}
+}
+
+// Add accounts the profile value at the timestamp (in milliseconds).
+func (b *HeatmapBuilder) Add(ts int64, v float64) {
+	b.cells.add(ts, HeatmapValueBucket(v, b.cells.resolution), 1)
+}
+
+func (b *HeatmapBuilder) Build() *typesv1.Heatmap { return b.cells.heatmap() }
+
+// HeatmapMerger merges heatmaps with the same bucket layout: as
+// the layout does not depend on the data, partial heatmaps can be
+// merged without loss of precision.
+type HeatmapMerger struct {
+	mu    sync.Mutex
+	cells heatmapCells
+}
+
+func NewHeatmapMerger(step int64, resolution int32) *HeatmapMerger {
+	return &HeatmapMerger{cells: newHeatmapCells(step, resolution)}
+}
+
+func (m *HeatmapMerger) MergeHeatmap(h *typesv1.Heatmap) error {
+	if h == nil || len(h.Cells) == 0 {
+		return nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if h.Step != m.cells.step || h.ValueResolution != m.cells.resolution {
+		return fmt.Errorf("heatmap layout mismatch: step %d and resolution %d, expected step %d and resolution %d",
+			h.Step, h.ValueResolution, m.cells.step, m.cells.resolution)
+	}
+	for _, c := range h.Cells {
+		m.cells.add(c.Timestamp, c.ValueBucket, c.Count)
+	}
+	return nil
+}
+
+func (m *HeatmapMerger) Heatmap() *typesv1.Heatmap {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.cells.heatmap()
+}
+
+func (m *HeatmapMerger) IsEmpty() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.cells.cells) == 0
+}
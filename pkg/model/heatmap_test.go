@@ -0,0 +1,65 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+)
+
+func Test_HeatmapValueBucket(t *testing.T) {
+	for _, tc := range []struct {
+		value  float64
+		bucket int32
+	}{
+		{value: -1, bucket: 0},
+		{value: 0, bucket: 0},
+		{value: 1, bucket: 0},
+		{value: 1.1, bucket: 1},
+		{value: 2, bucket: 4},
+		{value: 3, bucket: 7},
+		{value: 1024, bucket: 40},
+	} {
+		b := HeatmapValueBucket(tc.value, 4)
+		assert.Equal(t, tc.bucket, b, tc.value)
+		lo, hi := HeatmapValueBucketBounds(b, 4)
+		if b > 0 {
+			assert.Less(t, lo, tc.value)
+		}
+		assert.LessOrEqual(t, tc.value, hi)
+	}
+}
+
+func Test_HeatmapMerger(t *testing.T) {
+	a := NewHeatmapBuilder(10, 1)
+	a.Add(1, 1)
+	a.Add(5, 4)
+	a.Add(-5, 2)
+	b := NewHeatmapBuilder(10, 1)
+	b.Add(9, 3)
+	b.Add(10, 3)
+
+	m := NewHeatmapMerger(10, 1)
+	assert.True(t, m.IsEmpty())
+	require.NoError(t, m.MergeHeatmap(a.Build()))
+	require.NoError(t, m.MergeHeatmap(b.Build()))
+	// Empty heatmaps are ignored regardless of their layout.
+	require.NoError(t, m.MergeHeatmap(NewHeatmapBuilder(5, 1).Build()))
+	invalid := NewHeatmapBuilder(5, 1)
+	invalid.Add(1, 1)
+	require.Error(t, m.MergeHeatmap(invalid.Build()))
+
+	expected := &typesv1.Heatmap{
+		Step:            10,
+		ValueResolution: 1,
+		Cells: []*typesv1.HeatmapCell{
+			{Timestamp: -10, ValueBucket: 1, MinValue: 1, MaxValue: 2, Count: 1},
+			{Timestamp: 0, ValueBucket: 0, MinValue: 0, MaxValue: 1, Count: 1},
+			{Timestamp: 0, ValueBucket: 2, MinValue: 2, MaxValue: 4, Count: 2},
+			{Timestamp: 10, ValueBucket: 2, MinValue: 2, MaxValue: 4, Count: 1},
+		},
+	}
+	assert.Equal(t, expected, m.Heatmap())
+}
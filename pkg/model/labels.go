@@ -32,6 +32,7 @@ const (
 	LabelNameSessionID          = "__session_id__"
 	LabelNameType               = "__type__"
 	LabelNameUnit               = "__unit__"
+	LabelNameTenant             = "__tenant__"
 
 	LabelNameServiceGitRef     = "service_git_ref"
 	LabelNameServiceName       = "service_name"
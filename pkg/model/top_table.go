@@ -0,0 +1,126 @@
+package model
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"sync"
+
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+)
+
+// TopTableFromTree aggregates the tree nodes by function name. Values of
+// recursive calls are accounted once: only the outermost call contributes
+// to the function total. Sample counts are not available in the tree,
+// therefore the Samples field is not set.
+func TopTableFromTree(t *Tree) []*typesv1.TopTableEntry {
+	entries := make(map[string]*typesv1.TopTableEntry)
+	// Number of occurrences of the function on the current path.
+	onPath := make(map[string]int)
+	type frame struct {
+		n    *node
+		exit bool
+	}
+	nodes := make([]frame, 0, defaultDFSSize)
+	for i := len(t.root) - 1; i >= 0; i-- {
+		nodes = append(nodes, frame{n: t.root[i]})
+	}
+	var f frame
+	for len(nodes) > 0 {
+		f, nodes = nodes[len(nodes)-1], nodes[:len(nodes)-1]
+		if f.exit {
+			onPath[f.n.name]--
+			continue
+		}
+		e, ok := entries[f.n.name]
+		if !ok {
+			e = &typesv1.TopTableEntry{Name: f.n.name}
+			entries[f.n.name] = e
+		}
+		e.Self += f.n.self
+		if onPath[f.n.name] == 0 {
+			e.Total += f.n.total
+		}
+		onPath[f.n.name]++
+		nodes = append(nodes, frame{n: f.n, exit: true})
+		for i := len(f.n.children) - 1; i >= 0; i-- {
+			nodes = append(nodes, frame{n: f.n.children[i]})
+		}
+	}
+	table := make([]*typesv1.TopTableEntry, 0, len(entries))
+	for _, e := range entries {
+		table = append(table, e)
+	}
+	return table
+}
+
+// SortTopTable sorts the entries in descending order by the value
+// specified and then lexicographically by function name. If limit
+// is greater than zero, at most limit entries are returned.
+func SortTopTable(entries []*typesv1.TopTableEntry, orderBy typesv1.TopTableOrderBy, limit int64) []*typesv1.TopTableEntry {
+	value := func(e *typesv1.TopTableEntry) int64 { return e.Self }
+	if orderBy == typesv1.TopTableOrderBy_TOP_TABLE_ORDER_BY_TOTAL {
+		value = func(e *typesv1.TopTableEntry) int64 { return e.Total }
+	}
+	slices.SortFunc(entries, func(a, b *typesv1.TopTableEntry) int {
+		if c := cmp.Compare(value(b), value(a)); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	if limit > 0 && int64(len(entries)) > limit {
+		return entries[:limit]
+	}
+	return entries
+}
+
+// TopTableMerger merges top table entries by function name. Entries of
+// disjoint sample sets are additive, therefore partial tables can be
+// merged without loss of precision, unless they have been truncated.
+// The sample count of a function is only set if it is known in all
+// the entries merged.
+type TopTableMerger struct {
+	mu      sync.Mutex
+	entries map[string]*typesv1.TopTableEntry
+}
+
+func NewTopTableMerger() *TopTableMerger {
+	return &TopTableMerger{entries: make(map[string]*typesv1.TopTableEntry)}
+}
+
+func (m *TopTableMerger) MergeEntries(entries []*typesv1.TopTableEntry) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, e := range entries {
+		x, ok := m.entries[e.Name]
+		if !ok {
+			m.entries[e.Name] = e.CloneVT()
+			continue
+		}
+		x.Self += e.Self
+		x.Total += e.Total
+		if x.Samples != nil && e.Samples != nil {
+			*x.Samples += *e.Samples
+		} else {
+			x.Samples = nil
+		}
+	}
+}
+
+// Entries returns the merged entries sorted as specified.
+// If limit is greater than zero, at most limit entries are returned.
+func (m *TopTableMerger) Entries(orderBy typesv1.TopTableOrderBy, limit int64) []*typesv1.TopTableEntry {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entries := make([]*typesv1.TopTableEntry, 0, len(m.entries))
+	for _, e := range m.entries {
+		entries = append(entries, e)
+	}
+	return SortTopTable(entries, orderBy, limit)
+}
+
+func (m *TopTableMerger) IsEmpty() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.entries) == 0
+}
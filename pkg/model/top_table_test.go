@@ -0,0 +1,64 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+)
+
+func Test_TopTableFromTree(t *testing.T) {
+	tree := new(Tree)
+	tree.InsertStack(1, "main", "a", "b")
+	tree.InsertStack(2, "main", "a", "a", "c")
+	tree.InsertStack(3, "main", "b")
+	tree.InsertStack(4, "main")
+
+	expected := []*typesv1.TopTableEntry{
+		{Name: "b", Self: 4, Total: 4},
+		{Name: "main", Self: 4, Total: 10},
+		{Name: "c", Self: 2, Total: 2},
+		{Name: "a", Self: 0, Total: 3},
+	}
+	assert.Equal(t, expected, SortTopTable(TopTableFromTree(tree), typesv1.TopTableOrderBy_TOP_TABLE_ORDER_BY_SELF, 0))
+
+	expected = []*typesv1.TopTableEntry{
+		{Name: "main", Self: 4, Total: 10},
+		{Name: "b", Self: 4, Total: 4},
+	}
+	assert.Equal(t, expected, SortTopTable(TopTableFromTree(tree), typesv1.TopTableOrderBy_TOP_TABLE_ORDER_BY_TOTAL, 2))
+}
+
+func Test_TopTableMerger(t *testing.T) {
+	m := NewTopTableMerger()
+	assert.True(t, m.IsEmpty())
+	m.MergeEntries([]*typesv1.TopTableEntry{
+		{Name: "a", Self: 1, Total: 5, Samples: proto.Int64(2)},
+		{Name: "b", Self: 4, Total: 4, Samples: proto.Int64(1)},
+	})
+	m.MergeEntries([]*typesv1.TopTableEntry{
+		{Name: "a", Self: 2, Total: 2, Samples: proto.Int64(1)},
+		{Name: "c", Self: 7, Total: 7, Samples: proto.Int64(3)},
+	})
+
+	expected := []*typesv1.TopTableEntry{
+		{Name: "a", Self: 3, Total: 7, Samples: proto.Int64(3)},
+		{Name: "c", Self: 7, Total: 7, Samples: proto.Int64(3)},
+		{Name: "b", Self: 4, Total: 4, Samples: proto.Int64(1)},
+	}
+	assert.Equal(t, expected, m.Entries(typesv1.TopTableOrderBy_TOP_TABLE_ORDER_BY_TOTAL, 0))
+
+	expected = []*typesv1.TopTableEntry{
+		{Name: "c", Self: 7, Total: 7, Samples: proto.Int64(3)},
+	}
+	assert.Equal(t, expected, m.Entries(typesv1.TopTableOrderBy_TOP_TABLE_ORDER_BY_SELF, 1))
+
+	// The count is not set once merged with an entry without it.
+	m.MergeEntries([]*typesv1.TopTableEntry{{Name: "c", Self: 1, Total: 1}})
+	expected = []*typesv1.TopTableEntry{
+		{Name: "c", Self: 8, Total: 8},
+	}
+	assert.Equal(t, expected, m.Entries(typesv1.TopTableOrderBy_TOP_TABLE_ORDER_BY_SELF, 1))
+}
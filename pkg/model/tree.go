@@ -440,7 +440,10 @@ func UnmarshalTree(b []byte) (*Tree, error) {
 		}
 	}
 
-	// Remove the virtual root.
+	// Remove the virtual root. The top-level nodes keep the reference
+	// to their parent, which must be detached from the virtual root,
+	// just like the trees built with InsertStack.
+	root.children[0].parent = nil
 	t.root = root.children[0].children
 
 	return t, nil
@@ -0,0 +1,107 @@
+package model
+
+import (
+	profilev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+)
+
+// DiffBaseLabel is the sample label pprof uses to mark the samples of
+// the base profile in a diff (see pprof -diff_base option).
+const DiffBaseLabel = "pprof::base"
+
+// TreeToPprof converts the tree into a pprof profile of the given type.
+// Each stack trace of the tree becomes a sample; as the tree only has
+// the function names, each function has a single location.
+func TreeToPprof(t *Tree, profileType *typesv1.ProfileType) *profilev1.Profile {
+	b := newTreePprofBuilder(profileType)
+	b.addTree(t, 1, nil)
+	return b.profile
+}
+
+// DiffTreesToPprof converts the trees into a pprof profile that is
+// equivalent to the one pprof builds with -diff_base: the base (left)
+// samples are negated and labeled with DiffBaseLabel.
+func DiffTreesToPprof(left, right *Tree, profileType *typesv1.ProfileType) *profilev1.Profile {
+	b := newTreePprofBuilder(profileType)
+	b.addTree(right, 1, nil)
+	b.addTree(left, -1, []*profilev1.Label{{
+		Key: b.string(DiffBaseLabel),
+		Str: b.string("true"),
+	}})
+	return b.profile
+}
+
+type treePprofBuilder struct {
+	profile   *profilev1.Profile
+	strings   map[string]int64
+	functions map[string]uint64
+}
+
+func newTreePprofBuilder(profileType *typesv1.ProfileType) *treePprofBuilder {
+	b := &treePprofBuilder{
+		profile: &profilev1.Profile{
+			StringTable: []string{""},
+			Mapping:     []*profilev1.Mapping{{Id: 1}},
+		},
+		strings:   make(map[string]int64),
+		functions: make(map[string]uint64),
+	}
+	b.profile.SampleType = []*profilev1.ValueType{{
+		Type: b.string(profileType.SampleType),
+		Unit: b.string(profileType.SampleUnit),
+	}}
+	if profileType.PeriodType != "" {
+		b.profile.PeriodType = &profilev1.ValueType{
+			Type: b.string(profileType.PeriodType),
+			Unit: b.string(profileType.PeriodUnit),
+		}
+	}
+	return b
+}
+
+func (b *treePprofBuilder) addTree(t *Tree, scale int64, labels []*profilev1.Label) {
+	t.IterateStacks(func(_ string, self int64, stack []string) {
+		locations := make([]uint64, len(stack))
+		for i, name := range stack {
+			locations[i] = b.location(name)
+		}
+		b.profile.Sample = append(b.profile.Sample, &profilev1.Sample{
+			LocationId: locations,
+			Value:      []int64{self * scale},
+			Label:      labels,
+		})
+	})
+}
+
+// location returns the location of the function: functions and
+// locations have the same identifiers.
+func (b *treePprofBuilder) location(name string) uint64 {
+	id, ok := b.functions[name]
+	if ok {
+		return id
+	}
+	id = uint64(len(b.profile.Function) + 1)
+	s := b.string(name)
+	b.profile.Function = append(b.profile.Function, &profilev1.Function{
+		Id:         id,
+		Name:       s,
+		SystemName: s,
+	})
+	b.profile.Location = append(b.profile.Location, &profilev1.Location{
+		Id:        id,
+		MappingId: 1,
+		Line:      []*profilev1.Line{{FunctionId: id}},
+	})
+	b.functions[name] = id
+	return id
+}
+
+func (b *treePprofBuilder) string(s string) int64 {
+	id, ok := b.strings[s]
+	if !ok {
+		id = int64(len(b.profile.StringTable))
+		b.profile.StringTable = append(b.profile.StringTable, s)
+		b.strings[s] = id
+	}
+	return id
+}
@@ -0,0 +1,58 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+)
+
+func Test_TreeToPprof(t *testing.T) {
+	profileType := &typesv1.ProfileType{
+		SampleType: "cpu",
+		SampleUnit: "nanoseconds",
+		PeriodType: "cpu",
+		PeriodUnit: "nanoseconds",
+	}
+	tree := new(Tree)
+	tree.InsertStack(3, "main", "foo")
+	tree.InsertStack(2, "main", "foo", "bar")
+	tree.InsertStack(1, "main", "bar")
+
+	p := TreeToPprof(tree, profileType)
+	require.Len(t, p.SampleType, 1)
+	assert.Equal(t, "cpu", p.StringTable[p.SampleType[0].Type])
+	assert.Equal(t, "nanoseconds", p.StringTable[p.PeriodType.Unit])
+	assert.Len(t, p.Function, 3)
+	assert.Len(t, p.Location, 3)
+
+	b, err := TreeFromBackendProfile(p, -1)
+	require.NoError(t, err)
+	actual, err := UnmarshalTree(b)
+	require.NoError(t, err)
+	assert.Equal(t, tree.String(), actual.String())
+}
+
+func Test_DiffTreesToPprof(t *testing.T) {
+	left := new(Tree)
+	left.InsertStack(3, "main", "foo")
+	right := new(Tree)
+	right.InsertStack(1, "main", "foo")
+	right.InsertStack(2, "main", "bar")
+
+	p := DiffTreesToPprof(left, right, &typesv1.ProfileType{SampleType: "cpu", SampleUnit: "nanoseconds"})
+	require.Len(t, p.Sample, 3)
+	var base, total int64
+	for _, s := range p.Sample {
+		total += s.Value[0]
+		if len(s.Label) > 0 {
+			assert.Equal(t, DiffBaseLabel, p.StringTable[s.Label[0].Key])
+			base += s.Value[0]
+		}
+	}
+	assert.Equal(t, int64(-3), base)
+	assert.Equal(t, int64(0), total)
+	assert.Nil(t, p.PeriodType)
+}
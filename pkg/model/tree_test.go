@@ -250,6 +250,10 @@ func Test_Tree_MarshalUnmarshal(t *testing.T) {
 		actual, err := UnmarshalTree(buf.Bytes())
 		require.NoError(t, err)
 		require.Equal(t, expected.String(), actual.String())
+
+		buf.Reset()
+		actual.WriteCollapsed(&buf)
+		require.Equal(t, "a;b;c 2\na;b;c1 1\na;b1;c 1\na;b1;c1 1\na1;b;c 1\na1;b;c1 1\na1;b1;c 1\na1;b1;c1 1\n", buf.String())
 	})
 
 	t.Run("truncation", func(t *testing.T) {
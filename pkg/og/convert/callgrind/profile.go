@@ -0,0 +1,526 @@
+// Package callgrind converts the output of Valgrind's callgrind tool to pprof.
+// The format is described in https://valgrind.org/docs/manual/cl-format.html.
+package callgrind
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/prometheus/prometheus/model/labels"
+
+	profilev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+	distributormodel "github.com/grafana/pyroscope/pkg/distributor/model"
+	phlaremodel "github.com/grafana/pyroscope/pkg/model"
+	"github.com/grafana/pyroscope/pkg/og/ingestion"
+	"github.com/grafana/pyroscope/pkg/og/storage"
+	"github.com/grafana/pyroscope/pkg/pprof"
+)
+
+const (
+	// MetricName is the name of the profiles converted from callgrind output.
+	MetricName = "callgrind"
+
+	// maxDepth limits the depth of the call chains.
+	maxDepth = 128
+)
+
+// IsCallgrind reports whether the data is callgrind output.
+func IsCallgrind(b []byte) bool {
+	b = bytes.TrimLeft(b, " \t\r\n")
+	return bytes.HasPrefix(b, []byte("# callgrind format")) ||
+		bytes.HasPrefix(b, []byte("version:")) && bytes.Contains(b, []byte("\ncreator: callgrind"))
+}
+
+// RawProfile is the output of callgrind.
+type RawProfile struct {
+	RawData []byte
+}
+
+func (p *RawProfile) Bytes() ([]byte, error) { return p.RawData, nil }
+
+func (*RawProfile) ContentType() string { return "text/plain" }
+
+func (p *RawProfile) Parse(context.Context, storage.Putter, storage.MetricsExporter, ingestion.Metadata) error {
+	return fmt.Errorf("parsing callgrind to Tree/storage.Putter is not supported")
+}
+
+func (p *RawProfile) ParseToPprof(_ context.Context, md ingestion.Metadata) (*distributormodel.PushRequest, error) {
+	profile, err := ToPprof(p.RawData, md)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("failed to parse callgrind output: %w", err))
+	}
+	res := &distributormodel.PushRequest{
+		RawProfileSize: len(p.RawData),
+		RawProfileType: distributormodel.RawProfileTypeCallgrind,
+	}
+	if len(profile.Sample) == 0 {
+		return res, nil
+	}
+	res.Series = []*distributormodel.ProfileSeries{{
+		Labels: seriesLabels(md),
+		Samples: []*distributormodel.ProfileSample{{
+			Profile: pprof.RawFromProto(profile),
+		}},
+	}}
+	return res, nil
+}
+
+// ToPprof converts the callgrind output to a profile with a sample type
+// per event.
+//
+// Callgrind records the self cost of the functions and the inclusive cost
+// of the calls between them, but not the call chains: the call chains are
+// reconstructed from the call graph, starting from the functions that are
+// not called. The self cost of a function is split between its call chains
+// in proportion to the inclusive cost of the calls. If the output has been
+// recorded with --separate-callers, the functions are told apart by their
+// callers, making the reconstructed call chains exact up to that depth.
+func ToPprof(b []byte, md ingestion.Metadata) (*profilev1.Profile, error) {
+	g, err := parse(b)
+	if err != nil {
+		return nil, err
+	}
+	return newProfileBuilder(g, md).build(), nil
+}
+
+type function struct {
+	key     funcKey
+	file    string
+	self    []int64
+	calls   []*call
+	callers []*call
+	// Inclusive cost of the calls to the function.
+	called []int64
+}
+
+type funcKey struct {
+	object string
+	name   string
+}
+
+type call struct {
+	caller, callee *function
+	inclusive      []int64
+}
+
+type graph struct {
+	events    []string
+	functions []*function
+	byKey     map[funcKey]*function
+}
+
+func (g *graph) function(k funcKey) *function {
+	f, ok := g.byKey[k]
+	if !ok {
+		f = &function{
+			key:    k,
+			self:   make([]int64, len(g.events)),
+			called: make([]int64, len(g.events)),
+		}
+		g.functions = append(g.functions, f)
+		g.byKey[k] = f
+	}
+	return f
+}
+
+func (g *graph) call(caller, callee *function) *call {
+	for _, c := range caller.calls {
+		if c.callee == callee {
+			return c
+		}
+	}
+	c := &call{caller: caller, callee: callee, inclusive: make([]int64, len(g.events))}
+	caller.calls = append(caller.calls, c)
+	callee.callers = append(callee.callers, c)
+	return c
+}
+
+// parser holds the state of the parser: the current
+// position, the names defined, and the call to be costed.
+type parser struct {
+	graph     *graph
+	positions int
+	// Compressed names, by kind and ID.
+	names  map[string]map[string]string
+	object string
+	file   string
+	fn     *function
+	// Callee of the next calls= line.
+	calleeObject string
+	calleeName   string
+	call         *call
+	skipNext     bool
+}
+
+func parse(b []byte) (*graph, error) {
+	p := &parser{
+		graph:     &graph{byKey: make(map[funcKey]*function)},
+		positions: 1,
+		names: map[string]map[string]string{
+			"ob": {},
+			"fl": {},
+			"fn": {},
+		},
+	}
+	s := bufio.NewScanner(bytes.NewReader(b))
+	s.Buffer(make([]byte, 0, 64<<10), 1<<20)
+	for n := 1; s.Scan(); n++ {
+		if err := p.line(s.Text()); err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	if len(p.graph.events) == 0 {
+		return nil, errors.New("no events found")
+	}
+	return p.graph, nil
+}
+
+func (p *parser) line(line string) error {
+	line = strings.TrimSpace(line)
+	if line == "" || line[0] == '#' {
+		return nil
+	}
+	if p.skipNext {
+		// The source position of a jump.
+		p.skipNext = false
+		return nil
+	}
+	if c := line[0]; c >= '0' && c <= '9' || c == '+' || c == '-' || c == '*' {
+		return p.cost(line)
+	}
+	key, value, ok := strings.Cut(line, "=")
+	if !ok {
+		if key, value, ok = strings.Cut(line, ":"); !ok {
+			return fmt.Errorf("invalid line %q", line)
+		}
+		return p.header(strings.TrimSpace(key), strings.TrimSpace(value))
+	}
+	switch key {
+	case "ob":
+		p.object = p.name("ob", value)
+	case "fl":
+		p.file = p.name("fl", value)
+	case "fi", "fe":
+		// Inlined code: the cost is attributed to the function.
+		p.name("fl", value)
+	case "fn":
+		if p.graph.events == nil {
+			return errors.New("function defined before events")
+		}
+		p.fn = p.graph.function(funcKey{object: p.object, name: p.name("fn", value)})
+		if p.fn.file == "" {
+			p.fn.file = p.file
+		}
+	case "cob":
+		p.calleeObject = p.name("ob", value)
+	case "cfi", "cfl":
+		p.name("fl", value)
+	case "cfn":
+		p.calleeName = p.name("fn", value)
+	case "calls":
+		if p.fn == nil || p.calleeName == "" {
+			return errors.New("call without caller or callee")
+		}
+		object := p.calleeObject
+		if object == "" {
+			object = p.object
+		}
+		callee := p.graph.function(funcKey{object: object, name: p.calleeName})
+		p.call = p.graph.call(p.fn, callee)
+		p.calleeObject, p.calleeName = "", ""
+	case "jump", "jcnd":
+		p.skipNext = true
+	}
+	return nil
+}
+
+func (p *parser) header(key, value string) error {
+	switch key {
+	case "events":
+		events := strings.Fields(value)
+		if len(events) == 0 {
+			return errors.New("no events found")
+		}
+		// Parts of the output may repeat the events.
+		if p.graph.events != nil && strings.Join(p.graph.events, " ") != strings.Join(events, " ") {
+			return errors.New("events redefined")
+		}
+		p.graph.events = events
+	case "positions":
+		p.positions = len(strings.Fields(value))
+		if p.positions == 0 {
+			return errors.New("no positions found")
+		}
+	}
+	return nil
+}
+
+// name resolves the compressed name: "(id) name" defines
+// the name, "(id)" refers to a name defined before.
+func (p *parser) name(kind, value string) string {
+	value = strings.TrimSpace(value)
+	if !strings.HasPrefix(value, "(") {
+		return value
+	}
+	i := strings.IndexByte(value, ')')
+	if i < 0 {
+		return value
+	}
+	id, name := value[1:i], strings.TrimSpace(value[i+1:])
+	if name == "" {
+		return p.names[kind][id]
+	}
+	p.names[kind][id] = name
+	return name
+}
+
+// cost adds the costs of the line, which follow the position, either to
+// the self cost of the current function, or to the inclusive cost of the
+// call of the previous line.
+func (p *parser) cost(line string) error {
+	fields := strings.Fields(line)
+	if len(fields) < p.positions {
+		return fmt.Errorf("invalid cost line %q", line)
+	}
+	costs := fields[p.positions:]
+	if len(costs) > len(p.graph.events) {
+		return fmt.Errorf("too many costs in line %q", line)
+	}
+	values := make([]int64, len(costs))
+	for i := range costs {
+		v, err := strconv.ParseInt(costs[i], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid cost %q", costs[i])
+		}
+		values[i] = v
+	}
+	switch c := p.call; {
+	case c != nil:
+		for i, v := range values {
+			c.inclusive[i] += v
+			if c.caller != c.callee {
+				c.callee.called[i] += v
+			}
+		}
+		p.call = nil
+	case p.fn != nil:
+		for i, v := range values {
+			p.fn.self[i] += v
+		}
+	default:
+		return errors.New("cost without function")
+	}
+	return nil
+}
+
+type profileBuilder struct {
+	graph   *graph
+	profile *profilev1.Profile
+	strings map[string]int64
+	locs    map[*function]uint64
+	samples map[string]*profilev1.Sample
+	stack   []*function
+	keyBuf  []byte
+}
+
+func newProfileBuilder(g *graph, md ingestion.Metadata) *profileBuilder {
+	b := &profileBuilder{
+		graph:   g,
+		strings: map[string]int64{"": 0},
+		locs:    make(map[*function]uint64),
+		samples: make(map[string]*profilev1.Sample),
+	}
+	b.profile = &profilev1.Profile{
+		StringTable:   []string{""},
+		TimeNanos:     md.StartTime.UnixNano(),
+		DurationNanos: md.EndTime.Sub(md.StartTime).Nanoseconds(),
+		Period:        1,
+	}
+	for _, e := range g.events {
+		b.profile.SampleType = append(b.profile.SampleType, &profilev1.ValueType{
+			Type: b.string(e),
+			Unit: b.string("count"),
+		})
+	}
+	b.profile.PeriodType = b.profile.SampleType[0]
+	return b
+}
+
+func (b *profileBuilder) build() *profilev1.Profile {
+	// Functions which are not called are the roots of the call chains;
+	// if all the functions are called (recursion), all of them are.
+	var roots []*function
+	for _, f := range b.graph.functions {
+		if len(f.callers) == 0 {
+			roots = append(roots, f)
+		}
+	}
+	if len(roots) == 0 {
+		roots = b.graph.functions
+	}
+	scale := make([]float64, len(b.graph.events))
+	for _, f := range roots {
+		for i := range scale {
+			scale[i] = 1
+		}
+		b.visit(f, scale)
+	}
+	return b.profile
+}
+
+// visit adds the self cost of the function, scaled by the share of the call
+// chain in the cost of the function, and visits the functions it calls.
+func (b *profileBuilder) visit(f *function, scale []float64) {
+	b.stack = append(b.stack, f)
+	defer func() { b.stack = b.stack[:len(b.stack)-1] }()
+
+	values := make([]int64, len(scale))
+	var nonZero bool
+	for i, s := range scale {
+		values[i] = int64(float64(f.self[i])*s + 0.5)
+		nonZero = nonZero || values[i] != 0
+	}
+	if nonZero {
+		b.addSample(values)
+	}
+	if len(b.stack) >= maxDepth {
+		return
+	}
+	for _, c := range f.calls {
+		if b.onStack(c.callee) {
+			// The cost of recursive calls is included in the callers.
+			continue
+		}
+		next := make([]float64, len(scale))
+		var significant bool
+		for i := range scale {
+			if c.callee.called[i] > 0 {
+				next[i] = scale[i] * float64(c.inclusive[i]) / float64(c.callee.called[i])
+			}
+			// Skip the call chains costing less than a unit.
+			significant = significant || next[i]*float64(c.callee.called[i]) >= 1
+		}
+		if significant {
+			b.visit(c.callee, next)
+		}
+	}
+}
+
+func (b *profileBuilder) onStack(f *function) bool {
+	for _, x := range b.stack {
+		if x == f {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *profileBuilder) addSample(values []int64) {
+	b.keyBuf = b.keyBuf[:0]
+	locations := make([]uint64, len(b.stack))
+	for i, f := range b.stack {
+		loc := b.location(f)
+		locations[len(b.stack)-1-i] = loc
+	}
+	for _, loc := range locations {
+		b.keyBuf = strconv.AppendUint(b.keyBuf, loc, 10)
+		b.keyBuf = append(b.keyBuf, ',')
+	}
+	if s, ok := b.samples[string(b.keyBuf)]; ok {
+		for i, v := range values {
+			s.Value[i] += v
+		}
+		return
+	}
+	s := &profilev1.Sample{LocationId: locations, Value: values}
+	b.samples[string(b.keyBuf)] = s
+	b.profile.Sample = append(b.profile.Sample, s)
+}
+
+// location returns the location of the function. With --separate-callers,
+// the callers are appended to the function names, separated with "'".
+func (b *profileBuilder) location(f *function) uint64 {
+	if id, ok := b.locs[f]; ok {
+		return id
+	}
+	name, _, _ := strings.Cut(f.key.name, "'")
+	fn := &profilev1.Function{
+		Id:         uint64(len(b.profile.Function) + 1),
+		Name:       b.string(name),
+		SystemName: b.string(name),
+		Filename:   b.string(f.file),
+	}
+	b.profile.Function = append(b.profile.Function, fn)
+	m := b.mapping(f.key.object)
+	loc := &profilev1.Location{
+		Id:        uint64(len(b.profile.Location) + 1),
+		MappingId: m,
+		Line:      []*profilev1.Line{{FunctionId: fn.Id}},
+	}
+	b.profile.Location = append(b.profile.Location, loc)
+	b.locs[f] = loc.Id
+	return loc.Id
+}
+
+func (b *profileBuilder) mapping(object string) uint64 {
+	filename := b.string(object)
+	for _, m := range b.profile.Mapping {
+		if m.Filename == filename {
+			return m.Id
+		}
+	}
+	m := &profilev1.Mapping{
+		Id:           uint64(len(b.profile.Mapping) + 1),
+		Filename:     filename,
+		HasFunctions: true,
+	}
+	b.profile.Mapping = append(b.profile.Mapping, m)
+	return m.Id
+}
+
+func (b *profileBuilder) string(s string) int64 {
+	if i, ok := b.strings[s]; ok {
+		return i
+	}
+	i := int64(len(b.profile.StringTable))
+	b.profile.StringTable = append(b.profile.StringTable, s)
+	b.strings[s] = i
+	return i
+}
+
+func seriesLabels(md ingestion.Metadata) []*typesv1.LabelPair {
+	ls := make([]*typesv1.LabelPair, 0, len(md.LabelSet.Labels())+4)
+	ls = append(ls, &typesv1.LabelPair{
+		Name:  labels.MetricName,
+		Value: MetricName,
+	}, &typesv1.LabelPair{
+		Name:  phlaremodel.LabelNameDelta,
+		Value: "false",
+	}, &typesv1.LabelPair{
+		Name:  phlaremodel.LabelNamePyroscopeSpy,
+		Value: md.SpyName,
+	})
+	if _, ok := md.LabelSet.Labels()[phlaremodel.LabelNameServiceName]; !ok {
+		ls = append(ls, &typesv1.LabelPair{
+			Name:  phlaremodel.LabelNameServiceName,
+			Value: md.LabelSet.ServiceName(),
+		})
+	}
+	for k, v := range md.LabelSet.Labels() {
+		if !phlaremodel.IsLabelAllowedForIngestion(k) {
+			continue
+		}
+		ls = append(ls, &typesv1.LabelPair{Name: k, Value: v})
+	}
+	return ls
+}
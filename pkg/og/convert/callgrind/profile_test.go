@@ -0,0 +1,105 @@
+package callgrind
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/pyroscope/pkg/og/convert/pprof/bench"
+	"github.com/grafana/pyroscope/pkg/og/ingestion"
+)
+
+func TestToPprof(t *testing.T) {
+	b, err := os.ReadFile("testdata/callgrind.out.1234")
+	require.NoError(t, err)
+	require.True(t, IsCallgrind(b))
+
+	p, err := ToPprof(b, ingestion.Metadata{})
+	require.NoError(t, err)
+	require.Len(t, p.SampleType, 2)
+	assert.Equal(t, "Ir", p.StringTable[p.SampleType[0].Type])
+	assert.Equal(t, "Dr", p.StringTable[p.SampleType[1].Type])
+	assert.Equal(t, "count", p.StringTable[p.SampleType[1].Unit])
+
+	// The self cost of helper is split between main and work
+	// in proportion to the inclusive cost of their calls.
+	assert.Equal(t, []string{
+		"main 10",
+		"main;helper 100",
+		"main;work 400",
+		"main;work;helper 200",
+	}, bench.StackCollapseProto(p, 0, 1))
+	assert.Equal(t, []string{
+		"main 2",
+		"main;helper 20",
+		"main;work 150",
+		"main;work;helper 50",
+	}, bench.StackCollapseProto(p, 1, 1))
+	assert.Equal(t, "/usr/bin/app", p.StringTable[p.Mapping[0].Filename])
+	assert.Equal(t, "app.c", p.StringTable[p.Function[0].Filename])
+}
+
+func TestToPprof_SeparateCallers(t *testing.T) {
+	b := []byte(`events: Ir
+fn=main
+1 1
+cfn=work'main
+calls=1 2
+1 10
+cfn=helper'main
+calls=1 3
+1 5
+fn=work'main
+2 5
+cfn=helper'work'main
+calls=1 3
+2 5
+fn=helper'main
+3 5
+fn=helper'work'main
+3 5
+`)
+	p, err := ToPprof(b, ingestion.Metadata{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"main 1",
+		"main;helper 5",
+		"main;work 5",
+		"main;work;helper 5",
+	}, bench.StackCollapseProto(p, 0, 1))
+}
+
+func TestToPprof_Recursion(t *testing.T) {
+	b := []byte(`events: Ir
+fn=main
+1 1
+cfn=fib
+calls=1 2
+1 30
+fn=fib
+2 30
+cfn=fib
+calls=10 2
+2 20
+`)
+	p, err := ToPprof(b, ingestion.Metadata{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"main 1", "main;fib 30"}, bench.StackCollapseProto(p, 0, 1))
+}
+
+func TestToPprof_Invalid(t *testing.T) {
+	for _, b := range []string{
+		"",
+		"fn=main\n1 1\n",
+		"events: Ir\n1 1\n",
+		"events: Ir\nfn=main\n1 1 1\n",
+		"events: Ir\nfn=main\n1 x\n",
+		"events: Ir\nfn=main\ncalls=1 1\n",
+		"events: Ir\nevents: Dr\n",
+	} {
+		_, err := ToPprof([]byte(b), ingestion.Metadata{})
+		assert.Error(t, err, b)
+	}
+}
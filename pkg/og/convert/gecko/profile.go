@@ -0,0 +1,385 @@
+// Package gecko converts Gecko profiles, as written by the
+// Firefox Profiler before processing, to pprof.
+package gecko
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/prometheus/prometheus/model/labels"
+
+	profilev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+	distributormodel "github.com/grafana/pyroscope/pkg/distributor/model"
+	phlaremodel "github.com/grafana/pyroscope/pkg/model"
+	"github.com/grafana/pyroscope/pkg/og/ingestion"
+	"github.com/grafana/pyroscope/pkg/og/storage"
+	"github.com/grafana/pyroscope/pkg/pprof"
+)
+
+const (
+	// LabelNameThread and LabelNamePID are the sample labels
+	// holding the thread name and process ID of the samples.
+	LabelNameThread = "thread_name"
+	LabelNamePID    = "pid"
+)
+
+type profile struct {
+	Meta      meta       `json:"meta"`
+	Threads   []thread   `json:"threads"`
+	Processes []*profile `json:"processes"`
+}
+
+type meta struct {
+	// Sampling interval, in milliseconds.
+	Interval float64 `json:"interval"`
+}
+
+type thread struct {
+	Name        string          `json:"name"`
+	PID         json.RawMessage `json:"pid"`
+	Samples     table           `json:"samples"`
+	StackTable  table           `json:"stackTable"`
+	FrameTable  table           `json:"frameTable"`
+	StringTable []string        `json:"stringTable"`
+}
+
+// table is a table of the Gecko profile: the schema
+// maps the names of the columns to their index.
+type table struct {
+	Schema map[string]int  `json:"schema"`
+	Data   [][]json.Number `json:"data"`
+}
+
+// column returns the column of the row as an integer, or -1 if the
+// column is not present in the table, or its value is null.
+func (t *table) column(row []json.Number, name string) int64 {
+	i, ok := t.Schema[name]
+	if !ok || i >= len(row) || row[i] == "" {
+		return -1
+	}
+	v, err := row[i].Int64()
+	if err != nil {
+		return -1
+	}
+	return v
+}
+
+func (t *table) UnmarshalJSON(b []byte) error {
+	var raw struct {
+		Schema map[string]int      `json:"schema"`
+		Data   [][]json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	t.Schema = raw.Schema
+	t.Data = make([][]json.Number, len(raw.Data))
+	for i, row := range raw.Data {
+		t.Data[i] = make([]json.Number, len(row))
+		for j, v := range row {
+			// Columns other than numbers are not used.
+			var n json.Number
+			if json.Unmarshal(v, &n) == nil {
+				t.Data[i][j] = n
+			}
+		}
+	}
+	return nil
+}
+
+// IsGeckoProfile reports whether the data is a Gecko profile.
+func IsGeckoProfile(b []byte) bool {
+	var p struct {
+		Meta *struct {
+			// Only present in processed profiles.
+			PreprocessedProfileVersion *json.RawMessage `json:"preprocessedProfileVersion"`
+		} `json:"meta"`
+		Threads []json.RawMessage `json:"threads"`
+		Libs    []json.RawMessage `json:"libs"`
+	}
+	return json.Unmarshal(b, &p) == nil &&
+		p.Meta != nil && p.Meta.PreprocessedProfileVersion == nil &&
+		p.Threads != nil && p.Libs != nil
+}
+
+// RawProfile is a Gecko profile, optionally gzip-compressed.
+type RawProfile struct {
+	RawData []byte
+}
+
+func (p *RawProfile) Bytes() ([]byte, error) { return p.RawData, nil }
+
+func (*RawProfile) ContentType() string { return "application/json" }
+
+func (p *RawProfile) Parse(context.Context, storage.Putter, storage.MetricsExporter, ingestion.Metadata) error {
+	return fmt.Errorf("parsing Gecko profile to Tree/storage.Putter is not supported")
+}
+
+func (p *RawProfile) ParseToPprof(_ context.Context, md ingestion.Metadata) (*distributormodel.PushRequest, error) {
+	profile, err := ToPprof(p.RawData, md)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("failed to parse Gecko profile: %w", err))
+	}
+	res := &distributormodel.PushRequest{
+		RawProfileSize: len(p.RawData),
+		RawProfileType: distributormodel.RawProfileTypeGecko,
+	}
+	if len(profile.Sample) == 0 {
+		return res, nil
+	}
+	res.Series = []*distributormodel.ProfileSeries{{
+		Labels: seriesLabels(md),
+		Samples: []*distributormodel.ProfileSample{{
+			Profile: pprof.RawFromProto(profile),
+		}},
+	}}
+	return res, nil
+}
+
+// ToPprof converts the samples of all the threads of the Gecko profile,
+// and of its child processes, to a wall-clock profile. Samples are
+// weighted with the sampling interval of the profile; the thread name
+// and the process ID of the samples are kept as sample labels.
+func ToPprof(b []byte, md ingestion.Metadata) (*profilev1.Profile, error) {
+	if len(b) > 2 && b[0] == 0x1f && b[1] == 0x8b {
+		r, err := gzip.NewReader(bytes.NewReader(b))
+		if err != nil {
+			return nil, err
+		}
+		if b, err = io.ReadAll(r); err != nil {
+			return nil, err
+		}
+	}
+	var p profile
+	if err := json.Unmarshal(b, &p); err != nil {
+		return nil, err
+	}
+	if p.Meta.Interval <= 0 {
+		return nil, errors.New("invalid sampling interval")
+	}
+	builder := newProfileBuilder(md, int64(p.Meta.Interval*float64(time.Millisecond)))
+	if err := builder.addProfile(&p); err != nil {
+		return nil, err
+	}
+	return builder.profile, nil
+}
+
+// reLocation matches the frame locations of the form
+// "name (file:line:column)" and "name (in library)".
+var reLocation = regexp.MustCompile(`^(.+) \((?:in )?(.+?)(?::(\d+))?(?::\d+)?\)$`)
+
+type frameKey struct {
+	name string
+	file string
+	line int64
+}
+
+type sampleKey struct {
+	stack  string
+	thread string
+	pid    string
+}
+
+type profileBuilder struct {
+	profile   *profilev1.Profile
+	strings   map[string]int64
+	functions map[frameKey]uint64
+	locations map[frameKey]uint64
+	samples   map[sampleKey]*profilev1.Sample
+	keyBuf    []byte
+}
+
+func newProfileBuilder(md ingestion.Metadata, period int64) *profileBuilder {
+	b := &profileBuilder{
+		strings:   map[string]int64{"": 0},
+		functions: make(map[frameKey]uint64),
+		locations: make(map[frameKey]uint64),
+		samples:   make(map[sampleKey]*profilev1.Sample),
+	}
+	b.profile = &profilev1.Profile{
+		StringTable:   []string{""},
+		TimeNanos:     md.StartTime.UnixNano(),
+		DurationNanos: md.EndTime.Sub(md.StartTime).Nanoseconds(),
+		Period:        period,
+		Mapping:       []*profilev1.Mapping{{Id: 1, HasFunctions: true, HasFilenames: true, HasLineNumbers: true}},
+	}
+	b.profile.SampleType = []*profilev1.ValueType{{
+		Type: b.string("wall"),
+		Unit: b.string("nanoseconds"),
+	}}
+	b.profile.PeriodType = &profilev1.ValueType{
+		Type: b.string("wall"),
+		Unit: b.string("nanoseconds"),
+	}
+	return b
+}
+
+func (b *profileBuilder) addProfile(p *profile) error {
+	for i := range p.Threads {
+		if err := b.addThread(&p.Threads[i]); err != nil {
+			return fmt.Errorf("thread %q: %w", p.Threads[i].Name, err)
+		}
+	}
+	for _, child := range p.Processes {
+		if err := b.addProfile(child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *profileBuilder) addThread(t *thread) error {
+	pid := strings.Trim(string(t.PID), `"`)
+	if pid == "null" {
+		pid = ""
+	}
+	// Locations of the frames and stacks of the thread, by index.
+	frames := make([]uint64, len(t.FrameTable.Data))
+	for i, row := range t.FrameTable.Data {
+		loc := t.FrameTable.column(row, "location")
+		if loc < 0 || loc >= int64(len(t.StringTable)) {
+			return fmt.Errorf("invalid location of frame %d", i)
+		}
+		frames[i] = b.location(t.StringTable[loc], t.FrameTable.column(row, "line"))
+	}
+	stacks := make([][]uint64, len(t.StackTable.Data))
+	for i, row := range t.StackTable.Data {
+		frame := t.StackTable.column(row, "frame")
+		if frame < 0 || frame >= int64(len(frames)) {
+			return fmt.Errorf("invalid frame of stack %d", i)
+		}
+		stack := []uint64{frames[frame]}
+		// Prefixes precede the stacks referring to them.
+		if prefix := t.StackTable.column(row, "prefix"); prefix >= 0 {
+			if prefix >= int64(i) {
+				return fmt.Errorf("invalid prefix of stack %d", i)
+			}
+			stack = append(stack, stacks[prefix]...)
+		}
+		stacks[i] = stack
+	}
+	for _, row := range t.Samples.Data {
+		stack := t.Samples.column(row, "stack")
+		if stack < 0 {
+			continue
+		}
+		if stack >= int64(len(stacks)) {
+			return fmt.Errorf("invalid sample stack %d", stack)
+		}
+		b.addSample(stacks[stack], b.profile.Period, t.Name, pid)
+	}
+	return nil
+}
+
+func (b *profileBuilder) addSample(stack []uint64, value int64, thread, pid string) {
+	b.keyBuf = b.keyBuf[:0]
+	for _, loc := range stack {
+		b.keyBuf = strconv.AppendUint(b.keyBuf, loc, 10)
+		b.keyBuf = append(b.keyBuf, ',')
+	}
+	k := sampleKey{stack: string(b.keyBuf), thread: thread, pid: pid}
+	if s, ok := b.samples[k]; ok {
+		s.Value[0] += value
+		return
+	}
+	s := &profilev1.Sample{
+		LocationId: stack,
+		Value:      []int64{value},
+		Label:      []*profilev1.Label{{Key: b.string(LabelNameThread), Str: b.string(thread)}},
+	}
+	if pid != "" {
+		s.Label = append(s.Label, &profilev1.Label{Key: b.string(LabelNamePID), Str: b.string(pid)})
+	}
+	b.samples[k] = s
+	b.profile.Sample = append(b.profile.Sample, s)
+}
+
+// location returns the location of the frame, the line
+// of which overrides the one of the location string.
+func (b *profileBuilder) location(s string, line int64) uint64 {
+	k := frameKey{name: s}
+	if m := reLocation.FindStringSubmatch(s); m != nil {
+		k.name, k.file = m[1], m[2]
+		if m[3] != "" {
+			k.line, _ = strconv.ParseInt(m[3], 10, 64)
+		}
+	}
+	fn := b.function(frameKey{name: k.name, file: k.file})
+	if line > 0 {
+		k.line = line
+	}
+	if id, ok := b.locations[k]; ok {
+		return id
+	}
+	loc := &profilev1.Location{
+		Id:        uint64(len(b.profile.Location) + 1),
+		MappingId: 1,
+		Line:      []*profilev1.Line{{FunctionId: fn, Line: k.line}},
+	}
+	b.profile.Location = append(b.profile.Location, loc)
+	b.locations[k] = loc.Id
+	return loc.Id
+}
+
+func (b *profileBuilder) function(k frameKey) uint64 {
+	if id, ok := b.functions[k]; ok {
+		return id
+	}
+	fn := &profilev1.Function{
+		Id:         uint64(len(b.profile.Function) + 1),
+		Name:       b.string(k.name),
+		SystemName: b.string(k.name),
+		Filename:   b.string(k.file),
+	}
+	b.profile.Function = append(b.profile.Function, fn)
+	b.functions[k] = fn.Id
+	return fn.Id
+}
+
+func (b *profileBuilder) string(s string) int64 {
+	if i, ok := b.strings[s]; ok {
+		return i
+	}
+	i := int64(len(b.profile.StringTable))
+	b.profile.StringTable = append(b.profile.StringTable, s)
+	b.strings[s] = i
+	return i
+}
+
+func seriesLabels(md ingestion.Metadata) []*typesv1.LabelPair {
+	ls := make([]*typesv1.LabelPair, 0, len(md.LabelSet.Labels())+4)
+	ls = append(ls, &typesv1.LabelPair{
+		Name:  labels.MetricName,
+		Value: "wall",
+	}, &typesv1.LabelPair{
+		Name:  phlaremodel.LabelNameDelta,
+		Value: "false",
+	}, &typesv1.LabelPair{
+		Name:  phlaremodel.LabelNamePyroscopeSpy,
+		Value: md.SpyName,
+	})
+	if _, ok := md.LabelSet.Labels()[phlaremodel.LabelNameServiceName]; !ok {
+		ls = append(ls, &typesv1.LabelPair{
+			Name:  phlaremodel.LabelNameServiceName,
+			Value: md.LabelSet.ServiceName(),
+		})
+	}
+	for k, v := range md.LabelSet.Labels() {
+		if !phlaremodel.IsLabelAllowedForIngestion(k) {
+			continue
+		}
+		ls = append(ls, &typesv1.LabelPair{Name: k, Value: v})
+	}
+	return ls
+}
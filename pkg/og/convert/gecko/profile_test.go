@@ -0,0 +1,65 @@
+package gecko
+
+import (
+	"bytes"
+	"compress/gzip"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/pyroscope/pkg/og/convert/pprof/bench"
+	"github.com/grafana/pyroscope/pkg/og/ingestion"
+)
+
+func TestToPprof(t *testing.T) {
+	b, err := os.ReadFile("testdata/app.gecko.json")
+	require.NoError(t, err)
+	require.True(t, IsGeckoProfile(b))
+
+	var gz bytes.Buffer
+	w := gzip.NewWriter(&gz)
+	_, err = w.Write(b)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	for _, data := range [][]byte{b, gz.Bytes()} {
+		p, err := ToPprof(data, ingestion.Metadata{})
+		require.NoError(t, err)
+		assert.Equal(t, "wall", p.StringTable[p.SampleType[0].Type])
+		assert.Equal(t, int64(1000000), p.Period)
+		assert.Equal(t, []string{
+			"(thread_name = DOM Worker), (pid = 200) ||| render 1000000",
+			"(thread_name = GeckoMain), (pid = 100) ||| XRE_Main;0x7f0012345678 1000000",
+			"(thread_name = GeckoMain), (pid = 100) ||| XRE_Main;render 2000000",
+		}, bench.StackCollapseProtoWithOptions(p, bench.StackCollapseOptions{Scale: 1, WithLabels: true}))
+
+		// The frames of both processes share the function,
+		// the line of the frame overrides the location one.
+		require.Len(t, p.Function, 3)
+		render := p.Function[1]
+		assert.Equal(t, "render", p.StringTable[render.Name])
+		assert.Equal(t, "https://example.com/app.js", p.StringTable[render.Filename])
+		assert.Equal(t, "libxul.so", p.StringTable[p.Function[0].Filename])
+		var lines []int64
+		for _, loc := range p.Location {
+			if loc.Line[0].FunctionId == render.Id {
+				lines = append(lines, loc.Line[0].Line)
+			}
+		}
+		assert.Equal(t, []int64{12, 10}, lines)
+	}
+}
+
+func TestToPprof_Invalid(t *testing.T) {
+	for _, b := range []string{
+		`{"meta": {}, "threads": []}`,
+		`{"meta": {"interval": 1}, "threads": [{"stackTable": {"schema": {"frame": 0}, "data": [[0]]}}]}`,
+		`{"meta": {"interval": 1}, "threads": [{"samples": {"schema": {"stack": 0}, "data": [[0]]}}]}`,
+	} {
+		_, err := ToPprof([]byte(b), ingestion.Metadata{})
+		assert.Error(t, err, b)
+	}
+	assert.False(t, IsGeckoProfile([]byte(`{"meta": {"preprocessedProfileVersion": 47}, "threads": [], "libs": []}`)))
+}
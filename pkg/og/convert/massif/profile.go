@@ -0,0 +1,325 @@
+// Package massif converts the output of Valgrind's massif tool to pprof.
+package massif
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/prometheus/prometheus/model/labels"
+
+	profilev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+	distributormodel "github.com/grafana/pyroscope/pkg/distributor/model"
+	phlaremodel "github.com/grafana/pyroscope/pkg/model"
+	"github.com/grafana/pyroscope/pkg/og/ingestion"
+	"github.com/grafana/pyroscope/pkg/og/storage"
+	"github.com/grafana/pyroscope/pkg/pprof"
+)
+
+// belowThreshold is the name of the frame of the allocations
+// massif does not detail, being below its threshold.
+const belowThreshold = "[below threshold]"
+
+var (
+	// reNode matches the nodes of the heap tree: "n<children>: <bytes> <frame>".
+	reNode = regexp.MustCompile(`^( *)n(\d+): (\d+) (.*)$`)
+	// reFrame matches the frames of the form "0x4005A6: name (file:line)"
+	// and "0x4005A6: name (in object)".
+	reFrame = regexp.MustCompile(`^(0x[0-9A-Fa-f]+): (.*?)(?: \((?:in )?(.+?)(?::(\d+))?\))?$`)
+)
+
+// IsMassif reports whether the data is massif output.
+func IsMassif(b []byte) bool {
+	b = bytes.TrimLeft(b, " \t\r\n")
+	return bytes.HasPrefix(b, []byte("desc:")) &&
+		bytes.Contains(b, []byte("\ntime_unit:")) &&
+		bytes.Contains(b, []byte("\nsnapshot="))
+}
+
+// RawProfile is the output of massif.
+type RawProfile struct {
+	RawData []byte
+}
+
+func (p *RawProfile) Bytes() ([]byte, error) { return p.RawData, nil }
+
+func (*RawProfile) ContentType() string { return "text/plain" }
+
+func (p *RawProfile) Parse(context.Context, storage.Putter, storage.MetricsExporter, ingestion.Metadata) error {
+	return fmt.Errorf("parsing massif to Tree/storage.Putter is not supported")
+}
+
+func (p *RawProfile) ParseToPprof(_ context.Context, md ingestion.Metadata) (*distributormodel.PushRequest, error) {
+	profile, err := ToPprof(p.RawData, md)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("failed to parse massif output: %w", err))
+	}
+	res := &distributormodel.PushRequest{
+		RawProfileSize: len(p.RawData),
+		RawProfileType: distributormodel.RawProfileTypeMassif,
+	}
+	if len(profile.Sample) == 0 {
+		return res, nil
+	}
+	res.Series = []*distributormodel.ProfileSeries{{
+		Labels: seriesLabels(md),
+		Samples: []*distributormodel.ProfileSample{{
+			Profile: pprof.RawFromProto(profile),
+		}},
+	}}
+	return res, nil
+}
+
+type node struct {
+	parent int
+	bytes  int64
+	self   int64
+	frame  string
+}
+
+// ToPprof converts the heap tree of the peak snapshot, or of the last
+// detailed snapshot if the peak is not detailed, to an inuse_space profile.
+func ToPprof(b []byte, md ingestion.Metadata) (*profilev1.Profile, error) {
+	tree, err := detailedSnapshot(b)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := parseTree(tree)
+	if err != nil {
+		return nil, err
+	}
+	builder := newProfileBuilder(md)
+	for i, n := range nodes {
+		if i == 0 || n.self <= 0 {
+			// The root holds the allocation functions.
+			continue
+		}
+		// The children of the root are the functions calling the
+		// allocation functions, and the descendants their callers.
+		var stack []uint64
+		for j := i; j > 0; j = nodes[j].parent {
+			stack = append(stack, builder.location(nodes[j].frame))
+		}
+		slices.Reverse(stack)
+		builder.addSample(stack, n.self)
+	}
+	return builder.profile, nil
+}
+
+// detailedSnapshot returns the heap tree lines of the snapshot to convert.
+func detailedSnapshot(b []byte) ([]string, error) {
+	var (
+		last, peak []string
+		tree       *[]string
+	)
+	s := bufio.NewScanner(bytes.NewReader(b))
+	s.Buffer(make([]byte, 0, 64<<10), 1<<20)
+	for s.Scan() {
+		line := strings.TrimRight(s.Text(), "\r")
+		switch {
+		case strings.HasPrefix(line, "heap_tree="):
+			tree = nil
+			switch strings.TrimPrefix(line, "heap_tree=") {
+			case "detailed":
+				last = []string{}
+				tree = &last
+			case "peak":
+				peak = []string{}
+				tree = &peak
+			}
+		case strings.HasPrefix(line, "snapshot=") || strings.HasPrefix(line, "#"):
+			tree = nil
+		case tree != nil:
+			*tree = append(*tree, line)
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	switch {
+	case len(peak) > 0:
+		return peak, nil
+	case len(last) > 0:
+		return last, nil
+	}
+	return nil, errors.New("no detailed snapshot found")
+}
+
+// parseTree parses the heap tree: the children of a node follow it,
+// indented by one more space. The self size of the nodes is their
+// size less the size of their children.
+func parseTree(lines []string) ([]node, error) {
+	var (
+		nodes []node
+		// Index of the last node at each depth.
+		path []int
+	)
+	for _, line := range lines {
+		if line == "" {
+			continue
+		}
+		m := reNode.FindStringSubmatch(line)
+		if m == nil {
+			return nil, fmt.Errorf("invalid heap tree line %q", line)
+		}
+		depth := len(m[1])
+		size, err := strconv.ParseInt(m[3], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid heap tree line %q", line)
+		}
+		if depth > len(path) || depth == 0 && len(nodes) > 0 {
+			return nil, fmt.Errorf("invalid heap tree depth in line %q", line)
+		}
+		n := node{parent: -1, bytes: size, self: size, frame: m[4]}
+		if strings.Contains(n.frame, "below massif's threshold") {
+			n.frame = belowThreshold
+		}
+		if depth > 0 {
+			n.parent = path[depth-1]
+			nodes[n.parent].self -= size
+		}
+		path = append(path[:depth], len(nodes))
+		nodes = append(nodes, n)
+	}
+	if len(nodes) == 0 {
+		return nil, errors.New("empty heap tree")
+	}
+	return nodes, nil
+}
+
+type frameKey struct {
+	name string
+	file string
+	line int64
+}
+
+type profileBuilder struct {
+	profile   *profilev1.Profile
+	strings   map[string]int64
+	functions map[frameKey]uint64
+	locations map[string]uint64
+	samples   map[string]*profilev1.Sample
+	keyBuf    []byte
+}
+
+func newProfileBuilder(md ingestion.Metadata) *profileBuilder {
+	b := &profileBuilder{
+		strings:   map[string]int64{"": 0},
+		functions: make(map[frameKey]uint64),
+		locations: make(map[string]uint64),
+		samples:   make(map[string]*profilev1.Sample),
+	}
+	b.profile = &profilev1.Profile{
+		StringTable:   []string{""},
+		TimeNanos:     md.StartTime.UnixNano(),
+		DurationNanos: md.EndTime.Sub(md.StartTime).Nanoseconds(),
+		Mapping:       []*profilev1.Mapping{{Id: 1, HasFunctions: true, HasFilenames: true, HasLineNumbers: true}},
+	}
+	b.profile.SampleType = []*profilev1.ValueType{{
+		Type: b.string("inuse_space"),
+		Unit: b.string("bytes"),
+	}}
+	b.profile.PeriodType = &profilev1.ValueType{
+		Type: b.string("space"),
+		Unit: b.string("bytes"),
+	}
+	return b
+}
+
+func (b *profileBuilder) addSample(stack []uint64, value int64) {
+	b.keyBuf = b.keyBuf[:0]
+	for _, loc := range stack {
+		b.keyBuf = strconv.AppendUint(b.keyBuf, loc, 10)
+		b.keyBuf = append(b.keyBuf, ',')
+	}
+	if s, ok := b.samples[string(b.keyBuf)]; ok {
+		s.Value[0] += value
+		return
+	}
+	s := &profilev1.Sample{LocationId: stack, Value: []int64{value}}
+	b.samples[string(b.keyBuf)] = s
+	b.profile.Sample = append(b.profile.Sample, s)
+}
+
+// location returns the location of the frame. Frames which
+// are not symbolized are named after their address.
+func (b *profileBuilder) location(frame string) uint64 {
+	if id, ok := b.locations[frame]; ok {
+		return id
+	}
+	k := frameKey{name: frame}
+	if m := reFrame.FindStringSubmatch(frame); m != nil {
+		k.name, k.file = m[2], m[3]
+		if k.name == "???" || k.name == "" {
+			k.name = m[1]
+		}
+		if m[4] != "" {
+			k.line, _ = strconv.ParseInt(m[4], 10, 64)
+		}
+	}
+	fn, ok := b.functions[frameKey{name: k.name, file: k.file}]
+	if !ok {
+		f := &profilev1.Function{
+			Id:         uint64(len(b.profile.Function) + 1),
+			Name:       b.string(k.name),
+			SystemName: b.string(k.name),
+			Filename:   b.string(k.file),
+		}
+		b.profile.Function = append(b.profile.Function, f)
+		fn = f.Id
+		b.functions[frameKey{name: k.name, file: k.file}] = fn
+	}
+	loc := &profilev1.Location{
+		Id:        uint64(len(b.profile.Location) + 1),
+		MappingId: 1,
+		Line:      []*profilev1.Line{{FunctionId: fn, Line: k.line}},
+	}
+	b.profile.Location = append(b.profile.Location, loc)
+	b.locations[frame] = loc.Id
+	return loc.Id
+}
+
+func (b *profileBuilder) string(s string) int64 {
+	if i, ok := b.strings[s]; ok {
+		return i
+	}
+	i := int64(len(b.profile.StringTable))
+	b.profile.StringTable = append(b.profile.StringTable, s)
+	b.strings[s] = i
+	return i
+}
+
+func seriesLabels(md ingestion.Metadata) []*typesv1.LabelPair {
+	ls := make([]*typesv1.LabelPair, 0, len(md.LabelSet.Labels())+4)
+	ls = append(ls, &typesv1.LabelPair{
+		Name:  labels.MetricName,
+		Value: "memory",
+	}, &typesv1.LabelPair{
+		Name:  phlaremodel.LabelNameDelta,
+		Value: "false",
+	}, &typesv1.LabelPair{
+		Name:  phlaremodel.LabelNamePyroscopeSpy,
+		Value: md.SpyName,
+	})
+	if _, ok := md.LabelSet.Labels()[phlaremodel.LabelNameServiceName]; !ok {
+		ls = append(ls, &typesv1.LabelPair{
+			Name:  phlaremodel.LabelNameServiceName,
+			Value: md.LabelSet.ServiceName(),
+		})
+	}
+	for k, v := range md.LabelSet.Labels() {
+		if !phlaremodel.IsLabelAllowedForIngestion(k) {
+			continue
+		}
+		ls = append(ls, &typesv1.LabelPair{Name: k, Value: v})
+	}
+	return ls
+}
@@ -0,0 +1,71 @@
+package massif
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/pyroscope/pkg/og/convert/pprof/bench"
+	"github.com/grafana/pyroscope/pkg/og/ingestion"
+)
+
+func TestToPprof(t *testing.T) {
+	b, err := os.ReadFile("testdata/massif.out.1234")
+	require.NoError(t, err)
+	require.True(t, IsMassif(b))
+
+	p, err := ToPprof(b, ingestion.Metadata{})
+	require.NoError(t, err)
+	assert.Equal(t, "inuse_space", p.StringTable[p.SampleType[0].Type])
+	assert.Equal(t, "bytes", p.StringTable[p.SampleType[0].Unit])
+	// The peak snapshot is converted.
+	assert.Equal(t, []string{
+		"0x400700 600",
+		"[below threshold] 200",
+		"init;work 300",
+		"main;work 800",
+		"work 100",
+	}, bench.StackCollapseProto(p, 0, 1))
+
+	work := p.Location[0]
+	assert.Equal(t, int64(10), work.Line[0].Line)
+	assert.Equal(t, "app.c", p.StringTable[p.Function[0].Filename])
+}
+
+func TestToPprof_LastDetailed(t *testing.T) {
+	b := []byte(`desc: (none)
+cmd: ./app
+time_unit: i
+#-----------
+snapshot=0
+#-----------
+time=0
+heap_tree=detailed
+n1: 100 (heap allocation functions) malloc/new/new[], --alloc-fns, etc.
+ n0: 100 0x4005A6: work (in /usr/bin/app)
+#-----------
+snapshot=1
+#-----------
+time=10
+heap_tree=detailed
+n1: 200 (heap allocation functions) malloc/new/new[], --alloc-fns, etc.
+ n0: 200 0x4005A6: work (in /usr/bin/app)
+`)
+	p, err := ToPprof(b, ingestion.Metadata{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"work 200"}, bench.StackCollapseProto(p, 0, 1))
+	assert.Equal(t, "/usr/bin/app", p.StringTable[p.Function[0].Filename])
+}
+
+func TestToPprof_Invalid(t *testing.T) {
+	for _, b := range []string{
+		"desc: (none)\ntime_unit: i\nsnapshot=0\nheap_tree=empty\n",
+		"desc: (none)\ntime_unit: i\nsnapshot=0\nheap_tree=detailed\nn1: 100 root\n   n0: 100 0x1: f\n",
+		"desc: (none)\ntime_unit: i\nsnapshot=0\nheap_tree=detailed\nn1: x root\n",
+	} {
+		_, err := ToPprof([]byte(b), ingestion.Metadata{})
+		assert.Error(t, err, b)
+	}
+}
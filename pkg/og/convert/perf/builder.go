@@ -0,0 +1,181 @@
+package perf
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+
+	profilev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
+	"github.com/grafana/pyroscope/pkg/og/agent/types"
+	"github.com/grafana/pyroscope/pkg/og/ingestion"
+)
+
+// reSymbolOffset matches the offset perf appends
+// to the symbol names with the symoff field.
+var reSymbolOffset = regexp.MustCompile(`\+0x[0-9a-f]+$`)
+
+type mappingKey struct {
+	filename string
+	buildID  string
+}
+
+type symbolKey struct {
+	name   string
+	module string
+}
+
+type addressKey struct {
+	mapping uint64
+	address uint64
+}
+
+type sampleKey struct {
+	stack string
+	comm  string
+	pid   int
+}
+
+// profileBuilder builds a CPU profile from the perf samples.
+type profileBuilder struct {
+	profile *profilev1.Profile
+	period  int64
+
+	strings   map[string]int64
+	mappings  map[mappingKey]*profilev1.Mapping
+	symbols   map[symbolKey]uint64
+	addresses map[addressKey]uint64
+	samples   map[sampleKey]*profilev1.Sample
+	keyBuf    []byte
+}
+
+func newProfileBuilder(md ingestion.Metadata) *profileBuilder {
+	sampleRate := int64(md.SampleRate)
+	if sampleRate <= 0 {
+		sampleRate = types.DefaultSampleRate
+	}
+	b := &profileBuilder{
+		period:    time.Second.Nanoseconds() / sampleRate,
+		strings:   map[string]int64{"": 0},
+		mappings:  make(map[mappingKey]*profilev1.Mapping),
+		symbols:   make(map[symbolKey]uint64),
+		addresses: make(map[addressKey]uint64),
+		samples:   make(map[sampleKey]*profilev1.Sample),
+	}
+	b.profile = &profilev1.Profile{
+		StringTable:   []string{""},
+		TimeNanos:     md.StartTime.UnixNano(),
+		DurationNanos: md.EndTime.Sub(md.StartTime).Nanoseconds(),
+		Period:        b.period,
+	}
+	b.profile.SampleType = []*profilev1.ValueType{{
+		Type: b.string("cpu"),
+		Unit: b.string("nanoseconds"),
+	}}
+	b.profile.PeriodType = &profilev1.ValueType{
+		Type: b.string("cpu"),
+		Unit: b.string("nanoseconds"),
+	}
+	return b
+}
+
+// addSample adds the value to the sample with the locations
+// (leaf first) of the process.
+func (b *profileBuilder) addSample(locations []uint64, value int64, comm string, pid int) {
+	if len(locations) == 0 {
+		return
+	}
+	b.keyBuf = b.keyBuf[:0]
+	for _, loc := range locations {
+		b.keyBuf = strconv.AppendUint(b.keyBuf, loc, 10)
+		b.keyBuf = append(b.keyBuf, ',')
+	}
+	k := sampleKey{stack: string(b.keyBuf), comm: comm, pid: pid}
+	if s, ok := b.samples[k]; ok {
+		s.Value[0] += value
+		return
+	}
+	s := &profilev1.Sample{
+		LocationId: locations,
+		Value:      []int64{value},
+		Label: []*profilev1.Label{
+			{Key: b.string(LabelNameComm), Str: b.string(comm)},
+			{Key: b.string(LabelNamePID), Str: b.string(strconv.Itoa(pid))},
+		},
+	}
+	b.samples[k] = s
+	b.profile.Sample = append(b.profile.Sample, s)
+}
+
+// symbolLocation returns the location of the symbolized frame.
+func (b *profileBuilder) symbolLocation(f Frame) uint64 {
+	k := symbolKey{
+		name:   reSymbolOffset.ReplaceAllString(string(f.Symbol), ""),
+		module: string(f.Module),
+	}
+	if id, ok := b.symbols[k]; ok {
+		return id
+	}
+	fn := &profilev1.Function{
+		Id:         uint64(len(b.profile.Function) + 1),
+		Name:       b.string(k.name),
+		SystemName: b.string(k.name),
+		Filename:   b.string(k.module),
+	}
+	b.profile.Function = append(b.profile.Function, fn)
+	m := b.mapping(mappingKey{filename: k.module}, func(m *profilev1.Mapping) {
+		m.HasFunctions = true
+	})
+	id := b.location(m.Id, 0, []*profilev1.Line{{FunctionId: fn.Id}})
+	b.symbols[k] = id
+	return id
+}
+
+// addressLocation returns the location of the address in the mapping,
+// which is to be symbolized.
+func (b *profileBuilder) addressLocation(mapping, address uint64) uint64 {
+	k := addressKey{mapping: mapping, address: address}
+	if id, ok := b.addresses[k]; ok {
+		return id
+	}
+	id := b.location(mapping, address, nil)
+	b.addresses[k] = id
+	return id
+}
+
+func (b *profileBuilder) location(mapping, address uint64, lines []*profilev1.Line) uint64 {
+	loc := &profilev1.Location{
+		Id:        uint64(len(b.profile.Location) + 1),
+		MappingId: mapping,
+		Address:   address,
+		Line:      lines,
+	}
+	b.profile.Location = append(b.profile.Location, loc)
+	return loc.Id
+}
+
+// mapping returns the mapping of the file: init is
+// called once the mapping is created.
+func (b *profileBuilder) mapping(k mappingKey, init func(*profilev1.Mapping)) *profilev1.Mapping {
+	if m, ok := b.mappings[k]; ok {
+		return m
+	}
+	m := &profilev1.Mapping{
+		Id:       uint64(len(b.profile.Mapping) + 1),
+		Filename: b.string(k.filename),
+		BuildId:  b.string(k.buildID),
+	}
+	init(m)
+	b.profile.Mapping = append(b.profile.Mapping, m)
+	b.mappings[k] = m
+	return m
+}
+
+func (b *profileBuilder) string(s string) int64 {
+	if i, ok := b.strings[s]; ok {
+		return i
+	}
+	i := int64(len(b.profile.StringTable))
+	b.profile.StringTable = append(b.profile.StringTable, s)
+	b.strings[s] = i
+	return i
+}
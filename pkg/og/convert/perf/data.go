@@ -0,0 +1,649 @@
+package perf
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/bits"
+)
+
+// The perf.data format is described in
+// https://github.com/torvalds/linux/blob/master/tools/perf/Documentation/perf.data-file-format.txt
+// Only little-endian files are supported.
+
+var (
+	dataMagic = []byte("PERFILE2")
+
+	errDataTruncated = errors.New("perf.data: truncated")
+)
+
+// IsPerfData reports whether the data starts with the perf.data magic.
+func IsPerfData(b []byte) bool {
+	return bytes.HasPrefix(b, dataMagic)
+}
+
+const (
+	fileHeaderSize = 104
+	pipeHeaderSize = 16
+
+	// Feature bit of the build-id table section.
+	featureBuildID = 2
+)
+
+// Record types.
+const (
+	recordMmap          = 1
+	recordComm          = 3
+	recordFork          = 7
+	recordSample        = 9
+	recordMmap2         = 10
+	recordHeaderAttr    = 64
+	recordHeaderTracing = 66
+	recordHeaderBuildID = 67
+	recordAuxtrace      = 71
+	recordCompressed    = 81
+	recordCompressed2   = 83
+)
+
+// Header misc flags.
+const (
+	miscMmapBuildID = 1 << 14
+	miscBuildIDSize = 1 << 15
+)
+
+// Sample type bits.
+const (
+	sampleIP         = 1 << 0
+	sampleTID        = 1 << 1
+	sampleTime       = 1 << 2
+	sampleAddr       = 1 << 3
+	sampleRead       = 1 << 4
+	sampleCallchain  = 1 << 5
+	sampleID         = 1 << 6
+	sampleCPU        = 1 << 7
+	samplePeriod     = 1 << 8
+	sampleStreamID   = 1 << 9
+	sampleIdentifier = 1 << 16
+)
+
+// Read format bits.
+const (
+	readFormatTotalTimeEnabled = 1 << 0
+	readFormatTotalTimeRunning = 1 << 1
+	readFormatID               = 1 << 2
+	readFormatGroup            = 1 << 3
+	readFormatLost             = 1 << 4
+)
+
+// Event attribute flags.
+const (
+	attrFlagFreq        = 1 << 10
+	attrFlagSampleIDAll = 1 << 18
+)
+
+// Callchain context markers: addresses greater than
+// or equal to contextMax are not instruction pointers.
+const (
+	contextKernel = ^uint64(128 - 1) // (u64)-128
+	contextUser   = ^uint64(512 - 1) // (u64)-512
+	contextMax    = ^uint64(4095 - 1)
+)
+
+// Software event configs of the clock events: their period is in nanoseconds.
+const (
+	eventTypeSoftware = 1
+
+	softwareCPUClock  = 0
+	softwareTaskClock = 1
+)
+
+// EventAttr describes a sampled event.
+type EventAttr struct {
+	Type         uint32
+	Config       uint64
+	SamplePeriod uint64 // Sampling frequency, if Freq is set.
+	Freq         bool
+	SampleType   uint64
+	ReadFormat   uint64
+	SampleIDAll  bool
+	IDs          []uint64
+}
+
+// IsClock reports whether the event is a software clock event,
+// the period of which is measured in nanoseconds.
+func (a *EventAttr) IsClock() bool {
+	return a.Type == eventTypeSoftware && (a.Config == softwareCPUClock || a.Config == softwareTaskClock)
+}
+
+// Record is one of MmapRecord, CommRecord, ForkRecord, and SampleRecord.
+type Record interface{ record() }
+
+type MmapRecord struct {
+	PID      uint32
+	TID      uint32
+	Start    uint64
+	Len      uint64
+	PgOff    uint64
+	Filename string
+	// Hex-encoded build ID, if present in the record.
+	BuildID string
+}
+
+type CommRecord struct {
+	PID  uint32
+	TID  uint32
+	Comm string
+}
+
+type ForkRecord struct {
+	PID  uint32
+	PPID uint32
+	TID  uint32
+	PTID uint32
+}
+
+type SampleRecord struct {
+	Attr   *EventAttr
+	IP     uint64
+	PID    uint32
+	TID    uint32
+	Time   uint64
+	Period uint64
+	// Instruction pointers, leaf first, including the context markers.
+	Callchain []uint64
+}
+
+func (*MmapRecord) record()   {}
+func (*CommRecord) record()   {}
+func (*ForkRecord) record()   {}
+func (*SampleRecord) record() {}
+
+// DataReader reads the records of a perf.data file,
+// written by perf record either to a file or a pipe.
+type DataReader struct {
+	buf      []byte
+	records  []byte
+	pipe     bool
+	attrs    []*EventAttr
+	ids      map[uint64]*EventAttr
+	buildIDs map[string]string
+}
+
+func NewDataReader(b []byte) (*DataReader, error) {
+	if !IsPerfData(b) {
+		return nil, errors.New("perf.data: invalid magic")
+	}
+	if len(b) < pipeHeaderSize {
+		return nil, errDataTruncated
+	}
+	r := &DataReader{
+		buf:      b,
+		ids:      make(map[uint64]*EventAttr),
+		buildIDs: make(map[string]string),
+	}
+	size := binary.LittleEndian.Uint64(b[8:])
+	switch size {
+	case pipeHeaderSize:
+		// Attributes and build IDs are written as records.
+		r.pipe = true
+		r.records = b[pipeHeaderSize:]
+		return r, nil
+	case fileHeaderSize:
+	default:
+		return nil, fmt.Errorf("perf.data: unsupported header size %d", size)
+	}
+	if len(b) < fileHeaderSize {
+		return nil, errDataTruncated
+	}
+	attrs, err := section(b, 24)
+	if err != nil {
+		return nil, err
+	}
+	if r.records, err = section(b, 40); err != nil {
+		return nil, err
+	}
+	attrsOffset := binary.LittleEndian.Uint64(b[24:])
+	attrSize := binary.LittleEndian.Uint64(b[16:])
+	if err = r.readFileAttrs(attrsOffset, uint64(len(attrs)), attrSize); err != nil {
+		return nil, err
+	}
+	if err = r.readFeatures(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// section returns the file section described at the offset of the header.
+func section(b []byte, off int) ([]byte, error) {
+	o := binary.LittleEndian.Uint64(b[off:])
+	s := binary.LittleEndian.Uint64(b[off+8:])
+	if o > uint64(len(b)) || s > uint64(len(b))-o {
+		return nil, errDataTruncated
+	}
+	return b[o : o+s], nil
+}
+
+// readFileAttrs reads the attributes section: each entry
+// is followed by the section of the event identifiers.
+func (r *DataReader) readFileAttrs(off, size, attrSize uint64) error {
+	if attrSize < 16+48 {
+		return fmt.Errorf("perf.data: invalid attribute size %d", attrSize)
+	}
+	for end := off + size; off < end; off += attrSize {
+		if off+attrSize > end {
+			return errDataTruncated
+		}
+		a, err := parseEventAttr(r.buf[off : off+attrSize-16])
+		if err != nil {
+			return err
+		}
+		ids, err := section(r.buf, int(off+attrSize-16))
+		if err != nil {
+			return err
+		}
+		for ; len(ids) >= 8; ids = ids[8:] {
+			a.IDs = append(a.IDs, binary.LittleEndian.Uint64(ids))
+		}
+		r.addAttr(a)
+	}
+	return nil
+}
+
+func (r *DataReader) addAttr(a *EventAttr) {
+	r.attrs = append(r.attrs, a)
+	for _, id := range a.IDs {
+		r.ids[id] = a
+	}
+}
+
+func parseEventAttr(b []byte) (*EventAttr, error) {
+	// type, size, config, sample_period, sample_type, read_format, flags.
+	if len(b) < 48 {
+		return nil, errDataTruncated
+	}
+	flags := binary.LittleEndian.Uint64(b[40:])
+	return &EventAttr{
+		Type:         binary.LittleEndian.Uint32(b),
+		Config:       binary.LittleEndian.Uint64(b[8:]),
+		SamplePeriod: binary.LittleEndian.Uint64(b[16:]),
+		SampleType:   binary.LittleEndian.Uint64(b[24:]),
+		ReadFormat:   binary.LittleEndian.Uint64(b[32:]),
+		Freq:         flags&attrFlagFreq != 0,
+		SampleIDAll:  flags&attrFlagSampleIDAll != 0,
+	}, nil
+}
+
+// readFeatures reads the feature sections that follow the data section:
+// only the build-id table is used. The sections are written in the order
+// of the feature bits set in the header.
+func (r *DataReader) readFeatures() error {
+	features := binary.LittleEndian.Uint64(r.buf[72:])
+	if features&(1<<featureBuildID) == 0 {
+		return nil
+	}
+	off := binary.LittleEndian.Uint64(r.buf[40:]) + binary.LittleEndian.Uint64(r.buf[48:])
+	off += uint64(bits.OnesCount64(features&(1<<featureBuildID-1))) * 16
+	if off+16 > uint64(len(r.buf)) {
+		return errDataTruncated
+	}
+	s, err := section(r.buf, int(off))
+	if err != nil {
+		return err
+	}
+	return r.readBuildIDs(s)
+}
+
+func (r *DataReader) readBuildIDs(b []byte) error {
+	for len(b) > 0 {
+		_, misc, rec, rest, err := nextRecord(b)
+		if err != nil {
+			return err
+		}
+		r.readBuildID(misc, rec)
+		b = rest
+	}
+	return nil
+}
+
+func (r *DataReader) readBuildID(misc uint16, rec []byte) {
+	// pid, build_id[24], filename.
+	if len(rec) < 28 {
+		return
+	}
+	size := 20
+	if misc&miscBuildIDSize != 0 && int(rec[24]) <= 20 {
+		size = int(rec[24])
+	}
+	filename := cstring(rec[28:])
+	r.buildIDs[filename] = hex.EncodeToString(rec[4 : 4+size])
+}
+
+// nextRecord returns the record at the beginning of b, without the header.
+func nextRecord(b []byte) (typ uint32, misc uint16, rec, rest []byte, err error) {
+	if len(b) < 8 {
+		return 0, 0, nil, nil, errDataTruncated
+	}
+	typ = binary.LittleEndian.Uint32(b)
+	misc = binary.LittleEndian.Uint16(b[4:])
+	size := int(binary.LittleEndian.Uint16(b[6:]))
+	if size < 8 || size > len(b) {
+		return 0, 0, nil, nil, errDataTruncated
+	}
+	return typ, misc, b[8:size], b[size:], nil
+}
+
+func cstring(b []byte) string {
+	if i := bytes.IndexByte(b, 0); i >= 0 {
+		b = b[:i]
+	}
+	return string(b)
+}
+
+// Attrs returns the sampled events. In pipe mode, the
+// events are only known after the records have been read.
+func (r *DataReader) Attrs() []*EventAttr { return r.attrs }
+
+// BuildIDs returns the hex-encoded build IDs of the files, by file name.
+// In pipe mode, the build IDs are only known after the records have
+// been read.
+func (r *DataReader) BuildIDs() map[string]string { return r.buildIDs }
+
+// Records calls fn for each record of the file, in the order they were
+// written. Records of other types are skipped.
+func (r *DataReader) Records(fn func(Record) error) error {
+	b := r.records
+	for len(b) > 0 {
+		typ, misc, rec, rest, err := nextRecord(b)
+		if err != nil {
+			return err
+		}
+		b = rest
+		var x Record
+		switch typ {
+		case recordHeaderAttr:
+			if !r.pipe {
+				continue
+			}
+			if err = r.readHeaderAttr(rec); err != nil {
+				return err
+			}
+		case recordHeaderBuildID:
+			r.readBuildID(misc, rec)
+		case recordHeaderTracing, recordAuxtrace:
+			// The record is followed by the data of the size
+			// specified in the record.
+			b, err = skipPayload(typ, rec, b)
+		case recordCompressed, recordCompressed2:
+			return errors.New("perf.data: compressed records are not supported, record without -z")
+		case recordMmap:
+			x, err = parseMmap(rec)
+		case recordMmap2:
+			x, err = parseMmap2(misc, rec)
+		case recordComm:
+			x, err = parseComm(rec)
+		case recordFork:
+			x, err = parseFork(rec)
+		case recordSample:
+			x, err = r.parseSample(rec)
+		}
+		if err != nil {
+			return err
+		}
+		if x != nil {
+			if err = fn(x); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func skipPayload(typ uint32, rec, b []byte) ([]byte, error) {
+	var size uint64
+	switch {
+	case typ == recordHeaderTracing && len(rec) >= 4:
+		size = uint64(binary.LittleEndian.Uint32(rec))
+		size = (size + 7) &^ 7
+	case typ == recordAuxtrace && len(rec) >= 8:
+		size = binary.LittleEndian.Uint64(rec)
+	default:
+		return nil, errDataTruncated
+	}
+	if size > uint64(len(b)) {
+		return nil, errDataTruncated
+	}
+	return b[size:], nil
+}
+
+func (r *DataReader) readHeaderAttr(b []byte) error {
+	if len(b) < 8 {
+		return errDataTruncated
+	}
+	size := int(binary.LittleEndian.Uint32(b[4:]))
+	if size < 48 || size > len(b) {
+		return errDataTruncated
+	}
+	a, err := parseEventAttr(b[:size])
+	if err != nil {
+		return err
+	}
+	for ids := b[size:]; len(ids) >= 8; ids = ids[8:] {
+		a.IDs = append(a.IDs, binary.LittleEndian.Uint64(ids))
+	}
+	r.addAttr(a)
+	return nil
+}
+
+func parseMmap(b []byte) (*MmapRecord, error) {
+	// pid, tid, addr, len, pgoff, filename.
+	if len(b) < 32 {
+		return nil, errDataTruncated
+	}
+	return &MmapRecord{
+		PID:      binary.LittleEndian.Uint32(b),
+		TID:      binary.LittleEndian.Uint32(b[4:]),
+		Start:    binary.LittleEndian.Uint64(b[8:]),
+		Len:      binary.LittleEndian.Uint64(b[16:]),
+		PgOff:    binary.LittleEndian.Uint64(b[24:]),
+		Filename: cstring(b[32:]),
+	}, nil
+}
+
+func parseMmap2(misc uint16, b []byte) (*MmapRecord, error) {
+	// pid, tid, addr, len, pgoff, then either maj, min, ino,
+	// ino_generation or the build ID; prot, flags, filename.
+	if len(b) < 64 {
+		return nil, errDataTruncated
+	}
+	m, err := parseMmap(b[:32])
+	if err != nil {
+		return nil, err
+	}
+	if misc&miscMmapBuildID != 0 {
+		size := int(b[32])
+		if size > 20 {
+			size = 20
+		}
+		m.BuildID = hex.EncodeToString(b[36 : 36+size])
+	}
+	m.Filename = cstring(b[64:])
+	return m, nil
+}
+
+func parseComm(b []byte) (*CommRecord, error) {
+	if len(b) < 8 {
+		return nil, errDataTruncated
+	}
+	return &CommRecord{
+		PID:  binary.LittleEndian.Uint32(b),
+		TID:  binary.LittleEndian.Uint32(b[4:]),
+		Comm: cstring(b[8:]),
+	}, nil
+}
+
+func parseFork(b []byte) (*ForkRecord, error) {
+	if len(b) < 16 {
+		return nil, errDataTruncated
+	}
+	return &ForkRecord{
+		PID:  binary.LittleEndian.Uint32(b),
+		PPID: binary.LittleEndian.Uint32(b[4:]),
+		TID:  binary.LittleEndian.Uint32(b[8:]),
+		PTID: binary.LittleEndian.Uint32(b[12:]),
+	}, nil
+}
+
+// sampleAttr returns the attributes of the event the sample belongs to.
+// If there are several events, the sample identifier is used.
+func (r *DataReader) sampleAttr(b []byte) (*EventAttr, error) {
+	if len(r.attrs) == 0 {
+		return nil, errors.New("perf.data: sample of unknown event")
+	}
+	if len(r.attrs) == 1 {
+		return r.attrs[0], nil
+	}
+	// All the events share the sample type,
+	// if they can be told apart.
+	st := r.attrs[0].SampleType
+	var off int
+	switch {
+	case st&sampleIdentifier != 0:
+	case st&sampleID != 0:
+		for _, f := range []uint64{sampleIP, sampleTID, sampleTime, sampleAddr} {
+			if st&f != 0 {
+				off += 8
+			}
+		}
+	default:
+		return r.attrs[0], nil
+	}
+	if len(b) < off+8 {
+		return nil, errDataTruncated
+	}
+	a, ok := r.ids[binary.LittleEndian.Uint64(b[off:])]
+	if !ok {
+		return nil, errors.New("perf.data: sample of unknown event")
+	}
+	return a, nil
+}
+
+func (r *DataReader) parseSample(b []byte) (*SampleRecord, error) {
+	a, err := r.sampleAttr(b)
+	if err != nil {
+		return nil, err
+	}
+	s := &SampleRecord{Attr: a, Period: a.SamplePeriod}
+	if a.Freq {
+		s.Period = 0
+	}
+	d := decoder{b: b}
+	st := a.SampleType
+	if st&sampleIdentifier != 0 {
+		d.u64()
+	}
+	if st&sampleIP != 0 {
+		s.IP = d.u64()
+	}
+	if st&sampleTID != 0 {
+		s.PID = d.u32()
+		s.TID = d.u32()
+	}
+	if st&sampleTime != 0 {
+		s.Time = d.u64()
+	}
+	if st&sampleAddr != 0 {
+		d.u64()
+	}
+	if st&sampleID != 0 {
+		d.u64()
+	}
+	if st&sampleStreamID != 0 {
+		d.u64()
+	}
+	if st&sampleCPU != 0 {
+		d.u64()
+	}
+	if st&samplePeriod != 0 {
+		s.Period = d.u64()
+	}
+	if st&sampleRead != 0 {
+		d.skipRead(a.ReadFormat)
+	}
+	if st&sampleCallchain != 0 {
+		n := d.u64()
+		if n > uint64(len(d.b))/8 {
+			return nil, errDataTruncated
+		}
+		s.Callchain = make([]uint64, n)
+		for i := range s.Callchain {
+			s.Callchain[i] = d.u64()
+		}
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+	return s, nil
+}
+
+type decoder struct {
+	b   []byte
+	err error
+}
+
+func (d *decoder) u64() uint64 {
+	if len(d.b) < 8 {
+		d.err = errDataTruncated
+		d.b = nil
+		return 0
+	}
+	v := binary.LittleEndian.Uint64(d.b)
+	d.b = d.b[8:]
+	return v
+}
+
+func (d *decoder) u32() uint32 {
+	if len(d.b) < 4 {
+		d.err = errDataTruncated
+		d.b = nil
+		return 0
+	}
+	v := binary.LittleEndian.Uint32(d.b)
+	d.b = d.b[4:]
+	return v
+}
+
+func (d *decoder) skipRead(format uint64) {
+	value := 1
+	if format&readFormatID != 0 {
+		value++
+	}
+	if format&readFormatLost != 0 {
+		value++
+	}
+	var times int
+	if format&readFormatTotalTimeEnabled != 0 {
+		times++
+	}
+	if format&readFormatTotalTimeRunning != 0 {
+		times++
+	}
+	if format&readFormatGroup == 0 {
+		for i := 0; i < value+times; i++ {
+			d.u64()
+		}
+		return
+	}
+	n := d.u64()
+	for i := 0; i < times; i++ {
+		d.u64()
+	}
+	if n > uint64(len(d.b))/8 {
+		d.err = errDataTruncated
+		return
+	}
+	for i := 0; i < int(n)*value; i++ {
+		d.u64()
+	}
+}
@@ -0,0 +1,221 @@
+package perf
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+
+	profilev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
+	distributormodel "github.com/grafana/pyroscope/pkg/distributor/model"
+	"github.com/grafana/pyroscope/pkg/og/ingestion"
+	"github.com/grafana/pyroscope/pkg/og/storage"
+	"github.com/grafana/pyroscope/pkg/pprof"
+)
+
+const (
+	// kernelPID is the PID of the kernel and module mmap records.
+	kernelPID = ^uint32(0)
+	// kernelBuildIDName is the name of the kernel in the build-id table.
+	kernelBuildIDName = "[kernel.kallsyms]"
+	unknownMapping    = "[unknown]"
+)
+
+// RawPerfData is a perf.data file, as written by `perf record`.
+type RawPerfData struct {
+	RawData []byte
+}
+
+func (p *RawPerfData) Bytes() ([]byte, error) { return p.RawData, nil }
+
+func (*RawPerfData) ContentType() string { return "application/octet-stream" }
+
+func (p *RawPerfData) Parse(context.Context, storage.Putter, storage.MetricsExporter, ingestion.Metadata) error {
+	return fmt.Errorf("parsing perf.data to Tree/storage.Putter is not supported")
+}
+
+func (p *RawPerfData) ParseToPprof(_ context.Context, md ingestion.Metadata) (*distributormodel.PushRequest, error) {
+	profile, err := DataToPprof(p.RawData, md)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("failed to parse perf.data: %w", err))
+	}
+	res := &distributormodel.PushRequest{
+		RawProfileSize: len(p.RawData),
+		RawProfileType: distributormodel.RawProfileTypePerfData,
+	}
+	if len(profile.Sample) == 0 {
+		return res, nil
+	}
+	res.Series = []*distributormodel.ProfileSeries{{
+		Labels: seriesLabels(md, "process_cpu"),
+		Samples: []*distributormodel.ProfileSample{{
+			Profile: pprof.RawFromProto(profile),
+		}},
+	}}
+	return res, nil
+}
+
+// DataToPprof converts the samples of the first sampled event of the
+// perf.data file to a CPU profile. Samples of clock events are weighted
+// with their period; other samples are weighted with the sampling period
+// derived from the event frequency or, if the event was sampled with a
+// fixed period, from the sample rate of the request.
+//
+// The native frames are not symbolized: locations hold the addresses
+// relative to the files mapped, and mappings carry the build IDs of the
+// files, if recorded, so that the profile can be symbolized later.
+func DataToPprof(b []byte, md ingestion.Metadata) (*profilev1.Profile, error) {
+	r, err := NewDataReader(b)
+	if err != nil {
+		return nil, err
+	}
+	c := &dataConverter{
+		reader:    r,
+		builder:   newProfileBuilder(md),
+		processes: make(map[uint32]*process),
+	}
+	if err = r.Records(c.record); err != nil {
+		return nil, err
+	}
+	return c.builder.profile, nil
+}
+
+type process struct {
+	comm  string
+	mmaps []*MmapRecord
+}
+
+// find returns the most recent mapping of the address.
+func (p *process) find(addr uint64) *MmapRecord {
+	for i := len(p.mmaps) - 1; i >= 0; i-- {
+		m := p.mmaps[i]
+		if addr >= m.Start && addr-m.Start < m.Len {
+			return m
+		}
+	}
+	return nil
+}
+
+type dataConverter struct {
+	reader    *DataReader
+	builder   *profileBuilder
+	attr      *EventAttr
+	processes map[uint32]*process
+	kernel    process
+	locations []uint64
+}
+
+func (c *dataConverter) process(pid uint32) *process {
+	p, ok := c.processes[pid]
+	if !ok {
+		p = new(process)
+		c.processes[pid] = p
+	}
+	return p
+}
+
+func (c *dataConverter) record(rec Record) error {
+	switch r := rec.(type) {
+	case *MmapRecord:
+		if r.PID == kernelPID {
+			c.kernel.mmaps = append(c.kernel.mmaps, r)
+		} else {
+			p := c.process(r.PID)
+			p.mmaps = append(p.mmaps, r)
+		}
+	case *CommRecord:
+		c.process(r.PID).comm = r.Comm
+	case *ForkRecord:
+		if r.PID == r.PPID {
+			// A new thread shares the address space.
+			break
+		}
+		parent := c.process(r.PPID)
+		c.processes[r.PID] = &process{
+			comm:  parent.comm,
+			mmaps: append([]*MmapRecord(nil), parent.mmaps...),
+		}
+	case *SampleRecord:
+		c.sample(r)
+	}
+	return nil
+}
+
+func (c *dataConverter) sample(s *SampleRecord) {
+	if c.attr == nil {
+		c.attr = s.Attr
+	}
+	if s.Attr != c.attr {
+		return
+	}
+	p := c.process(s.PID)
+	c.locations = c.locations[:0]
+	if len(s.Callchain) == 0 {
+		c.locations = append(c.locations, c.location(p, s.IP, false))
+	}
+	var kernel bool
+	for _, addr := range s.Callchain {
+		if addr >= contextMax {
+			switch addr {
+			case contextKernel:
+				kernel = true
+			case contextUser:
+				kernel = false
+			}
+			continue
+		}
+		c.locations = append(c.locations, c.location(p, addr, kernel))
+	}
+	comm := p.comm
+	if comm == "" {
+		comm = fmt.Sprintf(":%d", s.PID)
+	}
+	c.builder.addSample(append([]uint64(nil), c.locations...), c.value(s), comm, int(s.PID))
+}
+
+func (c *dataConverter) value(s *SampleRecord) int64 {
+	switch {
+	case c.attr.IsClock() && s.Period > 0:
+		return int64(s.Period)
+	case c.attr.Freq && c.attr.SamplePeriod > 0:
+		return time.Second.Nanoseconds() / int64(c.attr.SamplePeriod)
+	default:
+		return c.builder.period
+	}
+}
+
+// location returns the location of the address. Addresses of user space
+// files are translated to file offsets, kernel addresses are kept as is.
+func (c *dataConverter) location(p *process, addr uint64, kernel bool) uint64 {
+	var m *MmapRecord
+	if !kernel {
+		m = p.find(addr)
+	}
+	if m == nil {
+		if m = c.kernel.find(addr); m != nil {
+			return c.builder.addressLocation(c.mapping(m, true).Id, addr)
+		}
+		unknown := c.builder.mapping(mappingKey{filename: unknownMapping}, func(*profilev1.Mapping) {})
+		return c.builder.addressLocation(unknown.Id, addr)
+	}
+	return c.builder.addressLocation(c.mapping(m, false).Id, addr-m.Start+m.PgOff)
+}
+
+func (c *dataConverter) mapping(m *MmapRecord, kernel bool) *profilev1.Mapping {
+	buildID := m.BuildID
+	if buildID == "" {
+		name := m.Filename
+		if kernel && strings.HasPrefix(name, kernelBuildIDName) {
+			// The kernel is mapped as [kernel.kallsyms]_text.
+			name = kernelBuildIDName
+		}
+		buildID = c.reader.BuildIDs()[name]
+	}
+	return c.builder.mapping(mappingKey{filename: m.Filename, buildID: buildID}, func(mapping *profilev1.Mapping) {
+		mapping.MemoryStart = m.Start
+		mapping.MemoryLimit = m.Start + m.Len
+		mapping.FileOffset = m.PgOff
+	})
+}
@@ -0,0 +1,230 @@
+package perf
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	profilev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
+	"github.com/grafana/pyroscope/pkg/og/ingestion"
+)
+
+const (
+	testKernelBuildID = "00112233445566778899aabbccddeeff00112233"
+	testAppBuildID    = "aabbccddeeff00112233445566778899aabbccdd"
+	testLibcBuildID   = "0123456789abcdef0123456789abcdef01234567"
+)
+
+// dataWriter writes perf.data files.
+type dataWriter struct {
+	attr    []byte
+	records bytes.Buffer
+}
+
+func newDataWriter(typ uint32, config, period, sampleType, flags uint64) *dataWriter {
+	w := new(dataWriter)
+	w.attr = fields(typ, uint32(0), config, period, sampleType, uint64(0), flags, uint64(0), uint64(0))
+	binary.LittleEndian.PutUint32(w.attr[4:], uint32(len(w.attr)))
+	return w
+}
+
+// fields encodes the values: strings are null-terminated
+// and padded to 8 bytes.
+func fields(values ...any) []byte {
+	var b bytes.Buffer
+	for _, v := range values {
+		switch v := v.(type) {
+		case string:
+			b.WriteString(v)
+			b.WriteByte(0)
+			for b.Len()%8 != 0 {
+				b.WriteByte(0)
+			}
+		default:
+			_ = binary.Write(&b, binary.LittleEndian, v)
+		}
+	}
+	return b.Bytes()
+}
+
+func record(typ uint32, misc uint16, values ...any) []byte {
+	b := fields(values...)
+	return append(fields(typ, misc, uint16(8+len(b))), b...)
+}
+
+func (w *dataWriter) record(typ uint32, misc uint16, values ...any) {
+	w.records.Write(record(typ, misc, values...))
+}
+
+func buildIDRecord(pid int32, buildID, filename string) []byte {
+	id := make([]byte, 24)
+	hex.Decode(id, []byte(buildID))
+	return record(0, 0, pid, id, filename)
+}
+
+func (w *dataWriter) file(buildIDs ...[]byte) []byte {
+	const attrSize = 64 + 16
+	ids := fields(uint64(1))
+	idsOffset := uint64(fileHeaderSize)
+	attrsOffset := idsOffset + uint64(len(ids))
+	dataOffset := attrsOffset + attrSize
+	var b bytes.Buffer
+	b.Write(dataMagic)
+	b.Write(fields(
+		uint64(fileHeaderSize), uint64(attrSize),
+		attrsOffset, uint64(attrSize),
+		dataOffset, uint64(w.records.Len()),
+		uint64(0), uint64(0),
+		uint64(1<<featureBuildID), uint64(0), uint64(0), uint64(0),
+	))
+	b.Write(ids)
+	b.Write(w.attr)
+	b.Write(fields(idsOffset, uint64(len(ids))))
+	b.Write(w.records.Bytes())
+	buildIDsOffset := uint64(b.Len()) + 16
+	buildIDsSize := uint64(len(bytes.Join(buildIDs, nil)))
+	b.Write(fields(buildIDsOffset, buildIDsSize))
+	b.Write(bytes.Join(buildIDs, nil))
+	return b.Bytes()
+}
+
+func (w *dataWriter) pipe(buildIDs ...[]byte) []byte {
+	var b bytes.Buffer
+	b.Write(dataMagic)
+	b.Write(fields(uint64(pipeHeaderSize)))
+	b.Write(record(recordHeaderAttr, 0, w.attr, uint64(1)))
+	for _, r := range buildIDs {
+		binary.LittleEndian.PutUint32(r, recordHeaderBuildID)
+		b.Write(r)
+	}
+	b.Write(w.records.Bytes())
+	return b.Bytes()
+}
+
+// writeTestRecords writes the records of the app process and its child,
+// sampled either with the period, or with the sampling frequency.
+func writeTestRecords(w *dataWriter, period bool) {
+	w.record(recordMmap, 0, int32(-1), int32(0),
+		uint64(0xffffffff81000000), uint64(0x1000000), uint64(0xffffffff81000000), "[kernel.kallsyms]_text")
+	w.record(recordComm, 0, uint32(100), uint32(100), "app")
+	appBuildID := make([]byte, 20)
+	hex.Decode(appBuildID, []byte(testAppBuildID))
+	w.record(recordMmap2, miscMmapBuildID, uint32(100), uint32(100),
+		uint64(0x55550000), uint64(0x10000), uint64(0x1000),
+		uint8(20), [3]uint8{}, appBuildID, uint32(5), uint32(2), "/usr/bin/app")
+	w.record(recordMmap, 0, uint32(100), uint32(100),
+		uint64(0x7f0000000000), uint64(0x200000), uint64(0), "/usr/lib/libc.so.6")
+	w.record(recordFork, 0, uint32(101), uint32(100), uint32(101), uint32(100), uint64(0))
+
+	sample := func(pid uint32, callchain ...uint64) {
+		values := []any{uint64(callchain[len(callchain)-1]), pid, pid, uint64(1)}
+		if period {
+			values = append(values, uint64(250000))
+		}
+		values = append(values, uint64(len(callchain)), callchain)
+		w.record(recordSample, 0, values...)
+	}
+	sample(100, contextKernel, 0xffffffff81001000, contextUser, 0x55551234, 0x7f0000010000)
+	sample(100, contextKernel, 0xffffffff81001000, contextUser, 0x55551234, 0x7f0000010000)
+	sample(101, contextUser, 0x55551234, 0x9999)
+}
+
+func testBuildIDs() [][]byte {
+	return [][]byte{
+		buildIDRecord(-1, testKernelBuildID, "[kernel.kallsyms]"),
+		buildIDRecord(100, testLibcBuildID, "/usr/lib/libc.so.6"),
+	}
+}
+
+// testPerfData returns the perf.data file of testdata/app.perf.data.
+func testPerfData() []byte {
+	w := newDataWriter(eventTypeSoftware, softwareCPUClock, 4000,
+		sampleIP|sampleTID|sampleTime|samplePeriod|sampleCallchain, attrFlagFreq|attrFlagSampleIDAll)
+	writeTestRecords(w, true)
+	return w.file(testBuildIDs()...)
+}
+
+func TestDataFixture(t *testing.T) {
+	b, err := os.ReadFile("testdata/app.perf.data")
+	if err != nil {
+		t.Fatalf("err %v", err)
+	}
+	if !bytes.Equal(b, testPerfData()) {
+		t.Fatalf("testdata/app.perf.data is not up to date")
+	}
+}
+
+func collapseData(p *profilev1.Profile) []string {
+	var res []string
+	for _, s := range p.Sample {
+		line := fmt.Sprintf("%s=%s %s=%s %d:",
+			p.StringTable[s.Label[0].Key], p.StringTable[s.Label[0].Str],
+			p.StringTable[s.Label[1].Key], p.StringTable[s.Label[1].Str],
+			s.Value[0])
+		for _, id := range s.LocationId {
+			loc := p.Location[id-1]
+			m := p.Mapping[loc.MappingId-1]
+			line += fmt.Sprintf(" %s(%s)@%x", p.StringTable[m.Filename], p.StringTable[m.BuildId], loc.Address)
+		}
+		res = append(res, line)
+	}
+	return res
+}
+
+func TestDataToPprof(t *testing.T) {
+	w := newDataWriter(0, 0, 4000, sampleIP|sampleTID|sampleTime|sampleCallchain, attrFlagFreq)
+	writeTestRecords(w, false)
+	for name, b := range map[string][]byte{
+		"file": testPerfData(),
+		"pipe": w.pipe(testBuildIDs()...),
+	} {
+		t.Run(name, func(t *testing.T) {
+			p, err := DataToPprof(b, ingestion.Metadata{SampleRate: 100})
+			if err != nil {
+				t.Fatalf("err %v", err)
+			}
+			expected := []string{
+				"comm=app pid=100 500000:" +
+					" [kernel.kallsyms]_text(" + testKernelBuildID + ")@ffffffff81001000" +
+					" /usr/bin/app(" + testAppBuildID + ")@2234" +
+					" /usr/lib/libc.so.6(" + testLibcBuildID + ")@10000",
+				"comm=app pid=101 250000:" +
+					" /usr/bin/app(" + testAppBuildID + ")@2234" +
+					" [unknown]()@9999",
+			}
+			if actual := collapseData(p); !reflect.DeepEqual(expected, actual) {
+				t.Fatalf("expected %q, got %q", expected, actual)
+			}
+			for _, m := range p.Mapping {
+				if m.HasFunctions {
+					t.Fatalf("unexpected symbolized mapping %v", m)
+				}
+			}
+			app := p.Mapping[1]
+			if app.MemoryStart != 0x55550000 || app.MemoryLimit != 0x55560000 || app.FileOffset != 0x1000 {
+				t.Fatalf("unexpected mapping %v", app)
+			}
+		})
+	}
+}
+
+func TestDataToPprofErrors(t *testing.T) {
+	b := testPerfData()
+	for name, data := range map[string][]byte{
+		"magic":     []byte("PERFILE1"),
+		"truncated": b[:len(b)-100],
+	} {
+		if _, err := DataToPprof(data, ingestion.Metadata{}); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+	w := newDataWriter(0, 0, 4000, sampleIP, 0)
+	w.record(recordCompressed, 0, uint64(0))
+	if _, err := DataToPprof(w.file(), ingestion.Metadata{}); err == nil {
+		t.Errorf("compressed: expected error")
+	}
+}
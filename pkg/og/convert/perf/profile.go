@@ -0,0 +1,105 @@
+package perf
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/prometheus/prometheus/model/labels"
+
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+	distributormodel "github.com/grafana/pyroscope/pkg/distributor/model"
+	phlaremodel "github.com/grafana/pyroscope/pkg/model"
+	"github.com/grafana/pyroscope/pkg/og/ingestion"
+	"github.com/grafana/pyroscope/pkg/og/storage"
+	"github.com/grafana/pyroscope/pkg/pprof"
+)
+
+const (
+	// LabelNameComm and LabelNamePID are the sample labels
+	// holding the command name and process ID of the samples.
+	LabelNameComm = "comm"
+	LabelNamePID  = "pid"
+)
+
+// RawProfile is the text output of `perf script`.
+type RawProfile struct {
+	RawData []byte
+}
+
+func (p *RawProfile) Bytes() ([]byte, error) { return p.RawData, nil }
+
+func (*RawProfile) ContentType() string { return "text/plain" }
+
+func (p *RawProfile) Parse(context.Context, storage.Putter, storage.MetricsExporter, ingestion.Metadata) error {
+	return fmt.Errorf("parsing perf script to Tree/storage.Putter is not supported")
+}
+
+// ParseToPprof converts the samples to a CPU profile. Samples of clock
+// events (cpu-clock, task-clock) are weighted with their period, if
+// present in the output; other samples are weighted with the sampling
+// period derived from the sample rate of the request.
+func (p *RawProfile) ParseToPprof(_ context.Context, md ingestion.Metadata) (*distributormodel.PushRequest, error) {
+	samples, err := NewScriptParser(p.RawData).ParseSamples()
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("failed to parse perf script: %w", err))
+	}
+	res := &distributormodel.PushRequest{
+		RawProfileSize: len(p.RawData),
+		RawProfileType: distributormodel.RawProfileTypePerfScript,
+	}
+	b := newProfileBuilder(md)
+	for _, s := range samples {
+		value := b.period
+		if isClockEvent(s.Event) && s.Period > 0 {
+			value = s.Period
+		}
+		locations := make([]uint64, len(s.Frames))
+		for i, f := range s.Frames {
+			locations[i] = b.symbolLocation(f)
+		}
+		b.addSample(locations, value, string(s.Comm), int(s.PID))
+	}
+	if len(b.profile.Sample) == 0 {
+		return res, nil
+	}
+	res.Series = []*distributormodel.ProfileSeries{{
+		Labels: seriesLabels(md, "process_cpu"),
+		Samples: []*distributormodel.ProfileSample{{
+			Profile: pprof.RawFromProto(b.profile),
+		}},
+	}}
+	return res, nil
+}
+
+func isClockEvent(event []byte) bool {
+	e := string(event)
+	return e == "cpu-clock" || e == "task-clock"
+}
+
+func seriesLabels(md ingestion.Metadata, name string) []*typesv1.LabelPair {
+	ls := make([]*typesv1.LabelPair, 0, len(md.LabelSet.Labels())+4)
+	ls = append(ls, &typesv1.LabelPair{
+		Name:  labels.MetricName,
+		Value: name,
+	}, &typesv1.LabelPair{
+		Name:  phlaremodel.LabelNameDelta,
+		Value: "false",
+	}, &typesv1.LabelPair{
+		Name:  phlaremodel.LabelNamePyroscopeSpy,
+		Value: md.SpyName,
+	})
+	if _, ok := md.LabelSet.Labels()[phlaremodel.LabelNameServiceName]; !ok {
+		ls = append(ls, &typesv1.LabelPair{
+			Name:  phlaremodel.LabelNameServiceName,
+			Value: md.LabelSet.ServiceName(),
+		})
+	}
+	for k, v := range md.LabelSet.Labels() {
+		if !phlaremodel.IsLabelAllowedForIngestion(k) {
+			continue
+		}
+		ls = append(ls, &typesv1.LabelPair{Name: k, Value: v})
+	}
+	return ls
+}
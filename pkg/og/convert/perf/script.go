@@ -12,6 +12,7 @@ var reEventStart = regexp.MustCompile("^(\\S.+?)\\s+(\\d+)/*(\\d+)*\\s+\\S.+")
 var errEventStartRegexMismatch = fmt.Errorf("reEventStart mismatch")
 var reStackFrame = regexp.MustCompile("^\\s*(\\w+)\\s*(.+) \\((\\S*)\\)")
 var errStackFrameRegexMismatch = fmt.Errorf("reStackFrame mismatch")
+var reEventPeriod = regexp.MustCompile("\\d+\\.\\d+:\\s+(?:(\\d+)\\s+)?([^\\s:]+)")
 var sep = []byte{'\n'}
 
 type ScriptParser struct {
@@ -62,26 +63,107 @@ func (p *ScriptParser) ParseEvent() ([][]byte, error) {
 		}
 		return nil, err
 	}
-	var sym []byte
+	frames, err := p.parseStack()
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range frames {
+		stack = append(stack, f.Symbol)
+	}
+	stack = append(stack, comm)
+	for i, j := 0, len(stack)-1; i < j; i, j = i+1, j-1 {
+		stack[i], stack[j] = stack[j], stack[i]
+	}
+	return stack, nil
+}
+
+// Sample is a sampled event of the perf script output.
+type Sample struct {
+	Comm []byte
+	PID  int
+	TID  int
+	// Event name, e.g. "cpu-clock" or "cycles".
+	Event []byte
+	// Period of the event, if present in the output: 0 otherwise.
+	Period int64
+	// Frames of the stack trace, leaf first.
+	Frames []Frame
+}
+
+type Frame struct {
+	Address []byte
+	Symbol  []byte
+	Module  []byte
+}
+
+// ParseSamples parses the events of the perf script output.
+// Comments and empty lines between the events are skipped.
+func (p *ScriptParser) ParseSamples() ([]Sample, error) {
+	samples := make([]Sample, 0, 256)
 	for {
-		line, err = p.nextLine()
+		sample, err := p.ParseSample()
 		if err != nil {
+			if err == io.EOF {
+				break
+			}
 			return nil, err
 		}
-		if parseEventEnd(line) {
+		samples = append(samples, sample)
+	}
+	return samples, nil
+}
+
+func (p *ScriptParser) ParseSample() (Sample, error) {
+	var line []byte
+	var err error
+	for {
+		if line, err = p.nextLine(); err != nil {
+			return Sample{}, err
+		}
+		if len(bytes.TrimSpace(line)) > 0 && line[0] != '#' {
 			break
 		}
-		_, sym, _, err = parseStackFrame(line)
+	}
+	var s Sample
+	if s.Comm, s.PID, s.TID, err = parseEventStart(line); err != nil {
+		return Sample{}, fmt.Errorf("line %d: %w", p.lineIndex, err)
+	}
+	if m := reEventPeriod.FindSubmatch(line); m != nil {
+		s.Event = m[2]
+		if m[1] != nil {
+			if s.Period, err = strconv.ParseInt(string(m[1]), 10, 64); err != nil {
+				return Sample{}, fmt.Errorf("line %d: %w", p.lineIndex, err)
+			}
+		}
+	}
+	if s.Frames, err = p.parseStack(); err != nil {
+		if err == io.EOF {
+			// The last event is not necessarily
+			// followed by an empty line.
+			return s, nil
+		}
+		return Sample{}, fmt.Errorf("line %d: %w", p.lineIndex, err)
+	}
+	return s, nil
+}
+
+// parseStack parses the stack frames up to the end of the event.
+func (p *ScriptParser) parseStack() ([]Frame, error) {
+	frames := make([]Frame, 0, 16)
+	for {
+		line, err := p.nextLine()
+		if err != nil {
+			return frames, err
+		}
+		if parseEventEnd(line) {
+			return frames, nil
+		}
+		adr, sym, mod, err := parseStackFrame(line)
 		if err != nil {
 			return nil, err
 		}
-		stack = append(stack, sym)
+		frames = append(frames, Frame{Address: adr, Symbol: sym, Module: mod})
 	}
-	stack = append(stack, comm)
-	for i, j := 0, len(stack)-1; i < j; i, j = i+1, j-1 {
-		stack[i], stack[j] = stack[j], stack[i]
-	}
-	return stack, nil
 }
 
 func IsPerfScript(buf []byte) bool {
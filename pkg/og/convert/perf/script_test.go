@@ -182,3 +182,32 @@ func TestMultipleEvents(t *testing.T) {
 		[]byte("do_syscall_64+0x69"),
 	}, events[1])
 }
+
+func TestParseSamples(t *testing.T) {
+	script := "# header\n" +
+		"\n" +
+		"app 100/101 [000] 1.000001:     250000 cpu-clock:ppp:\n" +
+		"        7f0000001000 work+0x10 (/usr/bin/app)\n" +
+		"\n" +
+		"\n" +
+		"V8 WorkerThread 24636/25607 [000] 94564.109216: cycles:\n" +
+		"        7f0000002000 main (/usr/bin/node)"
+	samples, err := NewScriptParser([]byte(script)).ParseSamples()
+	if err != nil {
+		t.Fatalf("err %v", err)
+	}
+	if len(samples) != 2 {
+		t.Fatalf("expected 2 samples, got %d", len(samples))
+	}
+	s := samples[0]
+	if string(s.Comm) != "app" || s.PID != 100 || s.TID != 101 || string(s.Event) != "cpu-clock" || s.Period != 250000 {
+		t.Fatalf("unexpected sample %+v", s)
+	}
+	if len(s.Frames) != 1 || string(s.Frames[0].Symbol) != "work+0x10" || string(s.Frames[0].Module) != "/usr/bin/app" {
+		t.Fatalf("unexpected frames %+v", s.Frames)
+	}
+	s = samples[1]
+	if string(s.Comm) != "V8 WorkerThread" || s.PID != 24636 || string(s.Event) != "cycles" || s.Period != 0 || len(s.Frames) != 1 {
+		t.Fatalf("unexpected sample %+v", s)
+	}
+}
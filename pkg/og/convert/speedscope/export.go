@@ -0,0 +1,74 @@
+package speedscope
+
+import (
+	"encoding/json"
+	"io"
+	"slices"
+
+	phlaremodel "github.com/grafana/pyroscope/pkg/model"
+)
+
+const exporter = "pyroscope"
+
+// NamedTree is a tree to be exported as a speedscope profile.
+type NamedTree struct {
+	Name string
+	Tree *phlaremodel.Tree
+}
+
+// Export writes the trees as a speedscope file: each tree is
+// exported as a sampled profile, the frames are shared.
+func Export(w io.Writer, name string, sampleUnit string, trees ...NamedTree) error {
+	file := speedscopeFile{
+		Schema:   schema,
+		Name:     name,
+		Exporter: exporter,
+		Profiles: make([]profile, 0, len(trees)),
+	}
+	frames := make(map[string]int)
+	u := unitFromSampleUnit(sampleUnit)
+	for _, t := range trees {
+		p := profile{
+			Type:    profileSampled,
+			Name:    t.Name,
+			Unit:    u,
+			Samples: []sample{},
+			Weights: []float64{},
+		}
+		t.Tree.IterateStacks(func(_ string, self int64, stack []string) {
+			// Stack traces are leaf first, speedscope
+			// samples are expected to be root first.
+			s := make(sample, 0, len(stack))
+			for _, n := range slices.Backward(stack) {
+				f, ok := frames[n]
+				if !ok {
+					f = len(file.Shared.Frames)
+					frames[n] = f
+					file.Shared.Frames = append(file.Shared.Frames, frame{Name: n})
+				}
+				s = append(s, float64(f))
+			}
+			p.Samples = append(p.Samples, s)
+			p.Weights = append(p.Weights, float64(self))
+			p.EndValue += float64(self)
+		})
+		file.Profiles = append(file.Profiles, p)
+	}
+	if file.Shared.Frames == nil {
+		file.Shared.Frames = []frame{}
+	}
+	return json.NewEncoder(w).Encode(file)
+}
+
+func unitFromSampleUnit(sampleUnit string) unit {
+	switch u := unit(sampleUnit); u {
+	case unitNanoseconds,
+		unitMicroseconds,
+		unitMilliseconds,
+		unitSeconds,
+		unitBytes:
+		return u
+	default:
+		return unitNone
+	}
+}
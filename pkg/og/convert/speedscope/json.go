@@ -14,44 +14,44 @@ const (
 )
 
 type speedscopeFile struct {
-	Schema             string `json:"$schema"`
-	Shared             shared
-	Profiles           []profile
-	Name               string
-	ActiveProfileIndex float64
-	Exporter           string
+	Schema             string    `json:"$schema"`
+	Shared             shared    `json:"shared"`
+	Profiles           []profile `json:"profiles"`
+	Name               string    `json:"name,omitempty"`
+	ActiveProfileIndex float64   `json:"activeProfileIndex"`
+	Exporter           string    `json:"exporter,omitempty"`
 }
 
 type shared struct {
-	Frames []frame
+	Frames []frame `json:"frames"`
 }
 
 type frame struct {
-	Name string
-	File string
-	Line float64
-	Col  float64
+	Name string  `json:"name"`
+	File string  `json:"file,omitempty"`
+	Line float64 `json:"line,omitempty"`
+	Col  float64 `json:"col,omitempty"`
 }
 
 type profile struct {
-	Type       string
-	Name       string
-	Unit       unit
-	StartValue float64
-	EndValue   float64
+	Type       string  `json:"type"`
+	Name       string  `json:"name"`
+	Unit       unit    `json:"unit"`
+	StartValue float64 `json:"startValue"`
+	EndValue   float64 `json:"endValue"`
 
 	// Evented profile
-	Events []event
+	Events []event `json:"events,omitempty"`
 
 	// Sample profile
-	Samples []sample
-	Weights []float64
+	Samples []sample  `json:"samples,omitempty"`
+	Weights []float64 `json:"weights,omitempty"`
 }
 
 type event struct {
-	Type  string
-	At    float64
-	Frame float64
+	Type  string  `json:"type"`
+	At    float64 `json:"at"`
+	Frame float64 `json:"frame"`
 }
 
 // Indexes into Frames
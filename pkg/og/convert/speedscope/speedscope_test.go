@@ -1,6 +1,7 @@
 package speedscope
 
 import (
+	"bytes"
 	"context"
 	"os"
 
@@ -8,6 +9,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/grafana/pyroscope/api/model/labelset"
+	phlaremodel "github.com/grafana/pyroscope/pkg/model"
 	"github.com/grafana/pyroscope/pkg/og/ingestion"
 	"github.com/grafana/pyroscope/pkg/og/storage/metadata"
 
@@ -75,4 +77,34 @@ a;b;d 400
 		Expect(input.Val.String()).To(Equal(expectedResult))
 		Expect(input.SampleRate).To(Equal(uint32(100)))
 	})
+
+	It("Can export trees as sampled profiles", func() {
+		left := new(phlaremodel.Tree)
+		left.InsertStack(500, "a", "b")
+		left.InsertStack(400, "a", "b", "d")
+		right := new(phlaremodel.Tree)
+		right.InsertStack(500, "a", "b", "c")
+
+		var buf bytes.Buffer
+		err := Export(&buf, "foo", "bytes",
+			NamedTree{Name: "left", Tree: left},
+			NamedTree{Name: "right", Tree: right},
+		)
+		Expect(err).ToNot(HaveOccurred())
+
+		key, err := labelset.Parse("foo")
+		Expect(err).ToNot(HaveOccurred())
+
+		ingester := new(mockIngester)
+		profile := &RawProfile{RawData: buf.Bytes()}
+
+		md := ingestion.Metadata{LabelSet: key, SampleRate: 100}
+		err = profile.Parse(context.Background(), ingester, nil, md)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(ingester.actual).To(HaveLen(2))
+		Expect(ingester.actual[0].Units).To(Equal(metadata.BytesUnits))
+		Expect(ingester.actual[0].Val.String()).To(Equal("a;b 500\na;b;d 400\n"))
+		Expect(ingester.actual[1].Val.String()).To(Equal("a;b;c 500\n"))
+	})
 })
@@ -0,0 +1,328 @@
+// Package v8 converts V8 CPU profiles (.cpuprofile), as written by
+// Chrome DevTools and `node --cpu-prof`, to pprof.
+package v8
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/prometheus/prometheus/model/labels"
+
+	profilev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+	distributormodel "github.com/grafana/pyroscope/pkg/distributor/model"
+	phlaremodel "github.com/grafana/pyroscope/pkg/model"
+	"github.com/grafana/pyroscope/pkg/og/agent/types"
+	"github.com/grafana/pyroscope/pkg/og/ingestion"
+	"github.com/grafana/pyroscope/pkg/og/storage"
+	"github.com/grafana/pyroscope/pkg/pprof"
+)
+
+// Names of the nodes V8 adds to the call tree.
+const (
+	nodeRoot = "(root)"
+	nodeIdle = "(idle)"
+)
+
+type cpuProfile struct {
+	Nodes      []node  `json:"nodes"`
+	StartTime  int64   `json:"startTime"`
+	EndTime    int64   `json:"endTime"`
+	Samples    []int64 `json:"samples"`
+	TimeDeltas []int64 `json:"timeDeltas"`
+}
+
+type node struct {
+	ID        int64     `json:"id"`
+	CallFrame callFrame `json:"callFrame"`
+	HitCount  int64     `json:"hitCount"`
+	Children  []int64   `json:"children"`
+}
+
+type callFrame struct {
+	FunctionName string `json:"functionName"`
+	URL          string `json:"url"`
+	LineNumber   int64  `json:"lineNumber"`
+	ColumnNumber int64  `json:"columnNumber"`
+}
+
+// IsCPUProfile reports whether the data is a V8 CPU profile.
+func IsCPUProfile(b []byte) bool {
+	var p struct {
+		Nodes     []json.RawMessage `json:"nodes"`
+		StartTime *json.Number      `json:"startTime"`
+	}
+	return json.Unmarshal(b, &p) == nil && len(p.Nodes) > 0 && p.StartTime != nil
+}
+
+// RawProfile is a V8 CPU profile.
+type RawProfile struct {
+	RawData []byte
+}
+
+func (p *RawProfile) Bytes() ([]byte, error) { return p.RawData, nil }
+
+func (*RawProfile) ContentType() string { return "application/json" }
+
+func (p *RawProfile) Parse(context.Context, storage.Putter, storage.MetricsExporter, ingestion.Metadata) error {
+	return fmt.Errorf("parsing V8 CPU profile to Tree/storage.Putter is not supported")
+}
+
+func (p *RawProfile) ParseToPprof(_ context.Context, md ingestion.Metadata) (*distributormodel.PushRequest, error) {
+	profile, err := ToPprof(p.RawData, md)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("failed to parse V8 CPU profile: %w", err))
+	}
+	res := &distributormodel.PushRequest{
+		RawProfileSize: len(p.RawData),
+		RawProfileType: distributormodel.RawProfileTypeCPUProfile,
+	}
+	if len(profile.Sample) == 0 {
+		return res, nil
+	}
+	res.Series = []*distributormodel.ProfileSeries{{
+		Labels: seriesLabels(md),
+		Samples: []*distributormodel.ProfileSample{{
+			Profile: pprof.RawFromProto(profile),
+		}},
+	}}
+	return res, nil
+}
+
+// ToPprof converts the V8 CPU profile to a CPU profile. Samples are
+// weighted with the time elapsed until the next sample. Profiles without
+// the samples are converted from the hit counts of the nodes, weighted with
+// the sampling period derived from the sample rate of the request.
+// Idle samples are omitted.
+func ToPprof(b []byte, md ingestion.Metadata) (*profilev1.Profile, error) {
+	var p cpuProfile
+	if err := json.Unmarshal(b, &p); err != nil {
+		return nil, err
+	}
+	if len(p.Nodes) == 0 {
+		return nil, errors.New("no nodes found")
+	}
+	if len(p.Samples) > 0 && len(p.TimeDeltas) != len(p.Samples) {
+		return nil, fmt.Errorf("%d time deltas for %d samples", len(p.TimeDeltas), len(p.Samples))
+	}
+	sampleRate := int64(md.SampleRate)
+	if sampleRate <= 0 {
+		sampleRate = types.DefaultSampleRate
+	}
+	c := &converter{
+		builder: newProfileBuilder(md, time.Second.Nanoseconds()/sampleRate),
+		nodes:   make(map[int64]*node, len(p.Nodes)),
+		parents: make(map[int64]int64, len(p.Nodes)),
+		stacks:  make(map[int64][]uint64),
+	}
+	for i := range p.Nodes {
+		n := &p.Nodes[i]
+		c.nodes[n.ID] = n
+		for _, child := range n.Children {
+			c.parents[child] = n.ID
+		}
+	}
+	if len(p.Samples) == 0 {
+		for i := range p.Nodes {
+			n := &p.Nodes[i]
+			if err := c.addSample(n.ID, n.HitCount*c.builder.profile.Period); err != nil {
+				return nil, err
+			}
+		}
+		return c.builder.profile, nil
+	}
+	// timeDeltas[i] is the time elapsed between samples i-1 and i.
+	t := p.StartTime
+	for i, id := range p.Samples {
+		t += p.TimeDeltas[i]
+		next := p.EndTime
+		if i+1 < len(p.Samples) {
+			next = t + p.TimeDeltas[i+1]
+		}
+		if next <= t {
+			continue
+		}
+		if err := c.addSample(id, (time.Duration(next-t) * time.Microsecond).Nanoseconds()); err != nil {
+			return nil, err
+		}
+	}
+	return c.builder.profile, nil
+}
+
+type converter struct {
+	builder *profileBuilder
+	nodes   map[int64]*node
+	parents map[int64]int64
+	stacks  map[int64][]uint64
+}
+
+func (c *converter) addSample(id int64, value int64) error {
+	if value <= 0 {
+		return nil
+	}
+	n, ok := c.nodes[id]
+	if !ok {
+		return fmt.Errorf("unknown node %d", id)
+	}
+	if n.CallFrame.FunctionName == nodeIdle {
+		return nil
+	}
+	stack, err := c.stack(id)
+	if err != nil {
+		return err
+	}
+	c.builder.addSample(stack, value)
+	return nil
+}
+
+// stack returns the locations of the node and
+// its ancestors, leaf first, without the root.
+func (c *converter) stack(leaf int64) ([]uint64, error) {
+	if s, ok := c.stacks[leaf]; ok {
+		return s, nil
+	}
+	var stack []uint64
+	for id, seen := leaf, 0; ; seen++ {
+		if seen > len(c.nodes) {
+			return nil, errors.New("cycle in the call tree")
+		}
+		n, ok := c.nodes[id]
+		if !ok {
+			return nil, fmt.Errorf("unknown node %d", id)
+		}
+		if n.CallFrame.FunctionName == nodeRoot {
+			break
+		}
+		stack = append(stack, c.builder.location(n.CallFrame))
+		if id, ok = c.parents[id]; !ok {
+			break
+		}
+	}
+	c.stacks[leaf] = stack
+	return stack, nil
+}
+
+type profileBuilder struct {
+	profile   *profilev1.Profile
+	strings   map[string]int64
+	locations map[callFrame]uint64
+	samples   map[string]*profilev1.Sample
+	keyBuf    []byte
+}
+
+func newProfileBuilder(md ingestion.Metadata, period int64) *profileBuilder {
+	b := &profileBuilder{
+		strings:   map[string]int64{"": 0},
+		locations: make(map[callFrame]uint64),
+		samples:   make(map[string]*profilev1.Sample),
+	}
+	b.profile = &profilev1.Profile{
+		StringTable:   []string{""},
+		TimeNanos:     md.StartTime.UnixNano(),
+		DurationNanos: md.EndTime.Sub(md.StartTime).Nanoseconds(),
+		Period:        period,
+		Mapping:       []*profilev1.Mapping{{Id: 1, HasFunctions: true, HasFilenames: true, HasLineNumbers: true}},
+	}
+	b.profile.SampleType = []*profilev1.ValueType{{
+		Type: b.string("cpu"),
+		Unit: b.string("nanoseconds"),
+	}}
+	b.profile.PeriodType = &profilev1.ValueType{
+		Type: b.string("cpu"),
+		Unit: b.string("nanoseconds"),
+	}
+	return b
+}
+
+func (b *profileBuilder) addSample(stack []uint64, value int64) {
+	if len(stack) == 0 {
+		return
+	}
+	b.keyBuf = b.keyBuf[:0]
+	for _, loc := range stack {
+		b.keyBuf = strconv.AppendUint(b.keyBuf, loc, 10)
+		b.keyBuf = append(b.keyBuf, ',')
+	}
+	if s, ok := b.samples[string(b.keyBuf)]; ok {
+		s.Value[0] += value
+		return
+	}
+	s := &profilev1.Sample{LocationId: stack, Value: []int64{value}}
+	b.samples[string(b.keyBuf)] = s
+	b.profile.Sample = append(b.profile.Sample, s)
+}
+
+// location returns the location of the call frame. V8 line
+// numbers are zero-based, while pprof ones are one-based.
+func (b *profileBuilder) location(f callFrame) uint64 {
+	f.ColumnNumber = 0
+	if id, ok := b.locations[f]; ok {
+		return id
+	}
+	name := f.FunctionName
+	if name == "" {
+		name = "(anonymous)"
+	}
+	var line int64
+	if f.LineNumber >= 0 {
+		line = f.LineNumber + 1
+	}
+	fn := &profilev1.Function{
+		Id:         uint64(len(b.profile.Function) + 1),
+		Name:       b.string(name),
+		SystemName: b.string(name),
+		Filename:   b.string(f.URL),
+		StartLine:  line,
+	}
+	b.profile.Function = append(b.profile.Function, fn)
+	loc := &profilev1.Location{
+		Id:        uint64(len(b.profile.Location) + 1),
+		MappingId: 1,
+		Line:      []*profilev1.Line{{FunctionId: fn.Id, Line: line}},
+	}
+	b.profile.Location = append(b.profile.Location, loc)
+	b.locations[f] = loc.Id
+	return loc.Id
+}
+
+func (b *profileBuilder) string(s string) int64 {
+	if i, ok := b.strings[s]; ok {
+		return i
+	}
+	i := int64(len(b.profile.StringTable))
+	b.profile.StringTable = append(b.profile.StringTable, s)
+	b.strings[s] = i
+	return i
+}
+
+func seriesLabels(md ingestion.Metadata) []*typesv1.LabelPair {
+	ls := make([]*typesv1.LabelPair, 0, len(md.LabelSet.Labels())+4)
+	ls = append(ls, &typesv1.LabelPair{
+		Name:  labels.MetricName,
+		Value: "process_cpu",
+	}, &typesv1.LabelPair{
+		Name:  phlaremodel.LabelNameDelta,
+		Value: "false",
+	}, &typesv1.LabelPair{
+		Name:  phlaremodel.LabelNamePyroscopeSpy,
+		Value: md.SpyName,
+	})
+	if _, ok := md.LabelSet.Labels()[phlaremodel.LabelNameServiceName]; !ok {
+		ls = append(ls, &typesv1.LabelPair{
+			Name:  phlaremodel.LabelNameServiceName,
+			Value: md.LabelSet.ServiceName(),
+		})
+	}
+	for k, v := range md.LabelSet.Labels() {
+		if !phlaremodel.IsLabelAllowedForIngestion(k) {
+			continue
+		}
+		ls = append(ls, &typesv1.LabelPair{Name: k, Value: v})
+	}
+	return ls
+}
@@ -0,0 +1,56 @@
+package v8
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/pyroscope/pkg/og/convert/pprof/bench"
+	"github.com/grafana/pyroscope/pkg/og/ingestion"
+)
+
+func TestToPprof(t *testing.T) {
+	b, err := os.ReadFile("testdata/app.cpuprofile")
+	require.NoError(t, err)
+	require.True(t, IsCPUProfile(b))
+
+	p, err := ToPprof(b, ingestion.Metadata{SampleRate: 100})
+	require.NoError(t, err)
+	assert.Equal(t, "cpu", p.StringTable[p.SampleType[0].Type])
+	assert.Equal(t, "nanoseconds", p.StringTable[p.SampleType[0].Unit])
+	assert.Equal(t, []string{
+		"(program) 1000000",
+		"main;(anonymous) 1000000",
+		"main;work 2000000",
+	}, bench.StackCollapseProto(p, 0, 1))
+
+	work := p.Location[0]
+	assert.Equal(t, int64(10), work.Line[0].Line)
+	fn := p.Function[work.Line[0].FunctionId-1]
+	assert.Equal(t, "work", p.StringTable[fn.Name])
+	assert.Equal(t, "file:///app/app.js", p.StringTable[fn.Filename])
+}
+
+func TestToPprof_HitCounts(t *testing.T) {
+	b := []byte(`{"nodes": [
+		{"id": 1, "callFrame": {"functionName": "(root)", "lineNumber": -1}, "children": [2]},
+		{"id": 2, "callFrame": {"functionName": "main", "url": "app.js", "lineNumber": 0}, "hitCount": 3}
+	], "startTime": 0, "endTime": 100}`)
+	p, err := ToPprof(b, ingestion.Metadata{SampleRate: 100})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"main 30000000"}, bench.StackCollapseProto(p, 0, 1))
+}
+
+func TestToPprof_Invalid(t *testing.T) {
+	for _, b := range []string{
+		`{"nodes": []}`,
+		`{"nodes": [{"id": 1, "callFrame": {"functionName": "main"}}], "samples": [1], "timeDeltas": []}`,
+		`{"nodes": [{"id": 1, "callFrame": {"functionName": "main"}}], "samples": [2], "timeDeltas": [1], "endTime": 10}`,
+	} {
+		_, err := ToPprof([]byte(b), ingestion.Metadata{})
+		assert.Error(t, err, b)
+	}
+	assert.False(t, IsCPUProfile([]byte(`{"version": "1.0.0", "flamebearer": {}}`)))
+}
@@ -32,6 +32,12 @@ const (
 	FormatLines      Format = "lines"
 	FormatGroups     Format = "groups"
 	FormatSpeedscope Format = "speedscope"
+	FormatPerfScript Format = "perf_script"
+	FormatPerfData   Format = "perf_data"
+	FormatCPUProfile Format = "cpuprofile"
+	FormatGecko      Format = "gecko"
+	FormatCallgrind  Format = "callgrind"
+	FormatMassif     Format = "massif"
 )
 
 type RawProfile interface {
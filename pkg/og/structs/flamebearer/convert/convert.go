@@ -14,8 +14,13 @@ import (
 	"unicode"
 
 	"github.com/grafana/pyroscope/pkg/og/agent/spy"
+	"github.com/grafana/pyroscope/pkg/og/convert/callgrind"
+	"github.com/grafana/pyroscope/pkg/og/convert/gecko"
+	"github.com/grafana/pyroscope/pkg/og/convert/massif"
 	"github.com/grafana/pyroscope/pkg/og/convert/perf"
 	"github.com/grafana/pyroscope/pkg/og/convert/pprof"
+	"github.com/grafana/pyroscope/pkg/og/convert/v8"
+	"github.com/grafana/pyroscope/pkg/og/ingestion"
 	"github.com/grafana/pyroscope/pkg/og/storage/metadata"
 	"github.com/grafana/pyroscope/pkg/og/storage/tree"
 	"github.com/grafana/pyroscope/pkg/og/structs/flamebearer"
@@ -45,6 +50,11 @@ const (
 	ProfileFileTypePprof      ProfileFileType = "pprof"
 	ProfileFileTypeCollapsed  ProfileFileType = "collapsed"
 	ProfileFileTypePerfScript ProfileFileType = "perf_script"
+	ProfileFileTypePerfData   ProfileFileType = "perf_data"
+	ProfileFileTypeCPUProfile ProfileFileType = "cpuprofile"
+	ProfileFileTypeGecko      ProfileFileType = "gecko"
+	ProfileFileTypeCallgrind  ProfileFileType = "callgrind"
+	ProfileFileTypeMassif     ProfileFileType = "massif"
 )
 
 type ConverterFn func(b []byte, name string, maxNodes int) ([]*flamebearer.FlamebearerProfile, error)
@@ -54,6 +64,11 @@ var formatConverters = map[ProfileFileType]ConverterFn{
 	ProfileFileTypePprof:      PprofToProfile,
 	ProfileFileTypeCollapsed:  CollapsedToProfile,
 	ProfileFileTypePerfScript: PerfScriptToProfile,
+	ProfileFileTypePerfData:   PerfDataToProfile,
+	ProfileFileTypeCPUProfile: CPUProfileToProfile,
+	ProfileFileTypeGecko:      GeckoToProfile,
+	ProfileFileTypeCallgrind:  CallgrindToProfile,
+	ProfileFileTypeMassif:     MassifToProfile,
 }
 
 func FlamebearerFromFile(f ProfileFile, maxNodes int) ([]*flamebearer.FlamebearerProfile, error) {
@@ -107,6 +122,16 @@ func converterToFormat(f ConverterFn) ProfileFileType {
 		return ProfileFileTypeCollapsed
 	case reflect.ValueOf(PerfScriptToProfile).Pointer():
 		return ProfileFileTypePerfScript
+	case reflect.ValueOf(PerfDataToProfile).Pointer():
+		return ProfileFileTypePerfData
+	case reflect.ValueOf(CPUProfileToProfile).Pointer():
+		return ProfileFileTypeCPUProfile
+	case reflect.ValueOf(GeckoToProfile).Pointer():
+		return ProfileFileTypeGecko
+	case reflect.ValueOf(CallgrindToProfile).Pointer():
+		return ProfileFileTypeCallgrind
+	case reflect.ValueOf(MassifToProfile).Pointer():
+		return ProfileFileTypeMassif
 	}
 	return "unknown"
 }
@@ -121,20 +146,23 @@ func converter(p ProfileFile) (ConverterFn, error) {
 		return f, nil
 	}
 	ext := strings.TrimPrefix(path.Ext(p.Name), ".")
+	if ext == "json" {
+		return jsonConverter(p.Data), nil
+	}
 	if f, ok := formatConverters[ProfileFileType(ext)]; ok {
 		return f, nil
 	}
 	if ext == "txt" {
-		if perf.IsPerfScript(p.Data) {
-			return PerfScriptToProfile, nil
-		}
-		return CollapsedToProfile, nil
+		return textConverter(p.Data), nil
 	}
 	if len(p.Data) < 2 {
 		return nil, errors.New("profile is too short")
 	}
 	if p.Data[0] == '{' {
-		return JSONToProfile, nil
+		return jsonConverter(p.Data), nil
+	}
+	if perf.IsPerfData(p.Data) {
+		return PerfDataToProfile, nil
 	}
 	if p.Data[0] == '\x1f' && p.Data[1] == '\x8b' {
 		// gzip magic number, assume pprof
@@ -152,10 +180,33 @@ func converter(p ProfileFile) (ConverterFn, error) {
 			return PprofToProfile, nil
 		}
 	}
-	if perf.IsPerfScript(p.Data) {
-		return PerfScriptToProfile, nil
+	return textConverter(p.Data), nil
+}
+
+// textConverter tells the text formats apart, the collapsed
+// format being the loosest one.
+func textConverter(b []byte) ConverterFn {
+	switch {
+	case callgrind.IsCallgrind(b):
+		return CallgrindToProfile
+	case massif.IsMassif(b):
+		return MassifToProfile
+	case perf.IsPerfScript(b):
+		return PerfScriptToProfile
+	}
+	return CollapsedToProfile
+}
+
+// jsonConverter tells V8 CPU profiles and Gecko
+// profiles apart from flamebearer JSON profiles.
+func jsonConverter(b []byte) ConverterFn {
+	switch {
+	case v8.IsCPUProfile(b):
+		return CPUProfileToProfile
+	case gecko.IsGeckoProfile(b):
+		return GeckoToProfile
 	}
-	return CollapsedToProfile, nil
+	return JSONToProfile
 }
 
 func JSONToProfile(b []byte, name string, maxNodes int) ([]*flamebearer.FlamebearerProfile, error) {
@@ -192,6 +243,10 @@ func PprofToProfile(b []byte, name string, maxNodes int) ([]*flamebearer.Flamebe
 	if err := pprof.Decode(bytes.NewReader(b), p); err != nil {
 		return nil, fmt.Errorf("parsing pprof: %w", err)
 	}
+	return pprofToProfiles(p, maxNodes)
+}
+
+func pprofToProfiles(p *profilev1.Profile, maxNodes int) ([]*flamebearer.FlamebearerProfile, error) {
 	fbs := make([]*flamebearer.FlamebearerProfile, 0)
 	for _, stype := range tree.SampleTypes(p) {
 		sampleRate := uint32(100)
@@ -274,3 +329,55 @@ func PerfScriptToProfile(b []byte, name string, maxNodes int) ([]*flamebearer.Fl
 	})
 	return []*flamebearer.FlamebearerProfile{&fb}, nil
 }
+
+// PerfDataToProfile converts a perf.data file. The native frames are not
+// symbolized: they are named after the file mapped and the address.
+func PerfDataToProfile(b []byte, name string, maxNodes int) ([]*flamebearer.FlamebearerProfile, error) {
+	p, err := perf.DataToPprof(b, ingestion.Metadata{})
+	if err != nil {
+		return nil, fmt.Errorf("parsing perf.data: %w", err)
+	}
+	for _, loc := range p.Location {
+		m := p.Mapping[loc.MappingId-1]
+		fn := &profilev1.Function{
+			Id:   uint64(len(p.Function) + 1),
+			Name: int64(len(p.StringTable)),
+		}
+		p.StringTable = append(p.StringTable, fmt.Sprintf("%s 0x%x", path.Base(p.StringTable[m.Filename]), loc.Address))
+		p.Function = append(p.Function, fn)
+		loc.Line = []*profilev1.Line{{FunctionId: fn.Id}}
+	}
+	return pprofToProfiles(p, maxNodes)
+}
+
+func CPUProfileToProfile(b []byte, name string, maxNodes int) ([]*flamebearer.FlamebearerProfile, error) {
+	p, err := v8.ToPprof(b, ingestion.Metadata{})
+	if err != nil {
+		return nil, fmt.Errorf("parsing V8 CPU profile: %w", err)
+	}
+	return pprofToProfiles(p, maxNodes)
+}
+
+func GeckoToProfile(b []byte, name string, maxNodes int) ([]*flamebearer.FlamebearerProfile, error) {
+	p, err := gecko.ToPprof(b, ingestion.Metadata{})
+	if err != nil {
+		return nil, fmt.Errorf("parsing Gecko profile: %w", err)
+	}
+	return pprofToProfiles(p, maxNodes)
+}
+
+func CallgrindToProfile(b []byte, name string, maxNodes int) ([]*flamebearer.FlamebearerProfile, error) {
+	p, err := callgrind.ToPprof(b, ingestion.Metadata{})
+	if err != nil {
+		return nil, fmt.Errorf("parsing callgrind output: %w", err)
+	}
+	return pprofToProfiles(p, maxNodes)
+}
+
+func MassifToProfile(b []byte, name string, maxNodes int) ([]*flamebearer.FlamebearerProfile, error) {
+	p, err := massif.ToPprof(b, ingestion.Metadata{})
+	if err != nil {
+		return nil, fmt.Errorf("parsing massif output: %w", err)
+	}
+	return pprofToProfiles(p, maxNodes)
+}
@@ -578,6 +578,112 @@ var _ = Describe("Server", func() {
 			})
 		})
 
+		Context("perf.data", func() {
+			When("detect by content", func() {
+				var m ProfileFile
+
+				BeforeEach(func() {
+					m = ProfileFile{
+						Name: "perf.data",
+						Data: readFile("../../../convert/perf/testdata/app.perf.data"),
+					}
+				})
+
+				It("should return perf_data", func() {
+					// We want to compare functions, which is not ideal.
+					expected := reflect.ValueOf(PerfDataToProfile).Pointer()
+					f, err := converter(m)
+					Expect(err).To(BeNil())
+					Expect(f).ToNot(BeNil())
+					Expect(reflect.ValueOf(f).Pointer()).To(Equal(expected))
+				})
+			})
+		})
+
+		Context("V8 CPU profile", func() {
+			When("detect by content", func() {
+				var m ProfileFile
+
+				BeforeEach(func() {
+					m = ProfileFile{
+						Name: "profile.json",
+						Data: readFile("../../../convert/v8/testdata/app.cpuprofile"),
+					}
+				})
+
+				It("should return cpuprofile", func() {
+					// We want to compare functions, which is not ideal.
+					expected := reflect.ValueOf(CPUProfileToProfile).Pointer()
+					f, err := converter(m)
+					Expect(err).To(BeNil())
+					Expect(f).ToNot(BeNil())
+					Expect(reflect.ValueOf(f).Pointer()).To(Equal(expected))
+				})
+			})
+		})
+
+		Context("Gecko profile", func() {
+			When("detect by content", func() {
+				var m ProfileFile
+
+				BeforeEach(func() {
+					m = ProfileFile{
+						Data: readFile("../../../convert/gecko/testdata/app.gecko.json"),
+					}
+				})
+
+				It("should return gecko", func() {
+					// We want to compare functions, which is not ideal.
+					expected := reflect.ValueOf(GeckoToProfile).Pointer()
+					f, err := converter(m)
+					Expect(err).To(BeNil())
+					Expect(f).ToNot(BeNil())
+					Expect(reflect.ValueOf(f).Pointer()).To(Equal(expected))
+				})
+			})
+		})
+
+		Context("valgrind", func() {
+			When("detect callgrind by content", func() {
+				var m ProfileFile
+
+				BeforeEach(func() {
+					m = ProfileFile{
+						Name: "callgrind.out.1234",
+						Data: readFile("../../../convert/callgrind/testdata/callgrind.out.1234"),
+					}
+				})
+
+				It("should return callgrind", func() {
+					// We want to compare functions, which is not ideal.
+					expected := reflect.ValueOf(CallgrindToProfile).Pointer()
+					f, err := converter(m)
+					Expect(err).To(BeNil())
+					Expect(f).ToNot(BeNil())
+					Expect(reflect.ValueOf(f).Pointer()).To(Equal(expected))
+				})
+			})
+			When("detect massif by content", func() {
+				var m ProfileFile
+
+				BeforeEach(func() {
+					m = ProfileFile{
+						Name: "massif.out.txt",
+						Data: readFile("../../../convert/massif/testdata/massif.out.1234"),
+					}
+				})
+
+				It("should return massif", func() {
+					// We want to compare functions, which is not ideal.
+					expected := reflect.ValueOf(MassifToProfile).Pointer()
+					f, err := converter(m)
+					Expect(err).To(BeNil())
+					Expect(f).ToNot(BeNil())
+					Expect(reflect.ValueOf(f).Pointer()).To(Equal(expected))
+				})
+			})
+		})
+
 		Context("with an empty ProfileFile", func() {
 			var m ProfileFile
 			It("should return an error", func() {
@@ -604,6 +710,71 @@ var _ = Describe("Convert", func() {
 		Expect(b).ToNot(BeNil())
 	})
 
+	It("converts perf.data", func() {
+		m := ProfileFile{
+			Data: readFile("../../../convert/perf/testdata/app.perf.data"),
+		}
+
+		f, err := converter(m)
+		Expect(err).To(BeNil())
+		Expect(f).ToNot(BeNil())
+
+		b, err := f(m.Data, "appname", 1024)
+		Expect(err).To(BeNil())
+		Expect(b).To(HaveLen(1))
+		Expect(b[0].FlamebearerProfileV1.Flamebearer.Names).To(ContainElements(
+			"libc.so.6 0x10000",
+			"app 0x2234",
+			"[kernel.kallsyms]_text 0xffffffff81001000",
+		))
+	})
+
+	It("converts V8 CPU profiles", func() {
+		m := ProfileFile{
+			Name: "app.cpuprofile",
+			Data: readFile("../../../convert/v8/testdata/app.cpuprofile"),
+		}
+
+		f, _, err := Converter(m)
+		Expect(err).To(BeNil())
+
+		b, err := f(m.Data, "appname", 1024)
+		Expect(err).To(BeNil())
+		Expect(b).To(HaveLen(1))
+		Expect(b[0].FlamebearerProfileV1.Flamebearer.Names).To(ContainElements("main", "work", "(anonymous)"))
+	})
+
+	It("converts Gecko profiles", func() {
+		m := ProfileFile{
+			Type: ProfileFileTypeGecko,
+			Data: readFile("../../../convert/gecko/testdata/app.gecko.json"),
+		}
+
+		f, _, err := Converter(m)
+		Expect(err).To(BeNil())
+
+		b, err := f(m.Data, "appname", 1024)
+		Expect(err).To(BeNil())
+		Expect(b).To(HaveLen(1))
+		Expect(b[0].FlamebearerProfileV1.Flamebearer.Names).To(ContainElements("XRE_Main", "render"))
+	})
+
+	It("converts callgrind output to a profile per event", func() {
+		m := ProfileFile{
+			Data: readFile("../../../convert/callgrind/testdata/callgrind.out.1234"),
+		}
+
+		f, _, err := Converter(m)
+		Expect(err).To(BeNil())
+
+		b, err := f(m.Data, "appname", 1024)
+		Expect(err).To(BeNil())
+		Expect(b).To(HaveLen(2))
+		Expect(b[0].Metadata.Name).To(Equal("Ir"))
+		Expect(b[1].Metadata.Name).To(Equal("Dr"))
+		Expect(b[0].FlamebearerProfileV1.Flamebearer.Names).To(ContainElements("main", "work", "helper"))
+	})
+
 	Describe("JSON", func() {
 		It("prunes tree", func() {
 			m := ProfileFile{
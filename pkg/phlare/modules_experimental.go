@@ -18,6 +18,7 @@ import (
 	"google.golang.org/grpc"
 	grpchealth "google.golang.org/grpc/health"
 
+	"github.com/grafana/pyroscope/pkg/experiment/block"
 	compactionworker "github.com/grafana/pyroscope/pkg/experiment/compactor"
 	adaptiveplacement "github.com/grafana/pyroscope/pkg/experiment/distributor/placement/adaptive_placement"
 	segmentwriter "github.com/grafana/pyroscope/pkg/experiment/ingester"
@@ -78,13 +79,30 @@ func (f *Phlare) initQueryFrontendV1() (services.Service, error) {
 		return nil, err
 	}
 	f.API.RegisterFrontendForQuerierHandler(f.frontend)
-	f.API.RegisterQuerierServiceHandler(f.frontend)
-	f.API.RegisterPyroscopeHandlers(f.frontend)
 	f.API.RegisterVCSServiceHandler(f.frontend)
+	if !f.Cfg.Frontend.TenantFederation {
+		f.API.RegisterQuerierServiceHandler(f.frontend)
+		f.API.RegisterPyroscopeHandlers(f.frontend)
+		return f.frontend, nil
+	}
+	// Queries are split by tenant in the router.
+	handler := readpath.NewRouter(
+		log.With(f.logger, "component", "read-path-router"),
+		f.Overrides,
+		f.frontend,
+		nil,
+		true,
+	)
+	f.API.RegisterQuerierServiceHandler(handler)
+	f.API.RegisterPyroscopeHandlers(handler)
 	return f.frontend, nil
 }
 
 func (f *Phlare) initQueryFrontendV2() (services.Service, error) {
+	resultsCache, err := queryfrontend.NewResultsCache(f.Cfg.Frontend.ResultsCache, log.With(f.logger, "component", "query-frontend"), f.reg)
+	if err != nil {
+		return nil, err
+	}
 	queryFrontend := queryfrontend.NewQueryFrontend(
 		log.With(f.logger, "component", "query-frontend"),
 		f.Overrides,
@@ -92,6 +110,7 @@ func (f *Phlare) initQueryFrontendV2() (services.Service, error) {
 		f.metastoreClient,
 		f.queryBackendClient,
 		f.symbolizer,
+		resultsCache,
 	)
 
 	vcsService := vcs.New(
@@ -99,9 +118,24 @@ func (f *Phlare) initQueryFrontendV2() (services.Service, error) {
 		f.reg,
 	)
 
-	f.API.RegisterQuerierServiceHandler(queryFrontend)
-	f.API.RegisterPyroscopeHandlers(queryFrontend)
 	f.API.RegisterVCSServiceHandler(vcsService)
+	f.API.RegisterProfileExport(queryFrontend)
+	f.registerDebuginfo()
+	if !f.Cfg.Frontend.TenantFederation {
+		f.API.RegisterQuerierServiceHandler(queryFrontend)
+		f.API.RegisterPyroscopeHandlers(queryFrontend)
+	} else {
+		// Queries are split by tenant in the router.
+		handler := readpath.NewRouter(
+			log.With(f.logger, "component", "read-path-router"),
+			f.Overrides,
+			nil,
+			queryFrontend,
+			true,
+		)
+		f.API.RegisterQuerierServiceHandler(handler)
+		f.API.RegisterPyroscopeHandlers(handler)
+	}
 
 	// New query frontend does not have any state.
 	// For simplicity, we return a no-op service.
@@ -120,6 +154,10 @@ func (f *Phlare) initQueryFrontendV12() (services.Service, error) {
 		return nil, err
 	}
 
+	resultsCache, err := queryfrontend.NewResultsCache(f.Cfg.Frontend.ResultsCache, log.With(f.logger, "component", "query-frontend"), f.reg)
+	if err != nil {
+		return nil, err
+	}
 	newFrontend := queryfrontend.NewQueryFrontend(
 		log.With(f.logger, "component", "query-frontend"),
 		f.Overrides,
@@ -127,6 +165,7 @@ func (f *Phlare) initQueryFrontendV12() (services.Service, error) {
 		f.metastoreClient,
 		f.queryBackendClient,
 		f.symbolizer,
+		resultsCache,
 	)
 
 	handler := readpath.NewRouter(
@@ -134,6 +173,7 @@ func (f *Phlare) initQueryFrontendV12() (services.Service, error) {
 		f.Overrides,
 		f.frontend,
 		newFrontend,
+		f.Cfg.Frontend.TenantFederation,
 	)
 
 	vcsService := vcs.New(
@@ -145,6 +185,9 @@ func (f *Phlare) initQueryFrontendV12() (services.Service, error) {
 	f.API.RegisterQuerierServiceHandler(handler)
 	f.API.RegisterPyroscopeHandlers(handler)
 	f.API.RegisterVCSServiceHandler(vcsService)
+	// Profiles are only exported from the v2 storage.
+	f.API.RegisterProfileExport(newFrontend)
+	f.registerDebuginfo()
 
 	return f.frontend, nil
 }
@@ -246,6 +289,11 @@ func (f *Phlare) initCompactionWorker() (svc services.Service, err error) {
 		}
 	}
 
+	var sym block.Symbolizer
+	if f.Cfg.CompactionWorker.Symbolize {
+		sym = f.symbolizer
+	}
+
 	w, err := compactionworker.New(
 		logger,
 		f.Cfg.CompactionWorker,
@@ -254,6 +302,8 @@ func (f *Phlare) initCompactionWorker() (svc services.Service, err error) {
 		registerer,
 		ruler,
 		exporter,
+		sym,
+		f.Overrides,
 	)
 	if err != nil {
 		return nil, err
@@ -382,16 +432,27 @@ func (f *Phlare) initSymbolizer() (services.Service, error) {
 		f.Cfg.Symbolizer,
 		f.reg,
 		prefixedBucket,
+		f.Overrides,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create symbolizer: %w", err)
 	}
 
 	f.symbolizer = sym
-
 	return nil, nil
 }
 
+// registerDebuginfo registers the debug info upload and lookup endpoints
+// of the symbolizer. The routes are only served by the query-frontend: the
+// other modules depending on the symbolizer do not expose them.
+func (f *Phlare) registerDebuginfo() {
+	debuginfoHandler := symbolizer.NewDebuginfoHandler(f.logger, f.symbolizer)
+	f.API.RegisterDebuginfo(debuginfoHandler)
+	if f.Cfg.Symbolizer.DebuginfodServerEnabled {
+		f.API.RegisterDebuginfod(debuginfoHandler)
+	}
+}
+
 func (f *Phlare) initPlacementAgent() (services.Service, error) {
 	f.placementAgent = adaptiveplacement.NewAgent(
 		f.logger,
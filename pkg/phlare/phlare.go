@@ -252,6 +252,9 @@ func (c *Config) registerServerFlagsWithChangedDefaultValues(fs *flag.FlagSet) {
 		c.AdaptivePlacement.RegisterFlags(throwaway)
 		c.LimitsConfig.WritePathOverrides.RegisterFlags(throwaway)
 		c.LimitsConfig.ReadPathOverrides.RegisterFlags(throwaway)
+		c.LimitsConfig.QueryBackendLimits.RegisterFlags(throwaway)
+		c.LimitsConfig.ExportLimits.RegisterFlags(throwaway)
+		c.LimitsConfig.OTLPLimits.RegisterFlags(throwaway)
 		c.LimitsConfig.AdaptivePlacementLimits.RegisterFlags(throwaway)
 		c.LimitsConfig.RecordingRules.RegisterFlags(throwaway)
 		c.LimitsConfig.Symbolizer.RegisterFlags(throwaway)
@@ -505,7 +508,7 @@ func (f *Phlare) setupModuleManager() error {
 			SegmentWriterClient: {Overrides, API, SegmentWriterRing, PlacementAgent},
 			PlacementAgent:      {Overrides, API, Storage},
 			PlacementManager:    {Overrides, API, Storage},
-			Symbolizer:          {Overrides, Storage},
+			Symbolizer:          {Overrides, API, Storage},
 		}
 		for k, v := range experimentalModules {
 			deps[k] = v
@@ -516,6 +519,9 @@ func (f *Phlare) setupModuleManager() error {
 		deps[Distributor] = append(deps[Distributor], SegmentWriterClient)
 		deps[Server] = append(deps[Server], HealthServer)
 		deps[Admin] = append(deps[Admin], MetastoreAdmin)
+		if f.Cfg.CompactionWorker.Symbolize {
+			deps[CompactionWorker] = append(deps[CompactionWorker], Symbolizer)
+		}
 
 		mm.RegisterModule(SegmentWriter, f.initSegmentWriter)
 		mm.RegisterModule(Metastore, f.initMetastore)
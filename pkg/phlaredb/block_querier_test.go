@@ -1165,7 +1165,7 @@ func TestSelectMergeStacktraces(t *testing.T) {
 		},
 		Start: 0,
 		End:   int64(model.TimeFromUnixNano(math.MaxInt64)),
-	}, 16<<10)
+	}, 16<<10, nil)
 	require.NoError(t, err)
 	expected := phlaremodel.Tree{}
 	expected.InsertStack(1000, "baz", "bar", "foo")
@@ -1375,7 +1375,7 @@ func testSelectMergeByStacktracesRace(t testing.TB, times int) {
 				},
 				Start: 0,
 				End:   int64(model.TimeFromUnixNano(math.MaxInt64)),
-			}, 16<<10)
+			}, 16<<10, nil)
 			if err != nil {
 				return err
 			}
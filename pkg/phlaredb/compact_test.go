@@ -96,7 +96,7 @@ func TestCompact(t *testing.T) {
 
 	it, err = querier.SelectMatchingProfiles(ctx, matchAll)
 	require.NoError(t, err)
-	res, err := querier.MergeByStacktraces(ctx, it, 0)
+	res, err := querier.MergeByStacktraces(ctx, it, 0, nil)
 	require.NoError(t, err)
 	require.NotNil(t, res)
 
@@ -173,7 +173,7 @@ func TestCompactWithDownsampling(t *testing.T) {
 
 	it, err = querier.SelectMatchingProfiles(ctx, matchAll)
 	require.NoError(t, err)
-	res, err := querier.MergeByStacktraces(ctx, it, 0)
+	res, err := querier.MergeByStacktraces(ctx, it, 0, nil)
 	require.NoError(t, err)
 	require.NotNil(t, res)
 
@@ -181,7 +181,7 @@ func TestCompactWithDownsampling(t *testing.T) {
 	expected.InsertStack(3, "baz", "bar", "foo")
 	require.Equal(t, expected.String(), res.String())
 
-	res, err = querier.SelectMergeByStacktraces(ctx, matchAll, 0)
+	res, err = querier.SelectMergeByStacktraces(ctx, matchAll, 0, nil)
 	require.NoError(t, err)
 	require.NotNil(t, res)
 	require.Equal(t, expected.String(), res.String())
@@ -306,7 +306,7 @@ func TestCompactWithSplitting(t *testing.T) {
 	// Finally test some stacktraces resolution.
 	it, err = queriers[1].SelectMatchingProfiles(ctx, matchAll)
 	require.NoError(t, err)
-	res, err := queriers[1].MergeByStacktraces(ctx, it, 0)
+	res, err := queriers[1].MergeByStacktraces(ctx, it, 0, nil)
 	require.NoError(t, err)
 
 	expected := new(phlaremodel.Tree)
@@ -17,6 +17,7 @@ import (
 )
 
 const (
+	IDColumnName                  = "ID"
 	SeriesIndexColumnName         = "SeriesIndex"
 	TimeNanosColumnName           = "TimeNanos"
 	StacktracePartitionColumnName = "StacktracePartition"
@@ -40,7 +41,7 @@ var (
 		phlareparquet.NewGroupField("SpanID", parquet.Optional(parquet.Encoded(parquet.Uint(64), &parquet.RLEDictionary))),
 	}
 	ProfilesSchema = parquet.NewSchema("Profile", phlareparquet.Group{
-		phlareparquet.NewGroupField("ID", parquet.UUID()),
+		phlareparquet.NewGroupField(IDColumnName, parquet.UUID()),
 		phlareparquet.NewGroupField(SeriesIndexColumnName, parquet.Encoded(parquet.Uint(32), &parquet.DeltaBinaryPacked)),
 		phlareparquet.NewGroupField(StacktracePartitionColumnName, parquet.Encoded(parquet.Uint(64), &parquet.DeltaBinaryPacked)),
 		phlareparquet.NewGroupField(TotalValueColumnName, parquet.Encoded(parquet.Uint(64), &parquet.DeltaBinaryPacked)),
@@ -37,6 +37,8 @@ type Resolver struct {
 
 	maxNodes int64
 	sts      *typesv1.StackTraceSelector
+	ff       *typesv1.FrameFilter
+	counts   bool
 }
 
 type ResolverOption func(*Resolver)
@@ -67,11 +69,29 @@ func WithResolverStackTraceSelector(sts *typesv1.StackTraceSelector) ResolverOpt
 	}
 }
 
+// WithResolverFrameFilter specifies the frame filter applied to the
+// stack traces before the tree is truncated. Only trees are filtered.
+func WithResolverFrameFilter(f *typesv1.FrameFilter) ResolverOption {
+	return func(r *Resolver) {
+		r.ff = f
+	}
+}
+
+// WithResolverSampleCounts instructs the resolver to count the samples
+// added, in addition to their values. The counts are only reported in the
+// top table.
+func WithResolverSampleCounts() ResolverOption {
+	return func(r *Resolver) {
+		r.counts = true
+	}
+}
+
 type lazyPartition struct {
 	id uint64
 
 	m       sync.Mutex
 	samples *SampleAppender
+	counts  *SampleAppender
 
 	fetchOnce sync.Once
 	resolver  *Resolver
@@ -130,33 +150,49 @@ func (r *Resolver) Release() {
 // AddSamples adds a collection of stack trace samples to the resolver.
 // Samples can be added to partitions concurrently.
 func (r *Resolver) AddSamples(partition uint64, s schemav1.Samples) {
-	r.withPartitionSamples(partition, func(samples *SampleAppender) {
-		samples.AppendMany(s.StacktraceIDs, s.Values)
-	})
+	p := r.partition(partition)
+	p.m.Lock()
+	defer p.m.Unlock()
+	p.samples.AppendMany(s.StacktraceIDs, s.Values)
+	if p.counts != nil {
+		for i, sid := range s.StacktraceIDs {
+			if sid > 0 && s.Values[i] > 0 {
+				p.counts.Append(sid, 1)
+			}
+		}
+	}
 }
 
 func (r *Resolver) AddSamplesWithSpanSelector(partition uint64, s schemav1.Samples, spanSelector model.SpanSelector) {
-	r.withPartitionSamples(partition, func(samples *SampleAppender) {
+	r.withPartitionSamples(partition, func(samples, counts *SampleAppender) {
 		for i, sid := range s.StacktraceIDs {
 			if _, ok := spanSelector[s.Spans[i]]; ok && sid > 0 {
 				samples.Append(sid, s.Values[i])
+				if counts != nil && s.Values[i] > 0 {
+					counts.Append(sid, 1)
+				}
 			}
 		}
 	})
 }
 
 func (r *Resolver) AddSamplesFromParquetRow(partition uint64, stacktraceIDs, values []parquet.Value) {
-	r.withPartitionSamples(partition, func(samples *SampleAppender) {
-		for i, sid := range stacktraceIDs {
-			if s := sid.Uint32(); s > 0 {
-				samples.Append(s, values[i].Uint64())
+	p := r.partition(partition)
+	p.m.Lock()
+	defer p.m.Unlock()
+	for i, sid := range stacktraceIDs {
+		if s := sid.Uint32(); s > 0 {
+			v := values[i].Uint64()
+			p.samples.Append(s, v)
+			if p.counts != nil && v > 0 {
+				p.counts.Append(s, 1)
 			}
 		}
-	})
+	}
 }
 
 func (r *Resolver) AddSamplesWithSpanSelectorFromParquetRow(partition uint64, stacktraces, values, spans []parquet.Value, spanSelector model.SpanSelector) {
-	r.withPartitionSamples(partition, func(samples *SampleAppender) {
+	r.withPartitionSamples(partition, func(samples, counts *SampleAppender) {
 		for i, sid := range stacktraces {
 			spanID := spans[i].Uint64()
 			stackID := sid.Uint32()
@@ -164,17 +200,21 @@ func (r *Resolver) AddSamplesWithSpanSelectorFromParquetRow(partition uint64, st
 				continue
 			}
 			if _, ok := spanSelector[spanID]; ok {
-				samples.Append(stackID, values[i].Uint64())
+				v := values[i].Uint64()
+				samples.Append(stackID, v)
+				if counts != nil && v > 0 {
+					counts.Append(stackID, 1)
+				}
 			}
 		}
 	})
 }
 
-func (r *Resolver) withPartitionSamples(partition uint64, fn func(*SampleAppender)) {
+func (r *Resolver) withPartitionSamples(partition uint64, fn func(samples, counts *SampleAppender)) {
 	p := r.partition(partition)
 	p.m.Lock()
 	defer p.m.Unlock()
-	fn(p.samples)
+	fn(p.samples, p.counts)
 }
 
 func (r *Resolver) CallSiteValues(values *CallSiteValues, partition uint64, samples schemav1.Samples) error {
@@ -218,6 +258,9 @@ func (r *Resolver) partition(partition uint64) *lazyPartition {
 		samples:  NewSampleAppender(),
 		resolver: r,
 	}
+	if r.counts {
+		p.counts = NewSampleAppender()
+	}
 	r.p[partition] = p
 	r.m.Unlock()
 	// Fetch partition in the background, not blocking the caller.
@@ -232,10 +275,14 @@ func (r *Resolver) partition(partition uint64) *lazyPartition {
 func (r *Resolver) Tree() (*model.Tree, error) {
 	span, ctx := opentracing.StartSpanFromContext(r.ctx, "Resolver.Tree")
 	defer span.Finish()
+	filter, err := model.NewFrameFilter(r.ff)
+	if err != nil {
+		return nil, err
+	}
 	var lock sync.Mutex
 	tree := new(model.Tree)
-	err := r.withSymbols(ctx, func(symbols *Symbols, appender *SampleAppender) error {
-		resolved, err := symbols.Tree(ctx, appender, r.maxNodes)
+	err = r.withSymbols(ctx, func(symbols *Symbols, appender *SampleAppender) error {
+		resolved, err := symbols.Tree(ctx, appender, r.maxNodes, filter)
 		if err != nil {
 			return err
 		}
@@ -264,7 +311,73 @@ func (r *Resolver) Pprof() (*googlev1.Profile, error) {
 	return p.Profile(), nil
 }
 
+// TopTable returns the functions of the resolved stack traces, ordered
+// by their self value. The result is not truncated.
+func (r *Resolver) TopTable() ([]*typesv1.TopTableEntry, error) {
+	span, ctx := opentracing.StartSpanFromContext(r.ctx, "Resolver.TopTable")
+	defer span.Finish()
+	m := model.NewTopTableMerger()
+	err := r.withPartitions(ctx, func(p *lazyPartition) error {
+		entries, err := p.reader.Symbols().TopTable(ctx, p.samples, p.counts)
+		if err != nil {
+			return err
+		}
+		m.MergeEntries(entries)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return m.Entries(typesv1.TopTableOrderBy_TOP_TABLE_ORDER_BY_SELF, 0), nil
+}
+
+// CallGraph returns the call graph of the resolved stack traces.
+// The result is not truncated.
+func (r *Resolver) CallGraph() (*typesv1.CallGraph, error) {
+	span, ctx := opentracing.StartSpanFromContext(r.ctx, "Resolver.CallGraph")
+	defer span.Finish()
+	m := model.NewCallGraphMerger()
+	err := r.withSymbols(ctx, func(symbols *Symbols, appender *SampleAppender) error {
+		g, err := symbols.CallGraph(ctx, appender)
+		if err != nil {
+			return err
+		}
+		m.MergeCallGraph(g)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return m.CallGraph(0), nil
+}
+
+// FunctionDetails returns the callers and callees of the function,
+// and the cost of its source code lines.
+func (r *Resolver) FunctionDetails(function string) (*model.FunctionDetails, error) {
+	span, ctx := opentracing.StartSpanFromContext(r.ctx, "Resolver.FunctionDetails")
+	defer span.Finish()
+	m := model.NewFunctionDetailsMerger()
+	err := r.withSymbols(ctx, func(symbols *Symbols, appender *SampleAppender) error {
+		d, err := symbols.FunctionDetails(ctx, appender, function, r.maxNodes)
+		if err != nil {
+			return err
+		}
+		m.MergeFunctionDetails(d)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return m.FunctionDetails(), nil
+}
+
 func (r *Resolver) withSymbols(ctx context.Context, fn func(*Symbols, *SampleAppender) error) error {
+	return r.withPartitions(ctx, func(p *lazyPartition) error {
+		return fn(p.reader.Symbols(), p.samples)
+	})
+}
+
+func (r *Resolver) withPartitions(ctx context.Context, fn func(*lazyPartition) error) error {
 	g, _ := errgroup.WithContext(ctx)
 	g.SetLimit(r.c)
 	for _, p := range r.p {
@@ -273,7 +386,7 @@ func (r *Resolver) withSymbols(ctx context.Context, fn func(*Symbols, *SampleApp
 			if err := p.fetch(ctx); err != nil {
 				return err
 			}
-			return fn(p.reader.Symbols(), p.samples)
+			return fn(p)
 		}))
 	}
 	return g.Wait()
@@ -288,10 +401,38 @@ func (r *Symbols) Pprof(
 	return buildPprof(ctx, r, appender.Samples(), maxNodes, selection)
 }
 
+// Tree builds the call tree of the samples. The frame filter is optional
+// and may be nil.
 func (r *Symbols) Tree(
 	ctx context.Context,
 	appender *SampleAppender,
 	maxNodes int64,
+	filter *model.FrameFilter,
 ) (*model.Tree, error) {
-	return buildTree(ctx, r, appender, maxNodes)
+	return buildTree(ctx, r, appender, maxNodes, filter)
+}
+
+// TopTable aggregates the samples by function.
+// Sample counts are optional and may be nil.
+func (r *Symbols) TopTable(
+	ctx context.Context,
+	appender *SampleAppender,
+	counts *SampleAppender,
+) ([]*typesv1.TopTableEntry, error) {
+	return buildTopTable(ctx, r, appender, counts)
+}
+
+// CallGraph builds the call graph of the samples.
+func (r *Symbols) CallGraph(ctx context.Context, appender *SampleAppender) (*typesv1.CallGraph, error) {
+	return buildCallGraph(ctx, r, appender)
+}
+
+// FunctionDetails builds the callers and callees trees of the function.
+func (r *Symbols) FunctionDetails(
+	ctx context.Context,
+	appender *SampleAppender,
+	function string,
+	maxNodes int64,
+) (*model.FunctionDetails, error) {
+	return buildFunctionDetails(ctx, r, appender, function, maxNodes)
 }
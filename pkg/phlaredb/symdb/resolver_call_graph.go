@@ -0,0 +1,142 @@
+package symdb
+
+import (
+	"context"
+	"sync"
+
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+	schemav1 "github.com/grafana/pyroscope/pkg/phlaredb/schemas/v1"
+)
+
+func buildCallGraph(
+	ctx context.Context,
+	symbols *Symbols,
+	appender *SampleAppender,
+) (*typesv1.CallGraph, error) {
+	samples := appender.Samples()
+	g := callGraphSymbolsFromPool()
+	defer g.reset()
+	g.init(symbols, samples)
+	if err := symbols.Stacktraces.ResolveStacktraceLocations(ctx, g, samples.StacktraceIDs); err != nil {
+		return nil, err
+	}
+	return g.callGraph(), nil
+}
+
+type callGraphNode struct {
+	self  int64
+	total int64
+	// The last stack trace the node has been accounted
+	// in: recursive calls are only counted once.
+	last int
+}
+
+type callGraphEdge struct{ caller, callee uint32 }
+
+type callGraphEdgeValue struct {
+	value int64
+	last  int
+}
+
+type callGraphSymbols struct {
+	symbols *Symbols
+	samples *schemav1.Samples
+	// Function name string index => node.
+	nodes map[uint32]*callGraphNode
+	edges map[callGraphEdge]*callGraphEdgeValue
+	cur   int
+}
+
+var callGraphSymbolsPool = sync.Pool{
+	New: func() any { return new(callGraphSymbols) },
+}
+
+func callGraphSymbolsFromPool() *callGraphSymbols {
+	return callGraphSymbolsPool.Get().(*callGraphSymbols)
+}
+
+func (r *callGraphSymbols) reset() {
+	r.symbols = nil
+	r.samples = nil
+	r.nodes = nil
+	r.edges = nil
+	r.cur = 0
+	callGraphSymbolsPool.Put(r)
+}
+
+func (r *callGraphSymbols) init(symbols *Symbols, samples schemav1.Samples) {
+	r.symbols = symbols
+	r.samples = &samples
+	r.nodes = make(map[uint32]*callGraphNode)
+	r.edges = make(map[callGraphEdge]*callGraphEdgeValue)
+}
+
+func (r *callGraphSymbols) InsertStacktrace(_ uint32, locations []int32) {
+	v := int64(r.samples.Values[r.cur])
+	// After the increment, cur is the sequence number of
+	// the stack trace (starting with 1).
+	r.cur++
+	// The leaf is the last frame visited,
+	// and the parent is its function name.
+	var parent uint32
+	var leaf *callGraphNode
+	// Stack trace locations are ordered from the leaf to the root,
+	// while lines of a location are ordered from the innermost
+	// inlined call to the outermost one.
+	for i := len(locations) - 1; i >= 0; i-- {
+		lines := r.symbols.Locations[locations[i]].Line
+		for j := len(lines) - 1; j >= 0; j-- {
+			name := r.symbols.Functions[lines[j].FunctionId].Name
+			n, ok := r.nodes[name]
+			if !ok {
+				n = new(callGraphNode)
+				r.nodes[name] = n
+			}
+			if n.last != r.cur {
+				n.last = r.cur
+				n.total += v
+			}
+			if leaf != nil && parent != name {
+				k := callGraphEdge{caller: parent, callee: name}
+				e, ok := r.edges[k]
+				if !ok {
+					e = new(callGraphEdgeValue)
+					r.edges[k] = e
+				}
+				if e.last != r.cur {
+					e.last = r.cur
+					e.value += v
+				}
+			}
+			parent = name
+			leaf = n
+		}
+	}
+	if leaf != nil {
+		leaf.self += v
+	}
+}
+
+func (r *callGraphSymbols) callGraph() *typesv1.CallGraph {
+	g := &typesv1.CallGraph{
+		Nodes: make([]*typesv1.CallGraphNode, 0, len(r.nodes)),
+		Edges: make([]*typesv1.CallGraphEdge, 0, len(r.edges)),
+	}
+	index := make(map[uint32]int32, len(r.nodes))
+	for name, n := range r.nodes {
+		index[name] = int32(len(g.Nodes))
+		g.Nodes = append(g.Nodes, &typesv1.CallGraphNode{
+			Name:  r.symbols.Strings[name],
+			Self:  n.self,
+			Total: n.total,
+		})
+	}
+	for k, e := range r.edges {
+		g.Edges = append(g.Edges, &typesv1.CallGraphEdge{
+			Caller: index[k.caller],
+			Callee: index[k.callee],
+			Value:  e.value,
+		})
+	}
+	return g
+}
@@ -0,0 +1,29 @@
+package symdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/pyroscope/pkg/model"
+)
+
+func Test_memory_Resolver_ResolveCallGraph(t *testing.T) {
+	s := newMemSuite(t, [][]string{{"testdata/profile.pb.gz"}})
+	samples := s.indexed[0][0].Samples
+
+	r := NewResolver(context.Background(), s.db)
+	r.AddSamples(0, samples)
+	tree, err := r.Tree()
+	require.NoError(t, err)
+	r.Release()
+	expected := model.CallGraphFromTree(tree)
+
+	r = NewResolver(context.Background(), s.db)
+	defer r.Release()
+	r.AddSamples(0, samples)
+	resolved, err := r.CallGraph()
+	require.NoError(t, err)
+	require.Equal(t, expected.String(), resolved.String())
+}
@@ -0,0 +1,82 @@
+package symdb
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/grafana/pyroscope/pkg/model"
+	schemav1 "github.com/grafana/pyroscope/pkg/phlaredb/schemas/v1"
+)
+
+func buildFunctionDetails(
+	ctx context.Context,
+	symbols *Symbols,
+	appender *SampleAppender,
+	function string,
+	maxNodes int64,
+) (*model.FunctionDetails, error) {
+	// Function names are referenced by the partition string
+	// table indices: if the name is not present in the table,
+	// none of the stack traces include the function.
+	name := slices.Index(symbols.Strings, function)
+	if name < 0 {
+		return &model.FunctionDetails{Callers: new(model.Tree), Callees: new(model.Tree)}, nil
+	}
+	samples := appender.Samples()
+	d := functionDetailsSymbolsFromPool()
+	defer d.reset()
+	d.init(symbols, samples, int32(name))
+	if err := symbols.Stacktraces.ResolveStacktraceLocations(ctx, d, samples.StacktraceIDs); err != nil {
+		return nil, err
+	}
+	return d.builder.Build(symbols.Strings, maxNodes), nil
+}
+
+type functionDetailsSymbols struct {
+	symbols *Symbols
+	samples *schemav1.Samples
+	builder *model.FunctionDetailsBuilder
+	frames  []model.FunctionFrame
+	cur     int
+}
+
+var functionDetailsSymbolsPool = sync.Pool{
+	New: func() any { return new(functionDetailsSymbols) },
+}
+
+func functionDetailsSymbolsFromPool() *functionDetailsSymbols {
+	return functionDetailsSymbolsPool.Get().(*functionDetailsSymbols)
+}
+
+func (r *functionDetailsSymbols) reset() {
+	r.symbols = nil
+	r.samples = nil
+	r.builder = nil
+	r.frames = r.frames[:0]
+	r.cur = 0
+	functionDetailsSymbolsPool.Put(r)
+}
+
+func (r *functionDetailsSymbols) init(symbols *Symbols, samples schemav1.Samples, function int32) {
+	r.symbols = symbols
+	r.samples = &samples
+	r.builder = model.NewFunctionDetailsBuilder(function)
+}
+
+func (r *functionDetailsSymbols) InsertStacktrace(_ uint32, locations []int32) {
+	r.frames = r.frames[:0]
+	for i := 0; i < len(locations); i++ {
+		lines := r.symbols.Locations[locations[i]].Line
+		for j := 0; j < len(lines); j++ {
+			f := r.symbols.Functions[lines[j].FunctionId]
+			r.frames = append(r.frames, model.FunctionFrame{
+				Name:     int32(f.Name),
+				FileName: int32(f.Filename),
+				Line:     int64(lines[j].Line),
+			})
+		}
+	}
+	r.builder.InsertStack(r.frames, int64(r.samples.Values[r.cur]))
+	r.cur++
+}
@@ -0,0 +1,39 @@
+package symdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/pyroscope/pkg/model"
+)
+
+func Test_memory_Resolver_ResolveFunctionDetails(t *testing.T) {
+	s := newMemSuite(t, [][]string{{"testdata/profile.pb.gz"}})
+	samples := s.indexed[0][0].Samples
+
+	r := NewResolver(context.Background(), s.db)
+	r.AddSamples(0, samples)
+	p, err := r.Pprof()
+	require.NoError(t, err)
+	table, err := r.TopTable()
+	require.NoError(t, err)
+	r.Release()
+	require.NotEmpty(t, table)
+	function := table[0].Name
+	expected, err := model.FunctionDetailsFromProfile(p, function, 0)
+	require.NoError(t, err)
+
+	r = NewResolver(context.Background(), s.db)
+	defer r.Release()
+	r.AddSamples(0, samples)
+	resolved, err := r.FunctionDetails(function)
+	require.NoError(t, err)
+	require.Equal(t, table[0].Total, resolved.Callers.Total())
+	require.Equal(t, table[0].Total, resolved.Callees.Total())
+	require.Equal(t, expected.Callers.String(), resolved.Callers.String())
+	require.Equal(t, expected.Callees.String(), resolved.Callees.String())
+	require.NotEmpty(t, resolved.Lines)
+	require.Equal(t, expected.Lines, resolved.Lines)
+}
@@ -0,0 +1,121 @@
+package symdb
+
+import (
+	"context"
+	"sync"
+
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+	schemav1 "github.com/grafana/pyroscope/pkg/phlaredb/schemas/v1"
+)
+
+func buildTopTable(
+	ctx context.Context,
+	symbols *Symbols,
+	appender *SampleAppender,
+	counts *SampleAppender,
+) ([]*typesv1.TopTableEntry, error) {
+	samples := appender.Samples()
+	t := topTableSymbolsFromPool()
+	defer t.reset()
+	t.init(symbols, samples)
+	if counts != nil {
+		// Both appenders are populated simultaneously, and the values
+		// are ordered by stack trace ID, therefore the counts match
+		// the samples.
+		t.counts = counts.Samples().Values
+	}
+	if err := symbols.Stacktraces.ResolveStacktraceLocations(ctx, t, samples.StacktraceIDs); err != nil {
+		return nil, err
+	}
+	return t.entries(), nil
+}
+
+type topTableEntry struct {
+	self    int64
+	total   int64
+	samples int64
+	// The last stack trace the function has been
+	// accounted in: recursive calls are only
+	// counted once.
+	last int
+}
+
+type topTableSymbols struct {
+	symbols *Symbols
+	samples *schemav1.Samples
+	counts  []uint64
+	// Function name string index => entry.
+	table map[uint32]*topTableEntry
+	cur   int
+}
+
+var topTableSymbolsPool = sync.Pool{
+	New: func() any { return new(topTableSymbols) },
+}
+
+func topTableSymbolsFromPool() *topTableSymbols {
+	return topTableSymbolsPool.Get().(*topTableSymbols)
+}
+
+func (r *topTableSymbols) reset() {
+	r.symbols = nil
+	r.samples = nil
+	r.counts = nil
+	r.table = nil
+	r.cur = 0
+	topTableSymbolsPool.Put(r)
+}
+
+func (r *topTableSymbols) init(symbols *Symbols, samples schemav1.Samples) {
+	r.symbols = symbols
+	r.samples = &samples
+	r.table = make(map[uint32]*topTableEntry)
+}
+
+func (r *topTableSymbols) InsertStacktrace(_ uint32, locations []int32) {
+	v := int64(r.samples.Values[r.cur])
+	var c int64
+	if r.cur < len(r.counts) {
+		c = int64(r.counts[r.cur])
+	}
+	// After the increment, cur is the sequence number of
+	// the stack trace (starting with 1).
+	r.cur++
+	leaf := true
+	for i := 0; i < len(locations); i++ {
+		lines := r.symbols.Locations[locations[i]].Line
+		for j := 0; j < len(lines); j++ {
+			name := r.symbols.Functions[lines[j].FunctionId].Name
+			e, ok := r.table[name]
+			if !ok {
+				e = new(topTableEntry)
+				r.table[name] = e
+			}
+			if leaf {
+				e.self += v
+				leaf = false
+			}
+			if e.last != r.cur {
+				e.last = r.cur
+				e.total += v
+				e.samples += c
+			}
+		}
+	}
+}
+
+func (r *topTableSymbols) entries() []*typesv1.TopTableEntry {
+	entries := make([]*typesv1.TopTableEntry, 0, len(r.table))
+	for name, e := range r.table {
+		entry := &typesv1.TopTableEntry{
+			Name:  r.symbols.Strings[name],
+			Self:  e.self,
+			Total: e.total,
+		}
+		if r.counts != nil {
+			entry.Samples = &e.samples
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
@@ -0,0 +1,76 @@
+package symdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+	"github.com/grafana/pyroscope/pkg/model"
+	schemav1 "github.com/grafana/pyroscope/pkg/phlaredb/schemas/v1"
+)
+
+func Test_memory_Resolver_ResolveTopTable(t *testing.T) {
+	s := newMemSuite(t, [][]string{{"testdata/profile.pb.gz"}})
+	samples := s.indexed[0][0].Samples
+
+	r := NewResolver(context.Background(), s.db)
+	r.AddSamples(0, samples)
+	tree, err := r.Tree()
+	require.NoError(t, err)
+	r.Release()
+	expected := model.SortTopTable(model.TopTableFromTree(tree), typesv1.TopTableOrderBy_TOP_TABLE_ORDER_BY_SELF, 0)
+
+	r = NewResolver(context.Background(), s.db, WithResolverSampleCounts())
+	defer r.Release()
+	r.AddSamples(0, samples)
+	resolved, err := r.TopTable()
+	require.NoError(t, err)
+	require.Len(t, resolved, len(expected))
+
+	for i, e := range resolved {
+		require.Equal(t, expected[i].Name, e.Name)
+		require.Equal(t, expected[i].Self, e.Self)
+		require.Equal(t, expected[i].Total, e.Total)
+		require.NotNil(t, e.Samples)
+		require.Greater(t, *e.Samples, int64(0))
+		require.LessOrEqual(t, *e.Samples, int64(samples.Len()))
+	}
+}
+
+func Test_memory_Resolver_ResolveTopTable_SpanSelector(t *testing.T) {
+	s := newMemSuite(t, [][]string{{"testdata/profile.pb.gz"}})
+	samples := s.indexed[0][0].Samples
+
+	// Every other sample belongs to the selected span.
+	withSpans := schemav1.Samples{
+		StacktraceIDs: samples.StacktraceIDs,
+		Values:        samples.Values,
+		Spans:         make([]uint64, samples.Len()),
+	}
+	var selected schemav1.Samples
+	for i := range withSpans.Spans {
+		withSpans.Spans[i] = uint64(i%2 + 1)
+		if withSpans.Spans[i] == 1 {
+			selected.StacktraceIDs = append(selected.StacktraceIDs, samples.StacktraceIDs[i])
+			selected.Values = append(selected.Values, samples.Values[i])
+		}
+	}
+
+	r := NewResolver(context.Background(), s.db, WithResolverSampleCounts())
+	r.AddSamples(0, selected)
+	expected, err := r.TopTable()
+	require.NoError(t, err)
+	r.Release()
+
+	r = NewResolver(context.Background(), s.db, WithResolverSampleCounts())
+	defer r.Release()
+	r.AddSamplesWithSpanSelector(0, withSpans, model.SpanSelector{1: {}})
+	actual, err := r.TopTable()
+	require.NoError(t, err)
+	for _, e := range actual {
+		require.NotNil(t, e.Samples)
+	}
+	require.ElementsMatch(t, expected, actual)
+}
@@ -18,15 +18,18 @@ func buildTree(
 	symbols *Symbols,
 	appender *SampleAppender,
 	maxNodes int64,
+	filter *model.FrameFilter,
 ) (*model.Tree, error) {
 	// If the number of samples is large (> 128K) and the StacktraceResolver
 	// implements the range iterator, we will be building the tree based on
 	// the parent pointer tree of the partition (a copy of). The only exception
 	// is when the number of nodes is not limited, or is close to the number of
 	// nodes in the original tree: the optimization is still beneficial in terms
-	// of CPU, but is very expensive in terms of memory.
+	// of CPU, but is very expensive in terms of memory. Frames can't be
+	// filtered in the parent pointer tree, therefore the optimization is
+	// not applicable if the frame filter is specified.
 	iterator, ok := symbols.Stacktraces.(StacktraceIDRangeIterator)
-	if ok && shouldCopyTree(appender, maxNodes) {
+	if ok && filter == nil && shouldCopyTree(appender, maxNodes) {
 		ranges := iterator.SplitStacktraceIDRanges(appender)
 		return buildTreeFromParentPointerTrees(ctx, ranges, symbols, maxNodes)
 	}
@@ -36,7 +39,7 @@ func buildTree(
 	samples := appender.Samples()
 	t := treeSymbolsFromPool()
 	defer t.reset()
-	t.init(symbols, samples)
+	t.init(symbols, samples, filter)
 	if err := symbols.Stacktraces.ResolveStacktraceLocations(ctx, t, samples.StacktraceIDs); err != nil {
 		return nil, err
 	}
@@ -53,6 +56,7 @@ type treeSymbols struct {
 	symbols *Symbols
 	samples *schemav1.Samples
 	tree    *model.StacktraceTree
+	filter  *model.FrameMatcher
 	lines   []int32
 	cur     int
 }
@@ -68,15 +72,19 @@ func treeSymbolsFromPool() *treeSymbols {
 func (r *treeSymbols) reset() {
 	r.symbols = nil
 	r.samples = nil
+	r.filter = nil
 	r.tree.Reset()
 	r.lines = r.lines[:0]
 	r.cur = 0
 	treeSymbolsPool.Put(r)
 }
 
-func (r *treeSymbols) init(symbols *Symbols, samples schemav1.Samples) {
+func (r *treeSymbols) init(symbols *Symbols, samples schemav1.Samples, filter *model.FrameFilter) {
 	r.symbols = symbols
 	r.samples = &samples
+	if filter != nil {
+		r.filter = filter.Matcher(symbols.Strings)
+	}
 	if r.tree == nil {
 		// Branching factor.
 		r.tree = model.NewStacktraceTree(samples.Len() * 2)
@@ -92,7 +100,14 @@ func (r *treeSymbols) InsertStacktrace(_ uint32, locations []int32) {
 			r.lines = append(r.lines, int32(f.Name))
 		}
 	}
-	r.tree.Insert(r.lines, int64(r.samples.Values[r.cur]))
+	lines := r.lines
+	if r.filter != nil {
+		if lines = r.filter.Filter(lines); len(lines) == 0 {
+			r.cur++
+			return
+		}
+	}
+	r.tree.Insert(lines, int64(r.samples.Values[r.cur]))
 	r.cur++
 }
 
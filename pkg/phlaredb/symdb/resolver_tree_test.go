@@ -2,12 +2,16 @@ package symdb
 
 import (
 	"context"
+	"regexp"
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	profilev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+	"github.com/grafana/pyroscope/pkg/model"
 	v1 "github.com/grafana/pyroscope/pkg/phlaredb/schemas/v1"
 )
 
@@ -34,6 +38,59 @@ func Test_block_Resolver_ResolveTree(t *testing.T) {
 	require.Equal(t, expectedFingerprint, treeFingerprint(resolved))
 }
 
+func Test_memory_Resolver_ResolveTree_FrameFilter(t *testing.T) {
+	s := newMemSuite(t, [][]string{{"testdata/profile.pb.gz"}})
+	resolve := func(f *typesv1.FrameFilter) (*model.Tree, error) {
+		r := NewResolver(context.Background(), s.db, WithResolverFrameFilter(f))
+		defer r.Release()
+		r.AddSamples(0, s.indexed[0][0].Samples)
+		return r.Tree()
+	}
+	stacks := func(tree *model.Tree) [][]string {
+		var stacks [][]string
+		tree.IterateStacks(func(name string, _ int64, stack []string) {
+			stacks = append(stacks, append([]string{name}, stack...))
+		})
+		return stacks
+	}
+
+	unfiltered, err := resolve(nil)
+	require.NoError(t, err)
+
+	runtime := regexp.MustCompile(`^runtime\.`)
+	focused, err := resolve(&typesv1.FrameFilter{Focus: runtime.String()})
+	require.NoError(t, err)
+	ignored, err := resolve(&typesv1.FrameFilter{Ignore: runtime.String()})
+	require.NoError(t, err)
+	assert.Greater(t, focused.Total(), int64(0))
+	assert.Greater(t, ignored.Total(), int64(0))
+	assert.Equal(t, unfiltered.Total(), focused.Total()+ignored.Total())
+	for _, stack := range stacks(ignored) {
+		assert.False(t, slices.ContainsFunc(stack, runtime.MatchString), stack)
+	}
+
+	hidden, err := resolve(&typesv1.FrameFilter{Hide: runtime.String()})
+	require.NoError(t, err)
+	// Stack traces that only consist of hidden frames are dropped.
+	assert.LessOrEqual(t, hidden.Total(), unfiltered.Total())
+	assert.Greater(t, hidden.Total(), ignored.Total())
+	for _, stack := range stacks(hidden) {
+		assert.False(t, slices.ContainsFunc(stack, runtime.MatchString), stack)
+	}
+
+	pruned, err := resolve(&typesv1.FrameFilter{PruneFrom: `^runtime\.main$`})
+	require.NoError(t, err)
+	assert.Equal(t, unfiltered.Total(), pruned.Total())
+	for _, stack := range stacks(pruned) {
+		if i := slices.Index(stack, "runtime.main"); i >= 0 {
+			assert.Equal(t, 0, i, stack)
+		}
+	}
+
+	_, err = resolve(&typesv1.FrameFilter{Focus: "("})
+	require.Error(t, err)
+}
+
 func Benchmark_Resolver_ResolveTree_Small(b *testing.B) {
 	s := newMemSuite(b, [][]string{{"testdata/profile.pb.gz"}})
 	samples := s.indexed[0][0].Samples
@@ -0,0 +1,109 @@
+package pprof
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ianlancetaylor/demangle"
+
+	profilev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
+)
+
+// DemangleMode tells how the names of the Itanium C++, Rust legacy and
+// Rust v0 mangled symbols are demangled.
+type DemangleMode string
+
+const (
+	// DemangleNone keeps the mangled names.
+	DemangleNone DemangleMode = "none"
+	// DemangleFull demangles the names with their template
+	// and function parameters, and return types.
+	DemangleFull DemangleMode = "full"
+	// DemangleTemplatesStripped demangles the names without their
+	// template and function parameters, and return types.
+	DemangleTemplatesStripped DemangleMode = "templates-stripped"
+)
+
+func (m *DemangleMode) Set(s string) error {
+	switch dm := DemangleMode(s); dm {
+	case DemangleNone, DemangleFull, DemangleTemplatesStripped:
+		*m = dm
+		return nil
+	}
+	return fmt.Errorf("invalid demangle mode: %s", s)
+}
+
+func (m *DemangleMode) String() string {
+	return string(*m)
+}
+
+// Enabled tells whether the names are demangled.
+func (m DemangleMode) Enabled() bool {
+	return m == DemangleFull || m == DemangleTemplatesStripped
+}
+
+// Demangle returns the demangled name of the symbol. The name is returned
+// as is if it is not mangled, or if it can't be demangled.
+func (m DemangleMode) Demangle(name string) string {
+	if !m.Enabled() {
+		return name
+	}
+	mangled := name
+	if strings.HasPrefix(mangled, "__Z") {
+		// Mach-O symbols have an extra leading underscore.
+		mangled = mangled[1:]
+	}
+	if !strings.HasPrefix(mangled, "_Z") && !strings.HasPrefix(mangled, "_R") {
+		return name
+	}
+	var demangled string
+	var err error
+	if m == DemangleFull {
+		demangled, err = demangle.ToString(mangled, demangle.NoClones)
+	} else {
+		demangled, err = demangle.ToString(mangled, demangle.NoParams, demangle.NoEnclosingParams, demangle.NoTemplateParams)
+		// Rust v0 generic arguments are left as empty
+		// brackets when the template parameters are omitted.
+		demangled = strings.ReplaceAll(demangled, "::<>", "")
+	}
+	if err != nil {
+		return name
+	}
+	return demangled
+}
+
+// DemangleFunctions demangles the names of the functions of the profile.
+// The mangled names are kept as the system names of the functions, unless
+// they already have one.
+func DemangleFunctions(p *profilev1.Profile, mode DemangleMode) {
+	if !mode.Enabled() {
+		return
+	}
+	demangled := make(map[int64]int64)
+	appended := make(map[string]int64)
+	for _, fn := range p.Function {
+		if fn.Name <= 0 || fn.Name >= int64(len(p.StringTable)) {
+			continue
+		}
+		idx, ok := demangled[fn.Name]
+		if !ok {
+			idx = fn.Name
+			name := p.StringTable[fn.Name]
+			if d := mode.Demangle(name); d != name {
+				if idx, ok = appended[d]; !ok {
+					idx = int64(len(p.StringTable))
+					p.StringTable = append(p.StringTable, d)
+					appended[d] = idx
+				}
+			}
+			demangled[fn.Name] = idx
+		}
+		if idx == fn.Name {
+			continue
+		}
+		if fn.SystemName == 0 {
+			fn.SystemName = fn.Name
+		}
+		fn.Name = idx
+	}
+}
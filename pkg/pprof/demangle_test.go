@@ -0,0 +1,92 @@
+package pprof
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	profilev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
+)
+
+func TestDemangleMode_Demangle(t *testing.T) {
+	for _, tc := range []struct {
+		name              string
+		full              string
+		templatesStripped string
+	}{
+		{
+			name:              "_ZNSt6vectorIiSaIiEE9push_backERKi",
+			full:              "std::vector<int, std::allocator<int> >::push_back(int const&)",
+			templatesStripped: "std::vector::push_back",
+		},
+		{
+			name:              "__ZN3foo3barEv",
+			full:              "foo::bar()",
+			templatesStripped: "foo::bar",
+		},
+		{
+			name:              "_ZN4core3ptr13drop_in_place17h1234567890abcdefE",
+			full:              "core::ptr::drop_in_place",
+			templatesStripped: "core::ptr::drop_in_place",
+		},
+		{
+			name:              "_RINvCs1234_7mycrate3foomE",
+			full:              "mycrate::foo::<u32>",
+			templatesStripped: "mycrate::foo",
+		},
+		{
+			name:              "main",
+			full:              "main",
+			templatesStripped: "main",
+		},
+		{
+			name:              "_Zinvalid",
+			full:              "_Zinvalid",
+			templatesStripped: "_Zinvalid",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.full, DemangleFull.Demangle(tc.name))
+			require.Equal(t, tc.templatesStripped, DemangleTemplatesStripped.Demangle(tc.name))
+			require.Equal(t, tc.name, DemangleNone.Demangle(tc.name))
+		})
+	}
+}
+
+func TestDemangleMode_Set(t *testing.T) {
+	var m DemangleMode
+	require.NoError(t, m.Set("templates-stripped"))
+	require.Equal(t, DemangleTemplatesStripped, m)
+	require.Error(t, m.Set("simplified"))
+	require.Equal(t, DemangleTemplatesStripped, m)
+}
+
+func TestDemangleFunctions(t *testing.T) {
+	p := &profilev1.Profile{
+		StringTable: []string{"", "_ZN3foo3barIiEEvT_", "_ZN3foo3barIlEEvT_", "main", "foo.cpp", "_ZN3bazEv"},
+		Function: []*profilev1.Function{
+			{Id: 1, Name: 1, Filename: 4},
+			{Id: 2, Name: 2, Filename: 4},
+			{Id: 3, Name: 3, SystemName: 3},
+			{Id: 4, Name: 1, SystemName: 3},
+		},
+	}
+
+	DemangleFunctions(p, DemangleNone)
+	require.Len(t, p.StringTable, 6)
+
+	DemangleFunctions(p, DemangleTemplatesStripped)
+	require.Len(t, p.StringTable, 7)
+	type function struct{ name, systemName string }
+	functions := make([]function, len(p.Function))
+	for i, fn := range p.Function {
+		functions[i] = function{p.StringTable[fn.Name], p.StringTable[fn.SystemName]}
+	}
+	require.Equal(t, []function{
+		{"foo::bar", "_ZN3foo3barIiEEvT_"},
+		{"foo::bar", "_ZN3foo3barIlEEvT_"},
+		{"main", "main"},
+		// The system name is kept.
+		{"foo::bar", "main"},
+	}, functions)
+}
@@ -0,0 +1,33 @@
+package querier
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/connect"
+
+	profilev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
+	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
+)
+
+// Ingesters and store-gateways do not expose profile IDs, therefore
+// exemplars are only available with the query backend. The response is
+// empty, which allows queries that span both read paths to succeed.
+
+var errProfileIDsUnsupported = errors.New("profiles can only be selected by ID with the query backend")
+
+func (q *Querier) SelectExemplars(
+	context.Context,
+	*connect.Request[querierv1.SelectExemplarsRequest],
+) (*connect.Response[querierv1.SelectExemplarsResponse], error) {
+	return connect.NewResponse(&querierv1.SelectExemplarsResponse{}), nil
+}
+
+// SelectProfileByID is not implemented: an empty profile would not tell
+// the caller the profile can't be found in the ingesters and store-gateways.
+func (q *Querier) SelectProfileByID(
+	context.Context,
+	*connect.Request[querierv1.SelectProfileByIDRequest],
+) (*connect.Response[profilev1.Profile], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errProfileIDsUnsupported)
+}
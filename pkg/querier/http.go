@@ -1,22 +1,26 @@
 package querier
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
 	"connectrpc.com/connect"
 	"github.com/gogo/status"
-	"github.com/google/pprof/profile"
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/promql/parser"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 
 	profilev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
 	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
@@ -24,9 +28,12 @@ import (
 	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
 	"github.com/grafana/pyroscope/pkg/frontend/dot/graph"
 	"github.com/grafana/pyroscope/pkg/frontend/dot/report"
+	"github.com/grafana/pyroscope/pkg/ingester/otlp"
 	phlaremodel "github.com/grafana/pyroscope/pkg/model"
+	"github.com/grafana/pyroscope/pkg/og/convert/speedscope"
 	"github.com/grafana/pyroscope/pkg/og/structs/flamebearer"
 	"github.com/grafana/pyroscope/pkg/og/util/attime"
+	"github.com/grafana/pyroscope/pkg/pprof"
 	"github.com/grafana/pyroscope/pkg/querier/timeline"
 	httputil "github.com/grafana/pyroscope/pkg/util/http"
 )
@@ -107,6 +114,38 @@ func (q *QueryHandlers) RenderDiff(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	format := req.URL.Query().Get("format")
+	if isTreeFormat(format) {
+		var left, right *phlaremodel.Tree
+		g, gCtx := errgroup.WithContext(req.Context())
+		g.Go(func() (err error) {
+			left, err = q.selectTree(gCtx, leftSelectParams)
+			return err
+		})
+		g.Go(func() (err error) {
+			right, err = q.selectTree(gCtx, rightSelectParams)
+			return err
+		})
+		if err = g.Wait(); err != nil {
+			httputil.Error(w, err)
+			return
+		}
+		if err = writeDiffTree(w, format, leftProfileType, left, right, rightSelectParams); err != nil {
+			httputil.Error(w, err)
+		}
+		return
+	}
+
+	var svgOptions phlaremodel.FlameGraphSVGOptions
+	if format == formatSVG {
+		if svgOptions, err = parseSVGOptions(req); err != nil {
+			httputil.Error(w, connect.NewError(connect.CodeInvalidArgument, err))
+			return
+		}
+		svgOptions.Title = fmt.Sprintf("%s diff", leftProfileType.ID)
+		svgOptions.Unit = leftProfileType.SampleUnit
+	}
+
 	res, err := q.client.Diff(req.Context(), connect.NewRequest(&querierv1.DiffRequest{
 		Left:  leftSelectParams,
 		Right: rightSelectParams,
@@ -116,6 +155,14 @@ func (q *QueryHandlers) RenderDiff(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	if format == formatSVG {
+		w.Header().Add("Content-Type", "image/svg+xml")
+		if err = phlaremodel.WriteFlameGraphDiffSVG(w, res.Msg.Flamegraph, svgOptions); err != nil {
+			httputil.Error(w, err)
+		}
+		return
+	}
+
 	w.Header().Add("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(phlaremodel.ExportDiffToFlamebearer(res.Msg.Flamegraph, leftProfileType)); err != nil {
 		httputil.Error(w, err)
@@ -148,31 +195,79 @@ func (q *QueryHandlers) Render(w http.ResponseWriter, req *http.Request) {
 
 	format := req.URL.Query().Get("format")
 	if format == "dot" {
-		// We probably should distinguish max nodes of the source pprof
-		// profile and max nodes value for the output profile in dot format.
-		sourceProfileMaxNodes := int64(512)
-		dotProfileMaxNodes := int64(100)
+		// We probably should distinguish max nodes of the source call
+		// graph and max nodes value for the output graph in dot format.
+		sourceGraphMaxNodes := int64(512)
+		dotGraphMaxNodes := int64(100)
 		if selectParams.MaxNodes != nil {
 			if v := *selectParams.MaxNodes; v > 0 {
-				dotProfileMaxNodes = v
+				dotGraphMaxNodes = v
 			}
-			if dotProfileMaxNodes > sourceProfileMaxNodes {
-				sourceProfileMaxNodes = dotProfileMaxNodes
+			if dotGraphMaxNodes > sourceGraphMaxNodes {
+				sourceGraphMaxNodes = dotGraphMaxNodes
 			}
 		}
-		resp, err := q.client.SelectMergeProfile(req.Context(), connect.NewRequest(&querierv1.SelectMergeProfileRequest{
+		resp, err := q.client.SelectCallGraph(req.Context(), connect.NewRequest(&querierv1.SelectCallGraphRequest{
 			Start:         selectParams.Start,
 			End:           selectParams.End,
 			ProfileTypeID: selectParams.ProfileTypeID,
 			LabelSelector: selectParams.LabelSelector,
-			MaxNodes:      &sourceProfileMaxNodes,
+			MaxNodes:      &sourceGraphMaxNodes,
 		}))
 		if err != nil {
 			httputil.Error(w, connect.NewError(connect.CodeInternal, err))
 			return
 		}
-		if err = pprofToDotProfile(w, resp.Msg, int(dotProfileMaxNodes)); err != nil {
-			httputil.Error(w, connect.NewError(connect.CodeInternal, err))
+		callGraphToDot(w, resp.Msg.CallGraph, profileType, int(dotGraphMaxNodes))
+		return
+	}
+
+	if format == formatOTLP {
+		resp, err := q.client.SelectMergeProfile(req.Context(), connect.NewRequest(&querierv1.SelectMergeProfileRequest{
+			ProfileTypeID: selectParams.ProfileTypeID,
+			LabelSelector: selectParams.LabelSelector,
+			Start:         selectParams.Start,
+			End:           selectParams.End,
+			MaxNodes:      selectParams.MaxNodes,
+		}))
+		if err != nil {
+			httputil.Error(w, err)
+			return
+		}
+		if err = writeOTLP(w, resp.Msg, req.Header.Get("Accept")); err != nil {
+			httputil.Error(w, err)
+		}
+		return
+	}
+
+	if format == formatSVG {
+		svgOptions, err := parseSVGOptions(req)
+		if err != nil {
+			httputil.Error(w, connect.NewError(connect.CodeInvalidArgument, err))
+			return
+		}
+		svgOptions.Title = profileType.ID
+		svgOptions.Unit = profileType.SampleUnit
+		resp, err := q.client.SelectMergeStacktraces(req.Context(), connect.NewRequest(selectParams))
+		if err != nil {
+			httputil.Error(w, err)
+			return
+		}
+		w.Header().Add("Content-Type", "image/svg+xml")
+		if err = phlaremodel.WriteFlameGraphSVG(w, resp.Msg.Flamegraph, svgOptions); err != nil {
+			httputil.Error(w, err)
+		}
+		return
+	}
+
+	if isTreeFormat(format) {
+		tree, err := q.selectTree(req.Context(), selectParams)
+		if err != nil {
+			httputil.Error(w, err)
+			return
+		}
+		if err = writeTree(w, format, profileType, tree, selectParams); err != nil {
+			httputil.Error(w, err)
 		}
 		return
 	}
@@ -240,19 +335,203 @@ func (q *QueryHandlers) Render(w http.ResponseWriter, req *http.Request) {
 	}
 }
 
-func pprofToDotProfile(w io.Writer, p *profilev1.Profile, maxNodes int) error {
-	data, err := p.MarshalVT()
+// MergeProfile writes the profile merged with SelectMergeProfile as OTLP
+// profiles data. The body is a SelectMergeProfileRequest: the request and
+// response are encoded in the same way as the OTLP/HTTP requests, with JSON
+// if the Content-Type and Accept headers are application/json, and
+// protobuf otherwise.
+func (q *QueryHandlers) MergeProfile(w http.ResponseWriter, req *http.Request) {
+	body, err := io.ReadAll(req.Body)
 	if err != nil {
-		return connect.NewError(connect.CodeInternal, err)
+		httputil.Error(w, connect.NewError(connect.CodeInvalidArgument, err))
+		return
+	}
+	var selectParams querierv1.SelectMergeProfileRequest
+	if strings.Contains(req.Header.Get("Content-Type"), "application/json") {
+		err = protojson.Unmarshal(body, &selectParams)
+	} else {
+		err = proto.Unmarshal(body, &selectParams)
 	}
-	pr, err := profile.ParseData(data)
 	if err != nil {
-		return connect.NewError(connect.CodeInternal, err)
+		httputil.Error(w, connect.NewError(connect.CodeInvalidArgument, err))
+		return
+	}
+	resp, err := q.client.SelectMergeProfile(req.Context(), connect.NewRequest(&selectParams))
+	if err != nil {
+		httputil.Error(w, err)
+		return
+	}
+	if err = writeOTLP(w, resp.Msg, req.Header.Get("Accept")); err != nil {
+		httputil.Error(w, err)
 	}
-	rpt := report.NewDefault(pr, report.Options{NodeCount: maxNodes})
+}
+
+func callGraphToDot(w io.Writer, g *typesv1.CallGraph, t *typesv1.ProfileType, maxNodes int) {
+	if g == nil {
+		g = new(typesv1.CallGraph)
+	}
+	rpt := report.NewCallGraph(g, report.Options{
+		NodeCount:  maxNodes,
+		SampleType: t.SampleType,
+		SampleUnit: t.SampleUnit,
+		OutputUnit: "minimum",
+	})
 	gr, cfg := report.GetDOT(rpt)
 	graph.ComposeDot(w, gr, &graph.DotAttributes{}, cfg)
-	return nil
+}
+
+const (
+	formatSpeedscope = "speedscope"
+	formatCollapsed  = "collapsed"
+	formatPprof      = "pprof"
+	formatSVG        = "svg"
+	formatOTLP       = "otlp"
+)
+
+// isTreeFormat reports whether the output in the format
+// is built from the merged tree.
+func isTreeFormat(format string) bool {
+	switch format {
+	case formatSpeedscope, formatCollapsed, formatPprof:
+		return true
+	}
+	return false
+}
+
+func (q *QueryHandlers) selectTree(ctx context.Context, req *querierv1.SelectMergeStacktracesRequest) (*phlaremodel.Tree, error) {
+	req = req.CloneVT()
+	req.Format = querierv1.ProfileFormat_PROFILE_FORMAT_TREE
+	resp, err := q.client.SelectMergeStacktraces(ctx, connect.NewRequest(req))
+	if err != nil {
+		return nil, err
+	}
+	return phlaremodel.UnmarshalTree(resp.Msg.Tree)
+}
+
+func writeTree(w http.ResponseWriter, format string, t *typesv1.ProfileType, tree *phlaremodel.Tree, req *querierv1.SelectMergeStacktracesRequest) error {
+	switch format {
+	case formatSpeedscope:
+		w.Header().Add("Content-Type", "application/json")
+		return speedscope.Export(w, t.ID, t.SampleUnit, speedscope.NamedTree{Name: t.ID, Tree: tree})
+	case formatCollapsed:
+		w.Header().Add("Content-Type", "text/plain")
+		tree.WriteCollapsed(w)
+		return nil
+	default:
+		return writePprof(w, phlaremodel.TreeToPprof(tree, t), req)
+	}
+}
+
+func writeDiffTree(w http.ResponseWriter, format string, t *typesv1.ProfileType, left, right *phlaremodel.Tree, req *querierv1.SelectMergeStacktracesRequest) error {
+	switch format {
+	case formatSpeedscope:
+		w.Header().Add("Content-Type", "application/json")
+		return speedscope.Export(w, t.ID, t.SampleUnit,
+			speedscope.NamedTree{Name: "left", Tree: left},
+			speedscope.NamedTree{Name: "right", Tree: right},
+		)
+	case formatCollapsed:
+		w.Header().Add("Content-Type", "text/plain")
+		writeCollapsedDiff(w, left, right)
+		return nil
+	default:
+		return writePprof(w, phlaremodel.DiffTreesToPprof(left, right, t), req)
+	}
+}
+
+func writePprof(w http.ResponseWriter, p *profilev1.Profile, req *querierv1.SelectMergeStacktracesRequest) error {
+	p.TimeNanos = model.Time(req.Start).UnixNano()
+	p.DurationNanos = model.Time(req.End).Sub(model.Time(req.Start)).Nanoseconds()
+	b, err := pprof.Marshal(p, true)
+	if err != nil {
+		return err
+	}
+	w.Header().Add("Content-Type", "application/octet-stream")
+	w.Header().Add("Content-Disposition", `attachment; filename="profile.pb.gz"`)
+	_, err = w.Write(b)
+	return err
+}
+
+// writeOTLP writes the profile as OTLP profiles data. The data is encoded
+// in the same way as the OTLP/HTTP requests: the encoding is selected with
+// the Accept header, protobuf is used by default.
+func writeOTLP(w http.ResponseWriter, p *profilev1.Profile, accept string) error {
+	data, err := otlp.ConvertGoogleToOtel(p)
+	if err != nil {
+		return err
+	}
+	var b []byte
+	if strings.Contains(accept, "application/json") {
+		w.Header().Add("Content-Type", "application/json")
+		b, err = protojson.Marshal(data)
+	} else {
+		w.Header().Add("Content-Type", "application/x-protobuf")
+		b, err = proto.Marshal(data)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// writeCollapsedDiff writes the stack traces in the format of the
+// difffolded.pl script of FlameGraph: each line consists of the
+// stack trace followed by the left and right values.
+func writeCollapsedDiff(w io.Writer, left, right *phlaremodel.Tree) {
+	values := make(map[string][2]int64)
+	collect := func(t *phlaremodel.Tree, i int) {
+		t.IterateStacks(func(_ string, self int64, stack []string) {
+			slices.Reverse(stack)
+			k := strings.Join(stack, ";")
+			v := values[k]
+			v[i] += self
+			values[k] = v
+		})
+	}
+	collect(left, 0)
+	collect(right, 1)
+	stacks := make([]string, 0, len(values))
+	for k := range values {
+		stacks = append(stacks, k)
+	}
+	slices.Sort(stacks)
+	for _, k := range stacks {
+		v := values[k]
+		_, _ = fmt.Fprintf(w, "%s %d %d\n", k, v[0], v[1])
+	}
+}
+
+// parseSVGOptions parses the width, minWidth and search
+// parameters of the flame graph SVG rendering.
+func parseSVGOptions(req *http.Request) (phlaremodel.FlameGraphSVGOptions, error) {
+	v := req.URL.Query()
+	opts := phlaremodel.FlameGraphSVGOptions{
+		Width:    phlaremodel.DefaultFlameGraphSVGWidth,
+		MinWidth: phlaremodel.DefaultFlameGraphSVGMinWidth,
+	}
+	if s := v.Get("width"); s != "" {
+		width, err := strconv.Atoi(s)
+		if err != nil || width <= 0 {
+			return opts, fmt.Errorf("invalid width: %q", s)
+		}
+		opts.Width = width
+	}
+	if s := v.Get("minWidth"); s != "" {
+		minWidth, err := strconv.ParseFloat(s, 64)
+		if err != nil || minWidth < 0 {
+			return opts, fmt.Errorf("invalid minWidth: %q", s)
+		}
+		opts.MinWidth = minWidth
+	}
+	if s := v.Get("search"); s != "" {
+		search, err := regexp.Compile(s)
+		if err != nil {
+			return opts, fmt.Errorf("invalid search expression: %w", err)
+		}
+		opts.Search = search
+	}
+	return opts, nil
 }
 
 type renderRequestFieldNames struct {
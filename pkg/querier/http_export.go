@@ -0,0 +1,119 @@
+package querier
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/parquet-go/parquet-go"
+	"github.com/prometheus/common/model"
+
+	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
+	queryv1 "github.com/grafana/pyroscope/api/gen/proto/go/query/v1"
+	phlaremodel "github.com/grafana/pyroscope/pkg/model"
+	httputil "github.com/grafana/pyroscope/pkg/util/http"
+)
+
+// ProfileExporter returns the raw profiles selected by the request.
+type ProfileExporter interface {
+	ExportProfiles(context.Context, *querierv1.SelectMergeStacktracesRequest) (*queryv1.ProfileExportReport, error)
+}
+
+// ExportedSample is a row of the Parquet file written by the export
+// handler: each sample of the exported profiles is written as a row.
+type ExportedSample struct {
+	ProfileID   string            `parquet:"profile_id,dict"`
+	Timestamp   int64             `parquet:"timestamp,timestamp(nanosecond)"`
+	ProfileType string            `parquet:"profile_type,dict"`
+	Labels      map[string]string `parquet:"labels"`
+	Value       int64             `parquet:"value"`
+	// Function names, root first.
+	Stack []string `parquet:"stack,list"`
+}
+
+// Keys of the Parquet file metadata describing the export.
+const (
+	ExportMetadataQuery = "pyroscope.export.query"
+	ExportMetadataStart = "pyroscope.export.start"
+	ExportMetadataEnd   = "pyroscope.export.end"
+)
+
+// NewExportHandler returns the handler writing the raw profiles
+// selected with the query as a Parquet file:
+//
+//	/pyroscope/export?query=process_cpu:cpu:nanoseconds:cpu:nanoseconds{service_name="foo"}&from=now-1h&until=now
+func NewExportHandler(exporter ProfileExporter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if err := req.ParseForm(); err != nil {
+			httputil.Error(w, connect.NewError(connect.CodeInvalidArgument, err))
+			return
+		}
+		selectParams, _, err := parseSelectProfilesRequest(renderRequestFieldNames{}, req)
+		if err != nil {
+			httputil.Error(w, connect.NewError(connect.CodeInvalidArgument, err))
+			return
+		}
+		report, err := exporter.ExportProfiles(req.Context(), selectParams)
+		if err != nil {
+			httputil.Error(w, err)
+			return
+		}
+		// The size of the report is bounded by the export limits: the
+		// file is written in full before the response, so that an error
+		// can't be sent after a part of the file.
+		var buf bytes.Buffer
+		if err = WriteExportedProfiles(&buf, report,
+			parquet.KeyValueMetadata(ExportMetadataQuery, req.Form.Get("query")),
+			parquet.KeyValueMetadata(ExportMetadataStart, model.Time(selectParams.Start).Time().Format(time.RFC3339)),
+			parquet.KeyValueMetadata(ExportMetadataEnd, model.Time(selectParams.End).Time().Format(time.RFC3339)),
+		); err != nil {
+			httputil.Error(w, err)
+			return
+		}
+		w.Header().Add("Content-Type", "application/vnd.apache.parquet")
+		w.Header().Add("Content-Disposition", `attachment; filename="profiles.parquet"`)
+		w.Header().Add("Content-Length", strconv.Itoa(buf.Len()))
+		_, _ = buf.WriteTo(w)
+	})
+}
+
+// WriteExportedProfiles writes the samples of the exported profiles as a
+// Parquet file with the ExportedSample schema.
+func WriteExportedProfiles(w io.Writer, report *queryv1.ProfileExportReport, options ...parquet.WriterOption) error {
+	pw := parquet.NewGenericWriter[*ExportedSample](w, options...)
+	row := make([]*ExportedSample, 1)
+	for _, p := range report.Profiles {
+		id, err := uuid.FromBytes(p.Id)
+		if err != nil {
+			return fmt.Errorf("invalid profile id: %w", err)
+		}
+		labels := make(map[string]string, len(p.Labels))
+		for _, l := range p.Labels {
+			labels[l.Name] = l.Value
+		}
+		for _, s := range p.Samples {
+			stack := make([]string, len(s.Stack))
+			for i, n := range s.Stack {
+				stack[i] = report.Strings[n]
+			}
+			row[0] = &ExportedSample{
+				ProfileID:   id.String(),
+				Timestamp:   p.Timestamp,
+				ProfileType: labels[phlaremodel.LabelNameProfileType],
+				Labels:      labels,
+				Value:       s.Value,
+				Stack:       stack,
+			}
+			if _, err = pw.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+	return pw.Close()
+}
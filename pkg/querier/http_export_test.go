@@ -0,0 +1,109 @@
+package querier
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
+	queryv1 "github.com/grafana/pyroscope/api/gen/proto/go/query/v1"
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+)
+
+type profileExporterFunc func(context.Context, *querierv1.SelectMergeStacktracesRequest) (*queryv1.ProfileExportReport, error)
+
+func (f profileExporterFunc) ExportProfiles(ctx context.Context, req *querierv1.SelectMergeStacktracesRequest) (*queryv1.ProfileExportReport, error) {
+	return f(ctx, req)
+}
+
+func Test_ExportHandler(t *testing.T) {
+	id := uuid.New()
+	var actualReq *querierv1.SelectMergeStacktracesRequest
+	handler := NewExportHandler(profileExporterFunc(func(_ context.Context, req *querierv1.SelectMergeStacktracesRequest) (*queryv1.ProfileExportReport, error) {
+		actualReq = req
+		return &queryv1.ProfileExportReport{
+			Strings: []string{"main", "foo", "bar"},
+			Profiles: []*queryv1.ExportedProfile{{
+				Id:        id[:],
+				Timestamp: 1700000000123456789,
+				Labels: []*typesv1.LabelPair{
+					{Name: "__profile_type__", Value: "process_cpu:cpu:nanoseconds:cpu:nanoseconds"},
+					{Name: "service_name", Value: "svc"},
+				},
+				Samples: []*queryv1.ExportedSample{
+					{Stack: []int32{0, 1}, Value: 10},
+					{Stack: []int32{0, 2}, Value: 20},
+				},
+			}},
+		}, nil
+	}))
+
+	req := httptest.NewRequest("GET", `/pyroscope/export?query=process_cpu:cpu:nanoseconds:cpu:nanoseconds{service_name="svc"}&from=1700000000&until=1700003600`, nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	require.Equal(t, 200, rec.Code, rec.Body.String())
+	assert.Equal(t, "application/vnd.apache.parquet", rec.Header().Get("Content-Type"))
+	assert.Equal(t, `{service_name="svc"}`, actualReq.LabelSelector)
+	assert.Equal(t, "process_cpu:cpu:nanoseconds:cpu:nanoseconds", actualReq.ProfileTypeID)
+	assert.Equal(t, int64(1700000000000), actualReq.Start)
+
+	b := rec.Body.Bytes()
+	f, err := parquet.OpenFile(bytes.NewReader(b), int64(len(b)))
+	require.NoError(t, err)
+	v, ok := f.Lookup(ExportMetadataQuery)
+	assert.True(t, ok)
+	assert.Equal(t, `process_cpu:cpu:nanoseconds:cpu:nanoseconds{service_name="svc"}`, v)
+
+	r := parquet.NewGenericReader[*ExportedSample](bytes.NewReader(b))
+	rows := make([]*ExportedSample, 3)
+	n, err := r.Read(rows)
+	if err != io.EOF {
+		require.NoError(t, err)
+	}
+	require.Equal(t, 2, n)
+	labels := map[string]string{
+		"__profile_type__": "process_cpu:cpu:nanoseconds:cpu:nanoseconds",
+		"service_name":     "svc",
+	}
+	assert.Equal(t, []*ExportedSample{
+		{
+			ProfileID:   id.String(),
+			Timestamp:   1700000000123456789,
+			ProfileType: "process_cpu:cpu:nanoseconds:cpu:nanoseconds",
+			Labels:      labels,
+			Value:       10,
+			Stack:       []string{"main", "foo"},
+		},
+		{
+			ProfileID:   id.String(),
+			Timestamp:   1700000000123456789,
+			ProfileType: "process_cpu:cpu:nanoseconds:cpu:nanoseconds",
+			Labels:      labels,
+			Value:       20,
+			Stack:       []string{"main", "bar"},
+		},
+	}, rows[:n])
+}
+
+func Test_ExportHandler_WriteError(t *testing.T) {
+	handler := NewExportHandler(profileExporterFunc(func(context.Context, *querierv1.SelectMergeStacktracesRequest) (*queryv1.ProfileExportReport, error) {
+		return &queryv1.ProfileExportReport{
+			Profiles: []*queryv1.ExportedProfile{{Id: []byte("invalid")}},
+		}, nil
+	}))
+
+	req := httptest.NewRequest("GET", `/pyroscope/export?query=process_cpu:cpu:nanoseconds:cpu:nanoseconds{service_name="svc"}&from=1700000000&until=1700003600`, nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	// The error is sent instead of the file.
+	assert.Equal(t, 500, rec.Code)
+	assert.NotEqual(t, "application/vnd.apache.parquet", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), "invalid profile id")
+}
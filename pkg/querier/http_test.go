@@ -1,16 +1,29 @@
 package querier
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"testing"
 	"time"
 
+	"connectrpc.com/connect"
 	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	otelProfile "go.opentelemetry.io/proto/otlp/profiles/v1development"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 
+	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
 	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+	phlaremodel "github.com/grafana/pyroscope/pkg/model"
+	"github.com/grafana/pyroscope/pkg/pprof"
+	"github.com/grafana/pyroscope/pkg/test/mocks/mockquerierv1connect"
 )
 
 func Test_ParseQuery(t *testing.T) {
@@ -40,3 +53,193 @@ func Test_ParseQuery(t *testing.T) {
 
 	require.Equal(t, `{foo="bar",bar=~"buzz"}`, queryRequest.LabelSelector)
 }
+
+func Test_CallGraphToDot(t *testing.T) {
+	g := &typesv1.CallGraph{
+		Nodes: []*typesv1.CallGraphNode{
+			{Name: "main", Self: 0, Total: 10},
+			{Name: "foo", Self: 7, Total: 7},
+			{Name: "bar", Self: 3, Total: 3},
+		},
+		Edges: []*typesv1.CallGraphEdge{
+			{Caller: 0, Callee: 1, Value: 7},
+			{Caller: 0, Callee: 2, Value: 3},
+		},
+	}
+	profileType := &typesv1.ProfileType{SampleType: "cpu", SampleUnit: "nanoseconds"}
+
+	var buf bytes.Buffer
+	callGraphToDot(&buf, g, profileType, 100)
+	dot := buf.String()
+	require.Contains(t, dot, "Type: cpu")
+	require.Contains(t, dot, `label="main\n0 of 10ns (100%)"`)
+	require.Contains(t, dot, `label="foo\n7ns (70.00%)"`)
+	require.Contains(t, dot, "N1 -> N2")
+
+	buf.Reset()
+	callGraphToDot(&buf, g, profileType, 2)
+	require.NotContains(t, buf.String(), "bar")
+}
+
+func Test_RenderTreeFormats(t *testing.T) {
+	tree := new(phlaremodel.Tree)
+	tree.InsertStack(3, "main", "foo")
+	tree.InsertStack(1, "main", "bar")
+
+	client := mockquerierv1connect.NewMockQuerierServiceClient(t)
+	client.On("SelectMergeStacktraces", mock.Anything, mock.Anything).
+		Return(func(_ context.Context, req *connect.Request[querierv1.SelectMergeStacktracesRequest]) (*connect.Response[querierv1.SelectMergeStacktracesResponse], error) {
+			require.Equal(t, querierv1.ProfileFormat_PROFILE_FORMAT_TREE, req.Msg.Format)
+			return connect.NewResponse(&querierv1.SelectMergeStacktracesResponse{Tree: tree.Bytes(-1)}), nil
+		})
+	h := NewHTTPHandlers(client)
+
+	render := func(format string) *httptest.ResponseRecorder {
+		q := url.Values{
+			"query":  []string{`process_cpu:cpu:nanoseconds:cpu:nanoseconds{service_name="foo"}`},
+			"from":   []string{"now-1h"},
+			"until":  []string{"now"},
+			"format": []string{format},
+		}
+		w := httptest.NewRecorder()
+		h.Render(w, httptest.NewRequest("GET", "/pyroscope/render?"+q.Encode(), nil))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		return w
+	}
+
+	require.Equal(t, "main;bar 1\nmain;foo 3\n", render("collapsed").Body.String())
+
+	var speedscope map[string]any
+	require.NoError(t, json.Unmarshal(render("speedscope").Body.Bytes(), &speedscope))
+	require.Equal(t, "https://www.speedscope.app/file-format-schema.json", speedscope["$schema"])
+	require.Len(t, speedscope["profiles"], 1)
+
+	p, err := pprof.RawFromBytes(render("pprof").Body.Bytes())
+	require.NoError(t, err)
+	require.Len(t, p.Sample, 2)
+	require.Equal(t, "cpu", p.StringTable[p.SampleType[0].Type])
+}
+
+func Test_RenderDiffTreeFormats(t *testing.T) {
+	left := new(phlaremodel.Tree)
+	left.InsertStack(3, "main", "foo")
+	right := new(phlaremodel.Tree)
+	right.InsertStack(2, "main", "foo")
+	right.InsertStack(1, "main", "bar")
+
+	client := mockquerierv1connect.NewMockQuerierServiceClient(t)
+	client.On("SelectMergeStacktraces", mock.Anything, mock.Anything).
+		Return(func(_ context.Context, req *connect.Request[querierv1.SelectMergeStacktracesRequest]) (*connect.Response[querierv1.SelectMergeStacktracesResponse], error) {
+			tree := left
+			if req.Msg.LabelSelector == `{service_name="right"}` {
+				tree = right
+			}
+			return connect.NewResponse(&querierv1.SelectMergeStacktracesResponse{Tree: tree.Bytes(-1)}), nil
+		})
+	h := NewHTTPHandlers(client)
+
+	render := func(format string) *httptest.ResponseRecorder {
+		q := url.Values{
+			"leftQuery":  []string{`process_cpu:cpu:nanoseconds:cpu:nanoseconds{service_name="left"}`},
+			"leftFrom":   []string{"now-2h"},
+			"leftUntil":  []string{"now-1h"},
+			"rightQuery": []string{`process_cpu:cpu:nanoseconds:cpu:nanoseconds{service_name="right"}`},
+			"rightFrom":  []string{"now-1h"},
+			"rightUntil": []string{"now"},
+			"format":     []string{format},
+		}
+		w := httptest.NewRecorder()
+		h.RenderDiff(w, httptest.NewRequest("GET", "/pyroscope/render-diff?"+q.Encode(), nil))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		return w
+	}
+
+	require.Equal(t, "main;bar 0 1\nmain;foo 3 2\n", render("collapsed").Body.String())
+
+	var speedscope map[string]any
+	require.NoError(t, json.Unmarshal(render("speedscope").Body.Bytes(), &speedscope))
+	require.Len(t, speedscope["profiles"], 2)
+
+	p, err := pprof.RawFromBytes(render("pprof").Body.Bytes())
+	require.NoError(t, err)
+	var total int64
+	for _, s := range p.Sample {
+		total += s.Value[0]
+	}
+	require.Equal(t, int64(0), total)
+}
+
+func Test_ParseSVGOptions(t *testing.T) {
+	req := httptest.NewRequest("GET", "/pyroscope/render?format=svg&width=800&minWidth=0.5&search=foo", nil)
+	opts, err := parseSVGOptions(req)
+	require.NoError(t, err)
+	require.Equal(t, 800, opts.Width)
+	require.Equal(t, 0.5, opts.MinWidth)
+	require.True(t, opts.Search.MatchString("foo"))
+
+	for _, q := range []string{"width=-1", "minWidth=x", "search=("} {
+		_, err = parseSVGOptions(httptest.NewRequest("GET", "/pyroscope/render?format=svg&"+q, nil))
+		require.Error(t, err, q)
+	}
+}
+
+func Test_RenderOTLP(t *testing.T) {
+	tree := new(phlaremodel.Tree)
+	tree.InsertStack(3, "main", "foo")
+	profileType := &typesv1.ProfileType{SampleType: "cpu", SampleUnit: "nanoseconds"}
+
+	client := mockquerierv1connect.NewMockQuerierServiceClient(t)
+	client.On("SelectMergeProfile", mock.Anything, mock.Anything).
+		Return(connect.NewResponse(phlaremodel.TreeToPprof(tree, profileType)), nil)
+	h := NewHTTPHandlers(client)
+
+	q := url.Values{
+		"query":  []string{`process_cpu:cpu:nanoseconds:cpu:nanoseconds{service_name="foo"}`},
+		"from":   []string{"now-1h"},
+		"until":  []string{"now"},
+		"format": []string{"otlp"},
+	}
+	w := httptest.NewRecorder()
+	h.Render(w, httptest.NewRequest("GET", "/pyroscope/render?"+q.Encode(), nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.Equal(t, "application/x-protobuf", w.Header().Get("Content-Type"))
+
+	var data otelProfile.ProfilesData
+	require.NoError(t, proto.Unmarshal(w.Body.Bytes(), &data))
+	p := data.ResourceProfiles[0].ScopeProfiles[0].Profiles[0]
+	require.Len(t, p.Sample, 1)
+	require.Equal(t, []int64{3}, p.Sample[0].Value)
+	require.Equal(t, "cpu", data.Dictionary.StringTable[p.SampleType[0].TypeStrindex])
+}
+
+func Test_MergeProfileOTLP(t *testing.T) {
+	tree := new(phlaremodel.Tree)
+	tree.InsertStack(3, "main", "foo")
+	profileType := &typesv1.ProfileType{SampleType: "cpu", SampleUnit: "nanoseconds"}
+
+	client := mockquerierv1connect.NewMockQuerierServiceClient(t)
+	client.On("SelectMergeProfile", mock.Anything, mock.MatchedBy(func(r *connect.Request[querierv1.SelectMergeProfileRequest]) bool {
+		return r.Msg.LabelSelector == `{service_name="foo"}` && r.Msg.End == 2
+	})).Return(connect.NewResponse(phlaremodel.TreeToPprof(tree, profileType)), nil)
+	h := NewHTTPHandlers(client)
+
+	body := `{"profileTypeID":"process_cpu:cpu:nanoseconds:cpu:nanoseconds","labelSelector":"{service_name=\"foo\"}","start":1,"end":2}`
+	req := httptest.NewRequest("POST", "/pyroscope/merge-profile", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	w := httptest.NewRecorder()
+	h.MergeProfile(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+	var data otelProfile.ProfilesData
+	require.NoError(t, protojson.Unmarshal(w.Body.Bytes(), &data))
+	p := data.ResourceProfiles[0].ScopeProfiles[0].Profiles[0]
+	require.Len(t, p.Sample, 1)
+	require.Equal(t, []int64{3}, p.Sample[0].Value)
+
+	// Malformed requests are rejected.
+	w = httptest.NewRecorder()
+	h.MergeProfile(w, httptest.NewRequest("POST", "/pyroscope/merge-profile", bytes.NewBufferString("{")))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -130,7 +130,8 @@ func (q *Querier) selectTreeFromIngesters(ctx context.Context, req *querierv1.Se
 					Type:          profileType,
 					Hints:         &ingestv1.Hints{Block: blockHints},
 				},
-				MaxNodes: req.MaxNodes,
+				MaxNodes:    req.MaxNodes,
+				FrameFilter: req.FrameFilter,
 			})
 		}))
 	}
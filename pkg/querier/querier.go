@@ -639,6 +639,100 @@ func (q *Querier) SelectMergeSpanProfile(ctx context.Context, req *connect.Reque
 	return connect.NewResponse(&resp), nil
 }
 
+func (q *Querier) SelectTopTable(ctx context.Context, req *connect.Request[querierv1.SelectTopTableRequest]) (*connect.Response[querierv1.SelectTopTableResponse], error) {
+	sp, ctx := opentracing.StartSpanFromContext(ctx, "SelectTopTable")
+	level.Info(spanlogger.FromContext(ctx, q.logger)).Log(
+		"start", model.Time(req.Msg.Start).Time().String(),
+		"end", model.Time(req.Msg.End).Time().String(),
+		"selector", req.Msg.LabelSelector,
+		"profile_id", req.Msg.ProfileTypeID,
+	)
+	defer func() {
+		sp.Finish()
+	}()
+
+	// The tree must not be truncated: otherwise,
+	// function totals would be inaccurate.
+	t, err := q.selectTree(ctx, &querierv1.SelectMergeStacktracesRequest{
+		ProfileTypeID: req.Msg.ProfileTypeID,
+		LabelSelector: req.Msg.LabelSelector,
+		Start:         req.Msg.Start,
+		End:           req.Msg.End,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entries := phlaremodel.SortTopTable(phlaremodel.TopTableFromTree(t), req.Msg.OrderBy, req.Msg.Limit)
+	return connect.NewResponse(&querierv1.SelectTopTableResponse{Entries: entries}), nil
+}
+
+func (q *Querier) SelectCallGraph(ctx context.Context, req *connect.Request[querierv1.SelectCallGraphRequest]) (*connect.Response[querierv1.SelectCallGraphResponse], error) {
+	sp, ctx := opentracing.StartSpanFromContext(ctx, "SelectCallGraph")
+	level.Info(spanlogger.FromContext(ctx, q.logger)).Log(
+		"start", model.Time(req.Msg.Start).Time().String(),
+		"end", model.Time(req.Msg.End).Time().String(),
+		"selector", req.Msg.LabelSelector,
+		"profile_id", req.Msg.ProfileTypeID,
+	)
+	defer func() {
+		sp.Finish()
+	}()
+
+	// The tree must not be truncated: otherwise,
+	// node and edge values would be inaccurate.
+	t, err := q.selectTree(ctx, &querierv1.SelectMergeStacktracesRequest{
+		ProfileTypeID: req.Msg.ProfileTypeID,
+		LabelSelector: req.Msg.LabelSelector,
+		Start:         req.Msg.Start,
+		End:           req.Msg.End,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	graph := phlaremodel.TruncateCallGraph(phlaremodel.CallGraphFromTree(t), req.Msg.GetMaxNodes())
+	return connect.NewResponse(&querierv1.SelectCallGraphResponse{CallGraph: graph}), nil
+}
+
+func (q *Querier) SelectFunctionDetails(ctx context.Context, req *connect.Request[querierv1.SelectFunctionDetailsRequest]) (*connect.Response[querierv1.SelectFunctionDetailsResponse], error) {
+	sp, ctx := opentracing.StartSpanFromContext(ctx, "SelectFunctionDetails")
+	level.Info(spanlogger.FromContext(ctx, q.logger)).Log(
+		"start", model.Time(req.Msg.Start).Time().String(),
+		"end", model.Time(req.Msg.End).Time().String(),
+		"selector", req.Msg.LabelSelector,
+		"profile_id", req.Msg.ProfileTypeID,
+		"function_name", req.Msg.FunctionName,
+	)
+	defer func() {
+		sp.Finish()
+	}()
+
+	if req.Msg.MaxNodes == nil || *req.Msg.MaxNodes == 0 {
+		mn := maxNodesDefault
+		req.Msg.MaxNodes = &mn
+	}
+
+	// The profile is not truncated: line numbers
+	// are only available in the pprof format.
+	p, err := q.selectProfile(ctx, &querierv1.SelectMergeProfileRequest{
+		ProfileTypeID: req.Msg.ProfileTypeID,
+		LabelSelector: req.Msg.LabelSelector,
+		Start:         req.Msg.Start,
+		End:           req.Msg.End,
+	})
+	if err != nil {
+		return nil, err
+	}
+	d, err := phlaremodel.FunctionDetailsFromProfile(p, req.Msg.FunctionName, req.Msg.GetMaxNodes())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	resp := phlaremodel.NewFunctionDetailsResponse(d, req.Msg.Format, req.Msg.GetMaxNodes())
+	return connect.NewResponse(resp), nil
+}
+
 func isEndpointNotExistingErr(err error) bool {
 	if err == nil {
 		return false
@@ -722,6 +816,7 @@ func (sq storeQuery) MergeStacktracesRequest(req *querierv1.SelectMergeStacktrac
 		ProfileTypeID: req.ProfileTypeID,
 		MaxNodes:      req.MaxNodes,
 		Format:        req.Format,
+		FrameFilter:   req.FrameFilter,
 	}
 }
 
@@ -973,6 +1068,18 @@ func (q *Querier) SelectSeries(ctx context.Context, req *connect.Request[querier
 	}), nil
 }
 
+var errHeatmapUnsupported = errors.New("heatmaps are only available with the query backend")
+
+// SelectHeatmap is not implemented: ingesters and store-gateways do not
+// return the values of individual profiles, and the points of a series
+// are aggregated per step.
+func (q *Querier) SelectHeatmap(
+	context.Context,
+	*connect.Request[querierv1.SelectHeatmapRequest],
+) (*connect.Response[querierv1.SelectHeatmapResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errHeatmapUnsupported)
+}
+
 func (q *Querier) selectSeries(ctx context.Context, req *connect.Request[querierv1.SelectSeriesRequest], plan map[string]*blockPlanEntry) ([]ResponseFromReplica[clientpool.BidiClientMergeProfilesLabels], error) {
 	stepMs := time.Duration(req.Msg.Step * float64(time.Second)).Milliseconds()
 	sort.Strings(req.Msg.GroupBy)
@@ -619,6 +619,28 @@ func TestSelectSeries(t *testing.T) {
 	}
 }
 
+func TestSelectHeatmap(t *testing.T) {
+	querier, err := New(&NewQuerierParams{
+		Cfg: Config{
+			PoolConfig: clientpool.PoolConfig{ClientCleanupPeriod: 1 * time.Millisecond},
+		},
+		IngestersRing: testhelper.NewMockRing([]ring.InstanceDesc{{Addr: "1"}}, 1),
+		PoolFactory: &poolFactory{f: func(addr string) (client.PoolClient, error) {
+			return newFakeQuerier(), nil
+		}},
+		Logger: log.NewLogfmtLogger(os.Stdout),
+	})
+	require.NoError(t, err)
+	_, err = querier.SelectHeatmap(context.Background(), connect.NewRequest(&querierv1.SelectHeatmapRequest{
+		LabelSelector: `{app="foo"}`,
+		ProfileTypeID: "memory:inuse_space:bytes:space:byte",
+		Start:         1,
+		End:           2,
+		Step:          1,
+	}))
+	require.Equal(t, connect.CodeUnimplemented, connect.CodeOf(err))
+}
+
 type fakeQuerierIngester struct {
 	mock.Mock
 	testhelper.FakePoolClient
@@ -222,7 +222,8 @@ func (q *Querier) selectTreeFromStoreGateway(ctx context.Context, req *querierv1
 					Type:          profileType,
 					Hints:         &ingestv1.Hints{Block: blockHints},
 				},
-				MaxNodes: req.MaxNodes,
+				MaxNodes:    req.MaxNodes,
+				FrameFilter: req.FrameFilter,
 			})
 		}))
 	}
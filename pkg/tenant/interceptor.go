@@ -35,9 +35,8 @@ func (i *authInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
 	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
 		// client side we extract the tenantID from the context and inject it into the request header
 		if req.Spec().IsClient {
-			tenantID, _ := ExtractTenantIDFromContext(ctx)
-			if tenantID != "" {
-				req.Header().Set("X-Scope-OrgID", tenantID)
+			if tenantIDs, _ := TenantIDs(ctx); len(tenantIDs) > 0 {
+				req.Header().Set("X-Scope-OrgID", JoinTenantIDs(tenantIDs))
 			}
 			return next(ctx, req)
 		}
@@ -46,7 +45,7 @@ func (i *authInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
 		if !i.enabled {
 			return next(InjectTenantID(ctx, DefaultTenantID), req)
 		}
-		_, ctx, _ = ExtractTenantIDFromHeaders(ctx, req.Header())
+		_, ctx, _ = ExtractTenantIDsFromHeaders(ctx, req.Header())
 
 		resp, err := next(ctx, req)
 		if err != nil && errors.Is(err, ErrNoTenantID) {
@@ -59,9 +58,8 @@ func (i *authInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
 func (i *authInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
 	return func(ctx context.Context, s connect.Spec) connect.StreamingClientConn {
 		conn := next(ctx, s)
-		tenantID, _ := ExtractTenantIDFromContext(ctx)
-		if tenantID != "" {
-			conn.RequestHeader().Set("X-Scope-OrgID", tenantID)
+		if tenantIDs, _ := TenantIDs(ctx); len(tenantIDs) > 0 {
+			conn.RequestHeader().Set("X-Scope-OrgID", JoinTenantIDs(tenantIDs))
 		}
 		return conn
 	}
@@ -72,7 +70,7 @@ func (i *authInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc
 		if !i.enabled {
 			return next(InjectTenantID(ctx, DefaultTenantID), conn)
 		}
-		_, ctx, _ = ExtractTenantIDFromHeaders(ctx, conn.RequestHeader())
+		_, ctx, _ = ExtractTenantIDsFromHeaders(ctx, conn.RequestHeader())
 		if err := next(ctx, conn); err != nil {
 			if errors.Is(err, ErrNoTenantID) {
 				return connect.NewError(connect.CodeUnauthenticated, err)
@@ -101,6 +99,22 @@ func ExtractTenantIDFromHeaders(ctx context.Context, headers http.Header) (strin
 	return tenantID, ctx, nil
 }
 
+// ExtractTenantIDsFromHeaders extracts all the tenant IDs from http headers.
+// Unlike ExtractTenantIDFromHeaders, it accepts org IDs that refer to
+// multiple tenants. If the org ID is invalid, the context is returned
+// as is, without the org ID.
+func ExtractTenantIDsFromHeaders(ctx context.Context, headers http.Header) ([]string, context.Context, error) {
+	orgID := headers.Get(user.OrgIDHeaderName)
+	if orgID == "" {
+		return nil, ctx, ErrNoTenantID
+	}
+	tenantIDs, err := defaultResolver.TenantIDs(InjectTenantID(ctx, orgID))
+	if err != nil {
+		return nil, ctx, err
+	}
+	return tenantIDs, InjectTenantID(ctx, JoinTenantIDs(tenantIDs)), nil
+}
+
 // ExtractTenantIDFromContext extracts a single TenantID from the context.
 func ExtractTenantIDFromContext(ctx context.Context) (string, error) {
 	tenantID, err := defaultResolver.TenantID(ctx)
@@ -59,6 +59,39 @@ func Test_AuthInterceptor(t *testing.T) {
 			require.NoError(t, err)
 			require.Nil(t, resp)
 		},
+		"server: enable, multiple tenants": func(t *testing.T) {
+			i := NewAuthInterceptor(true)
+			req := newFakeReq(false)
+			req.Header().Set("X-Scope-OrgID", "foo|bar|foo")
+			resp, err := i.WrapUnary(func(ctx context.Context, ar connect.AnyRequest) (connect.AnyResponse, error) {
+				tenantIDs, err := TenantIDs(ctx)
+				require.NoError(t, err)
+				require.Equal(t, []string{"bar", "foo"}, tenantIDs)
+				_, err = ExtractTenantIDFromContext(ctx)
+				require.Error(t, err)
+				return nil, nil
+			})(context.Background(), req)
+			require.NoError(t, err)
+			require.Nil(t, resp)
+		},
+		"server: enable, invalid tenant": func(t *testing.T) {
+			i := NewAuthInterceptor(true)
+			req := newFakeReq(false)
+			req.Header().Set("X-Scope-OrgID", "foo|..")
+			_, err := i.WrapUnary(func(ctx context.Context, ar connect.AnyRequest) (connect.AnyResponse, error) {
+				_, err := TenantIDs(ctx)
+				return nil, err
+			})(context.Background(), req)
+			require.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+		},
+		"client: forward multiple tenants": func(t *testing.T) {
+			i := NewAuthInterceptor(false)
+			_, err := i.WrapUnary(func(ctx context.Context, ar connect.AnyRequest) (connect.AnyResponse, error) {
+				require.Equal(t, "bar|foo", ar.Header().Get("X-Scope-OrgID"))
+				return nil, nil
+			})(InjectTenantID(context.Background(), "foo|bar"), newFakeReq(true))
+			require.NoError(t, err)
+		},
 		"streaming client should forward from context": func(t *testing.T) {
 			i := NewAuthInterceptor(false)
 			inConn := newFakeClientStreamingConn()
@@ -3,6 +3,7 @@ package tenant
 import (
 	"context"
 
+	"github.com/grafana/dskit/tenant"
 	"github.com/grafana/dskit/user"
 )
 
@@ -11,3 +12,16 @@ var ErrNoTenantID = user.ErrNoOrgID
 func InjectTenantID(ctx context.Context, tenantID string) context.Context {
 	return user.InjectOrgID(ctx, tenantID)
 }
+
+// TenantIDs returns all the tenant IDs from the context: a query may
+// span multiple tenants, specified as an org ID with the tenant IDs
+// separated by "|", e.g., "tenant-a|tenant-b". The returned list is
+// sorted and does not contain duplicates.
+func TenantIDs(ctx context.Context) ([]string, error) {
+	return defaultResolver.TenantIDs(ctx)
+}
+
+// JoinTenantIDs returns the org ID for the tenant IDs specified.
+func JoinTenantIDs(tenantIDs []string) string {
+	return tenant.JoinTenantIDs(tenantIDs)
+}
@@ -6,6 +6,8 @@ import (
 	time "time"
 
 	mock "github.com/stretchr/testify/mock"
+
+	validation "github.com/grafana/pyroscope/pkg/validation"
 )
 
 // MockLimits is an autogenerated mock type for the Limits type
@@ -21,6 +23,52 @@ func (_m *MockLimits) EXPECT() *MockLimits_Expecter {
 	return &MockLimits_Expecter{mock: &_m.Mock}
 }
 
+// ExportLimits provides a mock function with given fields: _a0
+func (_m *MockLimits) ExportLimits(_a0 string) validation.ExportLimits {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportLimits")
+	}
+
+	var r0 validation.ExportLimits
+	if rf, ok := ret.Get(0).(func(string) validation.ExportLimits); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(validation.ExportLimits)
+	}
+
+	return r0
+}
+
+// MockLimits_ExportLimits_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportLimits'
+type MockLimits_ExportLimits_Call struct {
+	*mock.Call
+}
+
+// ExportLimits is a helper method to define mock.On call
+//   - _a0 string
+func (_e *MockLimits_Expecter) ExportLimits(_a0 interface{}) *MockLimits_ExportLimits_Call {
+	return &MockLimits_ExportLimits_Call{Call: _e.mock.On("ExportLimits", _a0)}
+}
+
+func (_c *MockLimits_ExportLimits_Call) Run(run func(_a0 string)) *MockLimits_ExportLimits_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockLimits_ExportLimits_Call) Return(_a0 validation.ExportLimits) *MockLimits_ExportLimits_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLimits_ExportLimits_Call) RunAndReturn(run func(string) validation.ExportLimits) *MockLimits_ExportLimits_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // MaxFlameGraphNodesDefault provides a mock function with given fields: _a0
 func (_m *MockLimits) MaxFlameGraphNodesDefault(_a0 string) int {
 	ret := _m.Called(_a0)
@@ -297,6 +345,52 @@ func (_c *MockLimits_QueryAnalysisEnabled_Call) RunAndReturn(run func(string) bo
 	return _c
 }
 
+// QueryBackendLimits provides a mock function with given fields: _a0
+func (_m *MockLimits) QueryBackendLimits(_a0 string) validation.QueryBackendLimits {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QueryBackendLimits")
+	}
+
+	var r0 validation.QueryBackendLimits
+	if rf, ok := ret.Get(0).(func(string) validation.QueryBackendLimits); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(validation.QueryBackendLimits)
+	}
+
+	return r0
+}
+
+// MockLimits_QueryBackendLimits_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryBackendLimits'
+type MockLimits_QueryBackendLimits_Call struct {
+	*mock.Call
+}
+
+// QueryBackendLimits is a helper method to define mock.On call
+//   - _a0 string
+func (_e *MockLimits_Expecter) QueryBackendLimits(_a0 interface{}) *MockLimits_QueryBackendLimits_Call {
+	return &MockLimits_QueryBackendLimits_Call{Call: _e.mock.On("QueryBackendLimits", _a0)}
+}
+
+func (_c *MockLimits_QueryBackendLimits_Call) Run(run func(_a0 string)) *MockLimits_QueryBackendLimits_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockLimits_QueryBackendLimits_Call) Return(_a0 validation.QueryBackendLimits) *MockLimits_QueryBackendLimits_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLimits_QueryBackendLimits_Call) RunAndReturn(run func(string) validation.QueryBackendLimits) *MockLimits_QueryBackendLimits_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // QuerySplitDuration provides a mock function with given fields: _a0
 func (_m *MockLimits) QuerySplitDuration(_a0 string) time.Duration {
 	ret := _m.Called(_a0)
@@ -383,6 +383,242 @@ func (_c *MockQuerierServiceClient_ProfileTypes_Call) RunAndReturn(run func(cont
 	return _c
 }
 
+// SelectCallGraph provides a mock function with given fields: _a0, _a1
+func (_m *MockQuerierServiceClient) SelectCallGraph(_a0 context.Context, _a1 *connect.Request[querierv1.SelectCallGraphRequest]) (*connect.Response[querierv1.SelectCallGraphResponse], error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectCallGraph")
+	}
+
+	var r0 *connect.Response[querierv1.SelectCallGraphResponse]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *connect.Request[querierv1.SelectCallGraphRequest]) (*connect.Response[querierv1.SelectCallGraphResponse], error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *connect.Request[querierv1.SelectCallGraphRequest]) *connect.Response[querierv1.SelectCallGraphResponse]); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*connect.Response[querierv1.SelectCallGraphResponse])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *connect.Request[querierv1.SelectCallGraphRequest]) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerierServiceClient_SelectCallGraph_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectCallGraph'
+type MockQuerierServiceClient_SelectCallGraph_Call struct {
+	*mock.Call
+}
+
+// SelectCallGraph is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *connect.Request[querierv1.SelectCallGraphRequest]
+func (_e *MockQuerierServiceClient_Expecter) SelectCallGraph(_a0 interface{}, _a1 interface{}) *MockQuerierServiceClient_SelectCallGraph_Call {
+	return &MockQuerierServiceClient_SelectCallGraph_Call{Call: _e.mock.On("SelectCallGraph", _a0, _a1)}
+}
+
+func (_c *MockQuerierServiceClient_SelectCallGraph_Call) Run(run func(_a0 context.Context, _a1 *connect.Request[querierv1.SelectCallGraphRequest])) *MockQuerierServiceClient_SelectCallGraph_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*connect.Request[querierv1.SelectCallGraphRequest]))
+	})
+	return _c
+}
+
+func (_c *MockQuerierServiceClient_SelectCallGraph_Call) Return(_a0 *connect.Response[querierv1.SelectCallGraphResponse], _a1 error) *MockQuerierServiceClient_SelectCallGraph_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerierServiceClient_SelectCallGraph_Call) RunAndReturn(run func(context.Context, *connect.Request[querierv1.SelectCallGraphRequest]) (*connect.Response[querierv1.SelectCallGraphResponse], error)) *MockQuerierServiceClient_SelectCallGraph_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SelectExemplars provides a mock function with given fields: _a0, _a1
+func (_m *MockQuerierServiceClient) SelectExemplars(_a0 context.Context, _a1 *connect.Request[querierv1.SelectExemplarsRequest]) (*connect.Response[querierv1.SelectExemplarsResponse], error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectExemplars")
+	}
+
+	var r0 *connect.Response[querierv1.SelectExemplarsResponse]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *connect.Request[querierv1.SelectExemplarsRequest]) (*connect.Response[querierv1.SelectExemplarsResponse], error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *connect.Request[querierv1.SelectExemplarsRequest]) *connect.Response[querierv1.SelectExemplarsResponse]); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*connect.Response[querierv1.SelectExemplarsResponse])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *connect.Request[querierv1.SelectExemplarsRequest]) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerierServiceClient_SelectExemplars_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectExemplars'
+type MockQuerierServiceClient_SelectExemplars_Call struct {
+	*mock.Call
+}
+
+// SelectExemplars is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *connect.Request[querierv1.SelectExemplarsRequest]
+func (_e *MockQuerierServiceClient_Expecter) SelectExemplars(_a0 interface{}, _a1 interface{}) *MockQuerierServiceClient_SelectExemplars_Call {
+	return &MockQuerierServiceClient_SelectExemplars_Call{Call: _e.mock.On("SelectExemplars", _a0, _a1)}
+}
+
+func (_c *MockQuerierServiceClient_SelectExemplars_Call) Run(run func(_a0 context.Context, _a1 *connect.Request[querierv1.SelectExemplarsRequest])) *MockQuerierServiceClient_SelectExemplars_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*connect.Request[querierv1.SelectExemplarsRequest]))
+	})
+	return _c
+}
+
+func (_c *MockQuerierServiceClient_SelectExemplars_Call) Return(_a0 *connect.Response[querierv1.SelectExemplarsResponse], _a1 error) *MockQuerierServiceClient_SelectExemplars_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerierServiceClient_SelectExemplars_Call) RunAndReturn(run func(context.Context, *connect.Request[querierv1.SelectExemplarsRequest]) (*connect.Response[querierv1.SelectExemplarsResponse], error)) *MockQuerierServiceClient_SelectExemplars_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SelectFunctionDetails provides a mock function with given fields: _a0, _a1
+func (_m *MockQuerierServiceClient) SelectFunctionDetails(_a0 context.Context, _a1 *connect.Request[querierv1.SelectFunctionDetailsRequest]) (*connect.Response[querierv1.SelectFunctionDetailsResponse], error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectFunctionDetails")
+	}
+
+	var r0 *connect.Response[querierv1.SelectFunctionDetailsResponse]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *connect.Request[querierv1.SelectFunctionDetailsRequest]) (*connect.Response[querierv1.SelectFunctionDetailsResponse], error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *connect.Request[querierv1.SelectFunctionDetailsRequest]) *connect.Response[querierv1.SelectFunctionDetailsResponse]); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*connect.Response[querierv1.SelectFunctionDetailsResponse])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *connect.Request[querierv1.SelectFunctionDetailsRequest]) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerierServiceClient_SelectFunctionDetails_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectFunctionDetails'
+type MockQuerierServiceClient_SelectFunctionDetails_Call struct {
+	*mock.Call
+}
+
+// SelectFunctionDetails is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *connect.Request[querierv1.SelectFunctionDetailsRequest]
+func (_e *MockQuerierServiceClient_Expecter) SelectFunctionDetails(_a0 interface{}, _a1 interface{}) *MockQuerierServiceClient_SelectFunctionDetails_Call {
+	return &MockQuerierServiceClient_SelectFunctionDetails_Call{Call: _e.mock.On("SelectFunctionDetails", _a0, _a1)}
+}
+
+func (_c *MockQuerierServiceClient_SelectFunctionDetails_Call) Run(run func(_a0 context.Context, _a1 *connect.Request[querierv1.SelectFunctionDetailsRequest])) *MockQuerierServiceClient_SelectFunctionDetails_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*connect.Request[querierv1.SelectFunctionDetailsRequest]))
+	})
+	return _c
+}
+
+func (_c *MockQuerierServiceClient_SelectFunctionDetails_Call) Return(_a0 *connect.Response[querierv1.SelectFunctionDetailsResponse], _a1 error) *MockQuerierServiceClient_SelectFunctionDetails_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerierServiceClient_SelectFunctionDetails_Call) RunAndReturn(run func(context.Context, *connect.Request[querierv1.SelectFunctionDetailsRequest]) (*connect.Response[querierv1.SelectFunctionDetailsResponse], error)) *MockQuerierServiceClient_SelectFunctionDetails_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SelectHeatmap provides a mock function with given fields: _a0, _a1
+func (_m *MockQuerierServiceClient) SelectHeatmap(_a0 context.Context, _a1 *connect.Request[querierv1.SelectHeatmapRequest]) (*connect.Response[querierv1.SelectHeatmapResponse], error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectHeatmap")
+	}
+
+	var r0 *connect.Response[querierv1.SelectHeatmapResponse]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *connect.Request[querierv1.SelectHeatmapRequest]) (*connect.Response[querierv1.SelectHeatmapResponse], error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *connect.Request[querierv1.SelectHeatmapRequest]) *connect.Response[querierv1.SelectHeatmapResponse]); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*connect.Response[querierv1.SelectHeatmapResponse])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *connect.Request[querierv1.SelectHeatmapRequest]) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerierServiceClient_SelectHeatmap_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectHeatmap'
+type MockQuerierServiceClient_SelectHeatmap_Call struct {
+	*mock.Call
+}
+
+// SelectHeatmap is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *connect.Request[querierv1.SelectHeatmapRequest]
+func (_e *MockQuerierServiceClient_Expecter) SelectHeatmap(_a0 interface{}, _a1 interface{}) *MockQuerierServiceClient_SelectHeatmap_Call {
+	return &MockQuerierServiceClient_SelectHeatmap_Call{Call: _e.mock.On("SelectHeatmap", _a0, _a1)}
+}
+
+func (_c *MockQuerierServiceClient_SelectHeatmap_Call) Run(run func(_a0 context.Context, _a1 *connect.Request[querierv1.SelectHeatmapRequest])) *MockQuerierServiceClient_SelectHeatmap_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*connect.Request[querierv1.SelectHeatmapRequest]))
+	})
+	return _c
+}
+
+func (_c *MockQuerierServiceClient_SelectHeatmap_Call) Return(_a0 *connect.Response[querierv1.SelectHeatmapResponse], _a1 error) *MockQuerierServiceClient_SelectHeatmap_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerierServiceClient_SelectHeatmap_Call) RunAndReturn(run func(context.Context, *connect.Request[querierv1.SelectHeatmapRequest]) (*connect.Response[querierv1.SelectHeatmapResponse], error)) *MockQuerierServiceClient_SelectHeatmap_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SelectMergeProfile provides a mock function with given fields: _a0, _a1
 func (_m *MockQuerierServiceClient) SelectMergeProfile(_a0 context.Context, _a1 *connect.Request[querierv1.SelectMergeProfileRequest]) (*connect.Response[googlev1.Profile], error) {
 	ret := _m.Called(_a0, _a1)
@@ -560,6 +796,65 @@ func (_c *MockQuerierServiceClient_SelectMergeStacktraces_Call) RunAndReturn(run
 	return _c
 }
 
+// SelectProfileByID provides a mock function with given fields: _a0, _a1
+func (_m *MockQuerierServiceClient) SelectProfileByID(_a0 context.Context, _a1 *connect.Request[querierv1.SelectProfileByIDRequest]) (*connect.Response[googlev1.Profile], error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectProfileByID")
+	}
+
+	var r0 *connect.Response[googlev1.Profile]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *connect.Request[querierv1.SelectProfileByIDRequest]) (*connect.Response[googlev1.Profile], error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *connect.Request[querierv1.SelectProfileByIDRequest]) *connect.Response[googlev1.Profile]); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*connect.Response[googlev1.Profile])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *connect.Request[querierv1.SelectProfileByIDRequest]) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerierServiceClient_SelectProfileByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectProfileByID'
+type MockQuerierServiceClient_SelectProfileByID_Call struct {
+	*mock.Call
+}
+
+// SelectProfileByID is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *connect.Request[querierv1.SelectProfileByIDRequest]
+func (_e *MockQuerierServiceClient_Expecter) SelectProfileByID(_a0 interface{}, _a1 interface{}) *MockQuerierServiceClient_SelectProfileByID_Call {
+	return &MockQuerierServiceClient_SelectProfileByID_Call{Call: _e.mock.On("SelectProfileByID", _a0, _a1)}
+}
+
+func (_c *MockQuerierServiceClient_SelectProfileByID_Call) Run(run func(_a0 context.Context, _a1 *connect.Request[querierv1.SelectProfileByIDRequest])) *MockQuerierServiceClient_SelectProfileByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*connect.Request[querierv1.SelectProfileByIDRequest]))
+	})
+	return _c
+}
+
+func (_c *MockQuerierServiceClient_SelectProfileByID_Call) Return(_a0 *connect.Response[googlev1.Profile], _a1 error) *MockQuerierServiceClient_SelectProfileByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerierServiceClient_SelectProfileByID_Call) RunAndReturn(run func(context.Context, *connect.Request[querierv1.SelectProfileByIDRequest]) (*connect.Response[googlev1.Profile], error)) *MockQuerierServiceClient_SelectProfileByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SelectSeries provides a mock function with given fields: _a0, _a1
 func (_m *MockQuerierServiceClient) SelectSeries(_a0 context.Context, _a1 *connect.Request[querierv1.SelectSeriesRequest]) (*connect.Response[querierv1.SelectSeriesResponse], error) {
 	ret := _m.Called(_a0, _a1)
@@ -619,6 +914,65 @@ func (_c *MockQuerierServiceClient_SelectSeries_Call) RunAndReturn(run func(cont
 	return _c
 }
 
+// SelectTopTable provides a mock function with given fields: _a0, _a1
+func (_m *MockQuerierServiceClient) SelectTopTable(_a0 context.Context, _a1 *connect.Request[querierv1.SelectTopTableRequest]) (*connect.Response[querierv1.SelectTopTableResponse], error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectTopTable")
+	}
+
+	var r0 *connect.Response[querierv1.SelectTopTableResponse]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *connect.Request[querierv1.SelectTopTableRequest]) (*connect.Response[querierv1.SelectTopTableResponse], error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *connect.Request[querierv1.SelectTopTableRequest]) *connect.Response[querierv1.SelectTopTableResponse]); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*connect.Response[querierv1.SelectTopTableResponse])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *connect.Request[querierv1.SelectTopTableRequest]) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerierServiceClient_SelectTopTable_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectTopTable'
+type MockQuerierServiceClient_SelectTopTable_Call struct {
+	*mock.Call
+}
+
+// SelectTopTable is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *connect.Request[querierv1.SelectTopTableRequest]
+func (_e *MockQuerierServiceClient_Expecter) SelectTopTable(_a0 interface{}, _a1 interface{}) *MockQuerierServiceClient_SelectTopTable_Call {
+	return &MockQuerierServiceClient_SelectTopTable_Call{Call: _e.mock.On("SelectTopTable", _a0, _a1)}
+}
+
+func (_c *MockQuerierServiceClient_SelectTopTable_Call) Run(run func(_a0 context.Context, _a1 *connect.Request[querierv1.SelectTopTableRequest])) *MockQuerierServiceClient_SelectTopTable_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*connect.Request[querierv1.SelectTopTableRequest]))
+	})
+	return _c
+}
+
+func (_c *MockQuerierServiceClient_SelectTopTable_Call) Return(_a0 *connect.Response[querierv1.SelectTopTableResponse], _a1 error) *MockQuerierServiceClient_SelectTopTable_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerierServiceClient_SelectTopTable_Call) RunAndReturn(run func(context.Context, *connect.Request[querierv1.SelectTopTableRequest]) (*connect.Response[querierv1.SelectTopTableResponse], error)) *MockQuerierServiceClient_SelectTopTable_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Series provides a mock function with given fields: _a0, _a1
 func (_m *MockQuerierServiceClient) Series(_a0 context.Context, _a1 *connect.Request[querierv1.SeriesRequest]) (*connect.Response[querierv1.SeriesResponse], error) {
 	ret := _m.Called(_a0, _a1)
@@ -54,9 +54,9 @@ func NewLogInterceptor(logger log.Logger) connect.UnaryInterceptorFunc {
 			req connect.AnyRequest,
 		) (connect.AnyResponse, error) {
 			begin := time.Now()
-			tenantID, err := tenant.ExtractTenantIDFromContext(ctx)
-			if err != nil {
-				tenantID = "anonymous"
+			tenantID := "anonymous"
+			if tenantIDs, err := tenant.TenantIDs(ctx); err == nil {
+				tenantID = tenant.JoinTenantIDs(tenantIDs)
 			}
 			traceID, ok := tracing.ExtractTraceID(ctx)
 			if !ok {
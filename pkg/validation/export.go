@@ -0,0 +1,20 @@
+package validation
+
+import (
+	"flag"
+)
+
+// ExportLimits are the limits of the bulk export of raw profiles.
+type ExportLimits struct {
+	MaxProfiles int `yaml:"export_max_profiles" json:"export_max_profiles" category:"experimental" doc:"hidden"`
+	MaxSamples  int `yaml:"export_max_samples" json:"export_max_samples" category:"experimental" doc:"hidden"`
+}
+
+func (l *ExportLimits) RegisterFlags(f *flag.FlagSet) {
+	f.IntVar(&l.MaxProfiles, "export.max-profiles", 100000, "Maximum number of profiles a single export request can select. 0 to disable.")
+	f.IntVar(&l.MaxSamples, "export.max-samples", 10000000, "Maximum number of samples of the profiles a single export request can select. 0 to disable.")
+}
+
+func (o *Overrides) ExportLimits(tenantID string) ExportLimits {
+	return o.getOverridesForTenant(tenantID).ExportLimits
+}
@@ -17,6 +17,7 @@ import (
 	readpath "github.com/grafana/pyroscope/pkg/frontend/read_path"
 	phlaremodel "github.com/grafana/pyroscope/pkg/model"
 	"github.com/grafana/pyroscope/pkg/phlaredb/block"
+	"github.com/grafana/pyroscope/pkg/pprof"
 )
 
 const (
@@ -112,6 +113,15 @@ type Limits struct {
 	// Write path overrides used in query-frontend.
 	ReadPathOverrides readpath.Config `yaml:",inline" json:",inline"`
 
+	// Per-query resource limits enforced by the query backend.
+	QueryBackendLimits QueryBackendLimits `yaml:",inline" json:",inline"`
+
+	// Limits of the bulk export of raw profiles.
+	ExportLimits ExportLimits `yaml:",inline" json:",inline"`
+
+	// Ingestion of OpenTelemetry profiles.
+	OTLPLimits OTLPLimits `yaml:",inline" json:",inline"`
+
 	// Adaptive placement limits used in distributors and in the metastore.
 	// Distributors use these limits to determine how many shards to allocate
 	// to a tenant dataset by default, if no placement rules defined.
@@ -122,7 +132,7 @@ type Limits struct {
 	RecordingRules RecordingRules `yaml:"recording_rules" json:"recording_rules" category:"experimental" doc:"hidden"`
 
 	// Symbolizer.
-	Symbolizer Symbolizer `yaml:"symbolizer" json:"symbolizer" category:"experimental" doc:"hidden"`
+	Symbolizer Symbolizer `yaml:"symbolizer" json:"symbolizer" category:"experimental"`
 }
 
 // LimitError are errors that do not comply with the limits specified.
@@ -195,6 +205,11 @@ func (l *Limits) RegisterFlags(f *flag.FlagSet) {
 	f.Var(&l.IngestionRelabelingDefaultRulesPosition, "distributor.ingestion-relabeling-default-rules-position", "Position of the default ingestion relabeling rules in relation to relabel rules from overrides. Valid values are 'first', 'last' or 'disabled'.")
 	_ = l.IngestionRelabelingRules.Set("[]")
 	f.Var(&l.IngestionRelabelingRules, "distributor.ingestion-relabeling-rules", "List of ingestion relabel configurations. The relabeling rules work the same way, as those of [Prometheus](https://prometheus.io/docs/prometheus/latest/configuration/configuration/#relabel_config). All rules are applied in the order they are specified. Note: In most situations, it is more effective to use relabeling directly in Grafana Alloy.")
+
+	// The demangling applies at ingestion as well:
+	// the flag is registered with the other limits.
+	l.Symbolizer.Demangle = pprof.DemangleNone
+	f.Var(&l.Symbolizer.Demangle, "symbolizer.demangle", "How the C++ and Rust function names are demangled, by the symbolizer and at ingestion: none, full, or templates-stripped.")
 }
 
 // UnmarshalYAML implements the yaml.Unmarshaler interface.
@@ -225,6 +240,14 @@ func (l *Limits) Validate() error {
 		}
 	}
 
+	if err := l.OTLPLimits.AttributeRules.Validate(); err != nil {
+		return err
+	}
+
+	if err := l.Symbolizer.Validate(); err != nil {
+		return err
+	}
+
 	for idx, rule := range l.RecordingRules {
 		_, err := phlaremodel.NewRecordingRule(rule)
 		if err != nil {
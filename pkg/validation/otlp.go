@@ -0,0 +1,160 @@
+package validation
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/prometheus/prometheus/model/relabel"
+	"gopkg.in/yaml.v3"
+
+	phlaremodel "github.com/grafana/pyroscope/pkg/model"
+)
+
+// OTLPLimits configure the ingestion of OpenTelemetry profiles.
+type OTLPLimits struct {
+	// AttributeRules control how the resource and scope attributes of
+	// OTLP profiles are mapped into series labels.
+	AttributeRules OTLPAttributeRules `yaml:"otlp_attribute_rules" json:"otlp_attribute_rules" category:"experimental" doc:"hidden"`
+}
+
+func (l *OTLPLimits) RegisterFlags(f *flag.FlagSet) {
+	_ = l.AttributeRules.Set("[]")
+	f.Var(&l.AttributeRules, "otlp.attribute-rules", "List of rules mapping resource and scope attributes of OTLP profiles into series labels. The first rule matching the attribute key applies; attributes not matching any rule are used as labels as is.")
+}
+
+func (o *Overrides) OTLPAttributeRules(tenantID string) []*OTLPAttributeRule {
+	return o.getOverridesForTenant(tenantID).OTLPLimits.AttributeRules
+}
+
+type OTLPAttributeSource string
+
+const (
+	OTLPAttributeSourceAny      OTLPAttributeSource = ""
+	OTLPAttributeSourceResource OTLPAttributeSource = "resource"
+	OTLPAttributeSourceScope    OTLPAttributeSource = "scope"
+)
+
+type OTLPAttributeAction string
+
+const (
+	OTLPAttributeActionMap  OTLPAttributeAction = "map"
+	OTLPAttributeActionDrop OTLPAttributeAction = "drop"
+)
+
+// OTLPAttributeRule maps the attributes with keys matching
+// the regular expression into the target label, or drops them.
+type OTLPAttributeRule struct {
+	// Source of the attributes the rule applies to: "resource", "scope",
+	// or empty for both.
+	Source OTLPAttributeSource `yaml:"source,omitempty" json:"source,omitempty"`
+	// Regex is matched against the attribute key, and is fully anchored.
+	Regex relabel.Regexp `yaml:"regex" json:"regex"`
+	// Action is either "map" (default) or "drop".
+	Action OTLPAttributeAction `yaml:"action,omitempty" json:"action,omitempty"`
+	// TargetLabel is the name of the label, regex capture groups are
+	// expanded. If empty, the attribute key is used. Reserved names
+	// (service_name and names starting with "__") are not allowed.
+	TargetLabel string `yaml:"target_label,omitempty" json:"target_label,omitempty"`
+}
+
+func (r *OTLPAttributeRule) Validate() error {
+	switch r.Source {
+	case OTLPAttributeSourceAny, OTLPAttributeSourceResource, OTLPAttributeSourceScope:
+	default:
+		return fmt.Errorf("unknown attribute source %q", r.Source)
+	}
+	switch r.Action {
+	case "":
+		r.Action = OTLPAttributeActionMap
+	case OTLPAttributeActionMap:
+	case OTLPAttributeActionDrop:
+		if r.TargetLabel != "" {
+			return fmt.Errorf("target_label is not allowed with the %q action", r.Action)
+		}
+	default:
+		return fmt.Errorf("unknown action %q", r.Action)
+	}
+	if r.Regex.Regexp == nil {
+		return fmt.Errorf("regex is required")
+	}
+	if r.TargetLabel != "" {
+		// Names with capture groups can only be fully checked once expanded.
+		name := otlpTargetLabelGroupRef.ReplaceAllString(r.TargetLabel, "x")
+		if _, _, ok := SanitizeLabelName(name); !ok {
+			return fmt.Errorf("%q is not a valid target label", r.TargetLabel)
+		}
+		if name == r.TargetLabel && !isValidOTLPTargetLabel(name) {
+			return fmt.Errorf("%q is a reserved label name", r.TargetLabel)
+		}
+	}
+	return nil
+}
+
+// otlpTargetLabelGroupRef matches the references to regex
+// capture groups in the target label, e.g. "$1" or "${name}".
+var otlpTargetLabelGroupRef = regexp.MustCompile(`\$(?:\{\w+\}|\w+)`)
+
+// isValidOTLPTargetLabel reports whether the attribute can be mapped into
+// the label: service_name and the names starting with "__" are set by the
+// ingestion path itself.
+func isValidOTLPTargetLabel(name string) bool {
+	_, name, ok := SanitizeLabelName(name)
+	return ok &&
+		name != phlaremodel.LabelNameServiceName &&
+		!strings.HasPrefix(name, "__")
+}
+
+// Label returns the name of the label the attribute is mapped into,
+// and false if the rule does not apply to the attribute. The name is
+// empty if the expanded target label is not valid or is reserved.
+func (r *OTLPAttributeRule) Label(source OTLPAttributeSource, key string) (string, bool) {
+	if r.Source != OTLPAttributeSourceAny && r.Source != source {
+		return "", false
+	}
+	m := r.Regex.FindStringSubmatchIndex(key)
+	if m == nil {
+		return "", false
+	}
+	if r.TargetLabel == "" {
+		return key, true
+	}
+	name := string(r.Regex.ExpandString(nil, r.TargetLabel, key, m))
+	if !isValidOTLPTargetLabel(name) {
+		return "", true
+	}
+	return name, true
+}
+
+type OTLPAttributeRules []*OTLPAttributeRule
+
+func (p *OTLPAttributeRules) Set(s string) error {
+	v := []*OTLPAttributeRule{}
+	if err := yaml.Unmarshal([]byte(s), &v); err != nil {
+		return err
+	}
+	if err := OTLPAttributeRules(v).Validate(); err != nil {
+		return err
+	}
+	*p = v
+	return nil
+}
+
+func (p OTLPAttributeRules) Validate() error {
+	for idx, rule := range p {
+		if err := rule.Validate(); err != nil {
+			return fmt.Errorf("otlp attribute rule at pos %d is not valid: %w", idx, err)
+		}
+	}
+	return nil
+}
+
+func (p OTLPAttributeRules) String() string {
+	b, err := json.Marshal(p)
+	if err != nil {
+		panic(fmt.Errorf("error marshal json: %w", err))
+	}
+	return string(b)
+}
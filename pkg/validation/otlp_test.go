@@ -0,0 +1,52 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_OTLPAttributeRules(t *testing.T) {
+	var rules OTLPAttributeRules
+	require.NoError(t, rules.Set(`
+- regex: k8s\.(.*)\.name
+  target_label: k8s_$1
+- source: scope
+  regex: .*
+  action: drop
+`))
+	require.Len(t, rules, 2)
+	assert.Equal(t, OTLPAttributeActionMap, rules[0].Action)
+
+	name, ok := rules[0].Label(OTLPAttributeSourceResource, "k8s.pod.name")
+	assert.True(t, ok)
+	assert.Equal(t, "k8s_pod", name)
+	_, ok = rules[0].Label(OTLPAttributeSourceResource, "xk8s.pod.name")
+	assert.False(t, ok)
+	// Expanded names are checked too.
+	name, ok = rules[0].Label(OTLPAttributeSourceResource, "k8s.my-pod.name")
+	assert.True(t, ok)
+	assert.Equal(t, "", name)
+	_, ok = rules[1].Label(OTLPAttributeSourceResource, "host.name")
+	assert.False(t, ok)
+	_, ok = rules[1].Label(OTLPAttributeSourceScope, "host.name")
+	assert.True(t, ok)
+
+	for _, invalid := range []string{
+		`[{regex: "(", target_label: x}]`,
+		`[{target_label: x}]`,
+		`[{regex: x, action: keep}]`,
+		`[{regex: x, source: sample}]`,
+		`[{regex: x, action: drop, target_label: x}]`,
+		`[{regex: x, target_label: "k8s-pod"}]`,
+		`[{regex: x, target_label: "k8s-$1"}]`,
+		`[{regex: x, target_label: service.name}]`,
+		`[{regex: x, target_label: "1x"}]`,
+		`[{regex: x, target_label: service_name}]`,
+		`[{regex: x, target_label: __name__}]`,
+		`[{regex: x, target_label: __delta__}]`,
+	} {
+		assert.Error(t, new(OTLPAttributeRules).Set(invalid), invalid)
+	}
+}
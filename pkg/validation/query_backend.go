@@ -0,0 +1,28 @@
+package validation
+
+import (
+	"flag"
+
+	"github.com/prometheus/common/model"
+)
+
+// QueryBackendLimits are the per-query resource limits enforced by the
+// query backend: a single query can't exceed them, regardless of the
+// time range and the number of datasets it spans.
+type QueryBackendLimits struct {
+	MaxBlocks    int            `yaml:"query_backend_max_blocks" json:"query_backend_max_blocks" category:"experimental" doc:"hidden"`
+	MaxBytesRead int            `yaml:"query_backend_max_bytes_read" json:"query_backend_max_bytes_read" category:"experimental" doc:"hidden"`
+	MaxSeries    int            `yaml:"query_backend_max_series" json:"query_backend_max_series" category:"experimental" doc:"hidden"`
+	MaxWallTime  model.Duration `yaml:"query_backend_max_wall_time" json:"query_backend_max_wall_time" category:"experimental" doc:"hidden"`
+}
+
+func (l *QueryBackendLimits) RegisterFlags(f *flag.FlagSet) {
+	f.IntVar(&l.MaxBlocks, "query-backend.max-blocks", 0, "Maximum number of blocks a single query can read. 0 to disable.")
+	f.IntVar(&l.MaxBytesRead, "query-backend.max-bytes-read", 0, "Maximum number of bytes a single query can read from the object storage. 0 to disable.")
+	f.IntVar(&l.MaxSeries, "query-backend.max-series", 0, "Maximum number of series a single query can select. 0 to disable.")
+	f.Var(&l.MaxWallTime, "query-backend.max-wall-time", "Maximum execution time of a single query in the query backend. 0 to disable.")
+}
+
+func (o *Overrides) QueryBackendLimits(tenantID string) QueryBackendLimits {
+	return o.getOverridesForTenant(tenantID).QueryBackendLimits
+}
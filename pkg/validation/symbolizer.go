@@ -2,17 +2,33 @@ package validation
 
 import (
 	"flag"
+
+	"github.com/grafana/pyroscope/pkg/pprof"
 )
 
 type Symbolizer struct {
 	// Enabled enables the symbolizer in the query frontend.
 	Enabled bool `yaml:"enabled" json:"enabled" category:"experimental" doc:"hidden"`
+	// Demangle sets how the C++ and Rust function names are demangled,
+	// by the symbolizer and at ingestion.
+	Demangle pprof.DemangleMode `yaml:"demangle" json:"demangle" category:"experimental"`
 }
 
 func (s *Symbolizer) RegisterFlags(f *flag.FlagSet) {
 	f.BoolVar(&s.Enabled, "symbolizer.enabled", false, "Enable symbolization for tenants by default.")
 }
 
+func (s *Symbolizer) Validate() error {
+	if s.Demangle == "" {
+		return nil
+	}
+	return s.Demangle.Set(string(s.Demangle))
+}
+
 func (o *Overrides) SymbolizerEnabled(tenantID string) bool {
 	return o.getOverridesForTenant(tenantID).Symbolizer.Enabled
 }
+
+func (o *Overrides) SymbolizerDemangle(tenantID string) pprof.DemangleMode {
+	return o.getOverridesForTenant(tenantID).Symbolizer.Demangle
+}
@@ -2,6 +2,8 @@ package validation
 
 import (
 	"time"
+
+	"github.com/grafana/pyroscope/pkg/pprof"
 )
 
 type MockLimits struct {
@@ -32,7 +34,13 @@ type MockLimits struct {
 
 	MaxQueriersPerTenantValue int
 
-	SymbolizerEnabledValue bool
+	SymbolizerEnabledValue  bool
+	SymbolizerDemangleValue pprof.DemangleMode
+
+	QueryBackendLimitsValue QueryBackendLimits
+	ExportLimitsValue       ExportLimits
+
+	OTLPAttributeRulesValue []*OTLPAttributeRule
 }
 
 func (m MockLimits) QuerySplitDuration(string) time.Duration        { return m.QuerySplitDurationValue }
@@ -87,3 +95,13 @@ func (m MockLimits) RejectNewerThan(userID string) time.Duration {
 }
 
 func (m MockLimits) SymbolizerEnabled(s string) bool { return m.SymbolizerEnabledValue }
+
+func (m MockLimits) SymbolizerDemangle(string) pprof.DemangleMode { return m.SymbolizerDemangleValue }
+
+func (m MockLimits) QueryBackendLimits(string) QueryBackendLimits { return m.QueryBackendLimitsValue }
+
+func (m MockLimits) ExportLimits(string) ExportLimits { return m.ExportLimitsValue }
+
+func (m MockLimits) OTLPAttributeRules(string) []*OTLPAttributeRule {
+	return m.OTLPAttributeRulesValue
+}